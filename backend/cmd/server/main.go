@@ -10,9 +10,12 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// The runtime image has no zoneinfo; scheduled calls need time zones.
+	_ "time/tzdata"
 
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/app"
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/config"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/transport/http/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 )
@@ -39,7 +42,8 @@ func main() {
 	}()
 
 	gin.SetMode(gin.ReleaseMode)
-	engine := gin.Default()
+	engine := gin.New()
+	engine.Use(middleware.Logger(), gin.Recovery())
 	application.Router().Setup(engine)
 
 	srv := &http.Server{
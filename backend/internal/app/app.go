@@ -1,20 +1,32 @@
 package app
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/config"
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/infrastructure/blob"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/infrastructure/events"
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/infrastructure/jwt"
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/infrastructure/postgres"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/infrastructure/redis"
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/infrastructure/voip"
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/transport/http"
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/transport/http/handlers"
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/auth"
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/calls"
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/history"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/numbers"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/recordings"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/settings"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/voicemail"
 	"gorm.io/gorm"
 )
 
@@ -22,11 +34,21 @@ type App struct {
 	userRepo   domain.UserRepository
 	callRepo   domain.CallRepository
 	voipClient voip.Client
+	sessions   domain.SessionStore
 	router     *http.Router
 	db         *gorm.DB
 	config     *config.Config
+	// stop ends the background loops.
+	stop chan struct{}
 }
 
+const (
+	// voicemailPurgeInterval is how often expired voicemails are deleted.
+	voicemailPurgeInterval = time.Hour
+	// scheduledCallsInterval is how often due scheduled calls are fired.
+	scheduledCallsInterval = 15 * time.Second
+)
+
 func New(cfg *config.Config) (*App, error) {
 	db, err := postgres.NewConnection(&cfg.Database)
 	if err != nil {
@@ -45,13 +67,102 @@ func New(cfg *config.Config) (*App, error) {
 
 	userRepo := postgres.NewUserRepository(db)
 	callRepo := postgres.NewCallRepository(db)
+	phoneNumberRepo := postgres.NewPhoneNumberRepository(db)
+	voicemailRepo := postgres.NewVoicemailRepository(db)
+	recordingRepo := postgres.NewRecordingRepository(db)
+	conferenceRepo := postgres.NewConferenceRepository(db)
+	callerIDRepo := postgres.NewCallerIDRepository(db)
+	scheduledCallRepo := postgres.NewScheduledCallRepository(db)
+	userSettingsRepo := postgres.NewUserSettingsRepository(db)
+	auditRepo := postgres.NewAuditRepository(db)
+
+	voicemailBlobs, err := blob.NewLocalStore(cfg.Voicemail.StorageDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize voicemail storage: %w", err)
+	}
+	recordingBlobs, err := blob.NewLocalStore(cfg.Recording.StorageDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize recording storage: %w", err)
+	}
+
+	recordingPolicy, err := domain.NewRecordingPolicy(cfg.Recording.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse recording policy: %w", err)
+	}
+
+	holdPolicy, err := domain.ParseHoldPolicy(cfg.VoIP.HoldTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse hold policy: %w", err)
+	}
+
+	callRates, err := domain.NewCallRates(cfg.Billing.Rates, cfg.Billing.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse call rates: %w", err)
+	}
+
+	quietHours, err := domain.ParseQuietHours(cfg.QuietHours.Mode, cfg.QuietHours.Window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse quiet hours: %w", err)
+	}
+
+	emergencyRouting, err := domain.NewEmergencyRouting(cfg.VoIP.EmergencyRoutes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse emergency routes: %w", err)
+	}
+
+	statusCallbackURL := ""
+	transferCallbackURL := ""
+	callbackURL := ""
+	if cfg.VoIP.VoicePublicBaseURL != "" {
+		statusCallbackURL = strings.TrimSuffix(cfg.VoIP.VoicePublicBaseURL, "/") + "/api/voice/status"
+		transferCallbackURL = strings.TrimSuffix(cfg.VoIP.VoicePublicBaseURL, "/") + "/api/voice/transfer"
+		callbackURL = strings.TrimSuffix(cfg.VoIP.VoicePublicBaseURL, "/") + "/api/voice/callback"
+	}
+
+	// Voice webhooks are signed with the account auth token. The mock and
+	// gateway providers post their own status callbacks, so without a token
+	// they sign with a random key only this process knows.
+	webhookAuthToken := cfg.VoIP.AuthToken
+	if webhookAuthToken == "" && cfg.VoIP.Provider != "twilio" {
+		webhookAuthToken, err = randomToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate webhook auth token: %w", err)
+		}
+	}
+
+	sessions, err := newSessionStore(cfg, db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize session store: %w", err)
+	}
 
 	voipClient, err := voip.NewClient(&voip.Config{
-		Provider:   cfg.VoIP.Provider,
-		AccountSID: cfg.VoIP.AccountSID,
-		AuthToken:  cfg.VoIP.AuthToken,
-		FromNumber: cfg.VoIP.FromNumber,
-	})
+		Provider:               cfg.VoIP.Provider,
+		AccountSID:             cfg.VoIP.AccountSID,
+		AuthToken:              webhookAuthToken,
+		FromNumber:             cfg.VoIP.FromNumber,
+		StatusCallbackURL:      statusCallbackURL,
+		TransferCallbackURL:    transferCallbackURL,
+		CallbackURL:            callbackURL,
+		MockScenarios:          cfg.VoIP.MockScenarios,
+		APIBaseURL:             cfg.VoIP.APIBaseURL,
+		TwiMLURL:               cfg.VoIP.TwiMLURL,
+		BridgeTarget:           cfg.VoIP.BridgeTarget,
+		RecordCalls:            cfg.VoIP.RecordCalls,
+		MachineDetection:       cfg.VoIP.MachineDetection,
+		Codecs:                 cfg.VoIP.Codecs,
+		HoldMusicURL:           cfg.VoIP.HoldMusicURL,
+		GatewaySIPTrunk:        cfg.VoIP.GatewaySIPTrunk,
+		GatewaySIPUsername:     cfg.VoIP.GatewaySIPUsername,
+		GatewaySIPPassword:     cfg.VoIP.GatewaySIPPassword,
+		GatewayCarrierCodec:    cfg.VoIP.GatewayCarrierCodec,
+		GatewayICEServers:      cfg.WebRTC.STUNURLs,
+		GatewayInterfaces:      cfg.VoIP.GatewayInterfaces,
+		GatewayPublicIP:        cfg.VoIP.GatewayPublicIP,
+		GatewayUDPPortMin:      cfg.VoIP.GatewayUDPPortMin,
+		GatewayUDPPortMax:      cfg.VoIP.GatewayUDPPortMax,
+		GatewayHoldMusic:       cfg.VoIP.GatewayHoldMusic,
+		GatewayDTMFPayloadType: cfg.VoIP.GatewayDTMFPayloadType,
+	}, sessions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize voip client: %w", err)
 	}
@@ -69,41 +180,145 @@ func New(cfg *config.Config) (*App, error) {
 		}
 	}
 
+	var recordingFetcher voicemail.RecordingFetcher
+	if cfg.VoIP.Provider == "twilio" {
+		fetcher, err := voip.NewRecordingFetcher(&voip.Config{
+			AccountSID: cfg.VoIP.AccountSID,
+			AuthToken:  cfg.VoIP.AuthToken,
+			APIBaseURL: cfg.VoIP.APIBaseURL,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize recording fetcher: %w", err)
+		}
+		recordingFetcher = fetcher
+	}
+
+	iceConfig, err := voip.NewICEConfigProvider(&voip.ICEConfig{
+		STUNURLs:          cfg.WebRTC.STUNURLs,
+		TURNURLs:          cfg.WebRTC.TURNURLs,
+		TURNSecret:        cfg.WebRTC.TURNSecret,
+		TURNCredentialTTL: cfg.WebRTC.TURNCredentialTTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize ice config: %w", err)
+	}
+
 	jwtService := jwt.NewService(cfg.JWT.Secret)
+	eventBus := events.NewBus()
 
 	registerUC := auth.NewRegisterUseCase(userRepo)
 	loginUC := auth.NewLoginUseCase(userRepo, jwtService)
 	logoutUC := auth.NewLogoutUseCase()
-	startCallUC := calls.NewStartCallUseCase(callRepo)
-	endCallUC := calls.NewEndCallUseCase(callRepo)
+	startCallUC := calls.NewStartCallUseCase(callRepo, eventBus)
+	endCallUC := calls.NewEndCallUseCase(callRepo, eventBus, holdPolicy)
 	var tokenGenForUC calls.VoiceTokenGenerator
 	if voiceTokenGen != nil {
 		tokenGenForUC = voiceTokenGen
 	}
-	initiateCallUC := calls.NewInitiateCallUseCase(callRepo, voipClient, tokenGenForUC)
-	terminateCallUC := calls.NewTerminateCallUseCase(callRepo, voipClient)
-	listHistoryUC := history.NewListHistoryUseCase(callRepo)
+	shortCodeGuard := calls.NewShortCodeGuard(emergencyRouting, auditRepo)
+	initiateCallUC := calls.NewInitiateCallUseCase(callRepo, callerIDRepo, voipClient, tokenGenForUC, eventBus, calls.InitiateCallGuards{
+		Recording:  recordingPolicy,
+		QuietHours: calls.NewQuietHoursGuard(userSettingsRepo, quietHours),
+		ShortCodes: shortCodeGuard,
+	})
+	terminateCallUC := calls.NewTerminateCallUseCase(callRepo, voipClient, eventBus, holdPolicy)
+	answerCallUC := calls.NewAnswerCallUseCase(callRepo, voipClient, eventBus)
+	addCandidateUC := calls.NewAddCandidateUseCase(callRepo, voipClient)
+	listCandidatesUC := calls.NewListCandidatesUseCase(callRepo, sessions)
+	sendDTMFUC := calls.NewSendDTMFUseCase(callRepo, voipClient, auditRepo, eventBus)
+	holdCallUC := calls.NewHoldCallUseCase(callRepo, voipClient, eventBus)
+	resumeCallUC := calls.NewResumeCallUseCase(callRepo, voipClient, eventBus)
+	muteCallUC := calls.NewMuteCallUseCase(callRepo, voipClient, eventBus)
+	transferCallUC := calls.NewTransferCallUseCase(callRepo, userRepo, voipClient, eventBus, holdPolicy, shortCodeGuard)
+	completeTransferUC := calls.NewCompleteTransferUseCase(callRepo, voipClient, eventBus, holdPolicy)
+	createConferenceUC := calls.NewCreateConferenceUseCase(callRepo, conferenceRepo, voipClient, eventBus)
+	addParticipantUC := calls.NewAddParticipantUseCase(callRepo, conferenceRepo, voipClient, eventBus)
+	removeParticipantUC := calls.NewRemoveParticipantUseCase(callRepo, conferenceRepo, voipClient, eventBus, holdPolicy)
+	muteParticipantUC := calls.NewMuteParticipantUseCase(callRepo, conferenceRepo, voipClient, eventBus)
+	endConferenceUC := calls.NewEndConferenceUseCase(callRepo, conferenceRepo, voipClient, eventBus, holdPolicy)
+	callbackCallUC := calls.NewCallbackCallUseCase(callRepo, callerIDRepo, voipClient, eventBus, shortCodeGuard)
+	bridgeCallbackUC := calls.NewBridgeCallbackUseCase(callRepo, eventBus)
+	getCallerIDUC := calls.NewGetCallerIDUseCase(callRepo)
+	linkProviderCallUC := calls.NewLinkProviderCallUseCase(callRepo)
+	scheduleCallUC := calls.NewScheduleCallUseCase(scheduledCallRepo, callerIDRepo, voipClient, shortCodeGuard)
+	listScheduledCallsUC := calls.NewListScheduledCallsUseCase(scheduledCallRepo)
+	getScheduledCallUC := calls.NewGetScheduledCallUseCase(scheduledCallRepo)
+	rescheduleCallUC := calls.NewRescheduleCallUseCase(scheduledCallRepo, callerIDRepo, voipClient, shortCodeGuard)
+	deleteScheduledCallUC := calls.NewDeleteScheduledCallUseCase(scheduledCallRepo)
+	fireScheduledCallsUC := calls.NewFireScheduledCallsUseCase(scheduledCallRepo, callbackCallUC, eventBus)
+	if source, ok := voipClient.(voip.LocalCandidateSource); ok {
+		publishCandidateUC := calls.NewPublishCandidateUseCase(callRepo, eventBus)
+		source.OnLocalCandidate(func(session *domain.CallSession, candidate domain.ICECandidate) {
+			publishCandidateUC.Execute(context.Background(), session, candidate)
+		})
+	}
+	updateCallStatusUC := calls.NewUpdateCallStatusUseCase(callRepo, conferenceRepo, voipClient, eventBus, holdPolicy)
+	streamCallUC := calls.NewStreamCallUseCase(callRepo, eventBus)
+	receiveCallUC := calls.NewReceiveCallUseCase(phoneNumberRepo, callRepo, eventBus)
+	listHistoryUC := history.NewListHistoryUseCase(callRepo, callRates)
+	getCallUC := history.NewGetCallUseCase(callRepo, recordingRepo, auditRepo, callRates)
+	listConferencesUC := history.NewListConferencesUseCase(conferenceRepo, callRates)
+	getConferenceUC := history.NewGetConferenceUseCase(conferenceRepo, callRates)
+	listNumbersUC := numbers.NewListNumbersUseCase(phoneNumberRepo)
+	parseNumberUC := numbers.NewParseNumberUseCase()
+	getQuietHoursUC := settings.NewGetQuietHoursUseCase(userSettingsRepo, quietHours)
+	updateQuietHoursUC := settings.NewUpdateQuietHoursUseCase(userSettingsRepo)
+	resetQuietHoursUC := settings.NewResetQuietHoursUseCase(userSettingsRepo, quietHours)
+	listCallerIDsUC := numbers.NewListCallerIDsUseCase(callerIDRepo)
+	addCallerIDUC := numbers.NewAddCallerIDUseCase(callerIDRepo, auditRepo, voipClient)
+	verifyCallerIDUC := numbers.NewVerifyCallerIDUseCase(callerIDRepo)
+	setDefaultCallerIDUC := numbers.NewSetDefaultCallerIDUseCase(callerIDRepo)
+	deleteCallerIDUC := numbers.NewDeleteCallerIDUseCase(callerIDRepo)
+	retention := voicemail.RetentionPolicy{
+		MaxAge:     time.Duration(cfg.Voicemail.RetentionDays) * 24 * time.Hour,
+		MaxPerUser: cfg.Voicemail.MaxPerUser,
+		MaxSize:    int64(cfg.Voicemail.MaxSizeMB) << 20,
+	}
+	listVoicemailUC := voicemail.NewListVoicemailUseCase(voicemailRepo, retention)
+	getVoicemailAudioUC := voicemail.NewGetAudioUseCase(voicemailRepo, voicemailBlobs, retention)
+	markVoicemailReadUC := voicemail.NewMarkReadUseCase(voicemailRepo, retention)
+	deleteVoicemailUC := voicemail.NewDeleteVoicemailUseCase(voicemailRepo, voicemailBlobs, retention)
+	saveVoicemailUC := voicemail.NewSaveVoicemailUseCase(callRepo, voicemailRepo, voicemailBlobs, recordingFetcher, retention)
+	purgeVoicemailUC := voicemail.NewPurgeExpiredUseCase(voicemailRepo, voicemailBlobs, retention)
+	getRecordingAudioUC := recordings.NewGetAudioUseCase(recordingRepo, recordingBlobs)
+	saveRecordingUC := recordings.NewSaveRecordingUseCase(callRepo, recordingRepo, recordingBlobs, recordingFetcher, int64(cfg.Recording.MaxSizeMB)<<20)
 
 	authHandler := handlers.NewAuthHandler(registerUC, loginUC, logoutUC, jwtService)
 	callsHandler := handlers.NewCallsHandler(startCallUC, endCallUC)
-	webrtcHandler := handlers.NewWebRTCHandler(initiateCallUC, terminateCallUC)
+	webrtcHandler := handlers.NewWebRTCHandler(initiateCallUC, terminateCallUC, answerCallUC, addCandidateUC, listCandidatesUC, iceConfig)
+	callControlHandler := handlers.NewCallControlHandler(sendDTMFUC, holdCallUC, resumeCallUC, muteCallUC, transferCallUC, completeTransferUC, domain.CapabilitiesOf(voipClient))
+	callbackHandler := handlers.NewCallbackHandler(callbackCallUC)
+	scheduledCallsHandler := handlers.NewScheduledCallsHandler(scheduleCallUC, listScheduledCallsUC, getScheduledCallUC, rescheduleCallUC, deleteScheduledCallUC)
+	conferenceHandler := handlers.NewConferenceHandler(createConferenceUC, addParticipantUC, removeParticipantUC, muteParticipantUC, endConferenceUC, listConferencesUC, getConferenceUC)
 	var voiceHandler *handlers.VoiceHandler
 	if voiceTokenGen != nil {
-		voiceHandler = handlers.NewVoiceHandler(voiceTokenGen, cfg.VoIP.VoicePublicBaseURL, cfg.VoIP.FromNumber)
+		voiceHandler = handlers.NewVoiceHandler(voiceTokenGen, cfg.VoIP.VoicePublicBaseURL, cfg.VoIP.FromNumber, cfg.VoIP.InboundRingTimeout, recordingPolicy, shortCodeGuard, updateCallStatusUC, receiveCallUC, bridgeCallbackUC, getCallerIDUC, linkProviderCallUC)
 	} else {
-		voiceHandler = handlers.NewVoiceHandler(nil, "", "")
+		voiceHandler = handlers.NewVoiceHandler(nil, "", "", cfg.VoIP.InboundRingTimeout, recordingPolicy, shortCodeGuard, updateCallStatusUC, receiveCallUC, bridgeCallbackUC, getCallerIDUC, linkProviderCallUC)
 	}
-	historyHandler := handlers.NewHistoryHandler(listHistoryUC)
+	historyHandler := handlers.NewHistoryHandler(listHistoryUC, getCallUC)
+	eventsHandler := handlers.NewEventsHandler(eventBus, streamCallUC)
+	numbersHandler := handlers.NewNumbersHandler(listNumbersUC, parseNumberUC)
+	settingsHandler := handlers.NewSettingsHandler(getQuietHoursUC, updateQuietHoursUC, resetQuietHoursUC)
+	callerIDsHandler := handlers.NewCallerIDsHandler(listCallerIDsUC, addCallerIDUC, verifyCallerIDUC, setDefaultCallerIDUC, deleteCallerIDUC)
+	voicemailHandler := handlers.NewVoicemailHandler(listVoicemailUC, getVoicemailAudioUC, markVoicemailReadUC, deleteVoicemailUC, saveVoicemailUC)
+	recordingsHandler := handlers.NewRecordingsHandler(getRecordingAudioUC, saveRecordingUC)
 
-	router := http.NewRouter(authHandler, callsHandler, webrtcHandler, voiceHandler, historyHandler, jwtService)
+	router := http.NewRouter(authHandler, callsHandler, webrtcHandler, callControlHandler, callbackHandler, scheduledCallsHandler, conferenceHandler, voiceHandler, historyHandler, eventsHandler, numbersHandler, callerIDsHandler, settingsHandler, voicemailHandler, recordingsHandler, jwtService, webhookAuthToken, cfg.VoIP.VoicePublicBaseURL)
+
+	stop := make(chan struct{})
+	go runVoicemailPurge(purgeVoicemailUC, stop)
+	go runScheduledCalls(fireScheduledCallsUC, stop)
 
 	return &App{
 		userRepo:   userRepo,
 		callRepo:   callRepo,
 		voipClient: voipClient,
+		sessions:   sessions,
 		router:     router,
 		db:         db,
 		config:     cfg,
+		stop:       stop,
 	}, nil
 }
 
@@ -112,12 +327,80 @@ func (a *App) Router() *http.Router {
 }
 
 func (a *App) Close() error {
+	close(a.stop)
 	if err := a.voipClient.Close(); err != nil {
 		log.Printf("Error closing VoIP client: %v", err)
 	}
+	if err := a.sessions.Close(); err != nil {
+		log.Printf("Error closing session store: %v", err)
+	}
 	return postgres.Close(a.db)
 }
 
+// newSessionStore picks where VoIP sessions live. The in-memory store only
+// works with a single backend replica; postgres and redis are shared.
+func newSessionStore(cfg *config.Config, db *gorm.DB) (domain.SessionStore, error) {
+	switch cfg.VoIP.SessionStore {
+	case "", "memory":
+		return voip.NewSessionManager(), nil
+	case "postgres":
+		return postgres.NewSessionStore(db), nil
+	case "redis":
+		if cfg.Redis.URL == "" {
+			return nil, fmt.Errorf("REDIS_URL is required for redis session store")
+		}
+		return redis.NewSessionStore(cfg.Redis.URL)
+	default:
+		return nil, fmt.Errorf("unsupported session store: %s", cfg.VoIP.SessionStore)
+	}
+}
+
+// randomToken returns a random 256-bit key, hex encoded, for signing the
+// voice webhooks of providers that have no auth token.
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func runVoicemailPurge(purge *voicemail.PurgeExpiredUseCase, stop <-chan struct{}) {
+	ticker := time.NewTicker(voicemailPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := purge.Execute(context.Background()); err != nil {
+			log.Printf("Error purging expired voicemails: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// runScheduledCalls fires due scheduled calls. Every replica runs it; the
+// repository's row locking hands each call to one of them.
+func runScheduledCalls(fire *calls.FireScheduledCallsUseCase, stop <-chan struct{}) {
+	ticker := time.NewTicker(scheduledCallsInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := fire.Execute(context.Background()); err != nil {
+			log.Printf("Error firing scheduled calls: %v", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
 func findMigrationsPath() string {
 	possiblePaths := []string{
 		"migrations",
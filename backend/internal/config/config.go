@@ -3,13 +3,23 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	VoIP     VoIPConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Redis     RedisConfig
+	JWT       JWTConfig
+	VoIP      VoIPConfig
+	WebRTC    WebRTCConfig
+	Voicemail VoicemailConfig
+	Recording RecordingConfig
+	Billing   BillingConfig
+	// QuietHours are the defaults for users who have not set their own.
+	QuietHours QuietHoursConfig
 }
 
 type ServerConfig struct {
@@ -25,6 +35,10 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+type RedisConfig struct {
+	URL string
+}
+
 type JWTConfig struct {
 	Secret string
 }
@@ -38,6 +52,72 @@ type VoIPConfig struct {
 	FromNumber         string
 	TwimlAppSid        string
 	VoicePublicBaseURL string
+	MockScenarios      string
+	APIBaseURL         string
+	TwiMLURL           string
+	BridgeTarget       string
+	RecordCalls        bool
+	MachineDetection   string
+	SessionStore       string
+	Codecs             string
+	// HoldMusicURL is played to held Twilio calls; HoldTime ("include" or
+	// "exclude") decides whether time on hold counts towards call duration.
+	HoldMusicURL string
+	HoldTime     string
+	// InboundRingTimeout is how long, in seconds, an inbound call rings the
+	// browser before it goes to voicemail.
+	InboundRingTimeout int
+	// Media gateway (VOIP_PROVIDER=gateway): the SIP trunk the carrier leg
+	// is dialled on and its credentials, which G.711 law it uses, its hold
+	// music and the payload type of its DTMF events.
+	GatewaySIPTrunk        string
+	GatewaySIPUsername     string
+	GatewaySIPPassword     string
+	GatewayCarrierCodec    string
+	GatewayInterfaces      []string
+	GatewayPublicIP        string
+	GatewayUDPPortMin      int
+	GatewayUDPPortMax      int
+	GatewayHoldMusic       string
+	GatewayDTMFPayloadType int
+	// EmergencyRoutes lists the "REGION:code" short codes the carrier
+	// routes, see domain.NewEmergencyRouting. Other short codes are refused.
+	EmergencyRoutes []string
+}
+
+type VoicemailConfig struct {
+	StorageDir    string
+	RetentionDays int
+	MaxPerUser    int
+	MaxSizeMB     int
+}
+
+type QuietHoursConfig struct {
+	// Mode is off, warn or confirm; Window is the destination's local
+	// "22:00-08:00", see domain.ParseQuietHours.
+	Mode   string
+	Window string
+}
+
+type RecordingConfig struct {
+	StorageDir string
+	// Policy lists "prefix:rule" entries, see domain.NewRecordingPolicy.
+	Policy    []string
+	MaxSizeMB int
+}
+
+type BillingConfig struct {
+	// Rates lists "prefix:rate" entries, see domain.NewCallRates; without
+	// rates history shows no costs.
+	Rates    []string
+	Currency string
+}
+
+type WebRTCConfig struct {
+	STUNURLs          []string
+	TURNURLs          []string
+	TURNSecret        string
+	TURNCredentialTTL time.Duration
 }
 
 func Load() (*Config, error) {
@@ -53,26 +133,80 @@ func Load() (*Config, error) {
 			DBName:   getEnv("POSTGRES_DB", "postgres"),
 			SSLMode:  getEnv("POSTGRES_SSLMODE", "disable"),
 		},
+		Redis: RedisConfig{
+			URL: getEnv("REDIS_URL", ""),
+		},
 		JWT: JWTConfig{
 			Secret: getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
 		},
 		VoIP: VoIPConfig{
-			Provider:           getEnv("VOIP_PROVIDER", "twilio"),
-			AccountSID:         getEnv("VOIP_ACCOUNT_SID", ""),
-			AuthToken:          getEnv("VOIP_AUTH_TOKEN", ""),
-			APIKeySid:          getEnv("VOIP_API_KEY_SID", ""),
-			APIKeySecret:       getEnv("VOIP_API_KEY_SECRET", ""),
-			FromNumber:         getEnv("VOIP_FROM_NUMBER", ""),
-			TwimlAppSid:        getEnv("VOIP_TWIML_APP_SID", ""),
-			VoicePublicBaseURL: getEnv("VOICE_PUBLIC_BASE_URL", ""),
+			Provider:               getEnv("VOIP_PROVIDER", "twilio"),
+			AccountSID:             getEnv("VOIP_ACCOUNT_SID", ""),
+			AuthToken:              getEnv("VOIP_AUTH_TOKEN", ""),
+			APIKeySid:              getEnv("VOIP_API_KEY_SID", ""),
+			APIKeySecret:           getEnv("VOIP_API_KEY_SECRET", ""),
+			FromNumber:             getEnv("VOIP_FROM_NUMBER", ""),
+			TwimlAppSid:            getEnv("VOIP_TWIML_APP_SID", ""),
+			VoicePublicBaseURL:     getEnv("VOICE_PUBLIC_BASE_URL", ""),
+			MockScenarios:          getEnv("VOIP_MOCK_SCENARIOS", ""),
+			APIBaseURL:             getEnv("VOIP_API_BASE_URL", ""),
+			TwiMLURL:               getEnv("VOIP_TWIML_URL", ""),
+			BridgeTarget:           getEnv("VOIP_BRIDGE_TARGET", "client:{identity}"),
+			RecordCalls:            getEnvBool("VOIP_RECORD_CALLS", false),
+			MachineDetection:       getEnv("VOIP_MACHINE_DETECTION", ""),
+			SessionStore:           getEnv("VOIP_SESSION_STORE", "memory"),
+			Codecs:                 getEnv("VOIP_SDP_CODECS", "opus,PCMU,PCMA,telephone-event"),
+			HoldMusicURL:           getEnv("VOIP_HOLD_MUSIC_URL", ""),
+			HoldTime:               getEnv("VOIP_HOLD_TIME", "include"),
+			InboundRingTimeout:     getEnvInt("VOIP_INBOUND_RING_TIMEOUT", 20),
+			GatewaySIPTrunk:        getEnv("VOIP_GATEWAY_SIP_TRUNK", ""),
+			GatewaySIPUsername:     getEnv("VOIP_GATEWAY_SIP_USERNAME", ""),
+			GatewaySIPPassword:     getEnv("VOIP_GATEWAY_SIP_PASSWORD", ""),
+			GatewayCarrierCodec:    getEnv("VOIP_GATEWAY_CARRIER_CODEC", "PCMU"),
+			GatewayInterfaces:      getEnvList("VOIP_GATEWAY_INTERFACES", ""),
+			GatewayPublicIP:        getEnv("VOIP_GATEWAY_PUBLIC_IP", ""),
+			GatewayUDPPortMin:      getEnvInt("VOIP_GATEWAY_UDP_PORT_MIN", 0),
+			GatewayUDPPortMax:      getEnvInt("VOIP_GATEWAY_UDP_PORT_MAX", 0),
+			GatewayHoldMusic:       getEnv("VOIP_GATEWAY_HOLD_MUSIC", ""),
+			GatewayDTMFPayloadType: getEnvInt("VOIP_GATEWAY_DTMF_PAYLOAD_TYPE", 101),
+			EmergencyRoutes:        getEnvList("VOIP_EMERGENCY_ROUTES", ""),
+		},
+		WebRTC: WebRTCConfig{
+			STUNURLs:          getEnvList("WEBRTC_STUN_URLS", "stun:stun.l.google.com:19302"),
+			TURNURLs:          getEnvList("WEBRTC_TURN_URLS", ""),
+			TURNSecret:        getEnv("WEBRTC_TURN_SECRET", ""),
+			TURNCredentialTTL: time.Duration(getEnvInt("WEBRTC_TURN_TTL", 3600)) * time.Second,
+		},
+		Voicemail: VoicemailConfig{
+			StorageDir:    getEnv("VOICEMAIL_STORAGE_DIR", "data/voicemail"),
+			RetentionDays: getEnvInt("VOICEMAIL_RETENTION_DAYS", 30),
+			MaxPerUser:    getEnvInt("VOICEMAIL_MAX_PER_USER", 100),
+			MaxSizeMB:     getEnvInt("VOICEMAIL_MAX_SIZE_MB", 10),
+		},
+		Recording: RecordingConfig{
+			StorageDir: getEnv("RECORDING_STORAGE_DIR", "data/recordings"),
+			Policy:     getEnvList("RECORDING_POLICY", "*:consent"),
+			MaxSizeMB:  getEnvInt("RECORDING_MAX_SIZE_MB", 200),
+		},
+		Billing: BillingConfig{
+			Rates:    getEnvList("BILLING_RATES", ""),
+			Currency: getEnv("BILLING_CURRENCY", "USD"),
 		},
+		QuietHours: QuietHoursConfig{
+			Mode:   getEnv("QUIET_HOURS_MODE", "warn"),
+			Window: getEnv("QUIET_HOURS", "22:00-08:00"),
+		},
+	}
+
+	if cfg.VoIP.TwiMLURL == "" && cfg.VoIP.VoicePublicBaseURL != "" {
+		cfg.VoIP.TwiMLURL = strings.TrimSuffix(cfg.VoIP.VoicePublicBaseURL, "/") + "/api/voice/twiml"
 	}
 
 	if cfg.JWT.Secret == "your-secret-key-change-in-production" {
 		fmt.Println("WARNING: Using default JWT secret. Set JWT_SECRET environment variable in production.")
 	}
 
-	if cfg.VoIP.AccountSID == "" || cfg.VoIP.AuthToken == "" {
+	if cfg.VoIP.Provider == "twilio" && (cfg.VoIP.AccountSID == "" || cfg.VoIP.AuthToken == "") {
 		fmt.Println("WARNING: VoIP credentials not set. WebRTC calls will not work. Set VOIP_ACCOUNT_SID and VOIP_AUTH_TOKEN.")
 	}
 
@@ -90,3 +224,31 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+func getEnvList(key, defaultValue string) []string {
+	var list []string
+	for _, item := range strings.Split(getEnv(key, defaultValue), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+type AuditEventType string
+
+const (
+	// AuditShortCodeRefused records an emergency or service short code the
+	// platform refused to dial.
+	AuditShortCodeRefused AuditEventType = "short_code.refused"
+	// AuditShortCodeRouted records a short code dialled through the carrier's
+	// emergency routing.
+	AuditShortCodeRouted AuditEventType = "short_code.routed"
+	// AuditCallerIDVerificationCall records a call placed to read out a
+	// caller ID verification code.
+	AuditCallerIDVerificationCall AuditEventType = "caller_id.verification_call"
+	// AuditCallDTMF records digits sent into a call, masked, in Detail.
+	AuditCallDTMF AuditEventType = "call.dtmf"
+)
+
+// AuditEvent is a durable record of an action that may need to be accounted
+// for later. It outlives the user it belongs to.
+type AuditEvent struct {
+	ID          string
+	UserID      string
+	Type        AuditEventType
+	PhoneNumber string
+	Country     string
+	// Detail describes the event, e.g. the service a short code reaches.
+	Detail string
+	// CallID is set when the event belongs to a call.
+	CallID    string
+	CreatedAt time.Time
+}
+
+// AuditFilter selects audit events of one type created since a moment.
+// Empty UserID and PhoneNumber match any value.
+type AuditFilter struct {
+	Type        AuditEventType
+	UserID      string
+	PhoneNumber string
+	Since       time.Time
+}
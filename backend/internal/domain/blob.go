@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps binary objects such as call recordings. Keys are
+// slash-separated paths made of letters, digits, '-', '_' and '.'.
+type BlobStore interface {
+	// Put stores the reader's content under key, replacing any previous
+	// object, and returns the number of bytes written.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Open returns the object for reading; it is seekable so that it can be
+	// served with HTTP range requests.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type CallStatus string
 
@@ -8,20 +11,122 @@ const (
 	CallStatusInitiated CallStatus = "initiated"
 	CallStatusConnecting CallStatus = "connecting"
 	CallStatusActive    CallStatus = "active"
+	// CallStatusOnHold is an answered call whose parties cannot hear each
+	// other; the called party hears hold music.
+	CallStatusOnHold    CallStatus = "on_hold"
 	CallStatusCompleted CallStatus = "completed"
 	CallStatusFailed    CallStatus = "failed"
 	CallStatusCanceled  CallStatus = "canceled"
+	// CallStatusTransferred ends the caller's part in a call whose called
+	// party was handed over to someone else.
+	CallStatusTransferred CallStatus = "transferred"
+)
+
+// CallDirection tells calls the user placed from calls that rang the user's
+// number.
+type CallDirection string
+
+const (
+	CallDirectionOutbound CallDirection = "outbound"
+	CallDirectionInbound  CallDirection = "inbound"
+)
+
+// TransferMode tells how a transfer leg was placed: a blind transfer hands
+// the called party straight to the target, an attended one first connects
+// the caller to the target for a consultation.
+type TransferMode string
+
+const (
+	TransferBlind    TransferMode = "blind"
+	TransferAttended TransferMode = "attended"
 )
 
+func (m TransferMode) IsValid() bool {
+	return m == TransferBlind || m == TransferAttended
+}
+
 type Call struct {
-	ID          string
-	UserID      string
-	PhoneNumber string
-	StartTime   time.Time
-	Duration    int
-	Status      CallStatus
-	CreatedAt   time.Time
-	SessionID   string
-	SDPOffer    string
-	SDPAnswer   string
+	ID              string
+	UserID          string
+	PhoneNumber     string
+	StartTime       time.Time
+	Duration        int
+	Status          CallStatus
+	CreatedAt       time.Time
+	SessionID       string
+	ProviderCallSID string
+	SDPOffer        string
+	SDPAnswer       string
+	Direction       CallDirection
+	// Record is set when the caller asked for the call to be recorded.
+	Record bool
+	// Muted is set while the caller's audio is kept from the called party.
+	Muted bool
+	// HeldAt is when the current hold started; HoldDuration sums, in
+	// seconds, the holds that have ended.
+	HeldAt       *time.Time
+	HoldDuration int
+	// ParentCallID links a transfer leg to the call it was transferred
+	// from. A leg to a platform user has no PhoneNumber; TransferUserID
+	// names the user instead.
+	ParentCallID   string
+	TransferMode   TransferMode
+	TransferUserID string
+	// ConferenceID is set once the call has joined a conference.
+	ConferenceID string
+	// CallbackDestination is set on the first leg of a callback call, which
+	// rings the caller's own phone, PhoneNumber. Once that answers, the
+	// destination is dialled as a second leg linked by ParentCallID.
+	CallbackDestination string
+	// CallerID is the user's verified number shown to the called party;
+	// empty means the platform's number.
+	CallerID string
+}
+
+// EndHold adds the current hold, if any, to HoldDuration.
+func (c *Call) EndHold(now time.Time) {
+	if c.HeldAt == nil {
+		return
+	}
+	c.HoldDuration += int(now.Sub(*c.HeldAt).Seconds())
+	c.HeldAt = nil
+}
+
+// HoldPolicy decides whether time on hold counts towards a call's duration.
+type HoldPolicy string
+
+const (
+	HoldTimeIncluded HoldPolicy = "include"
+	HoldTimeExcluded HoldPolicy = "exclude"
+)
+
+func ParseHoldPolicy(s string) (HoldPolicy, error) {
+	switch policy := HoldPolicy(s); policy {
+	case HoldTimeIncluded, HoldTimeExcluded:
+		return policy, nil
+	case "":
+		return HoldTimeIncluded, nil
+	}
+	return "", fmt.Errorf("invalid hold time policy: %q", s)
+}
+
+// Apply ends the call's open hold, if any, and sets the finished call's
+// Duration from the total seconds it lasted.
+func (p HoldPolicy) Apply(call *Call, total int, now time.Time) {
+	call.EndHold(now)
+	call.Duration = total
+	if p == HoldTimeExcluded {
+		call.Duration -= call.HoldDuration
+		if call.Duration < 0 {
+			call.Duration = 0
+		}
+	}
+}
+
+func (s CallStatus) IsTerminal() bool {
+	switch s {
+	case CallStatusCompleted, CallStatusFailed, CallStatusCanceled, CallStatusTransferred:
+		return true
+	}
+	return false
 }
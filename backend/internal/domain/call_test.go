@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHoldPolicy_Apply(t *testing.T) {
+	now := time.Now()
+	heldAt := now.Add(-20 * time.Second)
+
+	tests := []struct {
+		policy HoldPolicy
+		total  int
+		want   int
+	}{
+		{HoldTimeIncluded, 120, 120},
+		{HoldTimeExcluded, 120, 90},
+		{HoldTimeExcluded, 10, 0},
+	}
+	for _, tc := range tests {
+		call := &Call{HeldAt: &heldAt, HoldDuration: 10}
+		tc.policy.Apply(call, tc.total, now)
+
+		if call.Duration != tc.want {
+			t.Errorf("%s of %ds: expected duration %d, got %d", tc.policy, tc.total, tc.want, call.Duration)
+		}
+		if call.HeldAt != nil || call.HoldDuration != 30 {
+			t.Errorf("expected the open hold to end, got held_at=%v hold_duration=%d", call.HeldAt, call.HoldDuration)
+		}
+	}
+}
+
+func TestParseHoldPolicy(t *testing.T) {
+	if policy, err := ParseHoldPolicy(""); err != nil || policy != HoldTimeIncluded {
+		t.Errorf("expected empty policy to include hold time, got %q, %v", policy, err)
+	}
+	if policy, err := ParseHoldPolicy("exclude"); err != nil || policy != HoldTimeExcluded {
+		t.Errorf("expected exclude, got %q, %v", policy, err)
+	}
+	if _, err := ParseHoldPolicy("sometimes"); err == nil {
+		t.Error("expected error for unknown policy")
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrCallerIDNotVerified = errors.New("caller id is not verified")
+
+// CallerID is a phone number a user has registered as their own, such as
+// their mobile. Only a verified number may be rung on the user's behalf or
+// shown to the parties they call.
+type CallerID struct {
+	ID         string
+	UserID     string
+	Number     string
+	VerifiedAt *time.Time
+	// IsDefault marks the number shown on the user's calls unless another
+	// verified number is picked for a call. A user has at most one.
+	IsDefault bool
+	// VerificationCode is the code read out by the pending verification
+	// call; VerifyAttempts counts the wrong codes entered for it.
+	VerificationCode string
+	CodeExpiresAt    *time.Time
+	VerifyAttempts   int
+	CreatedAt        time.Time
+}
+
+func (c *CallerID) Verified() bool {
+	return c.VerifiedAt != nil
+}
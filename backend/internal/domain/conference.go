@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+type ConferenceStatus string
+
+const (
+	ConferenceStatusActive ConferenceStatus = "active"
+	ConferenceStatusEnded  ConferenceStatus = "ended"
+)
+
+// Conference joins several of a user's calls so that the user and all the
+// called parties hear each other. Its participants are the calls whose
+// ConferenceID points at it.
+type Conference struct {
+	ID     string
+	UserID string
+	Status ConferenceStatus
+	// HostSessionID is the provider session that connects the user's
+	// browser to the conference, if the provider needs one.
+	HostSessionID string
+	StartTime     time.Time
+	EndTime       *time.Time
+	Duration      int
+	CreatedAt     time.Time
+}
+
+// End closes the conference and sets its Duration. Ending an ended
+// conference changes nothing.
+func (c *Conference) End(now time.Time) {
+	if c.Status == ConferenceStatusEnded {
+		return
+	}
+	c.Status = ConferenceStatusEnded
+	c.EndTime = &now
+	c.Duration = int(now.Sub(c.StartTime).Seconds())
+}
+
+// Elapsed is the conference's duration so far, or its final Duration once
+// it has ended.
+func (c *Conference) Elapsed(now time.Time) int {
+	if c.Status == ConferenceStatusEnded {
+		return c.Duration
+	}
+	return int(now.Sub(c.StartTime).Seconds())
+}
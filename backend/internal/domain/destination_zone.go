@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// destinationTimeZones maps dialling prefixes to the IANA zone of the
+// destination. Countries in a single zone are listed by country calling
+// code. Countries spanning several zones map their calling code to "" and
+// list area codes instead; their numbers without a known area code, such as
+// mobiles in Russia or Australia, have no single zone and may be in any of
+// the country's.
+var destinationTimeZones = map[string]string{
+	// North America: +1 with the area code.
+	"+1": "",
+	// Eastern
+	"+1201": "America/New_York", "+1202": "America/New_York", "+1203": "America/New_York",
+	"+1207": "America/New_York", "+1212": "America/New_York", "+1215": "America/New_York",
+	"+1216": "America/New_York", "+1240": "America/New_York", "+1267": "America/New_York",
+	"+1301": "America/New_York", "+1302": "America/New_York", "+1305": "America/New_York",
+	"+1313": "America/Detroit", "+1315": "America/New_York", "+1321": "America/New_York",
+	"+1347": "America/New_York", "+1401": "America/New_York", "+1404": "America/New_York",
+	"+1407": "America/New_York", "+1410": "America/New_York", "+1412": "America/New_York",
+	"+1413": "America/New_York", "+1416": "America/Toronto", "+1434": "America/New_York",
+	"+1437": "America/Toronto", "+1443": "America/New_York", "+1470": "America/New_York",
+	"+1475": "America/New_York", "+1478": "America/New_York", "+1484": "America/New_York",
+	"+1513": "America/New_York", "+1514": "America/Toronto", "+1516": "America/New_York",
+	"+1518": "America/New_York", "+1540": "America/New_York", "+1561": "America/New_York",
+	"+1571": "America/New_York", "+1585": "America/New_York", "+1603": "America/New_York",
+	"+1609": "America/New_York", "+1610": "America/New_York", "+1613": "America/Toronto",
+	"+1614": "America/New_York", "+1617": "America/New_York", "+1631": "America/New_York",
+	"+1646": "America/New_York", "+1647": "America/Toronto", "+1678": "America/New_York",
+	"+1703": "America/New_York", "+1704": "America/New_York", "+1716": "America/New_York",
+	"+1717": "America/New_York", "+1718": "America/New_York", "+1727": "America/New_York",
+	"+1732": "America/New_York", "+1754": "America/New_York", "+1757": "America/New_York",
+	"+1770": "America/New_York", "+1772": "America/New_York", "+1781": "America/New_York",
+	"+1786": "America/New_York", "+1802": "America/New_York", "+1803": "America/New_York",
+	"+1804": "America/New_York", "+1813": "America/New_York", "+1856": "America/New_York",
+	"+1857": "America/New_York", "+1860": "America/New_York", "+1904": "America/New_York",
+	"+1905": "America/Toronto", "+1908": "America/New_York", "+1910": "America/New_York",
+	"+1914": "America/New_York", "+1917": "America/New_York", "+1919": "America/New_York",
+	"+1929": "America/New_York", "+1941": "America/New_York", "+1954": "America/New_York",
+	"+1973": "America/New_York", "+1980": "America/New_York", "+1984": "America/New_York",
+	// Central
+	"+1205": "America/Chicago", "+1210": "America/Chicago", "+1214": "America/Chicago",
+	"+1217": "America/Chicago", "+1224": "America/Chicago", "+1225": "America/Chicago",
+	"+1262": "America/Chicago", "+1281": "America/Chicago", "+1312": "America/Chicago",
+	"+1314": "America/Chicago", "+1316": "America/Chicago", "+1318": "America/Chicago",
+	"+1319": "America/Chicago", "+1331": "America/Chicago", "+1346": "America/Chicago",
+	"+1402": "America/Chicago", "+1405": "America/Chicago", "+1414": "America/Chicago",
+	"+1417": "America/Chicago", "+1469": "America/Chicago", "+1501": "America/Chicago",
+	"+1504": "America/Chicago", "+1512": "America/Chicago", "+1515": "America/Chicago",
+	"+1563": "America/Chicago", "+1573": "America/Chicago", "+1601": "America/Chicago",
+	"+1608": "America/Chicago", "+1612": "America/Chicago", "+1615": "America/Chicago",
+	"+1618": "America/Chicago", "+1630": "America/Chicago", "+1651": "America/Chicago",
+	"+1662": "America/Chicago", "+1682": "America/Chicago", "+1708": "America/Chicago",
+	"+1713": "America/Chicago", "+1715": "America/Chicago", "+1731": "America/Chicago",
+	"+1737": "America/Chicago", "+1763": "America/Chicago", "+1773": "America/Chicago",
+	"+1779": "America/Chicago", "+1815": "America/Chicago", "+1816": "America/Chicago",
+	"+1817": "America/Chicago", "+1830": "America/Chicago", "+1832": "America/Chicago",
+	"+1847": "America/Chicago", "+1901": "America/Chicago", "+1913": "America/Chicago",
+	"+1918": "America/Chicago", "+1920": "America/Chicago", "+1952": "America/Chicago",
+	"+1956": "America/Chicago", "+1972": "America/Chicago", "+1979": "America/Chicago",
+	"+1204": "America/Winnipeg", "+1306": "America/Regina",
+	// Mountain
+	"+1303": "America/Denver", "+1307": "America/Denver", "+1385": "America/Denver",
+	"+1406": "America/Denver", "+1505": "America/Denver", "+1575": "America/Denver",
+	"+1719": "America/Denver", "+1720": "America/Denver", "+1801": "America/Denver",
+	"+1915": "America/Denver", "+1970": "America/Denver", "+1986": "America/Boise",
+	"+1208": "America/Boise", "+1403": "America/Edmonton", "+1587": "America/Edmonton",
+	"+1780": "America/Edmonton", "+1825": "America/Edmonton",
+	"+1480": "America/Phoenix", "+1520": "America/Phoenix", "+1602": "America/Phoenix",
+	"+1623": "America/Phoenix", "+1928": "America/Phoenix",
+	// Pacific
+	"+1206": "America/Los_Angeles", "+1209": "America/Los_Angeles", "+1213": "America/Los_Angeles",
+	"+1253": "America/Los_Angeles", "+1310": "America/Los_Angeles", "+1323": "America/Los_Angeles",
+	"+1360": "America/Los_Angeles", "+1408": "America/Los_Angeles", "+1415": "America/Los_Angeles",
+	"+1424": "America/Los_Angeles", "+1425": "America/Los_Angeles", "+1503": "America/Los_Angeles",
+	"+1510": "America/Los_Angeles", "+1530": "America/Los_Angeles", "+1541": "America/Los_Angeles",
+	"+1559": "America/Los_Angeles", "+1562": "America/Los_Angeles", "+1619": "America/Los_Angeles",
+	"+1626": "America/Los_Angeles", "+1628": "America/Los_Angeles", "+1650": "America/Los_Angeles",
+	"+1657": "America/Los_Angeles", "+1661": "America/Los_Angeles", "+1669": "America/Los_Angeles",
+	"+1702": "America/Los_Angeles", "+1707": "America/Los_Angeles", "+1714": "America/Los_Angeles",
+	"+1725": "America/Los_Angeles", "+1747": "America/Los_Angeles", "+1760": "America/Los_Angeles",
+	"+1775": "America/Los_Angeles", "+1805": "America/Los_Angeles", "+1818": "America/Los_Angeles",
+	"+1831": "America/Los_Angeles", "+1858": "America/Los_Angeles", "+1909": "America/Los_Angeles",
+	"+1916": "America/Los_Angeles", "+1925": "America/Los_Angeles", "+1949": "America/Los_Angeles",
+	"+1951": "America/Los_Angeles", "+1971": "America/Los_Angeles",
+	"+1236": "America/Vancouver", "+1250": "America/Vancouver", "+1604": "America/Vancouver",
+	"+1672": "America/Vancouver", "+1778": "America/Vancouver",
+	// Atlantic, Newfoundland, Alaska, Hawaii
+	"+1506": "America/Halifax", "+1782": "America/Halifax", "+1902": "America/Halifax",
+	"+1709": "America/St_Johns",
+	"+1907": "America/Anchorage",
+	"+1808": "Pacific/Honolulu",
+	"+1787": "America/Puerto_Rico", "+1939": "America/Puerto_Rico",
+
+	// Russia: +7 with the area code; +76 and +77 are Kazakhstan.
+	"+7":    "",
+	"+7301": "Asia/Irkutsk", "+7342": "Asia/Yekaterinburg", "+7343": "Asia/Yekaterinburg",
+	"+7351": "Asia/Yekaterinburg", "+7381": "Asia/Omsk", "+7383": "Asia/Novosibirsk",
+	"+7384": "Asia/Novokuznetsk", "+7385": "Asia/Barnaul", "+7391": "Asia/Krasnoyarsk",
+	"+7395": "Asia/Irkutsk", "+7401": "Europe/Kaliningrad", "+7411": "Asia/Yakutsk",
+	"+7413": "Asia/Magadan", "+7415": "Asia/Kamchatka", "+7416": "Asia/Yakutsk",
+	"+7421": "Asia/Vladivostok", "+7423": "Asia/Vladivostok", "+7424": "Asia/Sakhalin",
+	"+7495": "Europe/Moscow", "+7496": "Europe/Moscow", "+7498": "Europe/Moscow",
+	"+7499": "Europe/Moscow", "+7812": "Europe/Moscow", "+7813": "Europe/Moscow",
+	"+7831": "Europe/Moscow", "+7843": "Europe/Moscow", "+7845": "Europe/Saratov",
+	"+7846": "Europe/Samara", "+7861": "Europe/Moscow", "+7862": "Europe/Moscow",
+	"+7863": "Europe/Moscow", "+7873": "Europe/Moscow",
+	"+76": "Asia/Almaty", "+77": "Asia/Almaty",
+
+	// Australia: +61 with the area code; 4 is mobile, 8 spans three zones.
+	"+61": "", "+612": "Australia/Sydney", "+613": "Australia/Melbourne", "+617": "Australia/Brisbane",
+	"+6189": "Australia/Perth",
+
+	// Brazil: +55 with the two-digit area code.
+	"+55":   "",
+	"+5511": "America/Sao_Paulo", "+5512": "America/Sao_Paulo", "+5513": "America/Sao_Paulo",
+	"+5514": "America/Sao_Paulo", "+5515": "America/Sao_Paulo", "+5516": "America/Sao_Paulo",
+	"+5517": "America/Sao_Paulo", "+5518": "America/Sao_Paulo", "+5519": "America/Sao_Paulo",
+	"+5521": "America/Sao_Paulo", "+5522": "America/Sao_Paulo", "+5524": "America/Sao_Paulo",
+	"+5527": "America/Sao_Paulo", "+5531": "America/Sao_Paulo", "+5541": "America/Sao_Paulo",
+	"+5547": "America/Sao_Paulo", "+5548": "America/Sao_Paulo", "+5551": "America/Sao_Paulo",
+	"+5561": "America/Sao_Paulo", "+5562": "America/Sao_Paulo", "+5571": "America/Bahia",
+	"+5581": "America/Recife", "+5585": "America/Fortaleza", "+5591": "America/Belem",
+	"+5565": "America/Cuiaba", "+5567": "America/Campo_Grande", "+5568": "America/Rio_Branco",
+	"+5569": "America/Porto_Velho", "+5592": "America/Manaus", "+5595": "America/Boa_Vista",
+
+	// Mexico: +52 with the area code; most of the country is on Mexico City time.
+	"+52": "America/Mexico_City", "+52664": "America/Tijuana", "+52686": "America/Tijuana",
+	"+52656": "America/Ciudad_Juarez", "+52662": "America/Hermosillo", "+52612": "America/Mazatlan",
+	"+52669": "America/Mazatlan", "+52998": "America/Cancun",
+
+	// Indonesia: +62 with the area code; 8 is mobile.
+	"+62": "", "+6221": "Asia/Jakarta", "+6222": "Asia/Jakarta", "+6224": "Asia/Jakarta",
+	"+6231": "Asia/Jakarta", "+6261": "Asia/Jakarta", "+62361": "Asia/Makassar",
+	"+62411": "Asia/Makassar", "+62967": "Asia/Jayapura",
+
+	// Countries in a single zone.
+	"+20": "Africa/Cairo", "+27": "Africa/Johannesburg", "+30": "Europe/Athens",
+	"+31": "Europe/Amsterdam", "+32": "Europe/Brussels", "+33": "Europe/Paris",
+	"+34": "Europe/Madrid", "+36": "Europe/Budapest", "+39": "Europe/Rome",
+	"+40": "Europe/Bucharest", "+41": "Europe/Zurich", "+43": "Europe/Vienna",
+	"+44": "Europe/London", "+45": "Europe/Copenhagen", "+46": "Europe/Stockholm",
+	"+47": "Europe/Oslo", "+48": "Europe/Warsaw", "+49": "Europe/Berlin",
+	"+51": "America/Lima", "+53": "America/Havana", "+54": "America/Argentina/Buenos_Aires",
+	"+56": "America/Santiago", "+57": "America/Bogota", "+58": "America/Caracas",
+	"+60": "Asia/Kuala_Lumpur", "+63": "Asia/Manila", "+64": "Pacific/Auckland",
+	"+65": "Asia/Singapore", "+66": "Asia/Bangkok", "+81": "Asia/Tokyo",
+	"+82": "Asia/Seoul", "+84": "Asia/Ho_Chi_Minh", "+86": "Asia/Shanghai",
+	"+90": "Europe/Istanbul", "+91": "Asia/Kolkata", "+92": "Asia/Karachi",
+	"+94": "Asia/Colombo", "+95": "Asia/Yangon", "+98": "Asia/Tehran",
+	"+212": "Africa/Casablanca", "+213": "Africa/Algiers", "+216": "Africa/Tunis",
+	"+234": "Africa/Lagos", "+254": "Africa/Nairobi", "+255": "Africa/Dar_es_Salaam",
+	"+351": "Europe/Lisbon", "+353": "Europe/Dublin", "+358": "Europe/Helsinki",
+	"+359": "Europe/Sofia", "+370": "Europe/Vilnius", "+371": "Europe/Riga",
+	"+372": "Europe/Tallinn", "+373": "Europe/Chisinau", "+374": "Asia/Yerevan",
+	"+375": "Europe/Minsk", "+380": "Europe/Kyiv", "+381": "Europe/Belgrade",
+	"+385": "Europe/Zagreb", "+386": "Europe/Ljubljana", "+420": "Europe/Prague",
+	"+421": "Europe/Bratislava", "+852": "Asia/Hong_Kong", "+880": "Asia/Dhaka",
+	"+886": "Asia/Taipei", "+961": "Asia/Beirut", "+962": "Asia/Amman",
+	"+964": "Asia/Baghdad", "+966": "Asia/Riyadh", "+971": "Asia/Dubai",
+	"+972": "Asia/Jerusalem", "+974": "Asia/Qatar", "+992": "Asia/Dushanbe",
+	"+994": "Asia/Baku", "+995": "Asia/Tbilisi", "+996": "Asia/Bishkek",
+	"+998": "Asia/Tashkent",
+}
+
+// DestinationTimeZone returns the zone of an E.164 number by its longest
+// matching prefix. It reports false when the zone is unknown, including
+// numbers of multi-zone countries whose area code does not settle it.
+func DestinationTimeZone(number string) (*time.Location, bool) {
+	for i := len(number); i >= 2; i-- {
+		name, ok := destinationTimeZones[number[:i]]
+		if !ok {
+			continue
+		}
+		if name == "" {
+			return nil, false
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, false
+		}
+		return loc, true
+	}
+	return nil, false
+}
+
+// DestinationTimeZones returns the zones an E.164 number may be in: its
+// zone when DestinationTimeZone knows it, otherwise every zone listed for
+// its multi-zone country, sorted by name. Numbers of countries that are not
+// listed have none. For +7 the candidates include Kazakhstan's zone, which
+// shares its offset with Yekaterinburg.
+func DestinationTimeZones(number string) []*time.Location {
+	if loc, ok := DestinationTimeZone(number); ok {
+		return []*time.Location{loc}
+	}
+
+	country := ""
+	for i := len(number); i >= 2; i-- {
+		if name, ok := destinationTimeZones[number[:i]]; ok && name == "" {
+			country = number[:i]
+			break
+		}
+	}
+	if country == "" {
+		return nil
+	}
+
+	names := map[string]bool{}
+	for prefix, name := range destinationTimeZones {
+		if name != "" && strings.HasPrefix(prefix, country) {
+			names[name] = true
+		}
+	}
+
+	zones := make([]*time.Location, 0, len(names))
+	for name := range names {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		zones = append(zones, loc)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].String() < zones[j].String() })
+	return zones
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrEmergencyNotSupported is matched by ShortCodeError.
+var ErrEmergencyNotSupported = errors.New("emergency and short code calls are not supported")
+
+// ShortCodeError refuses an emergency or service short code the carrier
+// is not set up to route.
+type ShortCodeError struct {
+	Number  string
+	Country string
+	// Kind is "emergency" or "service".
+	Kind    string
+	Service string
+}
+
+func (e *ShortCodeError) Error() string {
+	return ErrEmergencyNotSupported.Error()
+}
+
+func (e *ShortCodeError) Is(target error) bool {
+	return target == ErrEmergencyNotSupported
+}
+
+// Guidance tells the user where to dial the number instead.
+func (e *ShortCodeError) Guidance() string {
+	if e.Kind == "emergency" {
+		return fmt.Sprintf("This service cannot place emergency calls. Dial %s from a mobile or landline phone; "+
+			"112 also works from any mobile phone in most countries.", e.Number)
+	}
+	return fmt.Sprintf("%s is a short code that only works from a local phone network. "+
+		"Dial it from a mobile or landline phone, or call the service's full number.", e.Number)
+}
+
+// EmergencyRouting lists the short codes the carrier can route, by region:
+// the calls to them are dialled as is instead of being refused.
+type EmergencyRouting struct {
+	routes map[string]map[string]bool
+}
+
+// NewEmergencyRouting parses "REGION:code" entries such as "US:911".
+func NewEmergencyRouting(entries []string) (*EmergencyRouting, error) {
+	routing := &EmergencyRouting{routes: make(map[string]map[string]bool)}
+	for _, entry := range entries {
+		region, code, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		region = strings.ToUpper(region)
+		if !ok || len(region) != 2 || strings.Trim(region, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+			return nil, fmt.Errorf("invalid emergency route %q", entry)
+		}
+		if code == "" || len(code) > 6 || strings.Trim(code, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid emergency route code %q", code)
+		}
+		if routing.routes[region] == nil {
+			routing.routes[region] = make(map[string]bool)
+		}
+		routing.routes[region][code] = true
+	}
+	return routing, nil
+}
+
+// Routes reports whether the carrier routes code dialled in region. An
+// empty region, for a code used in several countries, matches a route in
+// any of them.
+func (r *EmergencyRouting) Routes(region, code string) bool {
+	if r == nil {
+		return false
+	}
+	if region != "" {
+		return r.routes[strings.ToUpper(region)][code]
+	}
+	for _, codes := range r.routes {
+		if codes[code] {
+			return true
+		}
+	}
+	return false
+}
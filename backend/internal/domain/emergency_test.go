@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEmergencyRouting(t *testing.T) {
+	routing, err := NewEmergencyRouting([]string{"US:911", " gb:999"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !routing.Routes("US", "911") || !routing.Routes("gb", "999") {
+		t.Error("expected configured routes")
+	}
+	if routing.Routes("CA", "911") || routing.Routes("GB", "112") {
+		t.Error("expected unconfigured routes to be refused")
+	}
+	if !routing.Routes("", "911") || routing.Routes("", "112") {
+		t.Error("expected a code without region to match a route in any region")
+	}
+
+	var nilRouting *EmergencyRouting
+	if nilRouting.Routes("US", "911") {
+		t.Error("expected nil routing to route nothing")
+	}
+}
+
+func TestNewEmergencyRouting_Invalid(t *testing.T) {
+	for _, entry := range []string{"US", "USA:911", "U1:911", "US:", "US:9a1", "US:1234567", "+1:911"} {
+		if _, err := NewEmergencyRouting([]string{entry}); err == nil {
+			t.Errorf("%q: expected error", entry)
+		}
+	}
+}
+
+func TestShortCodeError(t *testing.T) {
+	var err error = &ShortCodeError{Number: "112", Kind: "emergency", Service: "general"}
+	if !errors.Is(err, ErrEmergencyNotSupported) {
+		t.Error("expected ShortCodeError to match ErrEmergencyNotSupported")
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrInvalidEventID = errors.New("invalid event id")
+
+type CallEventType string
+
+const (
+	CallEventStatusChanged CallEventType = "call.status"
+	// CallEventDurationTick reports the running duration of an active call.
+	// Ticks are generated per stream and are not published, so they carry
+	// no ID.
+	CallEventDurationTick CallEventType = "call.duration"
+	// CallEventICECandidate carries one of our trickled ICE candidates, or
+	// the end-of-candidates marker, to the browser.
+	CallEventICECandidate CallEventType = "call.ice_candidate"
+	// CallEventDTMF records a digit sequence sent into the call. The digits
+	// are masked.
+	CallEventDTMF CallEventType = "call.dtmf"
+	// CallEventScheduled reminds the client that a scheduled call is due, for
+	// the user to place it. It carries no CallID.
+	CallEventScheduled CallEventType = "call.scheduled"
+)
+
+// CallEvent is a change in a call's lifecycle delivered to the call owner.
+// ID is assigned by the publisher and grows monotonically, so clients can
+// resume from the last ID they have seen.
+type CallEvent struct {
+	ID           string        `json:"id,omitempty"`
+	Type         CallEventType `json:"type"`
+	UserID       string        `json:"-"`
+	CallID       string        `json:"call_id"`
+	Status       CallStatus    `json:"status"`
+	Duration     int           `json:"duration"`
+	OccurredAt   time.Time     `json:"timestamp"`
+	Candidate    *ICECandidate `json:"candidate,omitempty"`
+	DTMF         string        `json:"dtmf,omitempty"`
+	Muted        bool          `json:"muted,omitempty"`
+	ParentCallID string        `json:"parent_call_id,omitempty"`
+	ConferenceID string        `json:"conference_id,omitempty"`
+	// ScheduledCallID and PhoneNumber describe a CallEventScheduled.
+	ScheduledCallID string `json:"scheduled_call_id,omitempty"`
+	PhoneNumber     string `json:"phone_number,omitempty"`
+}
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event *CallEvent) error
+}
+
+// EventDeliverer is implemented by publishers that can tell whether an
+// event reached anyone.
+type EventDeliverer interface {
+	// Deliver publishes the event only if the user has a subscriber and
+	// returns how many subscribers received it. With none, nothing is
+	// published.
+	Deliver(ctx context.Context, event *CallEvent) (int, error)
+}
+
+type EventSubscription interface {
+	// Events delivers events in publish order. The channel is closed when the
+	// subscription ends, after which the client resumes from its last event ID.
+	Events() <-chan *CallEvent
+	Close()
+}
+
+type EventSubscriber interface {
+	Subscribe(userID, lastEventID string) (EventSubscription, error)
+}
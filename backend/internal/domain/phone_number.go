@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// PhoneNumber is a provider number owned or rented by a user. Calls to it
+// ring the owner's browser.
+type PhoneNumber struct {
+	ID          string
+	Number      string
+	UserID      string
+	ProviderSID string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrOffHours is matched by OffHoursError.
+var ErrOffHours = errors.New("destination is in quiet hours")
+
+type QuietHoursMode string
+
+const (
+	QuietHoursOff QuietHoursMode = "off"
+	// QuietHoursWarn lets the call through and reports the destination's
+	// local time.
+	QuietHoursWarn QuietHoursMode = "warn"
+	// QuietHoursConfirm refuses the call until the caller confirms it.
+	QuietHoursConfirm QuietHoursMode = "confirm"
+)
+
+// QuietHours is the part of the day, in the destination's local time, when
+// calls should not wake anyone. Start and End are minutes after midnight;
+// a window with Start after End runs over midnight.
+type QuietHours struct {
+	Mode  QuietHoursMode
+	Start int
+	End   int
+}
+
+// ParseQuietHours reads a mode and a "22:00-08:00" window.
+func ParseQuietHours(mode, window string) (QuietHours, error) {
+	switch QuietHoursMode(mode) {
+	case QuietHoursOff, QuietHoursWarn, QuietHoursConfirm:
+	default:
+		return QuietHours{}, fmt.Errorf("invalid quiet hours mode %q", mode)
+	}
+
+	from, to, ok := strings.Cut(window, "-")
+	if !ok {
+		return QuietHours{}, fmt.Errorf("invalid quiet hours %q", window)
+	}
+	start, err := parseClock(from)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	end, err := parseClock(to)
+	if err != nil {
+		return QuietHours{}, err
+	}
+	if start == end {
+		return QuietHours{}, fmt.Errorf("invalid quiet hours %q", window)
+	}
+
+	return QuietHours{Mode: QuietHoursMode(mode), Start: start, End: end}, nil
+}
+
+// Window formats the quiet hours as "22:00-08:00".
+func (q QuietHours) Window() string {
+	return formatClock(q.Start) + "-" + formatClock(q.End)
+}
+
+// Contains reports whether t, in its own location, falls in the quiet
+// hours.
+func (q QuietHours) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if q.Start < q.End {
+		return minute >= q.Start && minute < q.End
+	}
+	return minute >= q.Start || minute < q.End
+}
+
+// OffHoursError refuses a call placed in the destination's quiet hours
+// without the caller's confirmation.
+type OffHoursError struct {
+	LocalTime time.Time
+	TimeZone  string
+}
+
+func (e *OffHoursError) Error() string {
+	return ErrOffHours.Error()
+}
+
+func (e *OffHoursError) Is(target error) bool {
+	return target == ErrOffHours
+}
+
+// UserSettings holds a user's preferences. A nil QuietHours uses the
+// platform defaults.
+type UserSettings struct {
+	UserID     string
+	QuietHours *QuietHours
+	UpdatedAt  time.Time
+}
+
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
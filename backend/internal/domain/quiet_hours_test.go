@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDestinationTimeZone(t *testing.T) {
+	tests := map[string]string{
+		"+12125550100":   "America/New_York",
+		"+14155550100":   "America/Los_Angeles",
+		"+16025550100":   "America/Phoenix",
+		"+74951234567":   "Europe/Moscow",
+		"+74232123456":   "Asia/Vladivostok",
+		"+77011234567":   "Asia/Almaty",
+		"+491512345678":  "Europe/Berlin",
+		"+5592987654321": "America/Manaus",
+		"+526641234567":  "America/Tijuana",
+		"+525512345678":  "America/Mexico_City",
+	}
+	for number, want := range tests {
+		loc, ok := DestinationTimeZone(number)
+		if !ok || loc.String() != want {
+			t.Errorf("%s: expected %s, got %v (%v)", number, want, loc, ok)
+		}
+	}
+
+	// Mobiles in multi-zone countries and unlisted codes have no zone.
+	for _, number := range []string{"+79161234567", "+61412345678", "+18005550100", "+6281234567890", "+2421234567"} {
+		if loc, ok := DestinationTimeZone(number); ok {
+			t.Errorf("%s: expected no zone, got %s", number, loc)
+		}
+	}
+}
+
+func TestDestinationTimeZones(t *testing.T) {
+	zones := func(number string) []string {
+		var names []string
+		for _, loc := range DestinationTimeZones(number) {
+			names = append(names, loc.String())
+		}
+		return names
+	}
+
+	if got := zones("+491512345678"); len(got) != 1 || got[0] != "Europe/Berlin" {
+		t.Errorf("expected only Europe/Berlin, got %v", got)
+	}
+
+	got := zones("+61412345678")
+	want := []string{"Australia/Brisbane", "Australia/Melbourne", "Australia/Perth", "Australia/Sydney"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	}
+
+	russia := strings.Join(zones("+79161234567"), ",")
+	for _, name := range []string{"Europe/Kaliningrad", "Europe/Moscow", "Asia/Novosibirsk", "Asia/Kamchatka"} {
+		if !strings.Contains(russia, name) {
+			t.Errorf("expected %s among the zones of a Russian mobile, got %s", name, russia)
+		}
+	}
+
+	if got := zones("+2421234567"); len(got) != 0 {
+		t.Errorf("expected no zones for an unlisted country, got %v", got)
+	}
+}
+
+func TestDestinationTimeZones_Load(t *testing.T) {
+	for prefix, name := range destinationTimeZones {
+		if name == "" {
+			continue
+		}
+		if _, err := time.LoadLocation(name); err != nil {
+			t.Errorf("%s: %v", prefix, err)
+		}
+	}
+}
+
+func TestQuietHours_Contains(t *testing.T) {
+	night, err := ParseQuietHours("confirm", "22:00-08:00")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	afternoon, _ := ParseQuietHours("warn", "13:00-15:00")
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 10, 19, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		quiet QuietHours
+		t     time.Time
+		want  bool
+	}{
+		{night, at(3, 0), true},
+		{night, at(22, 0), true},
+		{night, at(7, 59), true},
+		{night, at(8, 0), false},
+		{night, at(12, 0), false},
+		{afternoon, at(14, 0), true},
+		{afternoon, at(15, 0), false},
+		{afternoon, at(3, 0), false},
+	}
+	for _, tt := range tests {
+		if got := tt.quiet.Contains(tt.t); got != tt.want {
+			t.Errorf("%s at %s: expected %v, got %v", tt.quiet.Window(), tt.t.Format("15:04"), tt.want, got)
+		}
+	}
+
+	if night.Window() != "22:00-08:00" {
+		t.Errorf("expected 22:00-08:00, got %s", night.Window())
+	}
+}
+
+func TestParseQuietHours_Invalid(t *testing.T) {
+	for _, tt := range [][2]string{{"loud", "22:00-08:00"}, {"warn", "22:00"}, {"warn", "25:00-08:00"}, {"warn", "08:00-08:00"}} {
+		if _, err := ParseQuietHours(tt[0], tt[1]); err == nil {
+			t.Errorf("expected %s %s to be rejected", tt[0], tt[1])
+		}
+	}
+}
+
+func TestOffHoursError_Is(t *testing.T) {
+	var err error = &OffHoursError{TimeZone: "Europe/Berlin"}
+	if !errors.Is(err, ErrOffHours) {
+		t.Error("expected OffHoursError to match ErrOffHours")
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Amount is a sum of money in millionths of the currency unit, fine enough
+// for per-minute carrier rates.
+type Amount int64
+
+const amountScale = 1_000_000
+
+// ParseAmount parses a non-negative decimal such as "0.013".
+func ParseAmount(s string) (Amount, error) {
+	units, fraction, _ := strings.Cut(s, ".")
+	if units == "" || len(fraction) > 6 ||
+		strings.Trim(units, "0123456789") != "" || strings.Trim(fraction, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	whole, err := strconv.ParseInt(units, 10, 64)
+	if err != nil || whole > (1<<62)/amountScale {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	micros, _ := strconv.ParseInt(fraction+strings.Repeat("0", 6-len(fraction)), 10, 64)
+	return Amount(whole*amountScale + micros), nil
+}
+
+// String formats the amount with at least two decimals: "0.026", "1.50".
+func (a Amount) String() string {
+	s := fmt.Sprintf("%d.%06d", a/amountScale, a%amountScale)
+	for strings.HasSuffix(s, "0") && len(s)-strings.Index(s, ".") > 3 {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// CallRates prices outbound calls per started minute by destination prefix,
+// the way RecordingPolicy picks rules: the longest matching prefix wins and
+// "*" sets the default.
+type CallRates struct {
+	currency string
+	rates    map[string]Amount
+}
+
+// NewCallRates parses "prefix:rate" entries such as "+1:0.013" or "*:0.1",
+// rates being per minute in currency.
+func NewCallRates(entries []string, currency string) (*CallRates, error) {
+	rates := &CallRates{currency: currency, rates: make(map[string]Amount, len(entries))}
+	for _, entry := range entries {
+		prefix, rate, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid call rate entry %q", entry)
+		}
+		if prefix != "*" && !isDialPrefix(prefix) {
+			return nil, fmt.Errorf("invalid call rate prefix %q", prefix)
+		}
+		amount, err := ParseAmount(rate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid call rate for %q: %w", prefix, err)
+		}
+		rates.rates[prefix] = amount
+	}
+	return rates, nil
+}
+
+func (r *CallRates) Currency() string {
+	if r == nil {
+		return ""
+	}
+	return r.currency
+}
+
+// Cost prices a call of seconds to an E.164 number. ok is false when no
+// rate applies: without rates, without a matching entry or "*" default, or
+// for a leg to a user's browser, which has no number.
+func (r *CallRates) Cost(number string, seconds int) (cost Amount, ok bool) {
+	if r == nil || number == "" {
+		return 0, false
+	}
+	rate, ok := r.rates["*"]
+	for i := len(number); i >= 2; i-- {
+		if prefixRate, found := r.rates[number[:i]]; found {
+			rate, ok = prefixRate, true
+			break
+		}
+	}
+	if !ok {
+		return 0, false
+	}
+	minutes := (seconds + 59) / 60
+	return rate * Amount(minutes), true
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "testing"
+
+func TestParseAmount(t *testing.T) {
+	tests := map[string]string{
+		"0.013":    "0.013",
+		"1.5":      "1.50",
+		"2":        "2.00",
+		"0.000001": "0.000001",
+	}
+	for input, want := range tests {
+		amount, err := ParseAmount(input)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", input, err)
+		}
+		if got := amount.String(); got != want {
+			t.Errorf("%s: expected %s, got %s", input, want, got)
+		}
+	}
+
+	for _, input := range []string{"", ".5", "-1", "0.0000001", "1,5", "abc"} {
+		if _, err := ParseAmount(input); err == nil {
+			t.Errorf("expected %q to be rejected", input)
+		}
+	}
+}
+
+func TestCallRates_Cost(t *testing.T) {
+	rates, err := NewCallRates([]string{"+1:0.013", "+1415:0.02", "*:0.1"}, "USD")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		number  string
+		seconds int
+		want    string
+	}{
+		{"+12125550100", 60, "0.013"},
+		{"+12125550100", 61, "0.026"},
+		{"+14155550100", 30, "0.02"},
+		{"+491512345678", 125, "0.30"},
+		{"+491512345678", 0, "0.00"},
+	}
+	for _, tt := range tests {
+		cost, ok := rates.Cost(tt.number, tt.seconds)
+		if !ok {
+			t.Fatalf("%s: expected a rate", tt.number)
+		}
+		if got := cost.String(); got != tt.want {
+			t.Errorf("%s for %ds: expected %s, got %s", tt.number, tt.seconds, tt.want, got)
+		}
+	}
+
+	if _, ok := rates.Cost("", 60); ok {
+		t.Error("expected no cost for a leg without a number")
+	}
+
+	var none *CallRates
+	if _, ok := none.Cost("+12125550100", 60); ok {
+		t.Error("expected no cost without rates")
+	}
+}
+
+func TestNewCallRates_Invalid(t *testing.T) {
+	for _, entry := range []string{"+1", "1:0.01", "+1:free", "+1:-0.01"} {
+		if _, err := NewCallRates([]string{entry}, "USD"); err == nil {
+			t.Errorf("expected %q to be rejected", entry)
+		}
+	}
+}
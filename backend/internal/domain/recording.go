@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrRecordingForbidden = errors.New("recording not allowed for destination")
+
+// Recording is an audio recording of a call, made when the caller opted in.
+// The audio lives in a BlobStore under BlobKey.
+type Recording struct {
+	ID           string
+	CallID       string
+	UserID       string
+	RecordingSID string
+	BlobKey      string
+	ContentType  string
+	Size         int64
+	Duration     int
+	CreatedAt    time.Time
+}
+
+// RecordingRule says whether a call to a destination may be recorded.
+type RecordingRule string
+
+const (
+	RecordingAllowed RecordingRule = "allow"
+	// RecordingConsent requires the called party to hear an announcement
+	// before the call is connected.
+	RecordingConsent   RecordingRule = "consent"
+	RecordingForbidden RecordingRule = "forbid"
+)
+
+// RecordingPolicy maps destinations to recording rules by country calling
+// code ("+49"), or by a longer prefix where a country needs a finer rule.
+// The longest matching prefix wins; "*" sets the default.
+type RecordingPolicy struct {
+	rules map[string]RecordingRule
+}
+
+// NewRecordingPolicy parses "prefix:rule" entries such as "+49:consent",
+// "+86:forbid" or "*:allow".
+func NewRecordingPolicy(entries []string) (*RecordingPolicy, error) {
+	policy := &RecordingPolicy{rules: make(map[string]RecordingRule, len(entries))}
+	for _, entry := range entries {
+		prefix, rule, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid recording policy entry %q", entry)
+		}
+		if prefix != "*" && !isDialPrefix(prefix) {
+			return nil, fmt.Errorf("invalid recording policy prefix %q", prefix)
+		}
+		switch RecordingRule(rule) {
+		case RecordingAllowed, RecordingConsent, RecordingForbidden:
+		default:
+			return nil, fmt.Errorf("invalid recording policy rule %q", rule)
+		}
+		policy.rules[prefix] = RecordingRule(rule)
+	}
+	return policy, nil
+}
+
+// Rule returns the rule for an E.164 number. Without a matching entry or
+// "*" default, recording needs consent.
+func (p *RecordingPolicy) Rule(number string) RecordingRule {
+	if p == nil {
+		return RecordingConsent
+	}
+	for i := len(number); i >= 2; i-- {
+		if rule, ok := p.rules[number[:i]]; ok {
+			return rule
+		}
+	}
+	if rule, ok := p.rules["*"]; ok {
+		return rule
+	}
+	return RecordingConsent
+}
+
+// isDialPrefix accepts the start of an E.164 number: "+", then digits.
+func isDialPrefix(prefix string) bool {
+	return len(prefix) >= 2 && prefix[0] == '+' && strings.Trim(prefix[1:], "0123456789") == ""
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "testing"
+
+func TestRecordingPolicy_Rule(t *testing.T) {
+	policy, err := NewRecordingPolicy([]string{"+1:allow", "+1415:consent", "+86:forbid", "*:consent"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tests := map[string]RecordingRule{
+		"+12125550100":   RecordingAllowed,
+		"+14155550100":   RecordingConsent,
+		"+8613800138000": RecordingForbidden,
+		"+491512345678":  RecordingConsent,
+	}
+	for number, want := range tests {
+		if got := policy.Rule(number); got != want {
+			t.Errorf("%s: expected %s, got %s", number, want, got)
+		}
+	}
+
+	var none *RecordingPolicy
+	if got := none.Rule("+12125550100"); got != RecordingConsent {
+		t.Errorf("expected consent without a policy, got %s", got)
+	}
+}
+
+func TestNewRecordingPolicy_Invalid(t *testing.T) {
+	for _, entry := range []string{"+49", "49:allow", "+49:record", "+4a:allow", "+:allow"} {
+		if _, err := NewRecordingPolicy([]string{entry}); err == nil {
+			t.Errorf("expected %q to be rejected", entry)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
@@ -12,5 +15,94 @@ type CallRepository interface {
 	Create(ctx context.Context, call *Call) error
 	Update(ctx context.Context, call *Call) error
 	GetByID(ctx context.Context, id string) (*Call, error)
+	GetByProviderCallSID(ctx context.Context, providerCallSID string) (*Call, error)
 	ListByUserID(ctx context.Context, userID string) ([]*Call, error)
 }
+
+type PhoneNumberRepository interface {
+	GetByNumber(ctx context.Context, number string) (*PhoneNumber, error)
+	ListByUserID(ctx context.Context, userID string) ([]*PhoneNumber, error)
+}
+
+type CallerIDRepository interface {
+	Create(ctx context.Context, callerID *CallerID) error
+	Update(ctx context.Context, callerID *CallerID) error
+	GetByID(ctx context.Context, id string) (*CallerID, error)
+	GetByUserIDAndNumber(ctx context.Context, userID, number string) (*CallerID, error)
+	// GetDefault returns the user's default caller ID, or nil.
+	GetDefault(ctx context.Context, userID string) (*CallerID, error)
+	// ListByUserID returns the user's caller IDs, oldest first.
+	ListByUserID(ctx context.Context, userID string) ([]*CallerID, error)
+	// SetDefault makes the caller ID the user's default and unsets the
+	// previous one.
+	SetDefault(ctx context.Context, userID, id string) error
+	Delete(ctx context.Context, id string) error
+}
+
+type VoicemailRepository interface {
+	Create(ctx context.Context, voicemail *Voicemail) error
+	GetByID(ctx context.Context, id string) (*Voicemail, error)
+	GetByRecordingSID(ctx context.Context, recordingSID string) (*Voicemail, error)
+	// ListByUserID returns the user's voicemails, newest first.
+	ListByUserID(ctx context.Context, userID string) ([]*Voicemail, error)
+	ListOlderThan(ctx context.Context, cutoff time.Time) ([]*Voicemail, error)
+	MarkRead(ctx context.Context, id string, readAt time.Time) error
+	Delete(ctx context.Context, id string) error
+}
+
+type RecordingRepository interface {
+	Create(ctx context.Context, recording *Recording) error
+	GetByID(ctx context.Context, id string) (*Recording, error)
+	GetByRecordingSID(ctx context.Context, recordingSID string) (*Recording, error)
+	// ListByCallID returns the call's recordings, oldest first.
+	ListByCallID(ctx context.Context, callID string) ([]*Recording, error)
+}
+
+type ConferenceRepository interface {
+	Create(ctx context.Context, conference *Conference) error
+	Update(ctx context.Context, conference *Conference) error
+	GetByID(ctx context.Context, id string) (*Conference, error)
+	// ListByUserID returns the user's conferences, newest first.
+	ListByUserID(ctx context.Context, userID string) ([]*Conference, error)
+	// ListParticipants returns the calls that joined the conference, oldest
+	// first.
+	ListParticipants(ctx context.Context, conferenceID string) ([]*Call, error)
+}
+
+type ScheduledCallRepository interface {
+	Create(ctx context.Context, scheduled *ScheduledCall) error
+	// Update saves a pending scheduled call. It returns
+	// ErrScheduledCallNotPending if the call is no longer pending.
+	Update(ctx context.Context, scheduled *ScheduledCall) error
+	GetByID(ctx context.Context, id string) (*ScheduledCall, error)
+	// ListByUserID returns the user's scheduled calls, soonest first.
+	ListByUserID(ctx context.Context, userID string) ([]*ScheduledCall, error)
+	// Delete removes a scheduled call that is not being fired. It returns
+	// ErrScheduledCallNotPending if a scheduler holds it.
+	Delete(ctx context.Context, id string) error
+	// ClaimDue marks up to limit pending calls due at now as firing and
+	// returns them. Rows are locked while claimed, so concurrent replicas
+	// never claim the same call.
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]*ScheduledCall, error)
+	// Finish records how a firing call ended, or returns it to pending to
+	// be claimed again.
+	Finish(ctx context.Context, scheduled *ScheduledCall) error
+	// FailStale marks calls claimed before the given time, whose replica
+	// stopped while firing them, as failed. It returns how many there were.
+	FailStale(ctx context.Context, claimedBefore time.Time) (int, error)
+}
+
+type UserSettingsRepository interface {
+	// Get returns nil when the user has not changed any settings.
+	Get(ctx context.Context, userID string) (*UserSettings, error)
+	Save(ctx context.Context, settings *UserSettings) error
+}
+
+type AuditRepository interface {
+	Create(ctx context.Context, event *AuditEvent) error
+	// Count returns how many events match the filter.
+	Count(ctx context.Context, filter AuditFilter) (int, error)
+	// ListByCallID returns the call's events of the given type, oldest
+	// first.
+	ListByCallID(ctx context.Context, callID string, eventType AuditEventType) ([]*AuditEvent, error)
+}
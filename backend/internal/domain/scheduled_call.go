@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrScheduledCallNotPending is returned when a scheduled call is changed
+// after the scheduler has picked it up.
+var ErrScheduledCallNotPending = errors.New("scheduled call is not pending")
+
+type ScheduledCallStatus string
+
+const (
+	ScheduledCallStatusPending ScheduledCallStatus = "pending"
+	// ScheduledCallStatusFiring is held while one scheduler replica places
+	// the call; no other replica picks the call up then.
+	ScheduledCallStatusFiring ScheduledCallStatus = "firing"
+	ScheduledCallStatusFired  ScheduledCallStatus = "fired"
+	// ScheduledCallStatusMissed is set when the backend was down at the
+	// scheduled time for longer than the scheduler's grace period.
+	ScheduledCallStatusMissed ScheduledCallStatus = "missed"
+	ScheduledCallStatusFailed ScheduledCallStatus = "failed"
+)
+
+type ScheduledCallMode string
+
+const (
+	// ScheduledCallModeNotify reminds the user's connected client to place
+	// the call from the browser.
+	ScheduledCallModeNotify ScheduledCallMode = "notify"
+	// ScheduledCallModeCallback places a callback call: the user's verified
+	// CallerNumber rings first, then PhoneNumber.
+	ScheduledCallModeCallback ScheduledCallMode = "callback"
+)
+
+func (m ScheduledCallMode) Valid() bool {
+	return m == ScheduledCallModeNotify || m == ScheduledCallModeCallback
+}
+
+// ScheduledCall is a call a user has planned for a later time. ScheduledAt
+// is the instant to fire at; TimeZone is the IANA zone the user picked the
+// time in, kept to show it back in the same zone.
+type ScheduledCall struct {
+	ID           string
+	UserID       string
+	PhoneNumber  string
+	CallerNumber string
+	Mode         ScheduledCallMode
+	ScheduledAt  time.Time
+	TimeZone     string
+	Status       ScheduledCallStatus
+	// ClaimedAt is when a scheduler replica took the call for firing.
+	ClaimedAt *time.Time
+	FiredAt   *time.Time
+	// CallID is the callback call placed for the scheduled call.
+	CallID    string
+	Error     string
+	CreatedAt time.Time
+}
+
+// LocalTime is ScheduledAt in the zone it was scheduled in.
+func (s *ScheduledCall) LocalTime() time.Time {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return s.ScheduledAt
+	}
+	return s.ScheduledAt.In(loc)
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// Voicemail is a message left on a call that nobody answered. The audio
+// lives in a BlobStore under BlobKey.
+type Voicemail struct {
+	ID           string
+	UserID       string
+	CallID       string
+	From         string
+	RecordingSID string
+	BlobKey      string
+	ContentType  string
+	Size         int64
+	Duration     int
+	ReadAt       *time.Time
+	CreatedAt    time.Time
+}
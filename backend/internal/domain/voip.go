@@ -3,38 +3,267 @@ package domain
 import (
 	"context"
 	"errors"
+	"regexp"
 	"time"
 )
 
-var ErrInvalidPhoneNumber = errors.New("invalid phone number")
+var (
+	ErrInvalidPhoneNumber = errors.New("invalid phone number")
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrSessionEnded       = errors.New("session already ended")
+	ErrCandidatesComplete = errors.New("end of candidates already signalled")
+	ErrInvalidDTMF        = errors.New("invalid dtmf digits")
+)
+
+// dtmfRe accepts the digits a keypad can send and "w", a half-second pause.
+var dtmfRe = regexp.MustCompile(`^[0-9*#w]{1,64}$`)
+
+// ValidateDTMF checks a digit sequence for SendDTMF.
+func ValidateDTMF(digits string) error {
+	if !dtmfRe.MatchString(digits) {
+		return ErrInvalidDTMF
+	}
+	return nil
+}
+
+// MaskDTMF hides the digits of a sequence, which may be a PIN or a card
+// number, and keeps its pauses.
+func MaskDTMF(digits string) string {
+	masked := []rune(digits)
+	for i, r := range masked {
+		if r != 'w' {
+			masked[i] = '•'
+		}
+	}
+	return string(masked)
+}
 
 type VoIPService interface {
-	InitiateCall(ctx context.Context, phoneNumber string) (*CallSession, error)
+	InitiateCall(ctx context.Context, phoneNumber string, opts CallOptions) (*CallSession, error)
 	TerminateCall(ctx context.Context, sessionID string) error
 	GetSessionStatus(ctx context.Context, sessionID string) (SessionStatus, error)
 }
 
+// AnswerAcceptor is implemented by VoIP services whose sessions negotiate
+// media with the browser's SDP answer.
+type AnswerAcceptor interface {
+	// AcceptAnswer hands the browser's validated SDP answer to the session.
+	AcceptAnswer(ctx context.Context, sessionID string, sdpAnswer string) error
+}
+
+// ICETrickler is implemented by VoIP services whose sessions take ICE
+// candidates trickled by the browser after its answer.
+type ICETrickler interface {
+	// AddICECandidate hands a trickled browser candidate, or the
+	// end-of-candidates marker, to the session.
+	AddICECandidate(ctx context.Context, sessionID string, candidate ICECandidate) error
+}
+
+// DTMFSender is implemented by VoIP services that can play DTMF digits into
+// a call, e.g. to navigate an IVR.
+type DTMFSender interface {
+	// SendDTMF plays a validated digit sequence to the called party. opts
+	// describe the call as it was initiated, for providers that resume the
+	// call flow once the digits are played.
+	SendDTMF(ctx context.Context, sessionID string, digits string, opts CallOptions) error
+}
+
+// CallHolder is implemented by VoIP services that can put a call on hold.
+// While held neither party hears the other and the called party hears the
+// provider's hold music.
+type CallHolder interface {
+	Hold(ctx context.Context, sessionID string) error
+	// Resume reconnects a held call. opts describe the call as in SendDTMF.
+	Resume(ctx context.Context, sessionID string, opts CallOptions) error
+}
+
+// CallMuter is implemented by VoIP services that can keep the caller's audio
+// from reaching the called party.
+type CallMuter interface {
+	SetMuted(ctx context.Context, sessionID string, muted bool) error
+}
+
+// ProviderCallTerminator is implemented by VoIP services that can hang up a
+// provider call that has no session, such as a Voice SDK call the browser
+// placed itself.
+type ProviderCallTerminator interface {
+	HangUp(ctx context.Context, providerCallSID string) error
+}
+
+// TransferTarget is who a call is transferred to: a phone number, or a
+// platform user reached in their browser. Exactly one field is set.
+type TransferTarget struct {
+	PhoneNumber string
+	UserID      string
+}
+
+// CallTransferrer is implemented by VoIP services that can hand the called
+// party of a call over to someone else.
+type CallTransferrer interface {
+	// Transfer connects the called party of the session to target and
+	// disconnects the caller. legID is the call record of the new leg,
+	// which the provider's status reports refer to.
+	Transfer(ctx context.Context, sessionID string, target TransferTarget, legID string) error
+	// Consult places a call from the caller to target, to talk before a
+	// transfer. It is answered like an initiated call.
+	Consult(ctx context.Context, target TransferTarget, opts CallOptions) (*CallSession, error)
+	// Bridge connects the called parties of a held call and of a
+	// consultation call to each other and disconnects the caller from both.
+	Bridge(ctx context.Context, sessionID, consultSessionID string) error
+}
+
+// Conferencer is implemented by VoIP services that can join calls into a
+// conference.
+type Conferencer interface {
+	// HostConference connects the caller's browser to the conference. The
+	// conference ends when the host leaves it.
+	HostConference(ctx context.Context, conferenceID string, opts CallOptions) (*CallSession, error)
+	// JoinConference moves the called party of the session into the
+	// conference, away from the caller. Joining again with another muted
+	// flag mutes or unmutes the participant.
+	JoinConference(ctx context.Context, sessionID, conferenceID string, muted bool) error
+}
+
+// CallbackPlacer is implemented by VoIP services that can place callback
+// calls, for callers whose connection is too poor for a browser call.
+type CallbackPlacer interface {
+	// PlaceCallback rings the caller's phone, callerNumber. Once it
+	// answers, the provider asks for the destination of callID, the call
+	// record of this first leg, and dials it.
+	PlaceCallback(ctx context.Context, callerNumber, callID string) (*CallSession, error)
+}
+
+// CallerIDVerifier is implemented by VoIP services that can prove a user
+// owns a phone number by calling it.
+type CallerIDVerifier interface {
+	// ReadOutCode calls phoneNumber and reads the verification code out to
+	// whoever answers.
+	ReadOutCode(ctx context.Context, phoneNumber, code string) error
+}
+
+// VoIPCapabilities lists the optional call controls a VoIP service supports.
+type VoIPCapabilities struct {
+	DTMF       bool `json:"dtmf"`
+	Hold       bool `json:"hold"`
+	Mute       bool `json:"mute"`
+	Transfer   bool `json:"transfer"`
+	Conference bool `json:"conference"`
+	Callback   bool `json:"callback"`
+	// VerifyCallerID tells whether users can register their own numbers
+	// as caller IDs.
+	VerifyCallerID bool `json:"verify_caller_id"`
+}
+
+func CapabilitiesOf(service VoIPService) VoIPCapabilities {
+	_, dtmf := service.(DTMFSender)
+	_, hold := service.(CallHolder)
+	_, mute := service.(CallMuter)
+	_, transfer := service.(CallTransferrer)
+	_, conference := service.(Conferencer)
+	_, callback := service.(CallbackPlacer)
+	_, verify := service.(CallerIDVerifier)
+	return VoIPCapabilities{
+		DTMF:           dtmf,
+		Hold:           hold,
+		Mute:           mute,
+		Transfer:       transfer,
+		Conference:     conference,
+		Callback:       callback,
+		VerifyCallerID: verify,
+	}
+}
+
+type SessionStore interface {
+	Save(ctx context.Context, session *CallSession) error
+	Get(ctx context.Context, sessionID string) (*CallSession, error)
+	UpdateStatus(ctx context.Context, sessionID string, status SessionStatus) (*CallSession, error)
+	// AddCandidate appends a trickled candidate to one side of the session.
+	// Save never touches candidates, so the two cannot overwrite each other.
+	AddCandidate(ctx context.Context, sessionID string, origin CandidateOrigin, candidate ICECandidate) (*CallSession, error)
+	// SetAnswer stores the browser's SDP answer on a session that has not
+	// ended, leaving its status and candidates as they are.
+	SetAnswer(ctx context.Context, sessionID, sdpAnswer string) (*CallSession, error)
+	Delete(ctx context.Context, sessionID string) error
+	Close() error
+}
+
+type CallOptions struct {
+	Identity string
+	// Record asks the provider to record the call, subject to the
+	// destination's recording policy.
+	Record bool
+	// CallerID is the user's verified number shown to the called party;
+	// empty means the platform's number.
+	CallerID string
+}
+
 type CallSession struct {
-	SessionID    string
-	PhoneNumber  string
-	SDPOffer     string
-	Status       SessionStatus
-	CreatedAt    time.Time
-	ExpiresAt    time.Time
+	SessionID       string
+	ProviderCallSID string
+	PhoneNumber     string
+	SDPOffer        string
+	SDPAnswer       string
+	// LocalCandidates are ours, pushed to the browser; RemoteCandidates came
+	// from the browser. Both end with the end-of-candidates marker once
+	// gathering is over.
+	LocalCandidates  []ICECandidate
+	RemoteCandidates []ICECandidate
+	Status           SessionStatus
+	CreatedAt        time.Time
+	ExpiresAt        time.Time
 }
 
+// ICECandidate is a trickled candidate in the browser's RTCIceCandidateInit
+// shape. An empty Candidate is the end-of-candidates marker.
+type ICECandidate struct {
+	Candidate        string  `json:"candidate"`
+	SDPMid           string  `json:"sdpMid,omitempty"`
+	SDPMLineIndex    *uint16 `json:"sdpMLineIndex,omitempty"`
+	UsernameFragment string  `json:"usernameFragment,omitempty"`
+}
+
+func (c ICECandidate) IsEndOfCandidates() bool {
+	return c.Candidate == ""
+}
+
+// CandidatesComplete reports whether a candidate list has been closed with
+// the end-of-candidates marker.
+func CandidatesComplete(candidates []ICECandidate) bool {
+	return len(candidates) > 0 && candidates[len(candidates)-1].IsEndOfCandidates()
+}
+
+type CandidateOrigin string
+
+const (
+	CandidateOriginLocal  CandidateOrigin = "local"
+	CandidateOriginRemote CandidateOrigin = "remote"
+)
+
 type SessionStatus string
 
 const (
 	SessionStatusInitialized SessionStatus = "initialized"
 	SessionStatusConnecting  SessionStatus = "connecting"
+	SessionStatusRinging     SessionStatus = "ringing"
 	SessionStatusActive      SessionStatus = "active"
 	SessionStatusCompleted   SessionStatus = "completed"
+	SessionStatusBusy        SessionStatus = "busy"
+	SessionStatusNoAnswer    SessionStatus = "no_answer"
 	SessionStatusFailed      SessionStatus = "failed"
 )
 
+func (s SessionStatus) IsTerminal() bool {
+	switch s {
+	case SessionStatusCompleted, SessionStatusBusy, SessionStatusNoAnswer, SessionStatusFailed:
+		return true
+	}
+	return false
+}
+
 type WebRTCConfig struct {
 	IceServers []IceServer `json:"iceServers"`
+	// TTL is how many seconds the TURN credentials stay valid.
+	TTL int `json:"ttl,omitempty"`
 }
 
 type IceServer struct {
@@ -0,0 +1,83 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+func TestLocalStore_PutOpenDelete(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	ctx := context.Background()
+
+	n, err := store.Put(ctx, "voicemail/user-1/RE1.wav", strings.NewReader("RIFF-audio"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if n != 10 {
+		t.Errorf("expected 10 bytes written, got %d", n)
+	}
+
+	f, err := store.Open(ctx, "voicemail/user-1/RE1.wav")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := f.Seek(5, io.SeekStart); err != nil {
+		t.Fatalf("expected blob to be seekable, got %v", err)
+	}
+	rest, _ := io.ReadAll(f)
+	f.Close()
+	if string(rest) != "audio" {
+		t.Errorf("expected 'audio', got %q", rest)
+	}
+
+	if err := store.Delete(ctx, "voicemail/user-1/RE1.wav"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := store.Open(ctx, "voicemail/user-1/RE1.wav"); !errors.Is(err, domain.ErrBlobNotFound) {
+		t.Errorf("expected ErrBlobNotFound after delete, got %v", err)
+	}
+	if err := store.Delete(ctx, "voicemail/user-1/RE1.wav"); !errors.Is(err, domain.ErrBlobNotFound) {
+		t.Errorf("expected ErrBlobNotFound for a missing blob, got %v", err)
+	}
+}
+
+func TestLocalStore_FailedPutLeavesNoObject(t *testing.T) {
+	root := t.TempDir()
+	store, _ := NewLocalStore(root)
+
+	_, err := store.Put(context.Background(), "a/b.wav", io.MultiReader(strings.NewReader("partial"), errReader{}))
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	entries, _ := os.ReadDir(filepath.Join(root, "a"))
+	if len(entries) != 0 {
+		t.Errorf("expected no files after a failed put, got %v", entries)
+	}
+}
+
+func TestLocalStore_RejectsKeysOutsideRoot(t *testing.T) {
+	store, _ := NewLocalStore(t.TempDir())
+
+	for _, key := range []string{"", "../etc/passwd", "a/../../b", "/abs", "a//b", "a/./b", ".hidden", `a\b`} {
+		if _, err := store.Put(context.Background(), key, strings.NewReader("x")); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("key %q: expected ErrInvalidKey, got %v", key, err)
+		}
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
@@ -0,0 +1,195 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+// historySize is how many recent events are kept per user for resuming.
+const historySize = 100
+
+// historyTTL is how long the history of a user without subscribers is kept
+// after their newest event, for clients to resume after a reconnect. Expired
+// histories are swept at most once per historySweepInterval.
+const (
+	historyTTL           = 10 * time.Minute
+	historySweepInterval = time.Minute
+)
+
+// subscriberBuffer is how many undelivered events a subscriber may lag
+// behind before it is dropped and has to resume with its last event ID.
+const subscriberBuffer = 64
+
+var ErrInvalidEventID = domain.ErrInvalidEventID
+
+// Bus is an in-process event bus. Use cases publish call events to it and
+// every open subscription of the event's user receives a copy. Events live
+// only in this process, so subscribers must be connected to the replica
+// that handled the change.
+type Bus struct {
+	mu          sync.Mutex
+	seq         uint64
+	history     map[string][]*domain.CallEvent
+	subscribers map[string]map[*Subscription]struct{}
+	// lastPublished is when each user's newest event was published.
+	lastPublished map[string]time.Time
+	lastSweep     time.Time
+	now           func() time.Time
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		history:       make(map[string][]*domain.CallEvent),
+		subscribers:   make(map[string]map[*Subscription]struct{}),
+		lastPublished: make(map[string]time.Time),
+		now:           time.Now,
+	}
+}
+
+type Subscription struct {
+	bus    *Bus
+	userID string
+	events chan *domain.CallEvent
+	once   sync.Once
+}
+
+func (s *Subscription) Events() <-chan *domain.CallEvent {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.remove(s)
+}
+
+func (b *Bus) Publish(ctx context.Context, event *domain.CallEvent) error {
+	if event == nil || event.UserID == "" {
+		return errors.New("event user is required")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.publish(event)
+	return nil
+}
+
+// Deliver publishes the event only if the user has a subscriber on this
+// replica. Otherwise the event is not kept for resuming either, so a caller
+// that retries later does not leave duplicates behind.
+func (b *Bus) Deliver(ctx context.Context, event *domain.CallEvent) (int, error) {
+	if event == nil || event.UserID == "" {
+		return 0, errors.New("event user is required")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.subscribers[event.UserID]) == 0 {
+		return 0, nil
+	}
+	return b.publish(event), nil
+}
+
+// publish stores the event in the user's history and hands it to their
+// subscribers. It returns how many of them received it.
+func (b *Bus) publish(event *domain.CallEvent) int {
+	b.seq++
+	event.ID = strconv.FormatUint(b.seq, 10)
+
+	history := append(b.history[event.UserID], event)
+	if len(history) > historySize {
+		history = history[len(history)-historySize:]
+	}
+	b.history[event.UserID] = history
+	now := b.now()
+	b.lastPublished[event.UserID] = now
+	b.sweep(now)
+
+	delivered := 0
+	for sub := range b.subscribers[event.UserID] {
+		select {
+		case sub.events <- event:
+			delivered++
+		default:
+			slog.Warn("dropping slow event subscriber", "user_id", event.UserID)
+			b.remove(sub)
+		}
+	}
+	return delivered
+}
+
+// Subscribe registers a subscriber for userID. When lastEventID is set, the
+// retained events published after it are replayed first.
+func (b *Bus) Subscribe(userID, lastEventID string) (domain.EventSubscription, error) {
+	var after uint64
+	if lastEventID != "" {
+		id, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			return nil, ErrInvalidEventID
+		}
+		after = id
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var missed []*domain.CallEvent
+	if lastEventID != "" {
+		for _, event := range b.history[userID] {
+			if id, _ := strconv.ParseUint(event.ID, 10, 64); id > after {
+				missed = append(missed, event)
+			}
+		}
+	}
+
+	sub := &Subscription{
+		bus:    b,
+		userID: userID,
+		events: make(chan *domain.CallEvent, subscriberBuffer+len(missed)),
+	}
+	for _, event := range missed {
+		sub.events <- event
+	}
+
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[*Subscription]struct{})
+	}
+	b.subscribers[userID][sub] = struct{}{}
+
+	return sub, nil
+}
+
+// sweep drops the history of users who have no subscribers and no event
+// newer than historyTTL.
+func (b *Bus) sweep(now time.Time) {
+	if now.Sub(b.lastSweep) < historySweepInterval {
+		return
+	}
+	b.lastSweep = now
+
+	for userID, published := range b.lastPublished {
+		if len(b.subscribers[userID]) > 0 || now.Sub(published) < historyTTL {
+			continue
+		}
+		delete(b.history, userID)
+		delete(b.lastPublished, userID)
+	}
+}
+
+func (b *Bus) remove(sub *Subscription) {
+	sub.once.Do(func() {
+		delete(b.subscribers[sub.userID], sub)
+		if len(b.subscribers[sub.userID]) == 0 {
+			delete(b.subscribers, sub.userID)
+		}
+		close(sub.events)
+	})
+}
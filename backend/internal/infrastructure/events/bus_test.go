@@ -0,0 +1,168 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+func publishStatus(t *testing.T, bus *Bus, userID string, status domain.CallStatus) *domain.CallEvent {
+	t.Helper()
+	event := &domain.CallEvent{
+		Type:   domain.CallEventStatusChanged,
+		UserID: userID,
+		CallID: "call-1",
+		Status: status,
+	}
+	if err := bus.Publish(context.Background(), event); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return event
+}
+
+func TestBus_FanOutToUserSubscriptions(t *testing.T) {
+	bus := NewBus()
+
+	first, err := bus.Subscribe("user-1", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer first.Close()
+	second, _ := bus.Subscribe("user-1", "")
+	defer second.Close()
+	other, _ := bus.Subscribe("user-2", "")
+	defer other.Close()
+
+	published := publishStatus(t, bus, "user-1", domain.CallStatusActive)
+
+	for _, sub := range []domain.EventSubscription{first, second} {
+		select {
+		case event := <-sub.Events():
+			if event.ID != published.ID || event.Status != domain.CallStatusActive {
+				t.Errorf("unexpected event: %+v", event)
+			}
+		default:
+			t.Error("expected event for every subscription of the user")
+		}
+	}
+
+	select {
+	case event := <-other.Events():
+		t.Errorf("expected no event for another user, got %+v", event)
+	default:
+	}
+}
+
+func TestBus_ResumeFromLastEventID(t *testing.T) {
+	bus := NewBus()
+
+	first := publishStatus(t, bus, "user-1", domain.CallStatusConnecting)
+	publishStatus(t, bus, "user-1", domain.CallStatusActive)
+	publishStatus(t, bus, "user-1", domain.CallStatusCompleted)
+
+	sub, err := bus.Subscribe("user-1", first.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer sub.Close()
+
+	var statuses []domain.CallStatus
+	for len(statuses) < 2 {
+		select {
+		case event := <-sub.Events():
+			statuses = append(statuses, event.Status)
+		default:
+			t.Fatalf("expected 2 replayed events, got %v", statuses)
+		}
+	}
+	if statuses[0] != domain.CallStatusActive || statuses[1] != domain.CallStatusCompleted {
+		t.Errorf("expected events after last id in order, got %v", statuses)
+	}
+
+	if _, err := bus.Subscribe("user-1", "abc"); err != ErrInvalidEventID {
+		t.Errorf("expected ErrInvalidEventID, got %v", err)
+	}
+}
+
+func TestBus_DropsSlowSubscriber(t *testing.T) {
+	bus := NewBus()
+
+	sub, _ := bus.Subscribe("user-1", "")
+	for i := 0; i <= subscriberBuffer; i++ {
+		publishStatus(t, bus, "user-1", domain.CallStatusActive)
+	}
+
+	count := 0
+	for range sub.Events() {
+		count++
+	}
+	if count != subscriberBuffer {
+		t.Errorf("expected %d buffered events before drop, got %d", subscriberBuffer, count)
+	}
+
+	sub.Close()
+}
+
+func TestBus_DeliverOnlyToSubscribedUsers(t *testing.T) {
+	bus := NewBus()
+
+	event := &domain.CallEvent{Type: domain.CallEventScheduled, UserID: "user-1", ScheduledCallID: "sc-1"}
+	delivered, err := bus.Deliver(context.Background(), event)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if delivered != 0 || len(bus.history["user-1"]) != 0 {
+		t.Fatalf("expected nothing delivered or kept without subscribers, got %d and %d events", delivered, len(bus.history["user-1"]))
+	}
+
+	first, _ := bus.Subscribe("user-1", "")
+	defer first.Close()
+	second, _ := bus.Subscribe("user-1", "")
+	defer second.Close()
+
+	delivered, err = bus.Deliver(context.Background(), event)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if delivered != 2 {
+		t.Errorf("expected the event delivered to both subscriptions, got %d", delivered)
+	}
+	if received := <-first.Events(); received.ScheduledCallID != "sc-1" {
+		t.Errorf("unexpected event: %+v", received)
+	}
+}
+
+func TestBus_ExpiresHistoryOfUsersWithoutSubscribers(t *testing.T) {
+	bus := NewBus()
+	now := time.Now()
+	bus.now = func() time.Time { return now }
+
+	first := publishStatus(t, bus, "user-1", domain.CallStatusConnecting)
+	publishStatus(t, bus, "user-1", domain.CallStatusActive)
+	sub, _ := bus.Subscribe("user-2", "")
+	defer sub.Close()
+	publishStatus(t, bus, "user-2", domain.CallStatusActive)
+
+	now = now.Add(historyTTL + historySweepInterval)
+	publishStatus(t, bus, "user-3", domain.CallStatusActive)
+
+	if _, ok := bus.history["user-1"]; ok {
+		t.Error("expected expired history of a user without subscribers to be dropped")
+	}
+	if len(bus.history["user-2"]) != 1 {
+		t.Errorf("expected history of a subscribed user to be kept, got %d events", len(bus.history["user-2"]))
+	}
+
+	resumed, err := bus.Subscribe("user-1", first.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer resumed.Close()
+	select {
+	case event := <-resumed.Events():
+		t.Errorf("expected nothing to replay, got %+v", event)
+	default:
+	}
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+type auditEventModel struct {
+	ID          string    `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID      *string   `gorm:"column:user_id;type:uuid"`
+	Type        string    `gorm:"column:type;not null"`
+	PhoneNumber string    `gorm:"column:phone_number"`
+	Country     string    `gorm:"column:country"`
+	Detail      string    `gorm:"column:detail"`
+	CallID      *string   `gorm:"column:call_id;type:uuid"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (auditEventModel) TableName() string {
+	return "audit_events"
+}
+
+func (r *AuditRepository) Create(ctx context.Context, event *domain.AuditEvent) error {
+	model := &auditEventModel{
+		Type:        string(event.Type),
+		PhoneNumber: event.PhoneNumber,
+		Country:     event.Country,
+		Detail:      event.Detail,
+	}
+	if event.UserID != "" {
+		model.UserID = &event.UserID
+	}
+	if event.CallID != "" {
+		model.CallID = &event.CallID
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	event.ID = model.ID
+	event.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *AuditRepository) Count(ctx context.Context, filter domain.AuditFilter) (int, error) {
+	query := r.db.WithContext(ctx).Model(&auditEventModel{}).
+		Where("type = ? AND created_at >= ?", string(filter.Type), filter.Since)
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.PhoneNumber != "" {
+		query = query.Where("phone_number = ?", filter.PhoneNumber)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *AuditRepository) ListByCallID(ctx context.Context, callID string, eventType domain.AuditEventType) ([]*domain.AuditEvent, error) {
+	var models []auditEventModel
+	if err := r.db.WithContext(ctx).
+		Where("call_id = ? AND type = ?", callID, string(eventType)).
+		Order("created_at").
+		Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.AuditEvent, 0, len(models))
+	for _, model := range models {
+		event := &domain.AuditEvent{
+			ID:          model.ID,
+			Type:        domain.AuditEventType(model.Type),
+			PhoneNumber: model.PhoneNumber,
+			Country:     model.Country,
+			Detail:      model.Detail,
+			CreatedAt:   model.CreatedAt,
+		}
+		if model.UserID != nil {
+			event.UserID = *model.UserID
+		}
+		if model.CallID != nil {
+			event.CallID = *model.CallID
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
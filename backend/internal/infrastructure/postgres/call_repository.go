@@ -18,26 +18,99 @@ func NewCallRepository(db *gorm.DB) *CallRepository {
 }
 
 type callModel struct {
-	ID          string    `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()"`
-	UserID      string    `gorm:"column:user_id;not null;index"`
-	PhoneNumber string    `gorm:"column:phone_number;not null"`
-	StartTime   time.Time `gorm:"column:start_time;not null;index"`
-	Duration    int       `gorm:"column:duration;default:0"`
-	Status      string    `gorm:"column:status;not null;default:initiated"`
-	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+	ID                  string     `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID              string     `gorm:"column:user_id;not null;index"`
+	PhoneNumber         string     `gorm:"column:phone_number;not null"`
+	StartTime           time.Time  `gorm:"column:start_time;not null;index"`
+	Duration            int        `gorm:"column:duration;default:0"`
+	Status              string     `gorm:"column:status;not null;default:initiated"`
+	CreatedAt           time.Time  `gorm:"column:created_at;autoCreateTime"`
+	SessionID           string     `gorm:"column:session_id"`
+	ProviderCallSID     string     `gorm:"column:provider_call_sid"`
+	SDPOffer            string     `gorm:"column:sdp_offer"`
+	SDPAnswer           string     `gorm:"column:sdp_answer"`
+	Direction           string     `gorm:"column:direction;not null;default:outbound"`
+	Record              bool       `gorm:"column:record;not null;default:false"`
+	Muted               bool       `gorm:"column:muted;not null;default:false"`
+	HeldAt              *time.Time `gorm:"column:held_at"`
+	HoldDuration        int        `gorm:"column:hold_duration;not null;default:0"`
+	ParentCallID        *string    `gorm:"column:parent_call_id;type:uuid;index"`
+	TransferMode        string     `gorm:"column:transfer_mode"`
+	TransferUserID      *string    `gorm:"column:transfer_user_id;type:uuid"`
+	ConferenceID        *string    `gorm:"column:conference_id;type:uuid;index"`
+	CallbackDestination string     `gorm:"column:callback_destination"`
+	CallerID            string     `gorm:"column:caller_id"`
 }
 
 func (callModel) TableName() string {
 	return "calls"
 }
 
+func (m *callModel) toDomain() *domain.Call {
+	call := &domain.Call{
+		ID:                  m.ID,
+		UserID:              m.UserID,
+		PhoneNumber:         m.PhoneNumber,
+		StartTime:           m.StartTime,
+		Duration:            m.Duration,
+		Status:              domain.CallStatus(m.Status),
+		CreatedAt:           m.CreatedAt,
+		SessionID:           m.SessionID,
+		ProviderCallSID:     m.ProviderCallSID,
+		SDPOffer:            m.SDPOffer,
+		SDPAnswer:           m.SDPAnswer,
+		Direction:           domain.CallDirection(m.Direction),
+		Record:              m.Record,
+		Muted:               m.Muted,
+		HeldAt:              m.HeldAt,
+		HoldDuration:        m.HoldDuration,
+		TransferMode:        domain.TransferMode(m.TransferMode),
+		CallbackDestination: m.CallbackDestination,
+		CallerID:            m.CallerID,
+	}
+	if m.ParentCallID != nil {
+		call.ParentCallID = *m.ParentCallID
+	}
+	if m.TransferUserID != nil {
+		call.TransferUserID = *m.TransferUserID
+	}
+	if m.ConferenceID != nil {
+		call.ConferenceID = *m.ConferenceID
+	}
+	return call
+}
+
 func (r *CallRepository) Create(ctx context.Context, call *domain.Call) error {
 	model := &callModel{
-		UserID:      call.UserID,
-		PhoneNumber: call.PhoneNumber,
-		StartTime:   call.StartTime,
-		Duration:    call.Duration,
-		Status:      string(call.Status),
+		UserID:              call.UserID,
+		PhoneNumber:         call.PhoneNumber,
+		StartTime:           call.StartTime,
+		Duration:            call.Duration,
+		Status:              string(call.Status),
+		SessionID:           call.SessionID,
+		ProviderCallSID:     call.ProviderCallSID,
+		SDPOffer:            call.SDPOffer,
+		SDPAnswer:           call.SDPAnswer,
+		Direction:           string(call.Direction),
+		Record:              call.Record,
+		Muted:               call.Muted,
+		HeldAt:              call.HeldAt,
+		HoldDuration:        call.HoldDuration,
+		TransferMode:        string(call.TransferMode),
+		CallbackDestination: call.CallbackDestination,
+		CallerID:            call.CallerID,
+	}
+	if call.ParentCallID != "" {
+		model.ParentCallID = &call.ParentCallID
+	}
+	if call.TransferUserID != "" {
+		model.TransferUserID = &call.TransferUserID
+	}
+	if call.ConferenceID != "" {
+		model.ConferenceID = &call.ConferenceID
+	}
+	if model.Direction == "" {
+		model.Direction = string(domain.CallDirectionOutbound)
 	}
 
 	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
@@ -51,9 +124,21 @@ func (r *CallRepository) Create(ctx context.Context, call *domain.Call) error {
 
 func (r *CallRepository) Update(ctx context.Context, call *domain.Call) error {
 	updates := map[string]interface{}{
-		"duration": call.Duration,
-		"status":   string(call.Status),
+		"duration":          call.Duration,
+		"status":            string(call.Status),
+		"session_id":        call.SessionID,
+		"provider_call_sid": call.ProviderCallSID,
+		"sdp_answer":        call.SDPAnswer,
+		"muted":             call.Muted,
+		"held_at":           call.HeldAt,
+		"hold_duration":     call.HoldDuration,
+	}
+
+	var conferenceID *string
+	if call.ConferenceID != "" {
+		conferenceID = &call.ConferenceID
 	}
+	updates["conference_id"] = conferenceID
 
 	result := r.db.WithContext(ctx).Model(&callModel{}).Where("id = ?", call.ID).Updates(updates)
 	if result.Error != nil {
@@ -78,15 +163,21 @@ func (r *CallRepository) GetByID(ctx context.Context, id string) (*domain.Call,
 		return nil, err
 	}
 
-	return &domain.Call{
-		ID:          model.ID,
-		UserID:      model.UserID,
-		PhoneNumber: model.PhoneNumber,
-		StartTime:   model.StartTime,
-		Duration:    model.Duration,
-		Status:      domain.CallStatus(model.Status),
-		CreatedAt:   model.CreatedAt,
-	}, nil
+	return model.toDomain(), nil
+}
+
+func (r *CallRepository) GetByProviderCallSID(ctx context.Context, providerCallSID string) (*domain.Call, error) {
+	var model callModel
+	err := r.db.WithContext(ctx).Where("provider_call_sid = ?", providerCallSID).First(&model).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
 }
 
 func (r *CallRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Call, error) {
@@ -102,15 +193,7 @@ func (r *CallRepository) ListByUserID(ctx context.Context, userID string) ([]*do
 
 	calls := make([]*domain.Call, 0, len(models))
 	for _, model := range models {
-		calls = append(calls, &domain.Call{
-			ID:          model.ID,
-			UserID:      model.UserID,
-			PhoneNumber: model.PhoneNumber,
-			StartTime:   model.StartTime,
-			Duration:    model.Duration,
-			Status:      domain.CallStatus(model.Status),
-			CreatedAt:   model.CreatedAt,
-		})
+		calls = append(calls, model.toDomain())
 	}
 
 	return calls, nil
@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+type CallerIDRepository struct {
+	db *gorm.DB
+}
+
+func NewCallerIDRepository(db *gorm.DB) *CallerIDRepository {
+	return &CallerIDRepository{db: db}
+}
+
+type callerIDModel struct {
+	ID               string     `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID           string     `gorm:"column:user_id;type:uuid;not null;index"`
+	Number           string     `gorm:"column:number;not null"`
+	VerifiedAt       *time.Time `gorm:"column:verified_at"`
+	IsDefault        bool       `gorm:"column:is_default;not null;default:false"`
+	VerificationCode string     `gorm:"column:verification_code"`
+	CodeExpiresAt    *time.Time `gorm:"column:code_expires_at"`
+	VerifyAttempts   int        `gorm:"column:verify_attempts;not null;default:0"`
+	CreatedAt        time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (callerIDModel) TableName() string {
+	return "caller_ids"
+}
+
+func (m *callerIDModel) toDomain() *domain.CallerID {
+	return &domain.CallerID{
+		ID:               m.ID,
+		UserID:           m.UserID,
+		Number:           m.Number,
+		VerifiedAt:       m.VerifiedAt,
+		IsDefault:        m.IsDefault,
+		VerificationCode: m.VerificationCode,
+		CodeExpiresAt:    m.CodeExpiresAt,
+		VerifyAttempts:   m.VerifyAttempts,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+func (r *CallerIDRepository) Create(ctx context.Context, callerID *domain.CallerID) error {
+	model := &callerIDModel{
+		UserID:           callerID.UserID,
+		Number:           callerID.Number,
+		VerifiedAt:       callerID.VerifiedAt,
+		IsDefault:        callerID.IsDefault,
+		VerificationCode: callerID.VerificationCode,
+		CodeExpiresAt:    callerID.CodeExpiresAt,
+		VerifyAttempts:   callerID.VerifyAttempts,
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	callerID.ID = model.ID
+	callerID.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// Update saves the verification state; IsDefault changes only through
+// SetDefault.
+func (r *CallerIDRepository) Update(ctx context.Context, callerID *domain.CallerID) error {
+	updates := map[string]interface{}{
+		"verified_at":       callerID.VerifiedAt,
+		"verification_code": callerID.VerificationCode,
+		"code_expires_at":   callerID.CodeExpiresAt,
+		"verify_attempts":   callerID.VerifyAttempts,
+	}
+
+	result := r.db.WithContext(ctx).Model(&callerIDModel{}).Where("id = ?", callerID.ID).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("caller id not found")
+	}
+
+	return nil
+}
+
+func (r *CallerIDRepository) GetByID(ctx context.Context, id string) (*domain.CallerID, error) {
+	var model callerIDModel
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+func (r *CallerIDRepository) GetByUserIDAndNumber(ctx context.Context, userID, number string) (*domain.CallerID, error) {
+	var model callerIDModel
+	err := r.db.WithContext(ctx).Where("user_id = ? AND number = ?", userID, number).First(&model).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+func (r *CallerIDRepository) GetDefault(ctx context.Context, userID string) (*domain.CallerID, error) {
+	var model callerIDModel
+	err := r.db.WithContext(ctx).Where("user_id = ? AND is_default", userID).First(&model).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+func (r *CallerIDRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.CallerID, error) {
+	var models []callerIDModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	callerIDs := make([]*domain.CallerID, 0, len(models))
+	for _, model := range models {
+		callerIDs = append(callerIDs, model.toDomain())
+	}
+
+	return callerIDs, nil
+}
+
+func (r *CallerIDRepository) SetDefault(ctx context.Context, userID, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&callerIDModel{}).
+			Where("user_id = ? AND is_default", userID).
+			Update("is_default", false).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&callerIDModel{}).
+			Where("id = ? AND user_id = ?", id, userID).
+			Update("is_default", true)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errors.New("caller id not found")
+		}
+
+		return nil
+	})
+}
+
+func (r *CallerIDRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&callerIDModel{}).Error
+}
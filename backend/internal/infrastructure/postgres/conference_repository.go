@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+type ConferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewConferenceRepository(db *gorm.DB) *ConferenceRepository {
+	return &ConferenceRepository{db: db}
+}
+
+type conferenceModel struct {
+	ID            string     `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID        string     `gorm:"column:user_id;type:uuid;not null;index"`
+	Status        string     `gorm:"column:status;not null;default:active"`
+	HostSessionID string     `gorm:"column:host_session_id"`
+	StartTime     time.Time  `gorm:"column:start_time;not null"`
+	EndTime       *time.Time `gorm:"column:end_time"`
+	Duration      int        `gorm:"column:duration;not null"`
+	CreatedAt     time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (conferenceModel) TableName() string {
+	return "conferences"
+}
+
+func (m *conferenceModel) toDomain() *domain.Conference {
+	return &domain.Conference{
+		ID:            m.ID,
+		UserID:        m.UserID,
+		Status:        domain.ConferenceStatus(m.Status),
+		HostSessionID: m.HostSessionID,
+		StartTime:     m.StartTime,
+		EndTime:       m.EndTime,
+		Duration:      m.Duration,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+func (r *ConferenceRepository) Create(ctx context.Context, conference *domain.Conference) error {
+	model := &conferenceModel{
+		UserID:        conference.UserID,
+		Status:        string(conference.Status),
+		HostSessionID: conference.HostSessionID,
+		StartTime:     conference.StartTime,
+		EndTime:       conference.EndTime,
+		Duration:      conference.Duration,
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	conference.ID = model.ID
+	conference.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *ConferenceRepository) Update(ctx context.Context, conference *domain.Conference) error {
+	updates := map[string]interface{}{
+		"status":          string(conference.Status),
+		"host_session_id": conference.HostSessionID,
+		"end_time":        conference.EndTime,
+		"duration":        conference.Duration,
+	}
+
+	result := r.db.WithContext(ctx).Model(&conferenceModel{}).Where("id = ?", conference.ID).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("conference not found")
+	}
+
+	return nil
+}
+
+func (r *ConferenceRepository) GetByID(ctx context.Context, id string) (*domain.Conference, error) {
+	var model conferenceModel
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+func (r *ConferenceRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Conference, error) {
+	var models []conferenceModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("start_time DESC").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	conferences := make([]*domain.Conference, 0, len(models))
+	for _, model := range models {
+		conferences = append(conferences, model.toDomain())
+	}
+
+	return conferences, nil
+}
+
+func (r *ConferenceRepository) ListParticipants(ctx context.Context, conferenceID string) ([]*domain.Call, error) {
+	var models []callModel
+	err := r.db.WithContext(ctx).
+		Where("conference_id = ?", conferenceID).
+		Order("start_time").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]*domain.Call, 0, len(models))
+	for _, model := range models {
+		calls = append(calls, model.toDomain())
+	}
+
+	return calls, nil
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+type PhoneNumberRepository struct {
+	db *gorm.DB
+}
+
+func NewPhoneNumberRepository(db *gorm.DB) *PhoneNumberRepository {
+	return &PhoneNumberRepository{db: db}
+}
+
+type phoneNumberModel struct {
+	ID          string    `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()"`
+	Number      string    `gorm:"column:number;uniqueIndex;not null"`
+	UserID      string    `gorm:"column:user_id;not null;index"`
+	ProviderSID string    `gorm:"column:provider_sid"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (phoneNumberModel) TableName() string {
+	return "phone_numbers"
+}
+
+func (m *phoneNumberModel) toDomain() *domain.PhoneNumber {
+	return &domain.PhoneNumber{
+		ID:          m.ID,
+		Number:      m.Number,
+		UserID:      m.UserID,
+		ProviderSID: m.ProviderSID,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func (r *PhoneNumberRepository) GetByNumber(ctx context.Context, number string) (*domain.PhoneNumber, error) {
+	var model phoneNumberModel
+	err := r.db.WithContext(ctx).Where("number = ?", number).First(&model).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+func (r *PhoneNumberRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.PhoneNumber, error) {
+	var models []phoneNumberModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	numbers := make([]*domain.PhoneNumber, 0, len(models))
+	for _, model := range models {
+		numbers = append(numbers, model.toDomain())
+	}
+
+	return numbers, nil
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+type RecordingRepository struct {
+	db *gorm.DB
+}
+
+func NewRecordingRepository(db *gorm.DB) *RecordingRepository {
+	return &RecordingRepository{db: db}
+}
+
+type recordingModel struct {
+	ID           string    `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()"`
+	CallID       string    `gorm:"column:call_id;type:uuid;not null;index"`
+	UserID       string    `gorm:"column:user_id;not null"`
+	RecordingSID string    `gorm:"column:recording_sid;uniqueIndex;not null"`
+	BlobKey      string    `gorm:"column:blob_key;not null"`
+	ContentType  string    `gorm:"column:content_type;not null"`
+	Size         int64     `gorm:"column:size;not null"`
+	Duration     int       `gorm:"column:duration;not null"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (recordingModel) TableName() string {
+	return "call_recordings"
+}
+
+func (m *recordingModel) toDomain() *domain.Recording {
+	return &domain.Recording{
+		ID:           m.ID,
+		CallID:       m.CallID,
+		UserID:       m.UserID,
+		RecordingSID: m.RecordingSID,
+		BlobKey:      m.BlobKey,
+		ContentType:  m.ContentType,
+		Size:         m.Size,
+		Duration:     m.Duration,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+func (r *RecordingRepository) Create(ctx context.Context, recording *domain.Recording) error {
+	model := &recordingModel{
+		CallID:       recording.CallID,
+		UserID:       recording.UserID,
+		RecordingSID: recording.RecordingSID,
+		BlobKey:      recording.BlobKey,
+		ContentType:  recording.ContentType,
+		Size:         recording.Size,
+		Duration:     recording.Duration,
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	recording.ID = model.ID
+	recording.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *RecordingRepository) GetByID(ctx context.Context, id string) (*domain.Recording, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+func (r *RecordingRepository) GetByRecordingSID(ctx context.Context, recordingSID string) (*domain.Recording, error) {
+	return r.first(ctx, "recording_sid = ?", recordingSID)
+}
+
+func (r *RecordingRepository) ListByCallID(ctx context.Context, callID string) ([]*domain.Recording, error) {
+	var models []recordingModel
+	err := r.db.WithContext(ctx).
+		Where("call_id = ?", callID).
+		Order("created_at").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	recordings := make([]*domain.Recording, 0, len(models))
+	for _, model := range models {
+		recordings = append(recordings, model.toDomain())
+	}
+
+	return recordings, nil
+}
+
+func (r *RecordingRepository) first(ctx context.Context, query string, args ...interface{}) (*domain.Recording, error) {
+	var model recordingModel
+	err := r.db.WithContext(ctx).Where(query, args...).First(&model).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
@@ -0,0 +1,240 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ScheduledCallRepository struct {
+	db *gorm.DB
+}
+
+func NewScheduledCallRepository(db *gorm.DB) *ScheduledCallRepository {
+	return &ScheduledCallRepository{db: db}
+}
+
+type scheduledCallModel struct {
+	ID           string     `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID       string     `gorm:"column:user_id;type:uuid;not null;index"`
+	PhoneNumber  string     `gorm:"column:phone_number;not null"`
+	CallerNumber string     `gorm:"column:caller_number"`
+	Mode         string     `gorm:"column:mode;not null"`
+	ScheduledAt  time.Time  `gorm:"column:scheduled_at;not null"`
+	TimeZone     string     `gorm:"column:time_zone;not null"`
+	Status       string     `gorm:"column:status;not null;default:pending"`
+	ClaimedAt    *time.Time `gorm:"column:claimed_at"`
+	FiredAt      *time.Time `gorm:"column:fired_at"`
+	CallID       *string    `gorm:"column:call_id;type:uuid"`
+	Error        string     `gorm:"column:error"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (scheduledCallModel) TableName() string {
+	return "scheduled_calls"
+}
+
+func (m *scheduledCallModel) toDomain() *domain.ScheduledCall {
+	scheduled := &domain.ScheduledCall{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		PhoneNumber:  m.PhoneNumber,
+		CallerNumber: m.CallerNumber,
+		Mode:         domain.ScheduledCallMode(m.Mode),
+		ScheduledAt:  m.ScheduledAt,
+		TimeZone:     m.TimeZone,
+		Status:       domain.ScheduledCallStatus(m.Status),
+		ClaimedAt:    m.ClaimedAt,
+		FiredAt:      m.FiredAt,
+		Error:        m.Error,
+		CreatedAt:    m.CreatedAt,
+	}
+	if m.CallID != nil {
+		scheduled.CallID = *m.CallID
+	}
+	return scheduled
+}
+
+func (r *ScheduledCallRepository) Create(ctx context.Context, scheduled *domain.ScheduledCall) error {
+	model := &scheduledCallModel{
+		UserID:       scheduled.UserID,
+		PhoneNumber:  scheduled.PhoneNumber,
+		CallerNumber: scheduled.CallerNumber,
+		Mode:         string(scheduled.Mode),
+		ScheduledAt:  scheduled.ScheduledAt,
+		TimeZone:     scheduled.TimeZone,
+		Status:       string(scheduled.Status),
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	scheduled.ID = model.ID
+	scheduled.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *ScheduledCallRepository) Update(ctx context.Context, scheduled *domain.ScheduledCall) error {
+	updates := map[string]interface{}{
+		"phone_number":  scheduled.PhoneNumber,
+		"caller_number": scheduled.CallerNumber,
+		"mode":          string(scheduled.Mode),
+		"scheduled_at":  scheduled.ScheduledAt,
+		"time_zone":     scheduled.TimeZone,
+	}
+
+	result := r.db.WithContext(ctx).Model(&scheduledCallModel{}).
+		Where("id = ? AND status = ?", scheduled.ID, string(domain.ScheduledCallStatusPending)).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrScheduledCallNotPending
+	}
+
+	return nil
+}
+
+func (r *ScheduledCallRepository) GetByID(ctx context.Context, id string) (*domain.ScheduledCall, error) {
+	var model scheduledCallModel
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+func (r *ScheduledCallRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.ScheduledCall, error) {
+	var models []scheduledCallModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("scheduled_at").
+		Find(&models).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := make([]*domain.ScheduledCall, 0, len(models))
+	for _, model := range models {
+		scheduled = append(scheduled, model.toDomain())
+	}
+
+	return scheduled, nil
+}
+
+func (r *ScheduledCallRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND status <> ?", id, string(domain.ScheduledCallStatusFiring)).
+		Delete(&scheduledCallModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrScheduledCallNotPending
+	}
+
+	return nil
+}
+
+// ClaimDue selects the due rows with FOR UPDATE SKIP LOCKED: a replica
+// claiming at the same time skips the rows locked here instead of waiting
+// for them, and finds them firing once this transaction commits.
+func (r *ScheduledCallRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*domain.ScheduledCall, error) {
+	var claimed []*domain.ScheduledCall
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var models []scheduledCallModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND scheduled_at <= ?", string(domain.ScheduledCallStatusPending), now).
+			Order("scheduled_at").
+			Limit(limit).
+			Find(&models).Error; err != nil {
+			return err
+		}
+
+		if len(models) == 0 {
+			return nil
+		}
+
+		ids := make([]string, 0, len(models))
+		for _, model := range models {
+			ids = append(ids, model.ID)
+		}
+
+		if err := tx.Model(&scheduledCallModel{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":     string(domain.ScheduledCallStatusFiring),
+				"claimed_at": now,
+			}).Error; err != nil {
+			return err
+		}
+
+		for _, model := range models {
+			scheduled := model.toDomain()
+			scheduled.Status = domain.ScheduledCallStatusFiring
+			scheduled.ClaimedAt = &now
+			claimed = append(claimed, scheduled)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+func (r *ScheduledCallRepository) Finish(ctx context.Context, scheduled *domain.ScheduledCall) error {
+	var callID *string
+	if scheduled.CallID != "" {
+		callID = &scheduled.CallID
+	}
+
+	updates := map[string]interface{}{
+		"status":   string(scheduled.Status),
+		"fired_at": scheduled.FiredAt,
+		"call_id":  callID,
+		"error":    scheduled.Error,
+	}
+
+	result := r.db.WithContext(ctx).Model(&scheduledCallModel{}).
+		Where("id = ? AND status = ?", scheduled.ID, string(domain.ScheduledCallStatusFiring)).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("scheduled call not firing")
+	}
+
+	return nil
+}
+
+func (r *ScheduledCallRepository) FailStale(ctx context.Context, claimedBefore time.Time) (int, error) {
+	result := r.db.WithContext(ctx).Model(&scheduledCallModel{}).
+		Where("status = ? AND claimed_at < ?", string(domain.ScheduledCallStatusFiring), claimedBefore).
+		Updates(map[string]interface{}{
+			"status": string(domain.ScheduledCallStatusFailed),
+			"error":  "interrupted",
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(result.RowsAffected), nil
+}
@@ -0,0 +1,239 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var terminalSessionStatuses = []string{
+	string(domain.SessionStatusCompleted),
+	string(domain.SessionStatusBusy),
+	string(domain.SessionStatusNoAnswer),
+	string(domain.SessionStatusFailed),
+}
+
+// SessionStore keeps VoIP sessions in the voip_sessions table so that every
+// backend replica resolves the same session. Expired rows are invisible to
+// reads and are purged periodically.
+type SessionStore struct {
+	db       *gorm.DB
+	stopChan chan struct{}
+}
+
+func NewSessionStore(db *gorm.DB) *SessionStore {
+	s := &SessionStore{
+		db:       db,
+		stopChan: make(chan struct{}),
+	}
+
+	go s.cleanupExpiredSessions()
+
+	return s
+}
+
+type sessionModel struct {
+	SessionID       string    `gorm:"column:session_id;primaryKey"`
+	ProviderCallSID string    `gorm:"column:provider_call_sid"`
+	PhoneNumber     string    `gorm:"column:phone_number;not null"`
+	SDPOffer        string    `gorm:"column:sdp_offer"`
+	SDPAnswer       string    `gorm:"column:sdp_answer"`
+	Status          string    `gorm:"column:status;not null"`
+	CreatedAt       time.Time `gorm:"column:created_at"`
+	ExpiresAt       time.Time `gorm:"column:expires_at;not null;index"`
+
+	LocalCandidates  []domain.ICECandidate `gorm:"column:local_candidates;type:jsonb;serializer:json"`
+	RemoteCandidates []domain.ICECandidate `gorm:"column:remote_candidates;type:jsonb;serializer:json"`
+}
+
+// sessionColumns are the columns Save overwrites; candidates only change
+// through AddCandidate.
+var sessionColumns = []string{
+	"provider_call_sid", "phone_number", "sdp_offer", "sdp_answer", "status", "created_at", "expires_at",
+}
+
+func (sessionModel) TableName() string {
+	return "voip_sessions"
+}
+
+func (m *sessionModel) toDomain() *domain.CallSession {
+	return &domain.CallSession{
+		SessionID:        m.SessionID,
+		ProviderCallSID:  m.ProviderCallSID,
+		PhoneNumber:      m.PhoneNumber,
+		SDPOffer:         m.SDPOffer,
+		SDPAnswer:        m.SDPAnswer,
+		LocalCandidates:  m.LocalCandidates,
+		RemoteCandidates: m.RemoteCandidates,
+		Status:           domain.SessionStatus(m.Status),
+		CreatedAt:        m.CreatedAt,
+		ExpiresAt:        m.ExpiresAt,
+	}
+}
+
+func (s *SessionStore) Save(ctx context.Context, session *domain.CallSession) error {
+	model := &sessionModel{
+		SessionID:       session.SessionID,
+		ProviderCallSID: session.ProviderCallSID,
+		PhoneNumber:     session.PhoneNumber,
+		SDPOffer:        session.SDPOffer,
+		SDPAnswer:       session.SDPAnswer,
+		Status:          string(session.Status),
+		CreatedAt:       session.CreatedAt,
+		ExpiresAt:       session.ExpiresAt,
+
+		LocalCandidates:  []domain.ICECandidate{},
+		RemoteCandidates: []domain.ICECandidate{},
+	}
+
+	return s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "session_id"}},
+			DoUpdates: clause.AssignmentColumns(sessionColumns),
+		}).
+		Create(model).Error
+}
+
+func (s *SessionStore) Get(ctx context.Context, sessionID string) (*domain.CallSession, error) {
+	var model sessionModel
+	err := s.db.WithContext(ctx).
+		Where("session_id = ? AND expires_at > ?", sessionID, time.Now()).
+		First(&model).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+// UpdateStatus changes the status with a single conditional UPDATE, so two
+// replicas racing on the same session cannot move it out of a final state.
+func (s *SessionStore) UpdateStatus(ctx context.Context, sessionID string, status domain.SessionStatus) (*domain.CallSession, error) {
+	var models []sessionModel
+	result := s.db.WithContext(ctx).
+		Model(&models).
+		Clauses(clause.Returning{}).
+		Where("session_id = ? AND expires_at > ? AND status NOT IN ?", sessionID, time.Now(), terminalSessionStatuses).
+		Update("status", string(status))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 || len(models) == 0 {
+		if _, err := s.Get(ctx, sessionID); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrSessionEnded
+	}
+
+	return models[0].toDomain(), nil
+}
+
+// AddCandidate appends with a single conditional UPDATE; the jsonb append
+// keeps candidates posted concurrently by several replicas.
+func (s *SessionStore) AddCandidate(ctx context.Context, sessionID string, origin domain.CandidateOrigin, candidate domain.ICECandidate) (*domain.CallSession, error) {
+	column := "remote_candidates"
+	if origin == domain.CandidateOriginLocal {
+		column = "local_candidates"
+	}
+
+	data, err := json.Marshal([]domain.ICECandidate{candidate})
+	if err != nil {
+		return nil, err
+	}
+
+	var models []sessionModel
+	result := s.db.WithContext(ctx).
+		Model(&models).
+		Clauses(clause.Returning{}).
+		Where("session_id = ? AND expires_at > ? AND status NOT IN ?", sessionID, time.Now(), terminalSessionStatuses).
+		Where("COALESCE("+column+" -> -1 ->> 'candidate', 'open') <> ''").
+		Update(column, gorm.Expr(column+" || ?::jsonb", string(data)))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 || len(models) == 0 {
+		session, err := s.Get(ctx, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		if session.Status.IsTerminal() {
+			return nil, domain.ErrSessionEnded
+		}
+		return nil, domain.ErrCandidatesComplete
+	}
+
+	return models[0].toDomain(), nil
+}
+
+// SetAnswer updates only sdp_answer, and only while the session has not
+// ended, so it cannot undo a concurrent status change or revive a deleted
+// session.
+func (s *SessionStore) SetAnswer(ctx context.Context, sessionID, sdpAnswer string) (*domain.CallSession, error) {
+	var models []sessionModel
+	result := s.db.WithContext(ctx).
+		Model(&models).
+		Clauses(clause.Returning{}).
+		Where("session_id = ? AND expires_at > ? AND status NOT IN ?", sessionID, time.Now(), terminalSessionStatuses).
+		Update("sdp_answer", sdpAnswer)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 || len(models) == 0 {
+		if _, err := s.Get(ctx, sessionID); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrSessionEnded
+	}
+
+	return models[0].toDomain(), nil
+}
+
+func (s *SessionStore) Delete(ctx context.Context, sessionID string) error {
+	return s.db.WithContext(ctx).
+		Where("session_id = ?", sessionID).
+		Delete(&sessionModel{}).Error
+}
+
+func (s *SessionStore) Close() error {
+	close(s.stopChan)
+	return nil
+}
+
+func (s *SessionStore) cleanupExpiredSessions() {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.removeExpiredSessions()
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+func (s *SessionStore) removeExpiredSessions() {
+	result := s.db.Where("expires_at <= ?", time.Now()).Delete(&sessionModel{})
+	if result.Error != nil {
+		slog.Warn("failed to remove expired sessions", "error", result.Error)
+		return
+	}
+
+	if result.RowsAffected > 0 {
+		slog.Info("expired sessions removed", "count", result.RowsAffected)
+	}
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserSettingsRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSettingsRepository(db *gorm.DB) *UserSettingsRepository {
+	return &UserSettingsRepository{db: db}
+}
+
+// userSettingsModel keeps quiet hours as nullable columns; NULL mode means
+// the user follows the platform defaults.
+type userSettingsModel struct {
+	UserID          string    `gorm:"column:user_id;primaryKey;type:uuid"`
+	QuietHoursMode  *string   `gorm:"column:quiet_hours_mode"`
+	QuietHoursStart *int      `gorm:"column:quiet_hours_start"`
+	QuietHoursEnd   *int      `gorm:"column:quiet_hours_end"`
+	UpdatedAt       time.Time `gorm:"column:updated_at"`
+}
+
+func (userSettingsModel) TableName() string {
+	return "user_settings"
+}
+
+func (m *userSettingsModel) toDomain() *domain.UserSettings {
+	settings := &domain.UserSettings{
+		UserID:    m.UserID,
+		UpdatedAt: m.UpdatedAt,
+	}
+	if m.QuietHoursMode != nil && m.QuietHoursStart != nil && m.QuietHoursEnd != nil {
+		settings.QuietHours = &domain.QuietHours{
+			Mode:  domain.QuietHoursMode(*m.QuietHoursMode),
+			Start: *m.QuietHoursStart,
+			End:   *m.QuietHoursEnd,
+		}
+	}
+	return settings
+}
+
+func (r *UserSettingsRepository) Get(ctx context.Context, userID string) (*domain.UserSettings, error) {
+	var model userSettingsModel
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&model).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+func (r *UserSettingsRepository) Save(ctx context.Context, settings *domain.UserSettings) error {
+	model := &userSettingsModel{
+		UserID:    settings.UserID,
+		UpdatedAt: time.Now(),
+	}
+	if settings.QuietHours != nil {
+		mode := string(settings.QuietHours.Mode)
+		model.QuietHoursMode = &mode
+		model.QuietHoursStart = &settings.QuietHours.Start
+		model.QuietHoursEnd = &settings.QuietHours.End
+	}
+
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quiet_hours_mode", "quiet_hours_start", "quiet_hours_end", "updated_at"}),
+		}).
+		Create(model).Error
+	if err != nil {
+		return err
+	}
+
+	settings.UpdatedAt = model.UpdatedAt
+	return nil
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"gorm.io/gorm"
+)
+
+type VoicemailRepository struct {
+	db *gorm.DB
+}
+
+func NewVoicemailRepository(db *gorm.DB) *VoicemailRepository {
+	return &VoicemailRepository{db: db}
+}
+
+type voicemailModel struct {
+	ID           string     `gorm:"column:id;primaryKey;type:uuid;default:gen_random_uuid()"`
+	UserID       string     `gorm:"column:user_id;not null;index"`
+	CallID       *string    `gorm:"column:call_id;type:uuid"`
+	From         string     `gorm:"column:from_number;not null"`
+	RecordingSID string     `gorm:"column:recording_sid;uniqueIndex;not null"`
+	BlobKey      string     `gorm:"column:blob_key;not null"`
+	ContentType  string     `gorm:"column:content_type;not null"`
+	Size         int64      `gorm:"column:size;not null"`
+	Duration     int        `gorm:"column:duration;not null"`
+	ReadAt       *time.Time `gorm:"column:read_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (voicemailModel) TableName() string {
+	return "voicemails"
+}
+
+func (m *voicemailModel) toDomain() *domain.Voicemail {
+	voicemail := &domain.Voicemail{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		From:         m.From,
+		RecordingSID: m.RecordingSID,
+		BlobKey:      m.BlobKey,
+		ContentType:  m.ContentType,
+		Size:         m.Size,
+		Duration:     m.Duration,
+		ReadAt:       m.ReadAt,
+		CreatedAt:    m.CreatedAt,
+	}
+	if m.CallID != nil {
+		voicemail.CallID = *m.CallID
+	}
+	return voicemail
+}
+
+func (r *VoicemailRepository) Create(ctx context.Context, voicemail *domain.Voicemail) error {
+	model := &voicemailModel{
+		UserID:       voicemail.UserID,
+		From:         voicemail.From,
+		RecordingSID: voicemail.RecordingSID,
+		BlobKey:      voicemail.BlobKey,
+		ContentType:  voicemail.ContentType,
+		Size:         voicemail.Size,
+		Duration:     voicemail.Duration,
+	}
+	if voicemail.CallID != "" {
+		model.CallID = &voicemail.CallID
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return err
+	}
+
+	voicemail.ID = model.ID
+	voicemail.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *VoicemailRepository) GetByID(ctx context.Context, id string) (*domain.Voicemail, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+func (r *VoicemailRepository) GetByRecordingSID(ctx context.Context, recordingSID string) (*domain.Voicemail, error) {
+	return r.first(ctx, "recording_sid = ?", recordingSID)
+}
+
+func (r *VoicemailRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Voicemail, error) {
+	return r.find(ctx, "created_at DESC", "user_id = ?", userID)
+}
+
+func (r *VoicemailRepository) ListOlderThan(ctx context.Context, cutoff time.Time) ([]*domain.Voicemail, error) {
+	return r.find(ctx, "created_at", "created_at < ?", cutoff)
+}
+
+func (r *VoicemailRepository) MarkRead(ctx context.Context, id string, readAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&voicemailModel{}).
+		Where("id = ? AND read_at IS NULL", id).
+		Update("read_at", readAt)
+	return result.Error
+}
+
+func (r *VoicemailRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&voicemailModel{}).Error
+}
+
+func (r *VoicemailRepository) first(ctx context.Context, query string, args ...interface{}) (*domain.Voicemail, error) {
+	var model voicemailModel
+	err := r.db.WithContext(ctx).Where(query, args...).First(&model).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return model.toDomain(), nil
+}
+
+func (r *VoicemailRepository) find(ctx context.Context, order string, query string, args ...interface{}) ([]*domain.Voicemail, error) {
+	var models []voicemailModel
+	err := r.db.WithContext(ctx).Where(query, args...).Order(order).Find(&models).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	voicemails := make([]*domain.Voicemail, 0, len(models))
+	for _, model := range models {
+		voicemails = append(voicemails, model.toDomain())
+	}
+
+	return voicemails, nil
+}
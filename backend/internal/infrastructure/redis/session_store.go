@@ -0,0 +1,239 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const sessionKeyPrefix = "voip:session:"
+
+// maxUpdateRetries bounds optimistic-lock retries when several replicas
+// update the same session at once.
+const maxUpdateRetries = 5
+
+// SessionStore keeps VoIP sessions in Redis. Each session is a JSON value
+// whose key expires together with the session.
+type SessionStore struct {
+	client *goredis.Client
+}
+
+func NewSessionStore(redisURL string) (*SessionStore, error) {
+	opts, err := goredis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+
+	return NewSessionStoreWithClient(goredis.NewClient(opts)), nil
+}
+
+func NewSessionStoreWithClient(client *goredis.Client) *SessionStore {
+	return &SessionStore{client: client}
+}
+
+type sessionRecord struct {
+	SessionID       string    `json:"session_id"`
+	ProviderCallSID string    `json:"provider_call_sid,omitempty"`
+	PhoneNumber     string    `json:"phone_number"`
+	SDPOffer        string    `json:"sdp_offer,omitempty"`
+	SDPAnswer       string    `json:"sdp_answer,omitempty"`
+	Status          string    `json:"status"`
+	CreatedAt       time.Time `json:"created_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+
+	LocalCandidates  []domain.ICECandidate `json:"local_candidates,omitempty"`
+	RemoteCandidates []domain.ICECandidate `json:"remote_candidates,omitempty"`
+}
+
+func (r *sessionRecord) toDomain() *domain.CallSession {
+	return &domain.CallSession{
+		SessionID:        r.SessionID,
+		ProviderCallSID:  r.ProviderCallSID,
+		PhoneNumber:      r.PhoneNumber,
+		SDPOffer:         r.SDPOffer,
+		SDPAnswer:        r.SDPAnswer,
+		LocalCandidates:  r.LocalCandidates,
+		RemoteCandidates: r.RemoteCandidates,
+		Status:           domain.SessionStatus(r.Status),
+		CreatedAt:        r.CreatedAt,
+		ExpiresAt:        r.ExpiresAt,
+	}
+}
+
+func sessionKey(sessionID string) string {
+	return sessionKeyPrefix + sessionID
+}
+
+// Save writes the session but keeps the candidates already stored, which
+// only change through AddCandidate.
+func (s *SessionStore) Save(ctx context.Context, session *domain.CallSession) error {
+	ttl := time.Until(session.ExpiresAt)
+	if ttl <= 0 {
+		return errors.New("session already expired")
+	}
+
+	record := &sessionRecord{
+		SessionID:       session.SessionID,
+		ProviderCallSID: session.ProviderCallSID,
+		PhoneNumber:     session.PhoneNumber,
+		SDPOffer:        session.SDPOffer,
+		SDPAnswer:       session.SDPAnswer,
+		Status:          string(session.Status),
+		CreatedAt:       session.CreatedAt,
+		ExpiresAt:       session.ExpiresAt,
+	}
+	key := sessionKey(session.SessionID)
+
+	txf := func(tx *goredis.Tx) error {
+		existing, err := s.get(ctx, tx, session.SessionID)
+		switch {
+		case err == nil:
+			record.LocalCandidates = existing.LocalCandidates
+			record.RemoteCandidates = existing.RemoteCandidates
+		case errors.Is(err, domain.ErrSessionNotFound):
+		default:
+			return err
+		}
+
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.Set(ctx, key, data, ttl)
+			return nil
+		})
+		return err
+	}
+
+	return s.watch(ctx, session.SessionID, txf)
+}
+
+func (s *SessionStore) Get(ctx context.Context, sessionID string) (*domain.CallSession, error) {
+	record, err := s.get(ctx, s.client, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return record.toDomain(), nil
+}
+
+// UpdateStatus uses WATCH/MULTI so a concurrent update from another replica
+// aborts the transaction instead of being overwritten.
+func (s *SessionStore) UpdateStatus(ctx context.Context, sessionID string, status domain.SessionStatus) (*domain.CallSession, error) {
+	return s.update(ctx, sessionID, func(record *sessionRecord) error {
+		if domain.SessionStatus(record.Status).IsTerminal() {
+			return domain.ErrSessionEnded
+		}
+		record.Status = string(status)
+		return nil
+	})
+}
+
+func (s *SessionStore) AddCandidate(ctx context.Context, sessionID string, origin domain.CandidateOrigin, candidate domain.ICECandidate) (*domain.CallSession, error) {
+	return s.update(ctx, sessionID, func(record *sessionRecord) error {
+		if domain.SessionStatus(record.Status).IsTerminal() {
+			return domain.ErrSessionEnded
+		}
+
+		candidates := &record.RemoteCandidates
+		if origin == domain.CandidateOriginLocal {
+			candidates = &record.LocalCandidates
+		}
+		if domain.CandidatesComplete(*candidates) {
+			return domain.ErrCandidatesComplete
+		}
+		*candidates = append(*candidates, candidate)
+		return nil
+	})
+}
+
+func (s *SessionStore) SetAnswer(ctx context.Context, sessionID, sdpAnswer string) (*domain.CallSession, error) {
+	return s.update(ctx, sessionID, func(record *sessionRecord) error {
+		if domain.SessionStatus(record.Status).IsTerminal() {
+			return domain.ErrSessionEnded
+		}
+		record.SDPAnswer = sdpAnswer
+		return nil
+	})
+}
+
+// update applies change to the stored record inside a WATCH transaction,
+// keeping the key's TTL.
+func (s *SessionStore) update(ctx context.Context, sessionID string, change func(*sessionRecord) error) (*domain.CallSession, error) {
+	key := sessionKey(sessionID)
+	var updated *domain.CallSession
+
+	txf := func(tx *goredis.Tx) error {
+		record, err := s.get(ctx, tx, sessionID)
+		if err != nil {
+			return err
+		}
+		if err := change(record); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.Set(ctx, key, data, goredis.KeepTTL)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		updated = record.toDomain()
+		return nil
+	}
+
+	if err := s.watch(ctx, sessionID, txf); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *SessionStore) watch(ctx context.Context, sessionID string, txf func(*goredis.Tx) error) error {
+	for i := 0; i < maxUpdateRetries; i++ {
+		err := s.client.Watch(ctx, txf, sessionKey(sessionID))
+		if errors.Is(err, goredis.TxFailedErr) {
+			continue
+		}
+		return err
+	}
+
+	return fmt.Errorf("session %s: too many concurrent updates", sessionID)
+}
+
+func (s *SessionStore) Delete(ctx context.Context, sessionID string) error {
+	return s.client.Del(ctx, sessionKey(sessionID)).Err()
+}
+
+func (s *SessionStore) Close() error {
+	return s.client.Close()
+}
+
+func (s *SessionStore) get(ctx context.Context, client goredis.Cmdable, sessionID string) (*sessionRecord, error) {
+	data, err := client.Get(ctx, sessionKey(sessionID)).Bytes()
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, domain.ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	var record sessionRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("corrupt session %s: %w", sessionID, err)
+	}
+
+	return &record, nil
+}
@@ -0,0 +1,204 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newTestSessionStore(t *testing.T) (*SessionStore, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	store, err := NewSessionStore("redis://" + server.Addr())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store, server
+}
+
+func newTestSession(id string) *domain.CallSession {
+	now := time.Now()
+	return &domain.CallSession{
+		SessionID:       id,
+		ProviderCallSID: "CA123",
+		PhoneNumber:     "+491512345678",
+		Status:          domain.SessionStatusInitialized,
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(time.Hour),
+	}
+}
+
+func TestSessionStore_SaveGetDelete(t *testing.T) {
+	store, _ := newTestSessionStore(t)
+	ctx := context.Background()
+
+	if err := store.Save(ctx, newTestSession("sess_1")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	session, err := store.Get(ctx, "sess_1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if session.ProviderCallSID != "CA123" || session.Status != domain.SessionStatusInitialized {
+		t.Errorf("unexpected session: %+v", session)
+	}
+
+	if err := store.Delete(ctx, "sess_1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := store.Get(ctx, "sess_1"); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func TestSessionStore_SharedBetweenReplicas(t *testing.T) {
+	first, server := newTestSessionStore(t)
+	second, err := NewSessionStore("redis://" + server.Addr())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer second.Close()
+	ctx := context.Background()
+
+	if err := first.Save(ctx, newTestSession("sess_1")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	updated, err := second.UpdateStatus(ctx, "sess_1", domain.SessionStatusActive)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if updated.Status != domain.SessionStatusActive {
+		t.Errorf("expected status 'active', got '%s'", updated.Status)
+	}
+
+	session, err := first.Get(ctx, "sess_1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if session.Status != domain.SessionStatusActive {
+		t.Errorf("expected status 'active' on other replica, got '%s'", session.Status)
+	}
+}
+
+func TestSessionStore_UpdateStatus_Terminal(t *testing.T) {
+	store, _ := newTestSessionStore(t)
+	ctx := context.Background()
+
+	if err := store.Save(ctx, newTestSession("sess_1")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := store.UpdateStatus(ctx, "sess_1", domain.SessionStatusBusy); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := store.UpdateStatus(ctx, "sess_1", domain.SessionStatusActive); !errors.Is(err, domain.ErrSessionEnded) {
+		t.Errorf("expected ErrSessionEnded, got %v", err)
+	}
+	if _, err := store.UpdateStatus(ctx, "missing", domain.SessionStatusActive); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func TestSessionStore_Expiry(t *testing.T) {
+	store, server := newTestSessionStore(t)
+	ctx := context.Background()
+
+	if err := store.Save(ctx, newTestSession("sess_1")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := store.UpdateStatus(ctx, "sess_1", domain.SessionStatusRinging); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if ttl := server.TTL(sessionKey("sess_1")); ttl <= 0 {
+		t.Fatalf("expected ttl to survive status update, got %v", ttl)
+	}
+
+	server.FastForward(2 * time.Hour)
+
+	if _, err := store.Get(ctx, "sess_1"); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound after expiry, got %v", err)
+	}
+}
+
+func TestSessionStore_AddCandidate(t *testing.T) {
+	store, _ := newTestSessionStore(t)
+	ctx := context.Background()
+
+	if err := store.Save(ctx, newTestSession("sess_1")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	host := domain.ICECandidate{Candidate: "candidate:1 1 udp 2122260223 192.168.1.20 54321 typ host", SDPMid: "0"}
+	if _, err := store.AddCandidate(ctx, "sess_1", domain.CandidateOriginRemote, host); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := store.AddCandidate(ctx, "sess_1", domain.CandidateOriginRemote, domain.ICECandidate{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := store.AddCandidate(ctx, "sess_1", domain.CandidateOriginRemote, host); !errors.Is(err, domain.ErrCandidatesComplete) {
+		t.Errorf("expected ErrCandidatesComplete, got %v", err)
+	}
+
+	// Saving the session must not drop candidates.
+	session := newTestSession("sess_1")
+	session.SDPAnswer = "v=0"
+	if err := store.Save(ctx, session); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	stored, err := store.Get(ctx, "sess_1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(stored.RemoteCandidates) != 2 || !domain.CandidatesComplete(stored.RemoteCandidates) {
+		t.Errorf("expected host candidate and end-of-candidates, got %+v", stored.RemoteCandidates)
+	}
+	if len(stored.LocalCandidates) != 0 {
+		t.Errorf("expected no local candidates, got %+v", stored.LocalCandidates)
+	}
+
+	if _, err := store.UpdateStatus(ctx, "sess_1", domain.SessionStatusCompleted); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := store.AddCandidate(ctx, "sess_1", domain.CandidateOriginLocal, host); !errors.Is(err, domain.ErrSessionEnded) {
+		t.Errorf("expected ErrSessionEnded, got %v", err)
+	}
+}
+
+func TestSessionStore_SetAnswer(t *testing.T) {
+	store, _ := newTestSessionStore(t)
+	ctx := context.Background()
+
+	if err := store.Save(ctx, newTestSession("sess_1")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := store.UpdateStatus(ctx, "sess_1", domain.SessionStatusRinging); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	session, err := store.SetAnswer(ctx, "sess_1", "v=0")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if session.SDPAnswer != "v=0" || session.Status != domain.SessionStatusRinging {
+		t.Errorf("expected answer stored and status kept, got %+v", session)
+	}
+
+	if _, err := store.UpdateStatus(ctx, "sess_1", domain.SessionStatusCompleted); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := store.SetAnswer(ctx, "sess_1", "v=0"); !errors.Is(err, domain.ErrSessionEnded) {
+		t.Errorf("expected ErrSessionEnded, got %v", err)
+	}
+	if _, err := store.SetAnswer(ctx, "missing", "v=0"); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound, got %v", err)
+	}
+}
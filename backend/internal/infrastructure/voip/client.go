@@ -1,15 +1,17 @@
 package voip
 
 import (
+	"context"
 	"errors"
 
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/sdp"
 )
 
 var (
 	ErrVoIPServiceUnavailable = errors.New("voip service unavailable")
 	ErrInvalidPhoneNumber     = errors.New("invalid phone number")
-	ErrSessionNotFound        = errors.New("session not found")
+	ErrSessionNotFound        = domain.ErrSessionNotFound
 	ErrCallAlreadyActive      = errors.New("call already active")
 	ErrUnauthorized           = errors.New("unauthorized")
 )
@@ -20,24 +22,106 @@ type Client interface {
 }
 
 type Config struct {
-	Provider   string
-	AccountSID string
-	AuthToken  string
-	FromNumber string
+	Provider          string
+	AccountSID        string
+	AuthToken         string
+	FromNumber        string
+	StatusCallbackURL string
+	MockScenarios     string
+	APIBaseURL        string
+	TwiMLURL          string
+	BridgeTarget      string
+	RecordCalls       bool
+	MachineDetection  string
+	// TransferCallbackURL receives the status of Twilio blind transfer legs.
+	TransferCallbackURL string
+	// CallbackURL returns the TwiML that dials the destination once the
+	// caller's phone answers a Twilio callback call.
+	CallbackURL string
+	// HoldMusicURL is played to a held Twilio call; empty means a track
+	// hosted by Twilio.
+	HoldMusicURL string
+	// Codecs is the comma-separated codec list offered to browsers, in
+	// preference order, e.g. "opus,PCMU,PCMA,telephone-event".
+	Codecs string
+
+	// Media gateway settings (Provider "gateway"). GatewaySIPTrunk is the
+	// carrier's SIP trunk as host[:port]; the username and password answer
+	// its digest challenges.
+	GatewaySIPTrunk     string
+	GatewaySIPUsername  string
+	GatewaySIPPassword  string
+	GatewayCarrierCodec string
+	GatewayICEServers   []string
+	GatewayInterfaces   []string
+	GatewayPublicIP     string
+	GatewayUDPPortMin   int
+	GatewayUDPPortMax   int
+	// GatewayHoldMusic is a 16-bit mono PCM WAV file played to the carrier
+	// leg of a held call; empty means silence.
+	GatewayHoldMusic string
+	// GatewayDTMFPayloadType is the RTP payload type of telephone-event on
+	// the carrier leg; 0 means 101.
+	GatewayDTMFPayloadType int
 }
 
-func NewClient(cfg *Config) (Client, error) {
+func NewClient(cfg *Config, sessions domain.SessionStore) (Client, error) {
 	if cfg == nil {
 		return nil, errors.New("config is required")
 	}
 
 	switch cfg.Provider {
 	case "twilio":
-		return NewTwilioClient(cfg)
+		return NewTwilioClient(cfg, sessions)
 	case "mock":
-		return NewMockClient(cfg)
+		return NewMockClient(cfg, sessions)
+	case "gateway":
+		return NewGatewayClient(cfg, sessions)
 	default:
 		return nil, errors.New("unsupported voip provider: " + cfg.Provider)
 	}
 }
 
+func newOfferBuilder(cfg *Config) (*sdp.OfferBuilder, error) {
+	codecs, err := sdp.ParseCodecList(cfg.Codecs)
+	if err != nil {
+		return nil, err
+	}
+	return sdp.NewOfferBuilder(codecs, nil)
+}
+
+// generateOffer builds a fresh SDP offer for a new session.
+func generateOffer(offers *sdp.OfferBuilder) (string, error) {
+	offer, _, err := offers.Offer()
+	if err != nil {
+		return "", err
+	}
+	return offer.Marshal(), nil
+}
+
+// storeAnswer records the browser's SDP answer on a session that is still
+// in progress. Only the answer is written, so a status change landing at
+// the same time is kept.
+func storeAnswer(ctx context.Context, sessions domain.SessionStore, sessionID, sdpAnswer string) (*domain.CallSession, error) {
+	return sessions.SetAnswer(ctx, sessionID, sdpAnswer)
+}
+
+// transferAddress is the Twilio-style address of a transfer target, which
+// the mock provider also accepts.
+func transferAddress(target domain.TransferTarget) string {
+	if target.UserID != "" {
+		return "client:" + target.UserID
+	}
+	return target.PhoneNumber
+}
+
+// LocalCandidateSource is implemented by clients that trickle their own ICE
+// candidates after the offer has been sent.
+type LocalCandidateSource interface {
+	OnLocalCandidate(fn func(session *domain.CallSession, candidate domain.ICECandidate))
+}
+
+// storeCandidate records a trickled browser candidate with the session.
+func storeCandidate(ctx context.Context, sessions domain.SessionStore, sessionID string, candidate domain.ICECandidate) (*domain.CallSession, error) {
+	return sessions.AddCandidate(ctx, sessionID, domain.CandidateOriginRemote, candidate)
+}
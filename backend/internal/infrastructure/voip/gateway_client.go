@@ -0,0 +1,1240 @@
+package voip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/media"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/sdp"
+	"github.com/emiago/sipgo"
+	"github.com/emiago/sipgo/sip"
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v4"
+)
+
+// gatewaySessionTTL bounds how long an unanswered gateway session is kept.
+const gatewaySessionTTL = 4 * time.Hour
+
+const defaultCarrierCodec = "PCMU"
+
+// DTMF timing on the carrier leg: each digit is a 100 ms event updated every
+// 20 ms, digits are 50 ms apart and "w" pauses for half a second.
+const (
+	dtmfToneDuration   = 100 * time.Millisecond
+	dtmfPacketInterval = 20 * time.Millisecond
+	dtmfDigitGap       = 50 * time.Millisecond
+	dtmfPause          = 500 * time.Millisecond
+	// dtmfVolume is the tone power in -dBm0.
+	dtmfVolume = 10
+	// dtmfEndRepeats is how many times the final packet of an event is sent.
+	dtmfEndRepeats = 3
+	// dtmfQueueSize bounds the sequences waiting to be played on one call.
+	dtmfQueueSize = 8
+)
+
+// holdFrameDuration is the audio carried by one packet of hold music.
+const holdFrameDuration = 20 * time.Millisecond
+
+// GatewayClient terminates the browser's WebRTC leg itself and places the
+// carrier leg as a SIP call on the operator's trunk, bridging the audio
+// between the two and transcoding between the codec negotiated with the
+// browser and the G.711 carrier codec where they differ. Opus from the browser
+// is decoded; towards the browser the gateway sends the first codec of the
+// answer it can encode, which is always G.711. The carrier's SIP responses
+// drive the session status.
+//
+// Media state lives in this process; the session store only carries the
+// signalling state, so a call must be answered by the replica that created it.
+type GatewayClient struct {
+	api          *webrtc.API
+	iceServers   []webrtc.ICEServer
+	codecs       []sdp.Codec
+	trunk        *gatewayTrunk
+	carrierCodec sdp.Codec
+	// portMin and portMax bound the carrier RTP sockets like the ICE ones;
+	// zero means any port.
+	portMin  int
+	portMax  int
+	dtmfType uint8
+	// holdMusic is the hold music in carrier payloads of holdFrameDuration.
+	holdMusic   [][]byte
+	sessions    domain.SessionStore
+	notifier    *statusNotifier
+	calls       map[string]*gatewayCall
+	onCandidate func(session *domain.CallSession, candidate domain.ICECandidate)
+	mu          sync.Mutex
+}
+
+type gatewayCall struct {
+	sessionID string
+	pc        *webrtc.PeerConnection
+	sender    *webrtc.RTPSender
+	// carrier is the call's RTP socket towards the carrier, offered in the
+	// INVITE; packets go to the address from the carrier's SDP answer.
+	carrier     *net.UDPConn
+	carrierSSRC uint32
+	// dialCtx is cancelled on close, which CANCELs an unanswered INVITE.
+	dialCtx  context.Context
+	stopDial context.CancelFunc
+	// ready is closed once the session is saved, so trickled candidates are
+	// only stored against a session that exists; done is closed on close.
+	ready chan struct{}
+	done  chan struct{}
+	// dtmf queues digit sequences for the carrier leg, in request order.
+	dtmf chan string
+
+	// carrierMu guards the carrier leg's RTP stream, which carries the
+	// browser's audio, our DTMF events and hold music. The browser's audio
+	// is held back while an event is playing, the call is held or muted;
+	// carrierTS and carrierAt are the last audio packet's timestamp and send
+	// time, from which the timestamps of our own packets are derived.
+	// carrierAddr and carrierDTMF come from the carrier's SDP; nothing is
+	// sent before it arrives.
+	carrierMu   sync.Mutex
+	carrierAddr *net.UDPAddr
+	carrierDTMF uint8
+	carrierSeq  uint16
+	carrierTS   uint32
+	carrierAt   time.Time
+	inEvent     bool
+	muted       bool
+	// holdStop is closed to stop the hold music; it is nil unless held.
+	holdStop chan struct{}
+
+	mu    sync.Mutex
+	track *webrtc.TrackLocalStaticRTP
+	// toBrowser converts carrier payloads to the browser's codec once the
+	// answer has fixed it.
+	toBrowser *media.Transcoder
+	// pending holds browser candidates that arrive before the answer.
+	pending  []webrtc.ICECandidateInit
+	answered bool
+	// dialog is the carrier's SIP dialog once it has answered.
+	dialog *sipgo.DialogClientSession
+
+	closeOnce sync.Once
+}
+
+func NewGatewayClient(cfg *Config, sessions domain.SessionStore) (*GatewayClient, error) {
+	if sessions == nil {
+		return nil, errors.New("session store is required")
+	}
+
+	if cfg.GatewaySIPTrunk == "" {
+		return nil, errors.New("gateway sip trunk is required")
+	}
+
+	carrierCodec, err := gatewayCarrierCodec(cfg.GatewayCarrierCodec)
+	if err != nil {
+		return nil, err
+	}
+
+	holdMusic, err := gatewayHoldMusic(cfg.GatewayHoldMusic, carrierCodec)
+	if err != nil {
+		return nil, err
+	}
+
+	dtmfType := cfg.GatewayDTMFPayloadType
+	if dtmfType == 0 {
+		dtmfType = sdp.CodecTelephoneEvent.PayloadType
+	}
+	if dtmfType < 96 || dtmfType > 127 {
+		return nil, fmt.Errorf("invalid gateway dtmf payload type: %d", dtmfType)
+	}
+
+	codecs, err := gatewayCodecs(cfg.Codecs)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaEngine := &webrtc.MediaEngine{}
+	for _, codec := range codecs {
+		if err := mediaEngine.RegisterCodec(rtpCodecParameters(codec), webrtc.RTPCodecTypeAudio); err != nil {
+			return nil, fmt.Errorf("failed to register codec %s: %w", codec.Name, err)
+		}
+	}
+
+	settings, err := gatewaySettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var iceServers []webrtc.ICEServer
+	if len(cfg.GatewayICEServers) > 0 {
+		iceServers = append(iceServers, webrtc.ICEServer{URLs: cfg.GatewayICEServers})
+	}
+
+	trunk, err := newGatewayTrunk(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("media gateway voip client initialized",
+		"trunk", cfg.GatewaySIPTrunk,
+		"carrier_codec", carrierCodec.Name,
+		"codecs", len(codecs))
+
+	return &GatewayClient{
+		api:          webrtc.NewAPI(webrtc.WithMediaEngine(mediaEngine), webrtc.WithSettingEngine(settings)),
+		iceServers:   iceServers,
+		codecs:       codecs,
+		trunk:        trunk,
+		portMin:      cfg.GatewayUDPPortMin,
+		portMax:      cfg.GatewayUDPPortMax,
+		carrierCodec: carrierCodec,
+		dtmfType:     uint8(dtmfType),
+		holdMusic:    holdMusic,
+		sessions:     sessions,
+		notifier:     newStatusNotifier(cfg.StatusCallbackURL, cfg.AccountSID, cfg.AuthToken, cfg.FromNumber),
+		calls:        make(map[string]*gatewayCall),
+	}, nil
+}
+
+func (c *GatewayClient) InitiateCall(ctx context.Context, phoneNumber string, opts domain.CallOptions) (*domain.CallSession, error) {
+	if phoneNumber == "" {
+		return nil, domain.ErrInvalidPhoneNumber
+	}
+
+	sessionID := fmt.Sprintf("gw_sess_%d", time.Now().UnixNano())
+
+	call, err := c.newCall(sessionID)
+	if err != nil {
+		slog.Error("failed to set up gateway call", "error", err, "session_id", sessionID)
+		return nil, ErrVoIPServiceUnavailable
+	}
+
+	offer, err := c.createOffer(call)
+	if err != nil {
+		call.close()
+		slog.Error("failed to create gateway sdp offer", "error", err, "session_id", sessionID)
+		return nil, ErrVoIPServiceUnavailable
+	}
+
+	session := &domain.CallSession{
+		SessionID:       sessionID,
+		ProviderCallSID: sessionID,
+		PhoneNumber:     phoneNumber,
+		SDPOffer:        offer,
+		Status:          domain.SessionStatusInitialized,
+		CreatedAt:       time.Now(),
+		ExpiresAt:       time.Now().Add(gatewaySessionTTL),
+	}
+
+	if err := c.sessions.Save(ctx, session); err != nil {
+		call.close()
+		slog.Error("failed to save gateway session", "error", err, "session_id", sessionID)
+		return nil, ErrVoIPServiceUnavailable
+	}
+
+	c.mu.Lock()
+	c.calls[sessionID] = call
+	c.mu.Unlock()
+	close(call.ready)
+
+	go c.fromCarrier(call)
+	go c.playDTMF(call)
+	go c.dial(call, phoneNumber)
+
+	snapshot := *session
+	go c.notifier.Notify(&snapshot, domain.SessionStatusInitialized)
+
+	slog.Info("gateway call initiated", "session_id", sessionID, "phone", phoneNumber)
+
+	return &snapshot, nil
+}
+
+func (c *GatewayClient) TerminateCall(ctx context.Context, sessionID string) error {
+	if call := c.removeCall(sessionID); call != nil {
+		call.close()
+	}
+
+	updated, err := c.sessions.UpdateStatus(ctx, sessionID, domain.SessionStatusCompleted)
+	switch {
+	case err == nil:
+		c.notifier.Notify(updated, domain.SessionStatusCompleted)
+	case errors.Is(err, domain.ErrSessionEnded):
+	default:
+		return err
+	}
+
+	if err := c.sessions.Delete(ctx, sessionID); err != nil {
+		slog.Warn("failed to delete gateway session", "error", err, "session_id", sessionID)
+	}
+
+	slog.Info("gateway call terminated", "session_id", sessionID)
+
+	return nil
+}
+
+func (c *GatewayClient) GetSessionStatus(ctx context.Context, sessionID string) (domain.SessionStatus, error) {
+	session, err := c.sessions.Get(ctx, sessionID)
+	if err != nil {
+		return "", err
+	}
+
+	return session.Status, nil
+}
+
+// AcceptAnswer applies the browser's answer to the session's peer connection.
+// The first voice codec in the answer the gateway can encode decides the codec
+// sent to the browser; the browser may send any of the answered codecs.
+func (c *GatewayClient) AcceptAnswer(ctx context.Context, sessionID string, sdpAnswer string) error {
+	c.mu.Lock()
+	call := c.calls[sessionID]
+	c.mu.Unlock()
+	if call == nil {
+		return domain.ErrSessionNotFound
+	}
+
+	codec, err := c.answerCodec(sdpAnswer)
+	if err != nil {
+		return err
+	}
+	if err := call.useBrowserCodec(codec, c.carrierCodec); err != nil {
+		return err
+	}
+
+	if _, err := storeAnswer(ctx, c.sessions, sessionID, sdpAnswer); err != nil {
+		return err
+	}
+
+	if err := call.pc.SetRemoteDescription(webrtc.SessionDescription{Type: webrtc.SDPTypeAnswer, SDP: sdpAnswer}); err != nil {
+		slog.Error("failed to apply sdp answer", "error", err, "session_id", sessionID)
+		return ErrVoIPServiceUnavailable
+	}
+	call.flushCandidates()
+
+	slog.Info("gateway sdp answer accepted", "session_id", sessionID, "codec", codec.Name)
+	return nil
+}
+
+// AddICECandidate stores a trickled browser candidate and applies it to the
+// peer connection, holding it back until the answer has been applied.
+func (c *GatewayClient) AddICECandidate(ctx context.Context, sessionID string, candidate domain.ICECandidate) error {
+	c.mu.Lock()
+	call := c.calls[sessionID]
+	c.mu.Unlock()
+	if call == nil {
+		return domain.ErrSessionNotFound
+	}
+
+	if _, err := storeCandidate(ctx, c.sessions, sessionID, candidate); err != nil {
+		return err
+	}
+
+	init := webrtc.ICECandidateInit{
+		Candidate:     candidate.Candidate,
+		SDPMLineIndex: candidate.SDPMLineIndex,
+	}
+	if candidate.SDPMid != "" {
+		init.SDPMid = &candidate.SDPMid
+	}
+	if candidate.UsernameFragment != "" {
+		init.UsernameFragment = &candidate.UsernameFragment
+	}
+
+	call.mu.Lock()
+	if !call.answered {
+		call.pending = append(call.pending, init)
+		call.mu.Unlock()
+		return nil
+	}
+	call.mu.Unlock()
+
+	if err := call.pc.AddICECandidate(init); err != nil {
+		slog.Warn("failed to apply ice candidate", "error", err, "session_id", sessionID)
+		return ErrVoIPServiceUnavailable
+	}
+	return nil
+}
+
+// SendDTMF queues the digits as RFC 4733 telephone-events on the carrier leg
+// and returns without waiting for them to be played.
+func (c *GatewayClient) SendDTMF(ctx context.Context, sessionID string, digits string, opts domain.CallOptions) error {
+	if err := domain.ValidateDTMF(digits); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	call := c.calls[sessionID]
+	c.mu.Unlock()
+	if call == nil {
+		return domain.ErrSessionNotFound
+	}
+
+	select {
+	case call.dtmf <- digits:
+	case <-call.done:
+		return domain.ErrSessionEnded
+	default:
+		slog.Warn("gateway dtmf queue is full", "session_id", sessionID)
+		return ErrVoIPServiceUnavailable
+	}
+
+	slog.Info("gateway dtmf queued", "session_id", sessionID, "digits", domain.MaskDTMF(digits))
+	return nil
+}
+
+// Hold stops the audio between the browser and the carrier and plays the
+// hold music to the carrier.
+func (c *GatewayClient) Hold(ctx context.Context, sessionID string) error {
+	c.mu.Lock()
+	call := c.calls[sessionID]
+	c.mu.Unlock()
+	if call == nil {
+		return domain.ErrSessionNotFound
+	}
+
+	call.carrierMu.Lock()
+	defer call.carrierMu.Unlock()
+
+	if call.holdStop == nil {
+		call.holdStop = make(chan struct{})
+		go call.playHoldMusic(call.holdStop, uint8(c.carrierCodec.PayloadType), uint32(c.carrierCodec.ClockRate), c.holdMusic)
+		slog.Info("gateway call on hold", "session_id", sessionID)
+	}
+	return nil
+}
+
+func (c *GatewayClient) Resume(ctx context.Context, sessionID string, opts domain.CallOptions) error {
+	c.mu.Lock()
+	call := c.calls[sessionID]
+	c.mu.Unlock()
+	if call == nil {
+		return domain.ErrSessionNotFound
+	}
+
+	call.carrierMu.Lock()
+	defer call.carrierMu.Unlock()
+
+	if call.holdStop != nil {
+		close(call.holdStop)
+		call.holdStop = nil
+		slog.Info("gateway call resumed", "session_id", sessionID)
+	}
+	return nil
+}
+
+// SetMuted stops or restarts forwarding the browser's audio to the carrier.
+func (c *GatewayClient) SetMuted(ctx context.Context, sessionID string, muted bool) error {
+	c.mu.Lock()
+	call := c.calls[sessionID]
+	c.mu.Unlock()
+	if call == nil {
+		return domain.ErrSessionNotFound
+	}
+
+	call.carrierMu.Lock()
+	call.muted = muted
+	call.carrierMu.Unlock()
+
+	slog.Info("gateway call mute changed", "session_id", sessionID, "muted", muted)
+	return nil
+}
+
+// OnLocalCandidate sets the function called for every local candidate the
+// gateway trickles, after it has been stored with the session.
+func (c *GatewayClient) OnLocalCandidate(fn func(session *domain.CallSession, candidate domain.ICECandidate)) {
+	c.mu.Lock()
+	c.onCandidate = fn
+	c.mu.Unlock()
+}
+
+func (c *GatewayClient) Close() error {
+	c.mu.Lock()
+	calls := c.calls
+	c.calls = make(map[string]*gatewayCall)
+	c.mu.Unlock()
+
+	for _, call := range calls {
+		call.close()
+	}
+	return c.trunk.Close()
+}
+
+func (c *GatewayClient) newCall(sessionID string) (*gatewayCall, error) {
+	carrier, err := c.listenCarrier()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open carrier rtp socket: %w", err)
+	}
+
+	pc, err := c.api.NewPeerConnection(webrtc.Configuration{ICEServers: c.iceServers})
+	if err != nil {
+		carrier.Close()
+		return nil, err
+	}
+
+	call := &gatewayCall{
+		sessionID:   sessionID,
+		pc:          pc,
+		carrier:     carrier,
+		carrierSSRC: uint32(time.Now().UnixNano()),
+		carrierDTMF: c.dtmfType,
+		ready:       make(chan struct{}),
+		done:        make(chan struct{}),
+		dtmf:        make(chan string, dtmfQueueSize),
+	}
+	call.dialCtx, call.stopDial = context.WithCancel(context.Background())
+
+	// Until the answer arrives the track carries the preferred codec the
+	// gateway can encode.
+	track, err := newGatewayTrack(browserSendCodec(c.codecs))
+	if err != nil {
+		call.close()
+		return nil, err
+	}
+	transceiver, err := pc.AddTransceiverFromTrack(track, webrtc.RTPTransceiverInit{
+		Direction: webrtc.RTPTransceiverDirectionSendrecv,
+	})
+	if err != nil {
+		call.close()
+		return nil, err
+	}
+	call.sender = transceiver.Sender()
+	call.track = track
+
+	pc.OnTrack(func(remote *webrtc.TrackRemote, _ *webrtc.RTPReceiver) {
+		go c.toCarrier(call, remote)
+	})
+	pc.OnConnectionStateChange(func(state webrtc.PeerConnectionState) {
+		c.onConnectionState(call, state)
+	})
+	pc.OnICECandidate(func(candidate *webrtc.ICECandidate) {
+		c.onLocalCandidate(call, candidate)
+	})
+
+	return call, nil
+}
+
+// listenCarrier opens the call's carrier RTP socket in the gateway's port
+// range, starting at a random port so that concurrent calls rarely collide.
+func (c *GatewayClient) listenCarrier() (*net.UDPConn, error) {
+	if c.portMin == 0 && c.portMax == 0 {
+		return net.ListenUDP("udp", nil)
+	}
+
+	span := c.portMax - c.portMin + 1
+	start := rand.IntN(span)
+	var err error
+	for i := 0; i < span; i++ {
+		var conn *net.UDPConn
+		conn, err = net.ListenUDP("udp", &net.UDPAddr{Port: c.portMin + (start+i)%span})
+		if err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
+}
+
+// createOffer returns the offer without waiting for ICE gathering; local
+// candidates are trickled to the browser as they are found.
+func (c *GatewayClient) createOffer(call *gatewayCall) (string, error) {
+	offer, err := call.pc.CreateOffer(nil)
+	if err != nil {
+		return "", err
+	}
+
+	if err := call.pc.SetLocalDescription(offer); err != nil {
+		return "", err
+	}
+
+	return offer.SDP, nil
+}
+
+// onLocalCandidate stores a gathered candidate, or the end-of-candidates
+// marker when candidate is nil, and hands it to the listener.
+func (c *GatewayClient) onLocalCandidate(call *gatewayCall, candidate *webrtc.ICECandidate) {
+	select {
+	case <-call.ready:
+	case <-call.done:
+		return
+	}
+
+	var local domain.ICECandidate
+	if candidate != nil {
+		init := candidate.ToJSON()
+		local.Candidate = init.Candidate
+		local.SDPMLineIndex = init.SDPMLineIndex
+		if init.SDPMid != nil {
+			local.SDPMid = *init.SDPMid
+		}
+	}
+
+	session, err := c.sessions.AddCandidate(context.Background(), call.sessionID, domain.CandidateOriginLocal, local)
+	if err != nil {
+		if !errors.Is(err, domain.ErrSessionNotFound) && !errors.Is(err, domain.ErrSessionEnded) {
+			slog.Warn("failed to store local ice candidate", "error", err, "session_id", call.sessionID)
+		}
+		return
+	}
+
+	c.mu.Lock()
+	listener := c.onCandidate
+	c.mu.Unlock()
+	if listener != nil {
+		listener(session, local)
+	}
+}
+
+// answerCodec returns the codec to send to the browser: the first answered
+// voice codec that was offered and that the gateway can encode.
+func (c *GatewayClient) answerCodec(sdpAnswer string) (sdp.Codec, error) {
+	desc, err := sdp.Parse(sdpAnswer)
+	if err != nil {
+		return sdp.Codec{}, err
+	}
+	audio := desc.AudioMedia()
+	if audio == nil {
+		return sdp.Codec{}, sdp.ErrNoAudio
+	}
+	answered, err := audio.Codecs()
+	if err != nil {
+		return sdp.Codec{}, err
+	}
+	var accepted []sdp.Codec
+	for _, codec := range answered {
+		for _, offered := range c.codecs {
+			if codec.Same(offered) {
+				accepted = append(accepted, offered)
+			}
+		}
+	}
+	if codec := browserSendCodec(accepted); codec.Name != "" {
+		return codec, nil
+	}
+	return sdp.Codec{}, sdp.ErrNoSupportedCodec
+}
+
+// browserSendCodec returns the first codec of the list the gateway can
+// encode, or the zero codec when there is none.
+func browserSendCodec(codecs []sdp.Codec) sdp.Codec {
+	for _, codec := range codecs {
+		if _, ok := media.LookupCodec(codec.Name); ok {
+			return codec
+		}
+	}
+	return sdp.Codec{}
+}
+
+// onConnectionState ends the call when the browser leg fails. The session
+// only becomes active once the carrier answers.
+func (c *GatewayClient) onConnectionState(call *gatewayCall, state webrtc.PeerConnectionState) {
+	slog.Debug("gateway peer connection state", "session_id", call.sessionID, "state", state.String())
+
+	if state == webrtc.PeerConnectionStateFailed {
+		c.endCall(call, domain.SessionStatusFailed)
+	}
+}
+
+// dial places the carrier leg: ringing on 180 or 183, active once the
+// carrier answers, and busy, no_answer or failed from a final error
+// response. A BYE from the carrier later completes the call.
+func (c *GatewayClient) dial(call *gatewayCall, phoneNumber string) {
+	ctx := call.dialCtx
+	offer := c.trunk.carrierOffer(call.carrier.LocalAddr().(*net.UDPAddr).Port, c.carrierCodec, c.dtmfType)
+
+	dialog, err := c.trunk.invite(ctx, phoneNumber, offer)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("failed to send sip invite", "error", err, "session_id", call.sessionID)
+			c.endCall(call, domain.SessionStatusFailed)
+		}
+		return
+	}
+
+	err = c.trunk.answer(ctx, dialog, func(res *sip.Response) {
+		switch res.StatusCode {
+		case sip.StatusRinging, sip.StatusSessionInProgress:
+			c.advance(call.sessionID, domain.SessionStatusRinging)
+		}
+		// Early media plays the carrier's ringback or announcements.
+		if res.StatusCode == sip.StatusSessionInProgress && len(res.Body()) > 0 {
+			if carrier, err := parseCarrierAnswer(res.Body(), c.carrierCodec, c.dtmfType); err == nil {
+				call.useCarrier(carrier)
+			}
+		}
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			// Closed while ringing; the INVITE has been cancelled.
+			return
+		}
+		status := domain.SessionStatusFailed
+		var refused *sipgo.ErrDialogResponse
+		if errors.As(err, &refused) {
+			status = sipFinalStatus(refused.Res.StatusCode)
+		}
+		slog.Info("carrier did not answer", "error", err, "session_id", call.sessionID, "status", status)
+		c.endCall(call, status)
+		return
+	}
+
+	carrier, err := parseCarrierAnswer(dialog.InviteResponse.Body(), c.carrierCodec, c.dtmfType)
+	if err != nil {
+		slog.Error("unusable carrier sdp answer", "error", err, "session_id", call.sessionID)
+		sipHangup(dialog)
+		c.endCall(call, domain.SessionStatusFailed)
+		return
+	}
+	call.useCarrier(carrier)
+
+	if !call.setDialog(dialog) {
+		sipHangup(dialog)
+		return
+	}
+	c.advance(call.sessionID, domain.SessionStatusActive)
+
+	select {
+	case <-dialog.Context().Done():
+		slog.Info("carrier hung up", "session_id", call.sessionID)
+		c.endCall(call, domain.SessionStatusCompleted)
+	case <-call.done:
+	}
+}
+
+// endCall closes a call that is still live and moves its session to status.
+func (c *GatewayClient) endCall(call *gatewayCall, status domain.SessionStatus) {
+	removed := c.removeCall(call.sessionID)
+	if removed == nil {
+		return
+	}
+	go removed.close()
+	c.advance(call.sessionID, status)
+}
+
+func (c *GatewayClient) advance(sessionID string, status domain.SessionStatus) {
+	updated, err := c.sessions.UpdateStatus(context.Background(), sessionID, status)
+	if err != nil {
+		if !errors.Is(err, domain.ErrSessionNotFound) && !errors.Is(err, domain.ErrSessionEnded) {
+			slog.Warn("failed to advance gateway session", "error", err, "session_id", sessionID)
+		}
+		return
+	}
+
+	slog.Info("gateway call status changed", "session_id", sessionID, "status", status)
+	go c.notifier.Notify(updated, status)
+}
+
+func (c *GatewayClient) removeCall(sessionID string) *gatewayCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	call := c.calls[sessionID]
+	delete(c.calls, sessionID)
+	return call
+}
+
+// toCarrier forwards the browser's audio to the carrier, rewriting the RTP
+// header onto the carrier leg's own stream.
+func (c *GatewayClient) toCarrier(call *gatewayCall, remote *webrtc.TrackRemote) {
+	transcoder, err := media.NewTranscoder(codecName(remote.Codec().MimeType), c.carrierCodec.Name)
+	if err != nil {
+		slog.Error("no transcoder for browser codec", "error", err, "session_id", call.sessionID, "codec", remote.Codec().MimeType)
+		return
+	}
+
+	var (
+		baseTS  uint32
+		started bool
+	)
+	for {
+		packet, _, err := remote.ReadRTP()
+		if err != nil {
+			return
+		}
+		if !started {
+			baseTS, started = packet.Timestamp, true
+		}
+
+		payload, err := transcoder.Transcode(packet.Payload)
+		if err != nil {
+			slog.Debug("dropping undecodable browser packet", "error", err, "session_id", call.sessionID)
+			continue
+		}
+
+		err = call.writeAudio(uint8(c.carrierCodec.PayloadType), packet.Marker,
+			transcoder.Timestamp(packet.Timestamp-baseTS), payload)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			slog.Debug("carrier rtp write failed", "error", err, "session_id", call.sessionID)
+		}
+	}
+}
+
+// playDTMF plays the call's queued digit sequences until the call closes.
+func (c *GatewayClient) playDTMF(call *gatewayCall) {
+	for {
+		select {
+		case digits := <-call.dtmf:
+			if err := c.playSequence(call, digits); err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					return
+				}
+				slog.Warn("failed to send dtmf", "error", err, "session_id", call.sessionID)
+			}
+		case <-call.done:
+			return
+		}
+	}
+}
+
+func (c *GatewayClient) playSequence(call *gatewayCall, digits string) error {
+	for _, digit := range digits {
+		wait := dtmfDigitGap
+		if event, ok := media.DTMFEvent(digit); ok {
+			if err := call.playEvent(call.dtmfType(), uint32(c.carrierCodec.ClockRate), event); err != nil {
+				return err
+			}
+		} else {
+			wait = dtmfPause
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-call.done:
+			return net.ErrClosed
+		}
+	}
+	return nil
+}
+
+// fromCarrier forwards the carrier's audio to the browser. Packets that
+// arrive before the browser has answered are dropped.
+func (c *GatewayClient) fromCarrier(call *gatewayCall) {
+	buf := make([]byte, 1500)
+	var (
+		baseTS  uint32
+		started bool
+	)
+	for {
+		n, err := call.carrier.Read(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+
+		var packet rtp.Packet
+		if err := packet.Unmarshal(buf[:n]); err != nil {
+			continue
+		}
+		if int(packet.PayloadType) != c.carrierCodec.PayloadType {
+			continue
+		}
+
+		track, transcoder := call.browserLeg()
+		if track == nil || call.onHold() {
+			continue
+		}
+		if !started {
+			baseTS, started = packet.Timestamp, true
+		}
+
+		payload, err := transcoder.Transcode(packet.Payload)
+		if err != nil {
+			continue
+		}
+		packet.Payload = payload
+		packet.Timestamp = transcoder.Timestamp(packet.Timestamp - baseTS)
+		if err := track.WriteRTP(&packet); err != nil && !errors.Is(err, net.ErrClosed) {
+			slog.Debug("browser rtp write failed", "error", err, "session_id", call.sessionID)
+		}
+	}
+}
+
+func (call *gatewayCall) useBrowserCodec(codec sdp.Codec, carrierCodec sdp.Codec) error {
+	transcoder, err := media.NewTranscoder(carrierCodec.Name, codec.Name)
+	if err != nil {
+		return err
+	}
+
+	call.mu.Lock()
+	defer call.mu.Unlock()
+
+	if !strings.EqualFold(codecName(call.track.Codec().MimeType), codec.Name) {
+		track, err := newGatewayTrack(codec)
+		if err != nil {
+			return err
+		}
+		if err := call.sender.ReplaceTrack(track); err != nil {
+			return err
+		}
+		call.track = track
+	}
+	call.toBrowser = transcoder
+	return nil
+}
+
+func (call *gatewayCall) browserLeg() (*webrtc.TrackLocalStaticRTP, *media.Transcoder) {
+	call.mu.Lock()
+	defer call.mu.Unlock()
+
+	if call.toBrowser == nil {
+		return nil, nil
+	}
+	return call.track, call.toBrowser
+}
+
+// flushCandidates applies the browser candidates that arrived before the
+// answer. Later candidates go straight to the peer connection.
+func (call *gatewayCall) flushCandidates() {
+	call.mu.Lock()
+	pending := call.pending
+	call.pending = nil
+	call.answered = true
+	call.mu.Unlock()
+
+	for _, candidate := range pending {
+		if err := call.pc.AddICECandidate(candidate); err != nil {
+			slog.Warn("failed to apply ice candidate", "error", err, "session_id", call.sessionID)
+		}
+	}
+}
+
+// writeAudio sends a packet of the browser's audio on the carrier leg,
+// unless a DTMF event is playing.
+func (call *gatewayCall) writeAudio(payloadType uint8, marker bool, timestamp uint32, payload []byte) error {
+	call.carrierMu.Lock()
+	defer call.carrierMu.Unlock()
+
+	if call.inEvent || call.muted || call.holdStop != nil {
+		return nil
+	}
+	call.carrierTS, call.carrierAt = timestamp, time.Now()
+	return call.writeCarrier(payloadType, marker, timestamp, payload)
+}
+
+// playEvent sends one telephone-event, RFC 4733 section 2.5.1: a first
+// packet with the marker bit, updates with the growing duration, and the
+// final packet, repeated, with the end bit set.
+func (call *gatewayCall) playEvent(payloadType uint8, clockRate uint32, event uint8) error {
+	call.carrierMu.Lock()
+	timestamp := call.nextTimestamp(clockRate)
+	call.inEvent = true
+	call.carrierMu.Unlock()
+
+	defer func() {
+		call.carrierMu.Lock()
+		call.inEvent = false
+		call.carrierMu.Unlock()
+	}()
+
+	step := uint16(dtmfPacketInterval * time.Duration(clockRate) / time.Second)
+	total := uint16(dtmfToneDuration * time.Duration(clockRate) / time.Second)
+
+	ticker := time.NewTicker(dtmfPacketInterval)
+	defer ticker.Stop()
+
+	for duration := step; ; duration += step {
+		end := duration >= total
+		payload := media.TelephoneEvent{Event: event, End: end, Volume: dtmfVolume, Duration: duration}.Marshal()
+
+		repeats := 1
+		if end {
+			repeats = dtmfEndRepeats
+		}
+		for i := 0; i < repeats; i++ {
+			call.carrierMu.Lock()
+			err := call.writeCarrier(payloadType, duration == step, timestamp, payload)
+			call.carrierMu.Unlock()
+			if err != nil {
+				return err
+			}
+		}
+		if end {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-call.done:
+			return net.ErrClosed
+		}
+	}
+}
+
+// playHoldMusic loops the hold music on the carrier leg until stop or the
+// call is closed.
+func (call *gatewayCall) playHoldMusic(stop chan struct{}, payloadType uint8, clockRate uint32, music [][]byte) {
+	if len(music) == 0 {
+		return
+	}
+
+	call.carrierMu.Lock()
+	timestamp := call.nextTimestamp(clockRate)
+	call.carrierMu.Unlock()
+	step := uint32(holdFrameDuration * time.Duration(clockRate) / time.Second)
+
+	ticker := time.NewTicker(holdFrameDuration)
+	defer ticker.Stop()
+
+	for i := 0; ; i++ {
+		call.carrierMu.Lock()
+		select {
+		case <-stop:
+			call.carrierMu.Unlock()
+			return
+		default:
+		}
+		err := call.writeCarrier(payloadType, i == 0, timestamp, music[i%len(music)])
+		if err == nil {
+			// Our later packets are timed from the music, as from audio.
+			call.carrierTS, call.carrierAt = timestamp, time.Now()
+		}
+		call.carrierMu.Unlock()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		timestamp += step
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		case <-call.done:
+			return
+		}
+	}
+}
+
+// useCarrier points the carrier leg at the media address from the carrier's
+// SDP.
+func (call *gatewayCall) useCarrier(carrier *carrierMedia) {
+	call.carrierMu.Lock()
+	defer call.carrierMu.Unlock()
+
+	call.carrierAddr = carrier.addr
+	call.carrierDTMF = carrier.dtmfType
+}
+
+func (call *gatewayCall) dtmfType() uint8 {
+	call.carrierMu.Lock()
+	defer call.carrierMu.Unlock()
+	return call.carrierDTMF
+}
+
+// setDialog keeps the answered carrier dialog so that close hangs it up. It
+// reports false when the call was closed first; the caller hangs up then.
+func (call *gatewayCall) setDialog(dialog *sipgo.DialogClientSession) bool {
+	call.mu.Lock()
+	defer call.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return false
+	default:
+	}
+	call.dialog = dialog
+	return true
+}
+
+func (call *gatewayCall) onHold() bool {
+	call.carrierMu.Lock()
+	defer call.carrierMu.Unlock()
+	return call.holdStop != nil
+}
+
+// nextTimestamp estimates the carrier stream's current timestamp from the
+// last audio packet. carrierMu must be held.
+func (call *gatewayCall) nextTimestamp(clockRate uint32) uint32 {
+	timestamp := call.carrierTS
+	if !call.carrierAt.IsZero() {
+		timestamp += uint32(time.Since(call.carrierAt) * time.Duration(clockRate) / time.Second)
+	}
+	return timestamp
+}
+
+// writeCarrier sends one packet on the carrier leg's stream, or drops it
+// before the carrier has sent its SDP. carrierMu must be held.
+func (call *gatewayCall) writeCarrier(payloadType uint8, marker bool, timestamp uint32, payload []byte) error {
+	if call.carrierAddr == nil {
+		return nil
+	}
+	call.carrierSeq++
+	packet := rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         marker,
+			PayloadType:    payloadType,
+			SequenceNumber: call.carrierSeq,
+			Timestamp:      timestamp,
+			SSRC:           call.carrierSSRC,
+		},
+		Payload: payload,
+	}
+	buf, err := packet.Marshal()
+	if err != nil {
+		return err
+	}
+	_, err = call.carrier.WriteToUDP(buf, call.carrierAddr)
+	return err
+}
+
+func (call *gatewayCall) close() {
+	call.closeOnce.Do(func() {
+		close(call.done)
+		call.stopDial()
+
+		call.mu.Lock()
+		dialog := call.dialog
+		call.mu.Unlock()
+		if dialog != nil {
+			sipHangup(dialog)
+		}
+
+		if err := call.pc.Close(); err != nil {
+			slog.Warn("failed to close peer connection", "error", err, "session_id", call.sessionID)
+		}
+		call.carrier.Close()
+	})
+}
+
+// gatewayCodecs keeps the configured voice codecs the gateway can decode.
+// telephone-event is not bridged. Opus is only decoded, so its format
+// parameters ask the browser for what the decoder handles, and the list must
+// keep a codec the gateway can send back.
+func gatewayCodecs(list string) ([]sdp.Codec, error) {
+	configured, err := sdp.ParseCodecList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	var codecs []sdp.Codec
+	for _, codec := range configured {
+		if codec.Same(sdp.CodecTelephoneEvent) {
+			continue
+		}
+		if _, ok := media.LookupDecoder(codec.Name); !ok {
+			slog.Warn("media gateway cannot transcode codec, leaving it out of the browser offer", "codec", codec.Name)
+			continue
+		}
+		if codec.Same(sdp.CodecOpus) {
+			codec.Fmtp = media.OpusFmtp
+		}
+		codecs = append(codecs, codec)
+	}
+	if len(codecs) == 0 {
+		return nil, errors.New("media gateway has no usable codec in VOIP_SDP_CODECS")
+	}
+	if browserSendCodec(codecs).Name == "" {
+		return nil, errors.New("media gateway has no codec it can send to browsers in VOIP_SDP_CODECS")
+	}
+	return codecs, nil
+}
+
+// gatewayHoldMusic encodes a WAV file as carrier payloads of
+// holdFrameDuration. No file means the held carrier leg gets silence.
+func gatewayHoldMusic(path string, carrierCodec sdp.Codec) ([][]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gateway hold music: %w", err)
+	}
+	defer f.Close()
+
+	pcm, rate, err := media.ReadWAV(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gateway hold music %q: %w", path, err)
+	}
+	pcm = media.Resample(pcm, rate, carrierCodec.ClockRate)
+
+	codec, ok := media.LookupCodec(carrierCodec.Name)
+	if !ok {
+		return nil, media.ErrUnsupportedCodec
+	}
+
+	frameSize := int(holdFrameDuration * time.Duration(carrierCodec.ClockRate) / time.Second)
+	var frames [][]byte
+	for i := 0; i+frameSize <= len(pcm); i += frameSize {
+		payload, err := codec.Encode(pcm[i : i+frameSize])
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, payload)
+	}
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("gateway hold music %q is shorter than one frame", path)
+	}
+	return frames, nil
+}
+
+func gatewayCarrierCodec(name string) (sdp.Codec, error) {
+	if name == "" {
+		name = defaultCarrierCodec
+	}
+	for _, codec := range []sdp.Codec{sdp.CodecPCMU, sdp.CodecPCMA} {
+		if strings.EqualFold(name, codec.Name) {
+			return codec, nil
+		}
+	}
+	return sdp.Codec{}, fmt.Errorf("unsupported carrier codec: %q", name)
+}
+
+func gatewaySettings(cfg *Config) (webrtc.SettingEngine, error) {
+	var settings webrtc.SettingEngine
+
+	if len(cfg.GatewayInterfaces) > 0 {
+		allowed := make(map[string]bool, len(cfg.GatewayInterfaces))
+		for _, name := range cfg.GatewayInterfaces {
+			allowed[name] = true
+			if iface, err := net.InterfaceByName(name); err == nil && iface.Flags&net.FlagLoopback != 0 {
+				settings.SetIncludeLoopbackCandidate(true)
+			}
+		}
+		settings.SetInterfaceFilter(func(name string) bool { return allowed[name] })
+	}
+
+	if cfg.GatewayUDPPortMin != 0 || cfg.GatewayUDPPortMax != 0 {
+		if cfg.GatewayUDPPortMin <= 0 || cfg.GatewayUDPPortMax > 65535 {
+			return settings, fmt.Errorf("invalid gateway udp port range: %d-%d", cfg.GatewayUDPPortMin, cfg.GatewayUDPPortMax)
+		}
+		if err := settings.SetEphemeralUDPPortRange(uint16(cfg.GatewayUDPPortMin), uint16(cfg.GatewayUDPPortMax)); err != nil {
+			return settings, fmt.Errorf("invalid gateway udp port range: %w", err)
+		}
+	}
+
+	if cfg.GatewayPublicIP != "" {
+		if net.ParseIP(cfg.GatewayPublicIP) == nil {
+			return settings, fmt.Errorf("invalid gateway public ip: %q", cfg.GatewayPublicIP)
+		}
+		settings.SetNAT1To1IPs([]string{cfg.GatewayPublicIP}, webrtc.ICECandidateTypeHost)
+	}
+
+	return settings, nil
+}
+
+func newGatewayTrack(codec sdp.Codec) (*webrtc.TrackLocalStaticRTP, error) {
+	return webrtc.NewTrackLocalStaticRTP(rtpCodecParameters(codec).RTPCodecCapability, "audio", "gateway")
+}
+
+func rtpCodecParameters(codec sdp.Codec) webrtc.RTPCodecParameters {
+	return webrtc.RTPCodecParameters{
+		RTPCodecCapability: webrtc.RTPCodecCapability{
+			MimeType:    "audio/" + codec.Name,
+			ClockRate:   uint32(codec.ClockRate),
+			Channels:    uint16(codec.Channels),
+			SDPFmtpLine: codec.Fmtp,
+		},
+		PayloadType: webrtc.PayloadType(codec.PayloadType),
+	}
+}
+
+func codecName(mimeType string) string {
+	_, name, _ := strings.Cut(mimeType, "/")
+	return name
+}
@@ -0,0 +1,784 @@
+package voip
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/media"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/sdp"
+	"github.com/emiago/sipgo"
+	"github.com/emiago/sipgo/sip"
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v4"
+)
+
+// newBrowserPeer is a stand-in for the browser: a pion peer on loopback that
+// supports the given codecs, PCMU only by default.
+func newBrowserPeer(t *testing.T, codecs ...sdp.Codec) *webrtc.PeerConnection {
+	t.Helper()
+
+	if len(codecs) == 0 {
+		codecs = []sdp.Codec{sdp.CodecPCMU}
+	}
+	mediaEngine := &webrtc.MediaEngine{}
+	for _, codec := range codecs {
+		if err := mediaEngine.RegisterCodec(rtpCodecParameters(codec), webrtc.RTPCodecTypeAudio); err != nil {
+			t.Fatalf("failed to register codec: %v", err)
+		}
+	}
+	var settings webrtc.SettingEngine
+	settings.SetIncludeLoopbackCandidate(true)
+	settings.SetInterfaceFilter(func(name string) bool { return name == "lo" })
+	settings.SetNetworkTypes([]webrtc.NetworkType{webrtc.NetworkTypeUDP4})
+
+	api := webrtc.NewAPI(webrtc.WithMediaEngine(mediaEngine), webrtc.WithSettingEngine(settings))
+	pc, err := api.NewPeerConnection(webrtc.Configuration{})
+	if err != nil {
+		t.Fatalf("failed to create browser peer: %v", err)
+	}
+	t.Cleanup(func() { pc.Close() })
+	return pc
+}
+
+// fakeTrunk is a stand-in for the carrier's SIP trunk on loopback. It rings
+// every INVITE, then answers it with finalCode: 200 answers with SDP that
+// points at rtp, 0 keeps ringing until the INVITE is cancelled.
+type fakeTrunk struct {
+	addr      string
+	rtp       *net.UDPConn
+	finalCode int
+
+	invites  chan *sip.Request
+	cancels  chan struct{}
+	byes     chan struct{}
+	answered chan *sipgo.DialogServerSession
+}
+
+func newFakeTrunk(t *testing.T, finalCode int) *fakeTrunk {
+	t.Helper()
+
+	rtpConn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("failed to listen for carrier rtp: %v", err)
+	}
+	t.Cleanup(func() { rtpConn.Close() })
+
+	sipConn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen for carrier sip: %v", err)
+	}
+
+	ua, err := sipgo.NewUA()
+	if err != nil {
+		t.Fatalf("failed to create carrier user agent: %v", err)
+	}
+	t.Cleanup(func() { ua.Close() })
+	server, err := sipgo.NewServer(ua)
+	if err != nil {
+		t.Fatalf("failed to create carrier server: %v", err)
+	}
+	client, err := sipgo.NewClient(ua)
+	if err != nil {
+		t.Fatalf("failed to create carrier client: %v", err)
+	}
+
+	host, port, _ := sip.ParseAddr(sipConn.LocalAddr().String())
+	dialogs := sipgo.NewDialogServerCache(client, sip.ContactHeader{Address: sip.Uri{Scheme: "sip", Host: host, Port: port}})
+
+	trunk := &fakeTrunk{
+		addr:      sipConn.LocalAddr().String(),
+		rtp:       rtpConn,
+		finalCode: finalCode,
+		invites:   make(chan *sip.Request, 4),
+		cancels:   make(chan struct{}, 4),
+		byes:      make(chan struct{}, 4),
+		answered:  make(chan *sipgo.DialogServerSession, 4),
+	}
+
+	server.OnInvite(func(req *sip.Request, tx sip.ServerTransaction) {
+		trunk.invites <- req
+		dialog, err := dialogs.ReadInvite(req, tx)
+		if err != nil {
+			return
+		}
+		dialog.Respond(sip.StatusRinging, "Ringing", nil)
+
+		switch trunk.finalCode {
+		case 0:
+			<-dialog.Context().Done()
+			trunk.cancels <- struct{}{}
+		case sip.StatusOK:
+			answer := fmt.Sprintf("v=0\r\no=- 1 1 IN IP4 127.0.0.1\r\ns=-\r\nc=IN IP4 127.0.0.1\r\nt=0 0\r\n"+
+				"m=audio %d RTP/AVP 0 8 101\r\na=rtpmap:101 telephone-event/8000\r\n", rtpConn.LocalAddr().(*net.UDPAddr).Port)
+			if err := dialog.RespondSDP([]byte(answer)); err == nil {
+				trunk.answered <- dialog
+			}
+		default:
+			dialog.Respond(trunk.finalCode, "Refused", nil)
+		}
+	})
+	server.OnAck(func(req *sip.Request, tx sip.ServerTransaction) {
+		dialogs.ReadAck(req, tx)
+	})
+	server.OnBye(func(req *sip.Request, tx sip.ServerTransaction) {
+		if err := dialogs.ReadBye(req, tx); err == nil {
+			trunk.byes <- struct{}{}
+		}
+	})
+	go server.ServeUDP(sipConn)
+
+	return trunk
+}
+
+// expect waits for the next value on ch.
+func expect[T any](t *testing.T, ch <-chan T, what string) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+		var zero T
+		return zero
+	}
+}
+
+func waitForGatewayStatus(t *testing.T, client *GatewayClient, sessionID string, want domain.SessionStatus) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		status, err := client.GetSessionStatus(context.Background(), sessionID)
+		if err == nil && status == want {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	status, _ := client.GetSessionStatus(context.Background(), sessionID)
+	t.Fatalf("expected status '%s', got '%s'", want, status)
+}
+
+// addBrowserTrack gives the browser a track to send in codec and collects the
+// packets it receives from the gateway.
+func addBrowserTrack(t *testing.T, browser *webrtc.PeerConnection, codec sdp.Codec) (*webrtc.TrackLocalStaticRTP, chan *rtp.Packet) {
+	t.Helper()
+
+	track, err := webrtc.NewTrackLocalStaticRTP(rtpCodecParameters(codec).RTPCodecCapability, "audio", "browser")
+	if err != nil {
+		t.Fatalf("failed to create track: %v", err)
+	}
+	if _, err := browser.AddTrack(track); err != nil {
+		t.Fatalf("failed to add track: %v", err)
+	}
+	received := make(chan *rtp.Packet, 16)
+	browser.OnTrack(func(remote *webrtc.TrackRemote, _ *webrtc.RTPReceiver) {
+		for {
+			packet, _, err := remote.ReadRTP()
+			if err != nil {
+				return
+			}
+			select {
+			case received <- packet:
+			default:
+			}
+		}
+	})
+	return track, received
+}
+
+// answerGateway answers the gateway's offer from the browser, exchanging
+// candidates both ways, and returns once the browser is connected. It
+// returns the browser's answer.
+func answerGateway(t *testing.T, client *GatewayClient, session *domain.CallSession, browser *webrtc.PeerConnection, gatewayCandidates chan domain.ICECandidate) string {
+	t.Helper()
+
+	ctx := context.Background()
+	connected := make(chan struct{}, 1)
+	browser.OnConnectionStateChange(func(state webrtc.PeerConnectionState) {
+		if state == webrtc.PeerConnectionStateConnected {
+			connected <- struct{}{}
+		}
+	})
+	browser.OnICECandidate(func(candidate *webrtc.ICECandidate) {
+		var trickled domain.ICECandidate
+		if candidate != nil {
+			trickled.Candidate = candidate.ToJSON().Candidate
+		}
+		if err := client.AddICECandidate(ctx, session.SessionID, trickled); err != nil {
+			t.Errorf("failed to add browser candidate: %v", err)
+		}
+	})
+
+	if err := browser.SetRemoteDescription(webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: session.SDPOffer}); err != nil {
+		t.Fatalf("browser rejected offer: %v", err)
+	}
+	go func() {
+		for candidate := range gatewayCandidates {
+			browser.AddICECandidate(webrtc.ICECandidateInit{Candidate: candidate.Candidate})
+			if candidate.IsEndOfCandidates() {
+				return
+			}
+		}
+	}()
+
+	answer, err := browser.CreateAnswer(nil)
+	if err != nil {
+		t.Fatalf("failed to create answer: %v", err)
+	}
+	if err := browser.SetLocalDescription(answer); err != nil {
+		t.Fatalf("failed to set answer: %v", err)
+	}
+	if err := client.AcceptAnswer(ctx, session.SessionID, answer.SDP); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expect(t, connected, "browser connection")
+	return answer.SDP
+}
+
+func TestGatewayClient_BridgesBrowserAndCarrier(t *testing.T) {
+	trunk := newFakeTrunk(t, sip.StatusOK)
+	carrier := trunk.rtp
+
+	client, err := NewGatewayClient(&Config{
+		Provider:            "gateway",
+		Codecs:              "opus,PCMU,PCMA,telephone-event",
+		GatewaySIPTrunk:     trunk.addr,
+		GatewayCarrierCodec: "PCMA",
+		GatewayInterfaces:   []string{"lo"},
+	}, NewSessionManager())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer client.Close()
+
+	// The gateway trickles its candidates; queue them until the browser
+	// has the offer.
+	gatewayCandidates := make(chan domain.ICECandidate, 32)
+	client.OnLocalCandidate(func(_ *domain.CallSession, candidate domain.ICECandidate) {
+		gatewayCandidates <- candidate
+	})
+
+	ctx := context.Background()
+	session, err := client.InitiateCall(ctx, "+491512345678", domain.CallOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	invite := expect(t, trunk.invites, "INVITE")
+	if invite.Recipient.User != "+491512345678" {
+		t.Errorf("expected INVITE for +491512345678, got %s", invite.Recipient.String())
+	}
+	offer, err := sdp.Parse(string(invite.Body()))
+	if err != nil {
+		t.Fatalf("carrier received invalid sdp: %v", err)
+	}
+	if offered, err := offer.AudioMedia().Codecs(); err != nil || !offered[0].Same(sdp.CodecPCMA) {
+		t.Errorf("expected PCMA to be offered to the carrier, got %v (%v)", offered, err)
+	}
+	expect(t, trunk.answered, "ACK")
+
+	browser := newBrowserPeer(t)
+	browserTrack, received := addBrowserTrack(t, browser, sdp.CodecPCMU)
+	answer := answerGateway(t, client, session, browser, gatewayCandidates)
+
+	if _, err := sdp.ValidateAnswer(answer, client.codecs); err != nil {
+		t.Fatalf("browser answer does not validate: %v", err)
+	}
+	waitForGatewayStatus(t, client, session.SessionID, domain.SessionStatusActive)
+
+	stored, err := client.sessions.Get(ctx, session.SessionID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(stored.LocalCandidates) == 0 || len(stored.RemoteCandidates) == 0 {
+		t.Errorf("expected both sides' candidates to be stored, got %d local and %d remote", len(stored.LocalCandidates), len(stored.RemoteCandidates))
+	}
+
+	// Browser -> carrier: PCMU in, PCMA out.
+	voice := []int16{0, 1000, -1000, 8000, -8000}
+	ulaw := make([]byte, len(voice))
+	for i, s := range voice {
+		ulaw[i] = media.LinearToMulaw(s)
+	}
+
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+		for seq := uint16(1); ; seq++ {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				browserTrack.WriteRTP(&rtp.Packet{
+					Header:  rtp.Header{Version: 2, SequenceNumber: seq, Timestamp: uint32(seq) * 160},
+					Payload: ulaw,
+				})
+			}
+		}
+	}()
+
+	carrier.SetReadDeadline(time.Now().Add(10 * time.Second))
+	buf := make([]byte, 1500)
+	n, gatewayAddr, err := carrier.ReadFromUDP(buf)
+	close(stop)
+	if err != nil {
+		t.Fatalf("carrier received no rtp: %v", err)
+	}
+	var toCarrier rtp.Packet
+	if err := toCarrier.Unmarshal(buf[:n]); err != nil {
+		t.Fatalf("carrier received invalid rtp: %v", err)
+	}
+	if toCarrier.PayloadType != uint8(sdp.CodecPCMA.PayloadType) {
+		t.Errorf("expected PCMA payload type, got %d", toCarrier.PayloadType)
+	}
+	for i, b := range toCarrier.Payload {
+		want := media.LinearToAlaw(media.MulawToLinear(ulaw[i]))
+		if b != want {
+			t.Errorf("sample %d: expected %#x, got %#x", i, want, b)
+		}
+	}
+
+	// Carrier -> browser: PCMA in, PCMU out.
+	alaw := []byte{media.LinearToAlaw(0), media.LinearToAlaw(4000), media.LinearToAlaw(-4000)}
+	var fromCarrier *rtp.Packet
+	deadline := time.After(10 * time.Second)
+	for seq := uint16(1); fromCarrier == nil; seq++ {
+		packet := rtp.Packet{
+			Header:  rtp.Header{Version: 2, PayloadType: uint8(sdp.CodecPCMA.PayloadType), SequenceNumber: seq, Timestamp: uint32(seq) * 160, SSRC: 42},
+			Payload: alaw,
+		}
+		raw, _ := packet.Marshal()
+		carrier.WriteToUDP(raw, gatewayAddr)
+
+		select {
+		case fromCarrier = <-received:
+		case <-time.After(20 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("browser received no rtp")
+		}
+	}
+	for i, b := range fromCarrier.Payload {
+		want := media.LinearToMulaw(media.AlawToLinear(alaw[i]))
+		if b != want {
+			t.Errorf("sample %d: expected %#x, got %#x", i, want, b)
+		}
+	}
+
+	if err := client.TerminateCall(ctx, session.SessionID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expect(t, trunk.byes, "BYE")
+	if _, err := client.GetSessionStatus(ctx, session.SessionID); err != domain.ErrSessionNotFound {
+		t.Errorf("expected session to be removed, got %v", err)
+	}
+}
+
+func TestGatewayClient_DecodesBrowserOpus(t *testing.T) {
+	trunk := newFakeTrunk(t, sip.StatusOK)
+	carrier := trunk.rtp
+
+	client, err := NewGatewayClient(&Config{
+		Provider:          "gateway",
+		Codecs:            "opus,PCMU,telephone-event",
+		GatewaySIPTrunk:   trunk.addr,
+		GatewayInterfaces: []string{"lo"},
+	}, NewSessionManager())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer client.Close()
+
+	gatewayCandidates := make(chan domain.ICECandidate, 32)
+	client.OnLocalCandidate(func(_ *domain.CallSession, candidate domain.ICECandidate) {
+		gatewayCandidates <- candidate
+	})
+
+	ctx := context.Background()
+	session, err := client.InitiateCall(ctx, "+491512345678", domain.CallOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expect(t, trunk.invites, "INVITE")
+	expect(t, trunk.answered, "ACK")
+
+	offer, err := sdp.Parse(session.SDPOffer)
+	if err != nil {
+		t.Fatalf("browser received invalid sdp: %v", err)
+	}
+	offered, err := offer.AudioMedia().Codecs()
+	if err != nil || !offered[0].Same(sdp.CodecOpus) || offered[0].Fmtp != media.OpusFmtp {
+		t.Fatalf("expected opus with the gateway's format parameters first, got %v (%v)", offered, err)
+	}
+
+	// The browser prefers Opus, as browsers do, and also takes PCMU.
+	browser := newBrowserPeer(t, sdp.CodecOpus, sdp.CodecPCMU)
+	browserTrack, received := addBrowserTrack(t, browser, sdp.CodecOpus)
+	answerGateway(t, client, session, browser, gatewayCandidates)
+	waitForGatewayStatus(t, client, session.SessionID, domain.SessionStatusActive)
+
+	// Browser -> carrier: 20 ms of SILK wideband Opus in, 20 ms of PCMU out.
+	silk := []byte{0x48, 0x83, 0xca, 0xde, 0x8a, 0xe5, 0x67, 0xd5, 0x1c, 0xac, 0xa2, 0x54, 0xfa, 0xff, 0xbf}
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+		for seq := uint16(1); ; seq++ {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				browserTrack.WriteRTP(&rtp.Packet{
+					Header:  rtp.Header{Version: 2, SequenceNumber: seq, Timestamp: uint32(seq) * 960},
+					Payload: silk,
+				})
+			}
+		}
+	}()
+
+	carrier.SetReadDeadline(time.Now().Add(10 * time.Second))
+	buf := make([]byte, 1500)
+	n, gatewayAddr, err := carrier.ReadFromUDP(buf)
+	close(stop)
+	if err != nil {
+		t.Fatalf("carrier received no rtp: %v", err)
+	}
+	var toCarrier rtp.Packet
+	if err := toCarrier.Unmarshal(buf[:n]); err != nil {
+		t.Fatalf("carrier received invalid rtp: %v", err)
+	}
+	if toCarrier.PayloadType != uint8(sdp.CodecPCMU.PayloadType) || len(toCarrier.Payload) != 160 {
+		t.Errorf("expected 160 bytes of PCMU, got payload type %d with %d bytes", toCarrier.PayloadType, len(toCarrier.Payload))
+	}
+
+	// Carrier -> browser: there is no Opus encoder, so the browser gets the
+	// PCMU it also answered.
+	ulaw := []byte{media.LinearToMulaw(0), media.LinearToMulaw(4000), media.LinearToMulaw(-4000)}
+	var fromCarrier *rtp.Packet
+	deadline := time.After(10 * time.Second)
+	for seq := uint16(1); fromCarrier == nil; seq++ {
+		packet := rtp.Packet{
+			Header:  rtp.Header{Version: 2, PayloadType: uint8(sdp.CodecPCMU.PayloadType), SequenceNumber: seq, Timestamp: uint32(seq) * 160, SSRC: 42},
+			Payload: ulaw,
+		}
+		raw, _ := packet.Marshal()
+		carrier.WriteToUDP(raw, gatewayAddr)
+
+		select {
+		case fromCarrier = <-received:
+		case <-time.After(20 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("browser received no rtp")
+		}
+	}
+	if fromCarrier.PayloadType != uint8(sdp.CodecPCMU.PayloadType) || !bytes.Equal(fromCarrier.Payload, ulaw) {
+		t.Errorf("expected the carrier's PCMU, got payload type %d with %x", fromCarrier.PayloadType, fromCarrier.Payload)
+	}
+}
+
+func TestGatewayClient_CarrierResponsesDriveStatus(t *testing.T) {
+	cases := []struct {
+		name      string
+		finalCode int
+		want      domain.SessionStatus
+	}{
+		{"busy", sip.StatusBusyHere, domain.SessionStatusBusy},
+		{"declined", sip.StatusGlobalDecline, domain.SessionStatusBusy},
+		{"unavailable", sip.StatusTemporarilyUnavailable, domain.SessionStatusNoAnswer},
+		{"not found", sip.StatusNotFound, domain.SessionStatusFailed},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			trunk := newFakeTrunk(t, tc.finalCode)
+			client := newTestGatewayClient(t, Config{GatewaySIPTrunk: trunk.addr})
+
+			session, err := client.InitiateCall(context.Background(), "+491512345678", domain.CallOptions{})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			waitForGatewayStatus(t, client, session.SessionID, tc.want)
+
+			if err := client.SendDTMF(context.Background(), session.SessionID, "1", domain.CallOptions{}); err != domain.ErrSessionNotFound {
+				t.Errorf("expected the call to be closed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGatewayClient_TerminateCancelsRingingCall(t *testing.T) {
+	trunk := newFakeTrunk(t, 0)
+	client := newTestGatewayClient(t, Config{GatewaySIPTrunk: trunk.addr})
+
+	ctx := context.Background()
+	session, err := client.InitiateCall(ctx, "+491512345678", domain.CallOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	// Ringing is reported, but nothing makes the call active before the
+	// carrier answers.
+	waitForGatewayStatus(t, client, session.SessionID, domain.SessionStatusRinging)
+
+	if err := client.TerminateCall(ctx, session.SessionID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expect(t, trunk.cancels, "CANCEL")
+}
+
+func TestGatewayClient_CarrierHangupCompletesCall(t *testing.T) {
+	trunk := newFakeTrunk(t, sip.StatusOK)
+	client := newTestGatewayClient(t, Config{GatewaySIPTrunk: trunk.addr})
+
+	session, err := client.InitiateCall(context.Background(), "+491512345678", domain.CallOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	dialog := expect(t, trunk.answered, "ACK")
+	waitForGatewayStatus(t, client, session.SessionID, domain.SessionStatusActive)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := dialog.Bye(ctx); err != nil {
+		t.Fatalf("failed to hang up: %v", err)
+	}
+	waitForGatewayStatus(t, client, session.SessionID, domain.SessionStatusCompleted)
+}
+
+// newTestGatewayClient builds a loopback gateway client from cfg.
+func newTestGatewayClient(t *testing.T, cfg Config) *GatewayClient {
+	t.Helper()
+
+	cfg.Provider = "gateway"
+	cfg.GatewayInterfaces = []string{"lo"}
+	client, err := NewGatewayClient(&cfg, NewSessionManager())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestGatewayClient_AcceptAnswer_UnknownSession(t *testing.T) {
+	client, err := NewGatewayClient(&Config{
+		Provider:          "gateway",
+		GatewaySIPTrunk:   "127.0.0.1:5060",
+		GatewayInterfaces: []string{"lo"},
+	}, NewSessionManager())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer client.Close()
+
+	if err := client.AcceptAnswer(context.Background(), "gw_sess_missing", "v=0"); err != domain.ErrSessionNotFound {
+		t.Errorf("expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func TestGatewayClient_SendDTMF(t *testing.T) {
+	trunk := newFakeTrunk(t, sip.StatusOK)
+	carrier := trunk.rtp
+	client := newTestGatewayClient(t, Config{GatewaySIPTrunk: trunk.addr})
+
+	ctx := context.Background()
+	session, err := client.InitiateCall(ctx, "+491512345678", domain.CallOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expect(t, trunk.answered, "ACK")
+
+	if err := client.SendDTMF(ctx, session.SessionID, "1x", domain.CallOptions{}); err != domain.ErrInvalidDTMF {
+		t.Errorf("expected ErrInvalidDTMF, got %v", err)
+	}
+	if err := client.SendDTMF(ctx, "gw_sess_missing", "1", domain.CallOptions{}); err != domain.ErrSessionNotFound {
+		t.Errorf("expected ErrSessionNotFound, got %v", err)
+	}
+	if err := client.SendDTMF(ctx, session.SessionID, "1w#", domain.CallOptions{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// 100 ms at 8 kHz in 20 ms updates: durations 160..800, the last one
+	// sent three times with the end bit.
+	var (
+		events []media.TelephoneEvent
+		seqs   []uint16
+		marked []bool
+		stamps = map[uint8]uint32{}
+	)
+	buf := make([]byte, 1500)
+	carrier.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for len(events) < 14 {
+		n, _, err := carrier.ReadFromUDP(buf)
+		if err != nil {
+			t.Fatalf("carrier received %d dtmf packets, then: %v", len(events), err)
+		}
+		var packet rtp.Packet
+		if err := packet.Unmarshal(buf[:n]); err != nil {
+			t.Fatalf("carrier received invalid rtp: %v", err)
+		}
+		if packet.PayloadType != 101 || len(packet.Payload) != 4 {
+			t.Fatalf("unexpected packet: pt=%d payload=%x", packet.PayloadType, packet.Payload)
+		}
+		event := media.TelephoneEvent{
+			Event:    packet.Payload[0],
+			End:      packet.Payload[1]&0x80 != 0,
+			Volume:   packet.Payload[1] & 0x3F,
+			Duration: uint16(packet.Payload[2])<<8 | uint16(packet.Payload[3]),
+		}
+		if ts, ok := stamps[event.Event]; ok && ts != packet.Timestamp {
+			t.Errorf("event %d changed timestamp from %d to %d", event.Event, ts, packet.Timestamp)
+		}
+		stamps[event.Event] = packet.Timestamp
+		events = append(events, event)
+		seqs = append(seqs, packet.SequenceNumber)
+		marked = append(marked, packet.Marker)
+	}
+
+	for i, digit := range []uint8{1, 11} {
+		got := events[i*7 : i*7+7]
+		for j, event := range got {
+			wantDuration := uint16(160 * (j + 1))
+			if j >= 4 {
+				wantDuration = 800
+			}
+			if event.Event != digit || event.Duration != wantDuration || event.End != (j >= 4) || event.Volume != 10 {
+				t.Errorf("digit %d packet %d: unexpected event %+v", digit, j, event)
+			}
+			if marked[i*7+j] != (j == 0) {
+				t.Errorf("digit %d packet %d: expected marker only on the first packet", digit, j)
+			}
+		}
+	}
+	for i := 1; i < len(seqs); i++ {
+		if seqs[i] != seqs[i-1]+1 {
+			t.Errorf("expected consecutive sequence numbers, got %v", seqs)
+			break
+		}
+	}
+}
+
+// writeHoldMusic writes a 16-bit mono WAV of a constant sample.
+func writeHoldMusic(t *testing.T, rate uint32, sample int16, samples int) string {
+	t.Helper()
+
+	pcm := make([]int16, samples)
+	for i := range pcm {
+		pcm[i] = sample
+	}
+	var wav bytes.Buffer
+	wav.WriteString("RIFF")
+	binary.Write(&wav, binary.LittleEndian, uint32(36+2*samples))
+	wav.WriteString("WAVEfmt ")
+	binary.Write(&wav, binary.LittleEndian, []uint32{16})
+	binary.Write(&wav, binary.LittleEndian, []uint16{1, 1})
+	binary.Write(&wav, binary.LittleEndian, []uint32{rate, 2 * rate})
+	binary.Write(&wav, binary.LittleEndian, []uint16{2, 16})
+	wav.WriteString("data")
+	binary.Write(&wav, binary.LittleEndian, uint32(2*samples))
+	binary.Write(&wav, binary.LittleEndian, pcm)
+
+	path := filepath.Join(t.TempDir(), "hold.wav")
+	if err := os.WriteFile(path, wav.Bytes(), 0o600); err != nil {
+		t.Fatalf("failed to write hold music: %v", err)
+	}
+	return path
+}
+
+func TestGatewayClient_HoldPlaysMusic(t *testing.T) {
+	trunk := newFakeTrunk(t, sip.StatusOK)
+	carrier := trunk.rtp
+	client := newTestGatewayClient(t, Config{
+		GatewaySIPTrunk:  trunk.addr,
+		GatewayHoldMusic: writeHoldMusic(t, 16000, 1000, 16000),
+	})
+
+	ctx := context.Background()
+	session, err := client.InitiateCall(ctx, "+491512345678", domain.CallOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expect(t, trunk.answered, "ACK")
+
+	if err := client.Hold(ctx, session.SessionID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	// A second hold keeps the one music stream.
+	if err := client.Hold(ctx, session.SessionID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	buf := make([]byte, 1500)
+	carrier.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var last rtp.Packet
+	for i := 0; i < 3; i++ {
+		n, _, err := carrier.ReadFromUDP(buf)
+		if err != nil {
+			t.Fatalf("carrier received no hold music: %v", err)
+		}
+		var packet rtp.Packet
+		if err := packet.Unmarshal(buf[:n]); err != nil {
+			t.Fatalf("carrier received invalid rtp: %v", err)
+		}
+		// 16 kHz music is resampled to 20 ms PCMU frames.
+		if packet.PayloadType != 0 || len(packet.Payload) != 160 || packet.Payload[0] != media.LinearToMulaw(1000) {
+			t.Fatalf("unexpected hold music packet: pt=%d len=%d", packet.PayloadType, len(packet.Payload))
+		}
+		if packet.Marker != (i == 0) {
+			t.Errorf("packet %d: expected marker only on the first packet", i)
+		}
+		if i > 0 && (packet.SequenceNumber != last.SequenceNumber+1 || packet.Timestamp != last.Timestamp+160) {
+			t.Errorf("packet %d: expected consecutive stream, got seq %d ts %d after seq %d ts %d",
+				i, packet.SequenceNumber, packet.Timestamp, last.SequenceNumber, last.Timestamp)
+		}
+		last = packet
+	}
+
+	if err := client.Resume(ctx, session.SessionID, domain.CallOptions{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Drain what was sent before the resume, then expect silence.
+	time.Sleep(50 * time.Millisecond)
+	carrier.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+	for {
+		if _, _, err := carrier.ReadFromUDP(buf); err != nil {
+			break
+		}
+	}
+	carrier.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, _, err := carrier.ReadFromUDP(buf); err == nil {
+		t.Error("expected hold music to stop after resume")
+	}
+
+	if err := client.SetMuted(ctx, "gw_sess_missing", true); err != domain.ErrSessionNotFound {
+		t.Errorf("expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func TestNewGatewayClient_Validation(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  Config
+	}{
+		{"missing sip trunk", Config{}},
+		{"bad sip trunk port", Config{GatewaySIPTrunk: "127.0.0.1:sip"}},
+		{"bad carrier codec", Config{GatewaySIPTrunk: "127.0.0.1", GatewayCarrierCodec: "G729"}},
+		{"opus only", Config{GatewaySIPTrunk: "127.0.0.1", Codecs: "opus"}},
+		{"bad port range", Config{GatewaySIPTrunk: "127.0.0.1", GatewayUDPPortMin: 20000, GatewayUDPPortMax: 10000}},
+		{"bad public ip", Config{GatewaySIPTrunk: "127.0.0.1", GatewayPublicIP: "gateway"}},
+		{"missing hold music", Config{GatewaySIPTrunk: "127.0.0.1", GatewayHoldMusic: "/nonexistent/hold.wav"}},
+		{"bad dtmf payload type", Config{GatewaySIPTrunk: "127.0.0.1", GatewayDTMFPayloadType: 8}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewGatewayClient(&tc.cfg, NewSessionManager()); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
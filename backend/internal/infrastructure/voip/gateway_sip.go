@@ -0,0 +1,281 @@
+package voip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/sdp"
+	"github.com/emiago/sipgo"
+	"github.com/emiago/sipgo/sip"
+)
+
+const defaultSIPPort = 5060
+
+// sipByeTimeout bounds how long hanging up waits for the carrier's 200 OK.
+const sipByeTimeout = 5 * time.Second
+
+var errCarrierAnswer = errors.New("carrier answer has no usable audio")
+
+// gatewayTrunk places the carrier leg of gateway calls as SIP INVITEs on the
+// operator's trunk over UDP. Requests the carrier sends inside a dialog, such
+// as BYE, arrive on the same socket and are handled by the server half.
+type gatewayTrunk struct {
+	ua      *sipgo.UserAgent
+	dialogs *sipgo.DialogClientCache
+	host    string
+	port    int
+	// localIP is advertised in Contact and in the carrier SDP.
+	localIP  net.IP
+	from     string
+	username string
+	password string
+}
+
+func newGatewayTrunk(cfg *Config) (*gatewayTrunk, error) {
+	host, port, err := splitTrunkAddr(cfg.GatewaySIPTrunk)
+	if err != nil {
+		return nil, err
+	}
+
+	localIP, err := trunkLocalIP(cfg.GatewayPublicIP, net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, err
+	}
+
+	ua, err := sipgo.NewUA(sipgo.WithUserAgent("calls-gateway"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sip user agent: %w", err)
+	}
+	server, err := sipgo.NewServer(ua)
+	if err != nil {
+		ua.Close()
+		return nil, fmt.Errorf("failed to create sip server: %w", err)
+	}
+	client, err := sipgo.NewClient(ua, sipgo.WithClientHostname(localIP.String()))
+	if err != nil {
+		ua.Close()
+		return nil, fmt.Errorf("failed to create sip client: %w", err)
+	}
+
+	t := &gatewayTrunk{
+		ua:       ua,
+		dialogs:  sipgo.NewDialogClientCache(client, sip.ContactHeader{Address: sip.Uri{Scheme: "sip", Host: localIP.String()}}),
+		host:     host,
+		port:     port,
+		localIP:  localIP,
+		from:     cfg.FromNumber,
+		username: cfg.GatewaySIPUsername,
+		password: cfg.GatewaySIPPassword,
+	}
+
+	server.OnBye(func(req *sip.Request, tx sip.ServerTransaction) {
+		if err := t.dialogs.ReadBye(req, tx); err != nil {
+			tx.Respond(sip.NewResponseFromRequest(req, sip.StatusCallTransactionDoesNotExists, "Call/Transaction Does Not Exist", nil))
+		}
+	})
+
+	return t, nil
+}
+
+// invite sends the INVITE for number and returns the early dialog; the
+// caller waits for the carrier's response with answer.
+func (t *gatewayTrunk) invite(ctx context.Context, number string, offer string) (*sipgo.DialogClientSession, error) {
+	recipient := sip.Uri{Scheme: "sip", User: number, Host: t.host, Port: t.port}
+
+	// Contact is filled in per call: the dialog cache would otherwise share
+	// and rewrite a single header between concurrent INVITEs.
+	headers := []sip.Header{
+		&sip.ContactHeader{Address: sip.Uri{Scheme: "sip", Host: t.localIP.String()}},
+		sip.NewHeader("Content-Type", "application/sdp"),
+	}
+	if t.from != "" {
+		from := &sip.FromHeader{
+			Address: sip.Uri{Scheme: "sip", User: t.from, Host: t.localIP.String()},
+			Params:  sip.NewParams(),
+		}
+		from.Params.Add("tag", sip.GenerateTagN(16))
+		headers = append(headers, from)
+	}
+
+	return t.dialogs.Invite(ctx, recipient, []byte(offer), headers...)
+}
+
+// answer waits for the carrier's final response to the INVITE. Provisional
+// and final responses are reported to onResponse; cancelling ctx before the
+// answer sends CANCEL.
+func (t *gatewayTrunk) answer(ctx context.Context, dialog *sipgo.DialogClientSession, onResponse func(res *sip.Response)) error {
+	err := dialog.WaitAnswer(ctx, sipgo.AnswerOptions{
+		OnResponse: func(res *sip.Response) error {
+			onResponse(res)
+			return nil
+		},
+		Username: t.username,
+		Password: t.password,
+	})
+	if err != nil {
+		return err
+	}
+	return dialog.Ack(ctx)
+}
+
+// sipHangup ends an answered carrier leg with BYE.
+func sipHangup(dialog *sipgo.DialogClientSession) {
+	ctx, cancel := context.WithTimeout(context.Background(), sipByeTimeout)
+	defer cancel()
+
+	if err := dialog.Bye(ctx); err != nil {
+		slog.Warn("failed to send sip bye", "error", err, "sip_call_id", dialog.InviteRequest.CallID().Value())
+	}
+}
+
+func (t *gatewayTrunk) Close() error {
+	return t.ua.Close()
+}
+
+// carrierOffer is the SDP offer of the carrier leg: plain RTP on the call's
+// socket, the carrier codec and telephone-event for DTMF.
+func (t *gatewayTrunk) carrierOffer(port int, codec sdp.Codec, dtmfType uint8) string {
+	addrType := "IP4"
+	if t.localIP.To4() == nil {
+		addrType = "IP6"
+	}
+	connection := &sdp.Connection{NetworkType: "IN", AddressType: addrType, Address: t.localIP.String()}
+	dtmf := strconv.Itoa(int(dtmfType))
+
+	desc := &sdp.SessionDescription{
+		Origin: sdp.Origin{
+			Username:       "-",
+			SessionID:      uint64(time.Now().UnixNano()),
+			SessionVersion: 1,
+			NetworkType:    "IN",
+			AddressType:    addrType,
+			Address:        t.localIP.String(),
+		},
+		SessionName: "-",
+		Connection:  connection,
+		Media: []*sdp.MediaDescription{{
+			Type:     "audio",
+			Port:     port,
+			Protocol: "RTP/AVP",
+			Formats:  []string{strconv.Itoa(codec.PayloadType), dtmf},
+			Attributes: []sdp.Attribute{
+				{Key: "rtpmap", Value: fmt.Sprintf("%d %s/%d", codec.PayloadType, codec.Name, codec.ClockRate)},
+				{Key: "rtpmap", Value: dtmf + " telephone-event/8000"},
+				{Key: "fmtp", Value: dtmf + " 0-16"},
+				{Key: "ptime", Value: strconv.Itoa(int(holdFrameDuration / time.Millisecond))},
+				{Key: "sendrecv"},
+			},
+		}},
+	}
+	return desc.Marshal()
+}
+
+// carrierMedia is where the carrier wants the call's RTP and the payload
+// type it expects for DTMF, from its SDP answer.
+type carrierMedia struct {
+	addr     *net.UDPAddr
+	dtmfType uint8
+}
+
+// parseCarrierAnswer reads the carrier's SDP. The answer must accept the
+// carrier codec we offered; its telephone-event payload type replaces ours.
+func parseCarrierAnswer(body []byte, codec sdp.Codec, dtmfType uint8) (*carrierMedia, error) {
+	desc, err := sdp.Parse(string(body))
+	if err != nil {
+		return nil, err
+	}
+	audio := desc.AudioMedia()
+	if audio == nil {
+		return nil, sdp.ErrNoAudio
+	}
+
+	connection := audio.Connection
+	if connection == nil {
+		connection = desc.Connection
+	}
+	if connection == nil {
+		return nil, fmt.Errorf("%w: no connection address", errCarrierAnswer)
+	}
+	ip := net.ParseIP(connection.Address)
+	if ip == nil {
+		return nil, fmt.Errorf("%w: invalid connection address %q", errCarrierAnswer, connection.Address)
+	}
+
+	codecs, err := audio.Codecs()
+	if err != nil {
+		return nil, err
+	}
+	carrier := &carrierMedia{addr: &net.UDPAddr{IP: ip, Port: audio.Port}, dtmfType: dtmfType}
+	accepted := false
+	for _, answered := range codecs {
+		switch {
+		case answered.Same(codec):
+			accepted = true
+		case answered.Same(sdp.CodecTelephoneEvent) && answered.PayloadType >= 96:
+			carrier.dtmfType = uint8(answered.PayloadType)
+		}
+	}
+	if !accepted {
+		return nil, fmt.Errorf("%w: %s not accepted", errCarrierAnswer, codec.Name)
+	}
+	return carrier, nil
+}
+
+// sipFinalStatus maps a failed INVITE's final response onto the session
+// status reported for the call.
+func sipFinalStatus(code int) domain.SessionStatus {
+	switch code {
+	case sip.StatusBusyHere, sip.StatusGlobalBusyEverywhere, sip.StatusGlobalDecline:
+		return domain.SessionStatusBusy
+	case sip.StatusRequestTimeout, sip.StatusTemporarilyUnavailable, sip.StatusRequestTerminated:
+		return domain.SessionStatusNoAnswer
+	default:
+		return domain.SessionStatusFailed
+	}
+}
+
+func splitTrunkAddr(trunk string) (string, int, error) {
+	if trunk == "" {
+		return "", 0, errors.New("gateway sip trunk is required")
+	}
+	host, portStr, err := net.SplitHostPort(trunk)
+	if err != nil {
+		// Without a port the trunk listens on the SIP default.
+		host, portStr, err = net.SplitHostPort(net.JoinHostPort(strings.Trim(trunk, "[]"), strconv.Itoa(defaultSIPPort)))
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid gateway sip trunk %q: %w", trunk, err)
+		}
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port <= 0 || port > 65535 || host == "" {
+		return "", 0, fmt.Errorf("invalid gateway sip trunk %q", trunk)
+	}
+	return host, port, nil
+}
+
+// trunkLocalIP is the address the carrier reaches us on: the public IP when
+// configured, otherwise the local address routed towards the trunk.
+func trunkLocalIP(publicIP, trunkAddr string) (net.IP, error) {
+	if publicIP != "" {
+		ip := net.ParseIP(publicIP)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid gateway public ip: %q", publicIP)
+		}
+		return ip, nil
+	}
+
+	// Connecting a UDP socket sends nothing; it only picks the route.
+	conn, err := net.Dial("udp", trunkAddr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway sip trunk %q: %w", trunkAddr, err)
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
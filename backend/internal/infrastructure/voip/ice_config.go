@@ -0,0 +1,77 @@
+package voip
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+const defaultTURNCredentialTTL = time.Hour
+
+type ICEConfig struct {
+	STUNURLs []string
+	TURNURLs []string
+	// TURNSecret is the static-auth-secret shared with coturn
+	// (use-auth-secret mode).
+	TURNSecret        string
+	TURNCredentialTTL time.Duration
+}
+
+// ICEConfigProvider hands out ICE servers for browsers. TURN credentials
+// follow the coturn REST API scheme: the username is "<expiry>:<userID>"
+// and the password is base64(HMAC-SHA1(secret, username)), so coturn can
+// verify them without a shared database.
+type ICEConfigProvider struct {
+	cfg *ICEConfig
+	now func() time.Time
+}
+
+func NewICEConfigProvider(cfg *ICEConfig) (*ICEConfigProvider, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("ice config is required")
+	}
+	if len(cfg.TURNURLs) > 0 && cfg.TURNSecret == "" {
+		return nil, fmt.Errorf("turn secret is required when turn servers are configured")
+	}
+	return &ICEConfigProvider{cfg: cfg, now: time.Now}, nil
+}
+
+func (p *ICEConfigProvider) WebRTCConfig(userID string) (*domain.WebRTCConfig, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required")
+	}
+
+	config := &domain.WebRTCConfig{IceServers: []domain.IceServer{}}
+
+	if len(p.cfg.STUNURLs) > 0 {
+		config.IceServers = append(config.IceServers, domain.IceServer{URLs: p.cfg.STUNURLs})
+	}
+
+	if len(p.cfg.TURNURLs) > 0 {
+		ttl := p.cfg.TURNCredentialTTL
+		if ttl <= 0 {
+			ttl = defaultTURNCredentialTTL
+		}
+		username, credential := turnCredentials(p.cfg.TURNSecret, userID, p.now().Add(ttl))
+		config.IceServers = append(config.IceServers, domain.IceServer{
+			URLs:       p.cfg.TURNURLs,
+			Username:   username,
+			Credential: credential,
+		})
+		config.TTL = int(ttl.Seconds())
+	}
+
+	return config, nil
+}
+
+func turnCredentials(secret, userID string, expiresAt time.Time) (string, string) {
+	username := strconv.FormatInt(expiresAt.Unix(), 10) + ":" + userID
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write([]byte(username))
+	return username, base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,54 @@
+package voip
+
+import (
+	"testing"
+	"time"
+)
+
+func TestICEConfigProvider_WebRTCConfig(t *testing.T) {
+	provider, err := NewICEConfigProvider(&ICEConfig{
+		STUNURLs:          []string{"stun:stun.example.com:3478"},
+		TURNURLs:          []string{"turn:turn.example.com:3478?transport=udp", "turns:turn.example.com:5349"},
+		TURNSecret:        "north",
+		TURNCredentialTTL: 10 * time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	provider.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	config, err := provider.WebRTCConfig("user-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(config.IceServers) != 2 {
+		t.Fatalf("expected stun and turn servers, got %+v", config.IceServers)
+	}
+
+	if config.IceServers[0].Username != "" || config.IceServers[0].Credential != "" {
+		t.Errorf("expected stun server without credentials, got %+v", config.IceServers[0])
+	}
+
+	turn := config.IceServers[1]
+	if turn.Username != "1700000600:user-1" {
+		t.Errorf("expected username '1700000600:user-1', got '%s'", turn.Username)
+	}
+	// echo -n "1700000600:user-1" | openssl dgst -sha1 -hmac north -binary | base64
+	if turn.Credential != "FRVCIGoEfeQq7UsZF7LcfHEPjeg=" {
+		t.Errorf("unexpected credential '%s'", turn.Credential)
+	}
+
+	if config.TTL != 600 {
+		t.Errorf("expected ttl 600, got %d", config.TTL)
+	}
+}
+
+func TestNewICEConfigProvider_TURNWithoutSecret(t *testing.T) {
+	_, err := NewICEConfigProvider(&ICEConfig{
+		TURNURLs: []string{"turn:turn.example.com:3478"},
+	})
+	if err == nil {
+		t.Error("expected error for turn servers without secret, got nil")
+	}
+}
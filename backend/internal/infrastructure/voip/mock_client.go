@@ -2,58 +2,105 @@ package voip
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/sdp"
 )
 
 type MockClient struct {
-	sessionManager *SessionManager
+	sessions       domain.SessionStore
+	scenarios      []MockScenario
+	offers         *sdp.OfferBuilder
+	notifier       *statusNotifier
+	timers         map[string][]*time.Timer
+	mu             sync.Mutex
 }
 
-func NewMockClient(cfg *Config) (*MockClient, error) {
-	sessionManager := NewSessionManager()
+func NewMockClient(cfg *Config, sessions domain.SessionStore) (*MockClient, error) {
+	if sessions == nil {
+		return nil, errors.New("session store is required")
+	}
+
+	scenarios, err := ParseMockScenarios(cfg.MockScenarios)
+	if err != nil {
+		return nil, err
+	}
 
-	slog.Info("mock voip client initialized")
+	offers, err := newOfferBuilder(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("mock voip client initialized", "scenarios", len(scenarios))
 
 	return &MockClient{
-		sessionManager: sessionManager,
+		sessions:       sessions,
+		scenarios:      scenarios,
+		offers:         offers,
+		notifier:       newStatusNotifier(cfg.StatusCallbackURL, cfg.AccountSID, cfg.AuthToken, cfg.FromNumber),
+		timers:         make(map[string][]*time.Timer),
 	}, nil
 }
 
-func (c *MockClient) InitiateCall(ctx context.Context, phoneNumber string) (*domain.CallSession, error) {
+func (c *MockClient) InitiateCall(ctx context.Context, phoneNumber string, opts domain.CallOptions) (*domain.CallSession, error) {
 	if phoneNumber == "" {
 		return nil, domain.ErrInvalidPhoneNumber
 	}
 
 	sessionID := fmt.Sprintf("mock_sess_%d", time.Now().UnixNano())
 
+	offer, err := generateOffer(c.offers)
+	if err != nil {
+		slog.Error("failed to generate sdp offer", "error", err)
+		return nil, ErrVoIPServiceUnavailable
+	}
+
 	session := &domain.CallSession{
-		SessionID:   sessionID,
-		PhoneNumber: phoneNumber,
-		SDPOffer:    generateMockSDP(),
-		Status:      domain.SessionStatusInitialized,
-		CreatedAt:   time.Now(),
-		ExpiresAt:   time.Now().Add(5 * time.Minute),
+		SessionID:       sessionID,
+		ProviderCallSID: sessionID,
+		PhoneNumber:     phoneNumber,
+		SDPOffer:        offer,
+		Status:          domain.SessionStatusInitialized,
+		CreatedAt:       time.Now(),
+		ExpiresAt:       time.Now().Add(5 * time.Minute),
+	}
+
+	if err := c.sessions.Save(ctx, session); err != nil {
+		slog.Error("failed to save mock session", "error", err, "session_id", sessionID)
+		return nil, ErrVoIPServiceUnavailable
 	}
 
-	c.sessionManager.AddSession(session)
+	snapshot := *session
+	go c.notifier.Notify(&snapshot, domain.SessionStatusInitialized)
 
-	slog.Info("mock call initiated", "session_id", sessionID, "phone", phoneNumber)
+	scenario := matchMockScenario(c.scenarios, phoneNumber)
+	c.schedule(sessionID, scenario)
 
-	return session, nil
+	slog.Info("mock call initiated", "session_id", sessionID, "phone", phoneNumber, "scenario", scenario.Pattern, "caller_id", opts.CallerID)
+
+	return &snapshot, nil
 }
 
 func (c *MockClient) TerminateCall(ctx context.Context, sessionID string) error {
-	session := c.sessionManager.GetSession(sessionID)
-	if session == nil {
-		return ErrSessionNotFound
+	c.cancelTimers(sessionID)
+
+	updated, err := c.sessions.UpdateStatus(ctx, sessionID, domain.SessionStatusCompleted)
+	switch {
+	case err == nil:
+		c.notifier.Notify(updated, domain.SessionStatusCompleted)
+	case errors.Is(err, domain.ErrSessionEnded):
+	default:
+		return err
 	}
 
-	session.Status = domain.SessionStatusCompleted
-	c.sessionManager.RemoveSession(sessionID)
+	if err := c.sessions.Delete(ctx, sessionID); err != nil {
+		slog.Warn("failed to delete mock session", "error", err, "session_id", sessionID)
+	}
 
 	slog.Info("mock call terminated", "session_id", sessionID)
 
@@ -61,16 +108,202 @@ func (c *MockClient) TerminateCall(ctx context.Context, sessionID string) error
 }
 
 func (c *MockClient) GetSessionStatus(ctx context.Context, sessionID string) (domain.SessionStatus, error) {
-	session := c.sessionManager.GetSession(sessionID)
-	if session == nil {
-		return "", ErrSessionNotFound
+	session, err := c.sessions.Get(ctx, sessionID)
+	if err != nil {
+		return "", err
 	}
 
 	return session.Status, nil
 }
 
+func (c *MockClient) AcceptAnswer(ctx context.Context, sessionID string, sdpAnswer string) error {
+	if _, err := storeAnswer(ctx, c.sessions, sessionID, sdpAnswer); err != nil {
+		return err
+	}
+
+	slog.Info("mock sdp answer accepted", "session_id", sessionID)
+	return nil
+}
+
+func (c *MockClient) AddICECandidate(ctx context.Context, sessionID string, candidate domain.ICECandidate) error {
+	if _, err := storeCandidate(ctx, c.sessions, sessionID, candidate); err != nil {
+		return err
+	}
+
+	slog.Debug("mock ice candidate added", "session_id", sessionID, "end_of_candidates", candidate.IsEndOfCandidates())
+	return nil
+}
+
+// SendDTMF only logs the masked digits; there is no far end to hear them.
+func (c *MockClient) SendDTMF(ctx context.Context, sessionID string, digits string, opts domain.CallOptions) error {
+	if err := domain.ValidateDTMF(digits); err != nil {
+		return err
+	}
+
+	if err := c.liveSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	slog.Info("mock dtmf sent", "session_id", sessionID, "digits", domain.MaskDTMF(digits))
+	return nil
+}
+
+func (c *MockClient) Hold(ctx context.Context, sessionID string) error {
+	if err := c.liveSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	slog.Info("mock call on hold", "session_id", sessionID)
+	return nil
+}
+
+func (c *MockClient) Resume(ctx context.Context, sessionID string, opts domain.CallOptions) error {
+	if err := c.liveSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	slog.Info("mock call resumed", "session_id", sessionID)
+	return nil
+}
+
+func (c *MockClient) SetMuted(ctx context.Context, sessionID string, muted bool) error {
+	if err := c.liveSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	slog.Info("mock call mute changed", "session_id", sessionID, "muted", muted)
+	return nil
+}
+
+// Transfer only logs; the transfer leg gets no status reports, so it stays
+// connecting.
+func (c *MockClient) Transfer(ctx context.Context, sessionID string, target domain.TransferTarget, legID string) error {
+	if err := c.liveSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	slog.Info("mock call transferred", "session_id", sessionID, "leg_id", legID, "target", transferAddress(target))
+	return nil
+}
+
+// Consult places the consultation call like any other mock call, following
+// the scenario that matches the target.
+func (c *MockClient) Consult(ctx context.Context, target domain.TransferTarget, opts domain.CallOptions) (*domain.CallSession, error) {
+	return c.InitiateCall(ctx, transferAddress(target), opts)
+}
+
+func (c *MockClient) Bridge(ctx context.Context, sessionID, consultSessionID string) error {
+	if err := c.liveSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	if err := c.liveSession(ctx, consultSessionID); err != nil {
+		return err
+	}
+
+	slog.Info("mock calls bridged", "session_id", sessionID, "consult_session_id", consultSessionID)
+	return nil
+}
+
+// HostConference rings the caller's browser like a consultation call to
+// them; the mock provider mixes no audio.
+func (c *MockClient) HostConference(ctx context.Context, conferenceID string, opts domain.CallOptions) (*domain.CallSession, error) {
+	return c.InitiateCall(ctx, transferAddress(domain.TransferTarget{UserID: opts.Identity}), opts)
+}
+
+func (c *MockClient) JoinConference(ctx context.Context, sessionID, conferenceID string, muted bool) error {
+	if err := c.liveSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	slog.Info("mock call joined conference", "session_id", sessionID, "conference_id", conferenceID, "muted", muted)
+	return nil
+}
+
+// PlaceCallback rings the caller's phone like any other mock call, following
+// the scenario that matches it. The mock provider never asks for the
+// destination, so no second leg is dialled.
+func (c *MockClient) PlaceCallback(ctx context.Context, callerNumber, callID string) (*domain.CallSession, error) {
+	slog.Info("mock callback call placed", "call_id", callID)
+	return c.InitiateCall(ctx, callerNumber, domain.CallOptions{})
+}
+
+// ReadOutCode places no call; the code is logged so that it can be entered
+// during development.
+func (c *MockClient) ReadOutCode(ctx context.Context, phoneNumber, code string) error {
+	if phoneNumber == "" {
+		return domain.ErrInvalidPhoneNumber
+	}
+
+	slog.Info("mock verification call placed", "phone", phoneNumber, "code", code)
+	return nil
+}
+
+// liveSession checks that the session exists and has not ended.
+func (c *MockClient) liveSession(ctx context.Context, sessionID string) error {
+	session, err := c.sessions.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session.Status.IsTerminal() {
+		return domain.ErrSessionEnded
+	}
+	return nil
+}
+
 func (c *MockClient) Close() error {
-	c.sessionManager.Close()
+	c.mu.Lock()
+	for sessionID, timers := range c.timers {
+		for _, t := range timers {
+			t.Stop()
+		}
+		delete(c.timers, sessionID)
+	}
+	c.mu.Unlock()
+
 	return nil
 }
 
+func (c *MockClient) schedule(sessionID string, scenario MockScenario) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timers := make([]*time.Timer, 0, len(scenario.Steps))
+	for _, step := range scenario.Steps {
+		step := step
+		timers = append(timers, time.AfterFunc(step.After, func() {
+			c.advance(sessionID, step.Status)
+		}))
+	}
+	c.timers[sessionID] = timers
+}
+
+func (c *MockClient) advance(sessionID string, status domain.SessionStatus) {
+	updated, err := c.sessions.UpdateStatus(context.Background(), sessionID, status)
+	if err != nil {
+		if !errors.Is(err, domain.ErrSessionNotFound) && !errors.Is(err, domain.ErrSessionEnded) {
+			slog.Warn("failed to advance mock session", "error", err, "session_id", sessionID)
+		}
+		c.cancelTimers(sessionID)
+		return
+	}
+
+	slog.Info("mock call status changed", "session_id", sessionID, "status", status)
+
+	if status.IsTerminal() {
+		c.cancelTimers(sessionID)
+	}
+
+	c.notifier.Notify(updated, status)
+}
+
+func (c *MockClient) cancelTimers(sessionID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, t := range c.timers[sessionID] {
+		t.Stop()
+	}
+	delete(c.timers, sessionID)
+}
@@ -0,0 +1,235 @@
+package voip
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/sdp"
+)
+
+type statusCallbackRecorder struct {
+	mu       sync.Mutex
+	statuses []string
+}
+
+func (r *statusCallbackRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	r.statuses = append(r.statuses, req.PostFormValue("CallStatus"))
+	r.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *statusCallbackRecorder) Statuses() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.statuses...)
+}
+
+func waitForStatus(t *testing.T, client *MockClient, sessionID string, want domain.SessionStatus) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		status, err := client.GetSessionStatus(context.Background(), sessionID)
+		if err == nil && status == want {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	status, _ := client.GetSessionStatus(context.Background(), sessionID)
+	t.Fatalf("expected status '%s', got '%s'", want, status)
+}
+
+func TestParseMockScenarios(t *testing.T) {
+	scenarios, err := ParseMockScenarios("+1555000*=busy@3s; +1555111*=answer@4s,ringing@1s,drop@30s")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(scenarios) != 2 {
+		t.Fatalf("expected 2 scenarios, got %d", len(scenarios))
+	}
+
+	if scenarios[0].Pattern != "+1555000*" || len(scenarios[0].Steps) != 1 {
+		t.Fatalf("unexpected first scenario: %+v", scenarios[0])
+	}
+	if scenarios[0].Steps[0].Status != domain.SessionStatusBusy || scenarios[0].Steps[0].After != 3*time.Second {
+		t.Errorf("expected busy after 3s, got %+v", scenarios[0].Steps[0])
+	}
+
+	steps := scenarios[1].Steps
+	if len(steps) != 3 || steps[0].Status != domain.SessionStatusRinging || steps[2].Status != domain.SessionStatusFailed {
+		t.Errorf("expected steps sorted by delay, got %+v", steps)
+	}
+}
+
+func TestParseMockScenarios_Invalid(t *testing.T) {
+	specs := []string{
+		"+1555000*",
+		"+1555000*=busy",
+		"+1555000*=explode@3s",
+		"+1555000*=busy@soon",
+		"+1*555=busy@3s",
+		"+1555000*=busy@1s,answer@2s",
+	}
+
+	for _, spec := range specs {
+		if _, err := ParseMockScenarios(spec); err == nil {
+			t.Errorf("expected error for spec '%s', got nil", spec)
+		}
+	}
+}
+
+func TestMockScenario_Matches(t *testing.T) {
+	scenario := MockScenario{Pattern: "+1555000*"}
+	if !scenario.Matches("+15550001234") {
+		t.Error("expected prefix pattern to match")
+	}
+	if scenario.Matches("+15551111234") {
+		t.Error("expected prefix pattern not to match")
+	}
+
+	exact := MockScenario{Pattern: "+491512345678"}
+	if !exact.Matches("+491512345678") || exact.Matches("+4915123456789") {
+		t.Error("expected exact pattern to match only the same number")
+	}
+}
+
+func TestMockClient_ScenarioProgression(t *testing.T) {
+	recorder := &statusCallbackRecorder{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	client, err := NewMockClient(&Config{
+		Provider:          "mock",
+		StatusCallbackURL: server.URL,
+		MockScenarios:     "+1555000*=ringing@10ms,busy@30ms",
+	}, NewSessionManager())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer client.Close()
+
+	session, err := client.InitiateCall(context.Background(), "+15550001234", domain.CallOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if session.Status != domain.SessionStatusInitialized {
+		t.Errorf("expected initial status 'initialized', got '%s'", session.Status)
+	}
+
+	waitForStatus(t, client, session.SessionID, domain.SessionStatusBusy)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(recorder.Statuses()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	statuses := recorder.Statuses()
+	if len(statuses) != 3 {
+		t.Fatalf("expected 3 status callbacks, got %v", statuses)
+	}
+	if statuses[len(statuses)-1] != "busy" {
+		t.Errorf("expected last callback 'busy', got %v", statuses)
+	}
+}
+
+func TestMockClient_InitiateCall_Offer(t *testing.T) {
+	client, err := NewMockClient(&Config{
+		Provider: "mock",
+		Codecs:   "PCMA,telephone-event",
+	}, NewSessionManager())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer client.Close()
+
+	first, err := client.InitiateCall(context.Background(), "+491512345678", domain.CallOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	second, _ := client.InitiateCall(context.Background(), "+491512345679", domain.CallOptions{})
+
+	offer, err := sdp.Parse(first.SDPOffer)
+	if err != nil {
+		t.Fatalf("expected offer to parse, got %v", err)
+	}
+	codecs, err := offer.AudioMedia().Codecs()
+	if err != nil || len(codecs) != 2 || codecs[0].Name != "PCMA" {
+		t.Errorf("expected configured codecs in offer, got %+v (%v)", codecs, err)
+	}
+
+	if first.SDPOffer == second.SDPOffer {
+		t.Error("expected each session to get a fresh offer")
+	}
+}
+
+func TestMockClient_TerminateStopsScenario(t *testing.T) {
+	client, err := NewMockClient(&Config{
+		Provider:      "mock",
+		MockScenarios: "*=answer@10ms,drop@50ms",
+	}, NewSessionManager())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer client.Close()
+
+	session, err := client.InitiateCall(context.Background(), "+491512345678", domain.CallOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	waitForStatus(t, client, session.SessionID, domain.SessionStatusActive)
+
+	if err := client.TerminateCall(context.Background(), session.SessionID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+
+	if _, err := client.GetSessionStatus(context.Background(), session.SessionID); err != ErrSessionNotFound {
+		t.Errorf("expected ErrSessionNotFound after terminate, got %v", err)
+	}
+}
+
+func TestMockClient_AddICECandidate(t *testing.T) {
+	sessions := NewSessionManager()
+	client, err := NewMockClient(&Config{Provider: "mock"}, sessions)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	session, err := client.InitiateCall(ctx, "+491512345678", domain.CallOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	candidate := domain.ICECandidate{Candidate: "candidate:1 1 udp 2130706431 192.0.2.10 50000 typ host", SDPMid: "0"}
+	if err := client.AddICECandidate(ctx, session.SessionID, candidate); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := client.AddICECandidate(ctx, session.SessionID, domain.ICECandidate{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := client.AddICECandidate(ctx, session.SessionID, candidate); err != domain.ErrCandidatesComplete {
+		t.Errorf("expected ErrCandidatesComplete after end of candidates, got %v", err)
+	}
+
+	stored, err := sessions.Get(ctx, session.SessionID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(stored.RemoteCandidates) != 2 || stored.RemoteCandidates[0] != candidate || !domain.CandidatesComplete(stored.RemoteCandidates) {
+		t.Errorf("unexpected remote candidates: %+v", stored.RemoteCandidates)
+	}
+	if len(stored.LocalCandidates) != 0 {
+		t.Errorf("expected no local candidates, got %+v", stored.LocalCandidates)
+	}
+}
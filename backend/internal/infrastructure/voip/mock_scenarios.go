@@ -0,0 +1,123 @@
+package voip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type MockStep struct {
+	After  time.Duration
+	Status domain.SessionStatus
+}
+
+type MockScenario struct {
+	Pattern string
+	Steps   []MockStep
+}
+
+var defaultMockScenario = MockScenario{
+	Pattern: "*",
+	Steps: []MockStep{
+		{After: 1 * time.Second, Status: domain.SessionStatusRinging},
+		{After: 3 * time.Second, Status: domain.SessionStatusActive},
+	},
+}
+
+var mockOutcomes = map[string]domain.SessionStatus{
+	"ringing":   domain.SessionStatusRinging,
+	"answer":    domain.SessionStatusActive,
+	"answered":  domain.SessionStatusActive,
+	"active":    domain.SessionStatusActive,
+	"busy":      domain.SessionStatusBusy,
+	"no-answer": domain.SessionStatusNoAnswer,
+	"no_answer": domain.SessionStatusNoAnswer,
+	"drop":      domain.SessionStatusFailed,
+	"fail":      domain.SessionStatusFailed,
+	"failed":    domain.SessionStatusFailed,
+	"hangup":    domain.SessionStatusCompleted,
+	"completed": domain.SessionStatusCompleted,
+}
+
+// ParseMockScenarios parses rules of the form
+// "+1555000*=busy@3s;+1555111*=ringing@1s,answer@4s,drop@30s".
+// Step offsets are measured from call initiation.
+func ParseMockScenarios(spec string) ([]MockScenario, error) {
+	var scenarios []MockScenario
+
+	for _, rule := range strings.Split(spec, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		pattern, stepsSpec, ok := strings.Cut(rule, "=")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || pattern == "" || strings.TrimSpace(stepsSpec) == "" {
+			return nil, fmt.Errorf("invalid mock scenario %q: expected pattern=outcome@delay", rule)
+		}
+		if strings.Contains(strings.TrimSuffix(pattern, "*"), "*") {
+			return nil, fmt.Errorf("invalid mock scenario pattern %q: only a trailing * is supported", pattern)
+		}
+
+		scenario := MockScenario{Pattern: pattern}
+		for _, stepSpec := range strings.Split(stepsSpec, ",") {
+			step, err := parseMockStep(strings.TrimSpace(stepSpec))
+			if err != nil {
+				return nil, fmt.Errorf("invalid mock scenario %q: %w", rule, err)
+			}
+			scenario.Steps = append(scenario.Steps, step)
+		}
+
+		sort.SliceStable(scenario.Steps, func(i, j int) bool {
+			return scenario.Steps[i].After < scenario.Steps[j].After
+		})
+		for i, step := range scenario.Steps {
+			if step.Status.IsTerminal() && i != len(scenario.Steps)-1 {
+				return nil, fmt.Errorf("invalid mock scenario %q: %s must be the last step", rule, step.Status)
+			}
+		}
+
+		scenarios = append(scenarios, scenario)
+	}
+
+	return scenarios, nil
+}
+
+func parseMockStep(spec string) (MockStep, error) {
+	outcome, delaySpec, ok := strings.Cut(spec, "@")
+	if !ok {
+		return MockStep{}, fmt.Errorf("step %q: expected outcome@delay", spec)
+	}
+
+	status, ok := mockOutcomes[strings.ToLower(strings.TrimSpace(outcome))]
+	if !ok {
+		return MockStep{}, fmt.Errorf("step %q: unknown outcome %q", spec, outcome)
+	}
+
+	delay, err := time.ParseDuration(strings.TrimSpace(delaySpec))
+	if err != nil || delay < 0 {
+		return MockStep{}, fmt.Errorf("step %q: invalid delay %q", spec, delaySpec)
+	}
+
+	return MockStep{After: delay, Status: status}, nil
+}
+
+func (s MockScenario) Matches(phoneNumber string) bool {
+	if prefix, ok := strings.CutSuffix(s.Pattern, "*"); ok {
+		return strings.HasPrefix(phoneNumber, prefix)
+	}
+	return s.Pattern == phoneNumber
+}
+
+func matchMockScenario(scenarios []MockScenario, phoneNumber string) MockScenario {
+	for _, scenario := range scenarios {
+		if scenario.Matches(phoneNumber) {
+			return scenario
+		}
+	}
+	return defaultMockScenario
+}
@@ -0,0 +1,89 @@
+package voip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const twilioAPIHost = "api.twilio.com"
+
+var ErrRecordingNotFound = errors.New("recording not found")
+
+// RecordingFetcher downloads call recordings from Twilio. Recording URLs
+// arrive in webhooks, so only URLs on the Twilio API host (or the configured
+// stand-in) are fetched, and the account credentials never leave for another
+// host.
+type RecordingFetcher struct {
+	accountSID  string
+	authToken   string
+	allowedHost string
+	scheme      string
+	client      *http.Client
+}
+
+func NewRecordingFetcher(cfg *Config) (*RecordingFetcher, error) {
+	if cfg.AccountSID == "" || cfg.AuthToken == "" {
+		return nil, fmt.Errorf("twilio credentials are required")
+	}
+
+	fetcher := &RecordingFetcher{
+		accountSID:  cfg.AccountSID,
+		authToken:   cfg.AuthToken,
+		allowedHost: twilioAPIHost,
+		scheme:      "https",
+		client:      &http.Client{Timeout: 2 * time.Minute},
+	}
+	if cfg.APIBaseURL != "" {
+		baseURL, err := url.Parse(cfg.APIBaseURL)
+		if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+			return nil, fmt.Errorf("invalid twilio api base url: %q", cfg.APIBaseURL)
+		}
+		fetcher.allowedHost = baseURL.Host
+		fetcher.scheme = baseURL.Scheme
+	}
+	return fetcher, nil
+}
+
+// Fetch downloads the recording as WAV. Twilio's RecordingUrl has no
+// extension; without one the API serves WAV.
+func (f *RecordingFetcher) Fetch(ctx context.Context, recordingURL string) (io.ReadCloser, string, error) {
+	u, err := url.Parse(recordingURL)
+	if err != nil || u.Host != f.allowedHost || u.Scheme != f.scheme || u.User != nil {
+		return nil, "", fmt.Errorf("recording url is not on %s", f.allowedHost)
+	}
+	if !strings.Contains(u.Path, "/Accounts/"+f.accountSID+"/") {
+		return nil, "", errors.New("recording belongs to another account")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.SetBasicAuth(f.accountSID, f.authToken)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrVoIPServiceUnavailable, err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, "", ErrRecordingNotFound
+	case resp.StatusCode != http.StatusOK:
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("%w: recording download returned %d", ErrVoIPServiceUnavailable, resp.StatusCode)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "audio/wav"
+	}
+	return resp.Body, contentType, nil
+}
@@ -0,0 +1,65 @@
+package voip
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRecordingFetcher_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != testAccountSID || pass != testAuthToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/2010-04-01/Accounts/"+testAccountSID+"/Recordings/RE1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/x-wav")
+		w.Write([]byte("RIFF"))
+	}))
+	defer server.Close()
+
+	fetcher, err := NewRecordingFetcher(&Config{AccountSID: testAccountSID, AuthToken: testAuthToken, APIBaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	base := server.URL + "/2010-04-01/Accounts/" + testAccountSID + "/Recordings/"
+
+	body, contentType, err := fetcher.Fetch(context.Background(), base+"RE1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "RIFF" || contentType != "audio/x-wav" {
+		t.Errorf("unexpected recording: %q %q", data, contentType)
+	}
+
+	if _, _, err := fetcher.Fetch(context.Background(), base+"RE2"); !errors.Is(err, ErrRecordingNotFound) {
+		t.Errorf("expected ErrRecordingNotFound, got %v", err)
+	}
+}
+
+func TestRecordingFetcher_RejectsForeignURLs(t *testing.T) {
+	fetcher, err := NewRecordingFetcher(&Config{AccountSID: testAccountSID, AuthToken: testAuthToken})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, u := range []string{
+		"https://evil.example.com/2010-04-01/Accounts/" + testAccountSID + "/Recordings/RE1",
+		"http://api.twilio.com/2010-04-01/Accounts/" + testAccountSID + "/Recordings/RE1",
+		"https://user@api.twilio.com/2010-04-01/Accounts/" + testAccountSID + "/Recordings/RE1",
+		"https://api.twilio.com/2010-04-01/Accounts/ACother/Recordings/RE1",
+	} {
+		if _, _, err := fetcher.Fetch(context.Background(), u); err == nil {
+			t.Errorf("expected %s to be rejected", u)
+		}
+	}
+}
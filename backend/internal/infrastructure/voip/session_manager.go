@@ -1,6 +1,7 @@
 package voip
 
 import (
+	"context"
 	"log/slog"
 	"sync"
 	"time"
@@ -25,31 +26,111 @@ func NewSessionManager() *SessionManager {
 	return sm
 }
 
-func (sm *SessionManager) AddSession(session *domain.CallSession) {
+func (sm *SessionManager) Save(ctx context.Context, session *domain.CallSession) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
-	sm.sessions[session.SessionID] = session
-	slog.Debug("session added", "session_id", session.SessionID)
+	snapshot := *session
+	if existing := sm.sessions[session.SessionID]; existing != nil {
+		snapshot.LocalCandidates = existing.LocalCandidates
+		snapshot.RemoteCandidates = existing.RemoteCandidates
+	} else {
+		snapshot.LocalCandidates = nil
+		snapshot.RemoteCandidates = nil
+	}
+	sm.sessions[session.SessionID] = &snapshot
+	slog.Debug("session saved", "session_id", session.SessionID)
+	return nil
 }
 
-func (sm *SessionManager) GetSession(sessionID string) *domain.CallSession {
+func (sm *SessionManager) Get(ctx context.Context, sessionID string) (*domain.CallSession, error) {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
-	return sm.sessions[sessionID]
+	session := sm.sessions[sessionID]
+	if session == nil || time.Now().After(session.ExpiresAt) {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	snapshot := *session
+	return &snapshot, nil
 }
 
-func (sm *SessionManager) RemoveSession(sessionID string) {
+func (sm *SessionManager) UpdateStatus(ctx context.Context, sessionID string, status domain.SessionStatus) (*domain.CallSession, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	session := sm.sessions[sessionID]
+	if session == nil || time.Now().After(session.ExpiresAt) {
+		return nil, domain.ErrSessionNotFound
+	}
+	if session.Status.IsTerminal() {
+		return nil, domain.ErrSessionEnded
+	}
+
+	session.Status = status
+	slog.Debug("session status updated", "session_id", sessionID, "status", status)
+
+	snapshot := *session
+	return &snapshot, nil
+}
+
+func (sm *SessionManager) AddCandidate(ctx context.Context, sessionID string, origin domain.CandidateOrigin, candidate domain.ICECandidate) (*domain.CallSession, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	session := sm.sessions[sessionID]
+	if session == nil || time.Now().After(session.ExpiresAt) {
+		return nil, domain.ErrSessionNotFound
+	}
+	if session.Status.IsTerminal() {
+		return nil, domain.ErrSessionEnded
+	}
+
+	// Appending to a fresh slice keeps snapshots handed out earlier intact.
+	candidates := &session.RemoteCandidates
+	if origin == domain.CandidateOriginLocal {
+		candidates = &session.LocalCandidates
+	}
+	if domain.CandidatesComplete(*candidates) {
+		return nil, domain.ErrCandidatesComplete
+	}
+	*candidates = append((*candidates)[:len(*candidates):len(*candidates)], candidate)
+
+	snapshot := *session
+	return &snapshot, nil
+}
+
+func (sm *SessionManager) SetAnswer(ctx context.Context, sessionID, sdpAnswer string) (*domain.CallSession, error) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	session := sm.sessions[sessionID]
+	if session == nil || time.Now().After(session.ExpiresAt) {
+		return nil, domain.ErrSessionNotFound
+	}
+	if session.Status.IsTerminal() {
+		return nil, domain.ErrSessionEnded
+	}
+
+	session.SDPAnswer = sdpAnswer
+
+	snapshot := *session
+	return &snapshot, nil
+}
+
+func (sm *SessionManager) Delete(ctx context.Context, sessionID string) error {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
 	delete(sm.sessions, sessionID)
 	slog.Debug("session removed", "session_id", sessionID)
+	return nil
 }
 
-func (sm *SessionManager) Close() {
+func (sm *SessionManager) Close() error {
 	close(sm.stopChan)
+	return nil
 }
 
 func (sm *SessionManager) cleanupExpiredSessions() {
@@ -84,4 +165,3 @@ func (sm *SessionManager) removeExpiredSessions() {
 		slog.Info("expired sessions removed", "count", expiredCount)
 	}
 }
-
@@ -0,0 +1,143 @@
+package voip
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+var twilioCallStatuses = map[domain.SessionStatus]string{
+	domain.SessionStatusInitialized: "initiated",
+	domain.SessionStatusConnecting:  "queued",
+	domain.SessionStatusRinging:     "ringing",
+	domain.SessionStatusActive:      "in-progress",
+	domain.SessionStatusCompleted:   "completed",
+	domain.SessionStatusBusy:        "busy",
+	domain.SessionStatusNoAnswer:    "no-answer",
+	domain.SessionStatusFailed:      "failed",
+}
+
+func TwilioCallStatus(status domain.SessionStatus) string {
+	if s, ok := twilioCallStatuses[status]; ok {
+		return s
+	}
+	return string(status)
+}
+
+func SessionStatusFromTwilio(callStatus string) (domain.SessionStatus, bool) {
+	switch strings.ToLower(callStatus) {
+	case "queued":
+		return domain.SessionStatusConnecting, true
+	case "initiated":
+		return domain.SessionStatusInitialized, true
+	case "ringing":
+		return domain.SessionStatusRinging, true
+	case "in-progress", "answered":
+		return domain.SessionStatusActive, true
+	case "completed":
+		return domain.SessionStatusCompleted, true
+	case "busy":
+		return domain.SessionStatusBusy, true
+	case "no-answer":
+		return domain.SessionStatusNoAnswer, true
+	case "failed", "canceled":
+		return domain.SessionStatusFailed, true
+	}
+	return "", false
+}
+
+type statusNotifier struct {
+	callbackURL string
+	accountSID  string
+	authToken   string
+	fromNumber  string
+	httpClient  *http.Client
+}
+
+func newStatusNotifier(callbackURL, accountSID, authToken, fromNumber string) *statusNotifier {
+	return &statusNotifier{
+		callbackURL: callbackURL,
+		accountSID:  accountSID,
+		authToken:   authToken,
+		fromNumber:  fromNumber,
+		httpClient:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (n *statusNotifier) Notify(session *domain.CallSession, status domain.SessionStatus) {
+	if n == nil || n.callbackURL == "" || session == nil {
+		return
+	}
+
+	form := url.Values{}
+	form.Set("CallSid", session.ProviderCallSID)
+	form.Set("AccountSid", n.accountSID)
+	form.Set("From", n.fromNumber)
+	form.Set("To", session.PhoneNumber)
+	form.Set("Direction", "outbound-api")
+	form.Set("CallStatus", TwilioCallStatus(status))
+	form.Set("Timestamp", time.Now().UTC().Format(time.RFC1123Z))
+	if status.IsTerminal() {
+		form.Set("CallDuration", strconv.Itoa(int(time.Since(session.CreatedAt).Seconds())))
+	}
+
+	n.post(form)
+}
+
+func (n *statusNotifier) post(form url.Values) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.callbackURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		slog.Error("failed to build status callback", "error", err, "url", n.callbackURL)
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if n.authToken != "" {
+		req.Header.Set("X-Twilio-Signature", twilioSignature(n.authToken, n.callbackURL, form))
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		slog.Warn("status callback failed", "error", err, "call_sid", form.Get("CallSid"))
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		slog.Warn("status callback rejected",
+			"status_code", resp.StatusCode,
+			"call_sid", form.Get("CallSid"),
+			"call_status", form.Get("CallStatus"))
+	}
+}
+
+// twilioSignature signs a webhook the way Twilio does: HMAC-SHA1 keyed by the
+// auth token over the URL followed by the sorted form parameters.
+func twilioSignature(authToken, callbackURL string, form url.Values) string {
+	keys := make([]string, 0, len(form))
+	for key := range form {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(callbackURL))
+	for _, key := range keys {
+		for _, value := range form[key] {
+			mac.Write([]byte(key + value))
+		}
+	}
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,42 @@
+package voip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	twilioclient "github.com/twilio/twilio-go/client"
+)
+
+func TestStatusNotifier_SignsCallbacks(t *testing.T) {
+	validator := twilioclient.NewRequestValidator(testAuthToken)
+	valid := make(chan bool, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		params := make(map[string]string)
+		for key, values := range r.PostForm {
+			params[key] = values[0]
+		}
+		valid <- validator.Validate("http://"+r.Host+r.URL.RequestURI(), params, r.Header.Get("X-Twilio-Signature"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := newStatusNotifier(server.URL+"/api/voice/status?Leg=1", testAccountSID, testAuthToken, "+15550000000")
+	notifier.Notify(&domain.CallSession{
+		ProviderCallSID: "CA123",
+		PhoneNumber:     "+15550001234",
+		CreatedAt:       time.Now(),
+	}, domain.SessionStatusCompleted)
+
+	select {
+	case ok := <-valid:
+		if !ok {
+			t.Error("expected a valid Twilio signature")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a status callback")
+	}
+}
@@ -40,7 +40,10 @@ func (g *TokenGenerator) GetToken(identity string, ttlSec int) (string, error) {
 		Nbf:           float64(time.Now().Unix()),
 	}
 	token := jwt.CreateAccessToken(params)
+	// Incoming lets the browser ring for calls to the user's numbers, which
+	// are dialled as <Client> with the same identity.
 	voiceGrant := &jwt.VoiceGrant{
+		Incoming: jwt.Incoming{Allow: true},
 		Outgoing: jwt.Outgoing{
 			ApplicationSid: g.cfg.TwimlAppSid,
 		},
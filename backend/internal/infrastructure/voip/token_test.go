@@ -0,0 +1,61 @@
+package voip
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTokenGenerator_GetToken_VoiceGrant(t *testing.T) {
+	gen, err := NewTokenGenerator(&TokenConfig{
+		AccountSid:   "AC123",
+		APIKeySid:    "SK123",
+		APIKeySecret: "secret",
+		TwimlAppSid:  "AP123",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	token, err := gen.GetToken("user-1", 60)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected a JWT, got %q", token)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+
+	var claims struct {
+		Grants struct {
+			Identity string `json:"identity"`
+			Voice    struct {
+				Incoming struct {
+					Allow bool `json:"allow"`
+				} `json:"incoming"`
+				Outgoing struct {
+					ApplicationSid string `json:"application_sid"`
+				} `json:"outgoing"`
+			} `json:"voice"`
+		} `json:"grants"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("failed to parse claims: %v", err)
+	}
+
+	if claims.Grants.Identity != "user-1" {
+		t.Errorf("expected identity 'user-1', got '%s'", claims.Grants.Identity)
+	}
+	if !claims.Grants.Voice.Incoming.Allow {
+		t.Error("expected incoming calls to be allowed")
+	}
+	if claims.Grants.Voice.Outgoing.ApplicationSid != "AP123" {
+		t.Errorf("expected outgoing application 'AP123', got '%s'", claims.Grants.Voice.Outgoing.ApplicationSid)
+	}
+}
@@ -2,23 +2,54 @@ package voip
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html"
 	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/sdp"
 	"github.com/twilio/twilio-go"
+	twilioclient "github.com/twilio/twilio-go/client"
 	openapi "github.com/twilio/twilio-go/rest/api/v2010"
 )
 
+// twilioSessionTTL matches Twilio's default call time limit, so a session
+// stays resolvable for as long as the provider call can be running.
+const twilioSessionTTL = 4 * time.Hour
+
+const defaultBridgeTarget = "client:{identity}"
+
+// defaultHoldMusicURL is one of the tracks Twilio hosts for hold music.
+const defaultHoldMusicURL = "https://com.twilio.music.classical.s3.amazonaws.com/BusyStrings.mp3"
+
+var twilioStatusCallbackEvents = []string{"initiated", "ringing", "answered", "completed"}
+
 type TwilioClient struct {
-	client         *twilio.RestClient
-	fromNumber     string
-	sessionManager *SessionManager
+	client              *twilio.RestClient
+	fromNumber          string
+	twimlURL            string
+	statusCallbackURL   string
+	transferCallbackURL string
+	callbackURL         string
+	bridgeTarget        string
+	recordCalls         bool
+	machineDetection    string
+	holdMusicURL        string
+	offers              *sdp.OfferBuilder
+	sessions            domain.SessionStore
 }
 
-func NewTwilioClient(cfg *Config) (*TwilioClient, error) {
+func NewTwilioClient(cfg *Config, sessions domain.SessionStore) (*TwilioClient, error) {
+	if sessions == nil {
+		return nil, errors.New("session store is required")
+	}
+
 	if cfg.AccountSID == "" || cfg.AuthToken == "" {
 		return nil, fmt.Errorf("twilio credentials are required")
 	}
@@ -27,31 +58,98 @@ func NewTwilioClient(cfg *Config) (*TwilioClient, error) {
 		return nil, fmt.Errorf("from_number is required")
 	}
 
-	client := twilio.NewRestClientWithParams(twilio.ClientParams{
+	switch cfg.MachineDetection {
+	case "", "Enable", "DetectMessageEnd":
+	default:
+		return nil, fmt.Errorf("unsupported machine detection mode: %q", cfg.MachineDetection)
+	}
+
+	bridgeTarget := cfg.BridgeTarget
+	if bridgeTarget == "" {
+		bridgeTarget = defaultBridgeTarget
+	}
+
+	holdMusicURL := cfg.HoldMusicURL
+	if holdMusicURL == "" {
+		holdMusicURL = defaultHoldMusicURL
+	}
+
+	params := twilio.ClientParams{
 		Username: cfg.AccountSID,
 		Password: cfg.AuthToken,
-	})
+	}
+	if cfg.APIBaseURL != "" {
+		baseURL, err := url.Parse(cfg.APIBaseURL)
+		if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+			return nil, fmt.Errorf("invalid twilio api base url: %q", cfg.APIBaseURL)
+		}
+		apiClient := &twilioclient.Client{
+			Credentials: twilioclient.NewCredentials(cfg.AccountSID, cfg.AuthToken),
+		}
+		apiClient.SetAccountSid(cfg.AccountSID)
+		params.Client = &baseURLClient{Client: apiClient, baseURL: baseURL}
+	}
+
+	client := twilio.NewRestClientWithParams(params)
 
-	sessionManager := NewSessionManager()
+	offers, err := newOfferBuilder(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	return &TwilioClient{
-		client:         client,
-		fromNumber:     cfg.FromNumber,
-		sessionManager: sessionManager,
+		client:              client,
+		fromNumber:          cfg.FromNumber,
+		twimlURL:            cfg.TwiMLURL,
+		statusCallbackURL:   cfg.StatusCallbackURL,
+		transferCallbackURL: cfg.TransferCallbackURL,
+		callbackURL:         cfg.CallbackURL,
+		bridgeTarget:        bridgeTarget,
+		recordCalls:         cfg.RecordCalls,
+		machineDetection:    cfg.MachineDetection,
+		holdMusicURL:        holdMusicURL,
+		offers:              offers,
+		sessions:            sessions,
 	}, nil
 }
 
-func (c *TwilioClient) InitiateCall(ctx context.Context, phoneNumber string) (*domain.CallSession, error) {
+func (c *TwilioClient) InitiateCall(ctx context.Context, phoneNumber string, opts domain.CallOptions) (*domain.CallSession, error) {
 	if phoneNumber == "" {
 		return nil, domain.ErrInvalidPhoneNumber
 	}
 
+	callFlowURL, err := c.callFlowURL(opts)
+	if err != nil {
+		slog.Error("outbound call flow is not configured", "error", err)
+		return nil, ErrVoIPServiceUnavailable
+	}
+
 	sessionID := generateSessionID()
 
+	offer, err := generateOffer(c.offers)
+	if err != nil {
+		slog.Error("failed to generate sdp offer", "error", err)
+		return nil, ErrVoIPServiceUnavailable
+	}
+
+	from := c.fromNumber
+	if opts.CallerID != "" {
+		from = opts.CallerID
+	}
+
 	params := &openapi.CreateCallParams{}
 	params.SetTo(phoneNumber)
-	params.SetFrom(c.fromNumber)
-	params.SetUrl("http://demo.twilio.com/docs/voice.xml")
+	params.SetFrom(from)
+	params.SetUrl(callFlowURL)
+	params.SetMethod("POST")
+	if c.statusCallbackURL != "" {
+		params.SetStatusCallback(c.statusCallbackURL)
+		params.SetStatusCallbackMethod("POST")
+		params.SetStatusCallbackEvent(twilioStatusCallbackEvents)
+	}
+	if c.machineDetection != "" {
+		params.SetMachineDetection(c.machineDetection)
+	}
 
 	resp, err := c.client.Api.CreateCall(params)
 	if err != nil {
@@ -63,56 +161,520 @@ func (c *TwilioClient) InitiateCall(ctx context.Context, phoneNumber string) (*d
 	}
 
 	session := &domain.CallSession{
-		SessionID:   sessionID,
-		PhoneNumber: phoneNumber,
-		SDPOffer:    generateMockSDP(),
-		Status:      domain.SessionStatusInitialized,
-		CreatedAt:   time.Now(),
-		ExpiresAt:   time.Now().Add(5 * time.Minute),
+		SessionID:       sessionID,
+		ProviderCallSID: *resp.Sid,
+		PhoneNumber:     phoneNumber,
+		SDPOffer:        offer,
+		Status:          domain.SessionStatusInitialized,
+		CreatedAt:       time.Now(),
+		ExpiresAt:       time.Now().Add(twilioSessionTTL),
 	}
 
-	c.sessionManager.AddSession(session)
+	if err := c.saveSession(ctx, session); err != nil {
+		return nil, err
+	}
 
 	slog.Info("twilio call initiated", 
 		"session_id", sessionID, 
 		"twilio_call_sid", *resp.Sid,
 		"phone", phoneNumber)
 
-	return session, nil
+	snapshot := *session
+	return &snapshot, nil
 }
 
 func (c *TwilioClient) TerminateCall(ctx context.Context, sessionID string) error {
-	session := c.sessionManager.GetSession(sessionID)
-	if session == nil {
-		return ErrSessionNotFound
+	session, err := c.sessions.Get(ctx, sessionID)
+	if err != nil {
+		return err
 	}
 
-	session.Status = domain.SessionStatusCompleted
-	c.sessionManager.RemoveSession(sessionID)
+	if session.ProviderCallSID != "" {
+		if err := c.HangUp(ctx, session.ProviderCallSID); err != nil {
+			return err
+		}
+	}
 
-	slog.Info("call terminated", "session_id", sessionID)
+	if err := c.sessions.Delete(ctx, sessionID); err != nil {
+		slog.Warn("failed to delete twilio session", "error", err, "session_id", sessionID)
+	}
+
+	slog.Info("call terminated", "session_id", sessionID, "twilio_call_sid", session.ProviderCallSID)
 
 	return nil
 }
 
+// HangUp completes a Twilio call by its SID. Voice SDK calls have no session,
+// so terminating them goes straight here. A call that has already ended is
+// not an error.
+func (c *TwilioClient) HangUp(ctx context.Context, providerCallSID string) error {
+	params := &openapi.UpdateCallParams{}
+	params.SetStatus("completed")
+
+	if _, err := c.client.Api.UpdateCall(providerCallSID, params); err != nil {
+		if !isTwilioCallAlreadyEndedError(err) {
+			slog.Error("failed to hang up twilio call", "error", err, "twilio_call_sid", providerCallSID)
+			return fmt.Errorf("%w: %v", ErrVoIPServiceUnavailable, err)
+		}
+		slog.Info("twilio call already ended", "twilio_call_sid", providerCallSID)
+	}
+	return nil
+}
+
 func (c *TwilioClient) GetSessionStatus(ctx context.Context, sessionID string) (domain.SessionStatus, error) {
-	session := c.sessionManager.GetSession(sessionID)
-	if session == nil {
-		return "", ErrSessionNotFound
+	session, err := c.sessions.Get(ctx, sessionID)
+	if err != nil {
+		return "", err
 	}
 
 	return session.Status, nil
 }
 
+// AcceptAnswer keeps the answer with the session. Twilio REST calls carry
+// media between Twilio and the callee, so the answer is not sent anywhere.
+func (c *TwilioClient) AcceptAnswer(ctx context.Context, sessionID string, sdpAnswer string) error {
+	session, err := storeAnswer(ctx, c.sessions, sessionID, sdpAnswer)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("sdp answer stored", "session_id", sessionID, "twilio_call_sid", session.ProviderCallSID)
+	return nil
+}
+
+// AddICECandidate keeps the candidate with the session for the same reason
+// AcceptAnswer only stores the answer.
+func (c *TwilioClient) AddICECandidate(ctx context.Context, sessionID string, candidate domain.ICECandidate) error {
+	session, err := storeCandidate(ctx, c.sessions, sessionID, candidate)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("ice candidate stored", "session_id", sessionID, "twilio_call_sid", session.ProviderCallSID)
+	return nil
+}
+
+// SendDTMF redirects the provider call to TwiML that plays the digits and then
+// resumes the call flow. Playing TwiML on the call ends its <Dial>, so the
+// bridge target is dialled again once the digits are played.
+func (c *TwilioClient) SendDTMF(ctx context.Context, sessionID string, digits string, opts domain.CallOptions) error {
+	if err := domain.ValidateDTMF(digits); err != nil {
+		return err
+	}
+
+	resumeURL, err := c.resumeURL(opts)
+	if err != nil {
+		slog.Error("outbound call flow is not configured", "error", err)
+		return ErrVoIPServiceUnavailable
+	}
+
+	// Twilio's "w" is the same half-second pause as ours.
+	params := &openapi.UpdateCallParams{}
+	params.SetTwiml(`<?xml version="1.0" encoding="UTF-8"?><Response><Play digits="` + digits + `"/>` +
+		`<Redirect method="POST">` + html.EscapeString(resumeURL) + `</Redirect></Response>`)
+
+	session, err := c.updateLiveCall(ctx, sessionID, params)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("dtmf sent",
+		"session_id", sessionID,
+		"twilio_call_sid", session.ProviderCallSID,
+		"digits", domain.MaskDTMF(digits))
+	return nil
+}
+
+// Hold replaces the call's <Dial> with hold music, which disconnects the
+// bridge target.
+func (c *TwilioClient) Hold(ctx context.Context, sessionID string) error {
+	params := &openapi.UpdateCallParams{}
+	params.SetTwiml(`<?xml version="1.0" encoding="UTF-8"?><Response><Play loop="0">` +
+		html.EscapeString(c.holdMusicURL) + `</Play></Response>`)
+
+	session, err := c.updateLiveCall(ctx, sessionID, params)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("twilio call on hold", "session_id", sessionID, "twilio_call_sid", session.ProviderCallSID)
+	return nil
+}
+
+// Resume runs the call flow again, dialling the bridge target.
+func (c *TwilioClient) Resume(ctx context.Context, sessionID string, opts domain.CallOptions) error {
+	resumeURL, err := c.resumeURL(opts)
+	if err != nil {
+		slog.Error("outbound call flow is not configured", "error", err)
+		return ErrVoIPServiceUnavailable
+	}
+
+	params := &openapi.UpdateCallParams{}
+	params.SetUrl(resumeURL)
+	params.SetMethod("POST")
+
+	session, err := c.updateLiveCall(ctx, sessionID, params)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("twilio call resumed", "session_id", sessionID, "twilio_call_sid", session.ProviderCallSID)
+	return nil
+}
+
+// Transfer replaces the call's <Dial> to the bridge target with a <Dial> to
+// the transfer target. The leg reports its answer to the transfer status
+// callback and its end to the <Dial> action, both identified by legID.
+func (c *TwilioClient) Transfer(ctx context.Context, sessionID string, target domain.TransferTarget, legID string) error {
+	if c.transferCallbackURL == "" {
+		slog.Error("transfer callback url is not configured", "session_id", sessionID)
+		return ErrVoIPServiceUnavailable
+	}
+
+	query := "?" + url.Values{"CallID": {legID}}.Encode()
+	statusCallback := ` statusCallbackEvent="answered" statusCallbackMethod="POST" statusCallback="` +
+		html.EscapeString(strings.TrimSuffix(c.transferCallbackURL, "/")+"/status"+query) + `"`
+
+	noun := `<Number` + statusCallback + `>` + html.EscapeString(target.PhoneNumber) + `</Number>`
+	if target.UserID != "" {
+		noun = `<Client` + statusCallback + `>` + html.EscapeString(target.UserID) + `</Client>`
+	}
+
+	params := &openapi.UpdateCallParams{}
+	params.SetTwiml(`<?xml version="1.0" encoding="UTF-8"?><Response>` +
+		`<Dial callerId="` + html.EscapeString(c.fromNumber) + `" method="POST" action="` +
+		html.EscapeString(c.transferCallbackURL+query) + `">` + noun + `</Dial></Response>`)
+
+	session, err := c.updateLiveCall(ctx, sessionID, params)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("twilio call transferred",
+		"session_id", sessionID,
+		"twilio_call_sid", session.ProviderCallSID,
+		"leg_id", legID)
+	return nil
+}
+
+// Consult calls the transfer target and bridges it to the caller, as
+// InitiateCall does for a phone number.
+func (c *TwilioClient) Consult(ctx context.Context, target domain.TransferTarget, opts domain.CallOptions) (*domain.CallSession, error) {
+	return c.InitiateCall(ctx, transferAddress(target), opts)
+}
+
+// Bridge moves both called parties into a conference of their own, which
+// ends when either hangs up. The held party keeps hearing hold music until
+// the consultation call's target joins.
+func (c *TwilioClient) Bridge(ctx context.Context, sessionID, consultSessionID string) error {
+	conference := html.EscapeString("transfer-" + sessionID)
+
+	held := &openapi.UpdateCallParams{}
+	held.SetTwiml(`<?xml version="1.0" encoding="UTF-8"?><Response><Dial><Conference beep="false"` +
+		` startConferenceOnEnter="false" endConferenceOnExit="true" waitMethod="GET" waitUrl="` +
+		html.EscapeString(c.holdMusicURL) + `">` + conference + `</Conference></Dial></Response>`)
+
+	session, err := c.updateLiveCall(ctx, sessionID, held)
+	if err != nil {
+		return err
+	}
+
+	consult := &openapi.UpdateCallParams{}
+	consult.SetTwiml(`<?xml version="1.0" encoding="UTF-8"?><Response><Dial><Conference beep="false"` +
+		` startConferenceOnEnter="true" endConferenceOnExit="true">` + conference + `</Conference></Dial></Response>`)
+
+	consultSession, err := c.updateLiveCall(ctx, consultSessionID, consult)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("twilio calls bridged",
+		"session_id", sessionID,
+		"twilio_call_sid", session.ProviderCallSID,
+		"consult_session_id", consultSessionID,
+		"consult_twilio_call_sid", consultSession.ProviderCallSID)
+	return nil
+}
+
+// HostConference calls the caller at the bridge target, as the call flow
+// does once a call is answered, straight into the conference. The
+// conference starts when the caller answers and ends when they hang up.
+func (c *TwilioClient) HostConference(ctx context.Context, conferenceID string, opts domain.CallOptions) (*domain.CallSession, error) {
+	if strings.Contains(c.bridgeTarget, "{identity}") && opts.Identity == "" {
+		slog.Error("bridge target requires a caller identity", "conference_id", conferenceID)
+		return nil, ErrVoIPServiceUnavailable
+	}
+	host := strings.ReplaceAll(c.bridgeTarget, "{identity}", opts.Identity)
+
+	params := &openapi.CreateCallParams{}
+	params.SetTo(host)
+	params.SetFrom(c.fromNumber)
+	params.SetTwiml(`<?xml version="1.0" encoding="UTF-8"?><Response><Dial><Conference beep="false"` +
+		` startConferenceOnEnter="true" endConferenceOnExit="true">` +
+		html.EscapeString(twilioConferenceName(conferenceID)) + `</Conference></Dial></Response>`)
+
+	resp, err := c.client.Api.CreateCall(params)
+	if err != nil {
+		slog.Error("failed to create twilio conference host call", "error", err, "conference_id", conferenceID)
+		return nil, ErrVoIPServiceUnavailable
+	}
+
+	session := &domain.CallSession{
+		SessionID:       generateSessionID(),
+		ProviderCallSID: *resp.Sid,
+		PhoneNumber:     host,
+		Status:          domain.SessionStatusInitialized,
+		CreatedAt:       time.Now(),
+		ExpiresAt:       time.Now().Add(twilioSessionTTL),
+	}
+
+	if err := c.saveSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	slog.Info("twilio conference hosted",
+		"conference_id", conferenceID,
+		"session_id", session.SessionID,
+		"twilio_call_sid", *resp.Sid)
+
+	snapshot := *session
+	return &snapshot, nil
+}
+
+// JoinConference replaces the call's <Dial> to the caller with the
+// conference. Participants hear hold music until the host joins and are
+// disconnected when the host leaves.
+func (c *TwilioClient) JoinConference(ctx context.Context, sessionID, conferenceID string, muted bool) error {
+	params := &openapi.UpdateCallParams{}
+	params.SetTwiml(`<?xml version="1.0" encoding="UTF-8"?><Response><Dial><Conference beep="false"` +
+		` muted="` + strconv.FormatBool(muted) + `" startConferenceOnEnter="false" endConferenceOnExit="false"` +
+		` waitMethod="GET" waitUrl="` + html.EscapeString(c.holdMusicURL) + `">` +
+		html.EscapeString(twilioConferenceName(conferenceID)) + `</Conference></Dial></Response>`)
+
+	session, err := c.updateLiveCall(ctx, sessionID, params)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("twilio call joined conference",
+		"session_id", sessionID,
+		"twilio_call_sid", session.ProviderCallSID,
+		"conference_id", conferenceID,
+		"muted", muted)
+	return nil
+}
+
+// PlaceCallback calls the caller's phone. Once it answers, Twilio requests
+// the callback URL for the call record, which dials the destination.
+func (c *TwilioClient) PlaceCallback(ctx context.Context, callerNumber, callID string) (*domain.CallSession, error) {
+	if callerNumber == "" {
+		return nil, domain.ErrInvalidPhoneNumber
+	}
+
+	if c.callbackURL == "" {
+		slog.Error("callback url is not configured", "call_id", callID)
+		return nil, ErrVoIPServiceUnavailable
+	}
+
+	params := &openapi.CreateCallParams{}
+	params.SetTo(callerNumber)
+	params.SetFrom(c.fromNumber)
+	params.SetUrl(c.callbackURL + "?" + url.Values{"CallID": {callID}}.Encode())
+	params.SetMethod("POST")
+	if c.statusCallbackURL != "" {
+		params.SetStatusCallback(c.statusCallbackURL)
+		params.SetStatusCallbackMethod("POST")
+		params.SetStatusCallbackEvent(twilioStatusCallbackEvents)
+	}
+
+	resp, err := c.client.Api.CreateCall(params)
+	if err != nil {
+		slog.Error("failed to create twilio callback call", "error", err, "call_id", callID)
+		if isTwilioInvalidNumberError(err) {
+			return nil, domain.ErrInvalidPhoneNumber
+		}
+		return nil, ErrVoIPServiceUnavailable
+	}
+
+	session := &domain.CallSession{
+		SessionID:       generateSessionID(),
+		ProviderCallSID: *resp.Sid,
+		PhoneNumber:     callerNumber,
+		Status:          domain.SessionStatusInitialized,
+		CreatedAt:       time.Now(),
+		ExpiresAt:       time.Now().Add(twilioSessionTTL),
+	}
+
+	if err := c.sessions.Save(ctx, session); err != nil {
+		slog.Error("failed to save twilio session",
+			"error", err,
+			"session_id", session.SessionID,
+			"twilio_call_sid", *resp.Sid)
+		return nil, ErrVoIPServiceUnavailable
+	}
+
+	slog.Info("twilio callback call placed",
+		"call_id", callID,
+		"session_id", session.SessionID,
+		"twilio_call_sid", *resp.Sid)
+
+	snapshot := *session
+	return &snapshot, nil
+}
+
+// ReadOutCode calls the number from the platform's number and says the code
+// twice, digit by digit.
+func (c *TwilioClient) ReadOutCode(ctx context.Context, phoneNumber, code string) error {
+	if phoneNumber == "" {
+		return domain.ErrInvalidPhoneNumber
+	}
+
+	say := `<Say language="en-US">Your verification code is ` + html.EscapeString(strings.Join(strings.Split(code, ""), ", ")) + `.</Say>`
+
+	params := &openapi.CreateCallParams{}
+	params.SetTo(phoneNumber)
+	params.SetFrom(c.fromNumber)
+	params.SetTwiml(`<?xml version="1.0" encoding="UTF-8"?><Response><Pause length="1"/>` +
+		say + `<Pause length="1"/>` + say + `</Response>`)
+
+	resp, err := c.client.Api.CreateCall(params)
+	if err != nil {
+		slog.Error("failed to create twilio verification call", "error", err)
+		if isTwilioInvalidNumberError(err) {
+			return domain.ErrInvalidPhoneNumber
+		}
+		return ErrVoIPServiceUnavailable
+	}
+
+	slog.Info("twilio verification call placed", "twilio_call_sid", *resp.Sid)
+	return nil
+}
+
+// saveSession stores the session of a call that was just placed. A call
+// that cannot be tracked is hung up rather than left ringing: nothing could
+// control or end it later.
+func (c *TwilioClient) saveSession(ctx context.Context, session *domain.CallSession) error {
+	if err := c.sessions.Save(ctx, session); err != nil {
+		slog.Error("failed to save twilio session",
+			"error", err,
+			"session_id", session.SessionID,
+			"twilio_call_sid", session.ProviderCallSID)
+		if err := c.HangUp(ctx, session.ProviderCallSID); err != nil {
+			slog.Warn("untracked twilio call left running", "twilio_call_sid", session.ProviderCallSID)
+		}
+		return ErrVoIPServiceUnavailable
+	}
+	return nil
+}
+
+func twilioConferenceName(conferenceID string) string {
+	return "conference-" + conferenceID
+}
+
+// updateLiveCall applies new instructions to the session's provider call.
+func (c *TwilioClient) updateLiveCall(ctx context.Context, sessionID string, params *openapi.UpdateCallParams) (*domain.CallSession, error) {
+	session, err := c.sessions.Get(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.Status.IsTerminal() {
+		return nil, domain.ErrSessionEnded
+	}
+
+	if _, err := c.client.Api.UpdateCall(session.ProviderCallSID, params); err != nil {
+		if isTwilioCallAlreadyEndedError(err) {
+			return nil, domain.ErrSessionEnded
+		}
+		slog.Error("failed to update twilio call",
+			"error", err,
+			"session_id", sessionID,
+			"twilio_call_sid", session.ProviderCallSID)
+		return nil, fmt.Errorf("%w: %v", ErrVoIPServiceUnavailable, err)
+	}
+
+	return session, nil
+}
+
 func (c *TwilioClient) Close() error {
-	c.sessionManager.Close()
 	return nil
 }
 
+// callFlowURL points Twilio at our TwiML endpoint. Once the destination
+// answers, the endpoint bridges it to the caller's target, so REST-originated
+// calls go through the same TwiML as Voice SDK calls.
+func (c *TwilioClient) callFlowURL(opts domain.CallOptions) (string, error) {
+	if c.twimlURL == "" {
+		return "", errors.New("twiml url is required")
+	}
+
+	u, err := url.Parse(c.twimlURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid twiml url: %w", err)
+	}
+
+	target := strings.ReplaceAll(c.bridgeTarget, "{identity}", opts.Identity)
+	if strings.Contains(c.bridgeTarget, "{identity}") && opts.Identity == "" {
+		return "", errors.New("bridge target requires a caller identity")
+	}
+
+	q := u.Query()
+	q.Set("Bridge", target)
+	if c.recordCalls || opts.Record {
+		q.Set("Record", "true")
+	}
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// resumeURL is the call flow URL for a call that is already connected; the
+// destination has heard the recording announcement then.
+func (c *TwilioClient) resumeURL(opts domain.CallOptions) (string, error) {
+	callFlowURL, err := c.callFlowURL(opts)
+	if err != nil {
+		return "", err
+	}
+
+	u, _ := url.Parse(callFlowURL)
+	q := u.Query()
+	q.Set("Resume", "true")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// baseURLClient redirects requests from api.twilio.com to another host, such
+// as a local stand-in used in tests.
+type baseURLClient struct {
+	*twilioclient.Client
+	baseURL *url.URL
+}
+
+func (c *baseURLClient) SendRequest(method string, rawURL string, data url.Values,
+	headers map[string]interface{}, body ...byte) (*http.Response, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Scheme = c.baseURL.Scheme
+	u.Host = c.baseURL.Host
+	u.Path = strings.TrimSuffix(c.baseURL.Path, "/") + u.Path
+	return c.Client.SendRequest(method, u.String(), data, headers, body...)
+}
+
 func generateSessionID() string {
 	return fmt.Sprintf("sess_%d", time.Now().UnixNano())
 }
 
+func isTwilioCallAlreadyEndedError(err error) bool {
+	var restErr *twilioclient.TwilioRestError
+	if errors.As(err, &restErr) {
+		return restErr.Code == 21220
+	}
+	return strings.Contains(err.Error(), "21220")
+}
+
 func isTwilioInvalidNumberError(err error) bool {
 	s := err.Error()
 	return strings.Contains(s, "21211") ||
@@ -120,15 +682,3 @@ func isTwilioInvalidNumberError(err error) bool {
 		strings.Contains(strings.ToLower(s), "not a valid") ||
 		strings.Contains(strings.ToLower(s), "invalid phone")
 }
-
-func generateMockSDP() string {
-	return `v=0
-o=- 0 0 IN IP4 127.0.0.1
-s=WebRTC Call
-t=0 0
-m=audio 9 UDP/TLS/RTP/SAVPF 0 8 101
-c=IN IP4 0.0.0.0
-a=rtcp:9 IN IP4 0.0.0.0
-a=sendrecv`
-}
-
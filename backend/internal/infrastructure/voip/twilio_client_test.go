@@ -0,0 +1,528 @@
+package voip
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/infrastructure/voip/twiliotest"
+)
+
+const (
+	testAccountSID = "ACtest0000000000000000000000000000"
+	testAuthToken  = "testauthtoken"
+	testFromNumber = "+15005550006"
+)
+
+// failingSessionStore rejects every session, as Postgres rejects values
+// that do not fit their column.
+type failingSessionStore struct {
+	*SessionManager
+}
+
+func (s failingSessionStore) Save(ctx context.Context, session *domain.CallSession) error {
+	return errors.New("value too long for type character varying(20)")
+}
+
+func newTestTwilioClient(t *testing.T, fake *twiliotest.Server, statusCallbackURL string) *TwilioClient {
+	t.Helper()
+	return newTestTwilioClientWithStore(t, fake, statusCallbackURL, NewSessionManager())
+}
+
+func newTestTwilioClientWithStore(t *testing.T, fake *twiliotest.Server, statusCallbackURL string, sessions domain.SessionStore) *TwilioClient {
+	t.Helper()
+	client, err := NewTwilioClient(&Config{
+		Provider:          "twilio",
+		AccountSID:        testAccountSID,
+		AuthToken:         testAuthToken,
+		FromNumber:        testFromNumber,
+		APIBaseURL:        fake.URL,
+		TwiMLURL:          "https://calls.example.com/api/voice/twiml",
+		StatusCallbackURL: statusCallbackURL,
+		RecordCalls:       true,
+		MachineDetection:  "Enable",
+	}, sessions)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestTwilioClient_InitiateStatusTerminate(t *testing.T) {
+	recorder := &statusCallbackRecorder{}
+	callbackServer := httptest.NewServer(recorder)
+	defer callbackServer.Close()
+
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClient(t, fake, callbackServer.URL)
+
+	session, err := client.InitiateCall(context.Background(), "+491512345678", domain.CallOptions{Identity: "user-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 call at provider, got %d", len(calls))
+	}
+	if calls[0].To != "+491512345678" || calls[0].From != testFromNumber {
+		t.Errorf("unexpected call parameters: to=%s from=%s", calls[0].To, calls[0].From)
+	}
+	wantURL := "https://calls.example.com/api/voice/twiml?Bridge=client%3Auser-1&Record=true"
+	if calls[0].URL != wantURL {
+		t.Errorf("expected call flow url '%s', got '%s'", wantURL, calls[0].URL)
+	}
+	if calls[0].StatusCallback != callbackServer.URL {
+		t.Errorf("expected status callback '%s', got '%s'", callbackServer.URL, calls[0].StatusCallback)
+	}
+	if calls[0].Params.Get("MachineDetection") != "Enable" {
+		t.Errorf("expected machine detection 'Enable', got '%s'", calls[0].Params.Get("MachineDetection"))
+	}
+
+	for _, status := range []string{"ringing", "in-progress"} {
+		if err := fake.SetCallStatus(calls[0].Sid, status); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	statuses := recorder.Statuses()
+	if len(statuses) != 2 || statuses[0] != "ringing" || statuses[1] != "in-progress" {
+		t.Errorf("expected ringing and in-progress callbacks, got %v", statuses)
+	}
+
+	if session.ProviderCallSID != calls[0].Sid {
+		t.Errorf("expected provider call sid '%s', got '%s'", calls[0].Sid, session.ProviderCallSID)
+	}
+
+	if err := client.TerminateCall(context.Background(), session.SessionID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	call, _ := fake.Call(calls[0].Sid)
+	if call.Status != "completed" {
+		t.Errorf("expected provider call status 'completed', got '%s'", call.Status)
+	}
+
+	statuses = recorder.Statuses()
+	if statuses[len(statuses)-1] != "completed" {
+		t.Errorf("expected completed callback after terminate, got %v", statuses)
+	}
+
+	if _, err := client.GetSessionStatus(context.Background(), session.SessionID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound after terminate, got %v", err)
+	}
+}
+
+func TestTwilioClient_TerminateCall_AlreadyCompleted(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClient(t, fake, "")
+
+	session, err := client.InitiateCall(context.Background(), "+491512345678", domain.CallOptions{Identity: "user-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := fake.SetCallStatus(session.ProviderCallSID, "completed"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := client.TerminateCall(context.Background(), session.SessionID); err != nil {
+		t.Fatalf("expected already completed call to terminate cleanly, got %v", err)
+	}
+}
+
+func TestTwilioClient_TerminateCall_ProviderFailure(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+
+	client := newTestTwilioClient(t, fake, "")
+
+	session, err := client.InitiateCall(context.Background(), "+491512345678", domain.CallOptions{Identity: "user-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	fake.Close()
+
+	err = client.TerminateCall(context.Background(), session.SessionID)
+	if !errors.Is(err, ErrVoIPServiceUnavailable) {
+		t.Fatalf("expected ErrVoIPServiceUnavailable, got %v", err)
+	}
+
+	if _, err := client.GetSessionStatus(context.Background(), session.SessionID); err != nil {
+		t.Errorf("expected session to be kept for retry, got %v", err)
+	}
+}
+
+func TestTwilioClient_HangUp(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClient(t, fake, "")
+
+	// The call is hung up by its SID alone, as a Voice SDK call would be.
+	session, err := client.InitiateCall(context.Background(), "+491512345678", domain.CallOptions{Identity: "user-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := client.HangUp(context.Background(), session.ProviderCallSID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if call, _ := fake.Call(session.ProviderCallSID); call.Status != "completed" {
+		t.Errorf("expected provider call status 'completed', got '%s'", call.Status)
+	}
+
+	if err := client.HangUp(context.Background(), session.ProviderCallSID); err != nil {
+		t.Errorf("expected already completed call to hang up cleanly, got %v", err)
+	}
+}
+
+func TestTwilioClient_InitiateCall_InvalidNumber(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClient(t, fake, "")
+
+	_, err := client.InitiateCall(context.Background(), "+12", domain.CallOptions{Identity: "user-1"})
+	if !errors.Is(err, domain.ErrInvalidPhoneNumber) {
+		t.Errorf("expected ErrInvalidPhoneNumber, got %v", err)
+	}
+}
+
+func TestTwilioClient_InitiateCall_BadCredentials(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, "othertoken")
+	defer fake.Close()
+
+	client := newTestTwilioClient(t, fake, "")
+
+	_, err := client.InitiateCall(context.Background(), "+491512345678", domain.CallOptions{Identity: "user-1"})
+	if !errors.Is(err, ErrVoIPServiceUnavailable) {
+		t.Errorf("expected ErrVoIPServiceUnavailable, got %v", err)
+	}
+
+	requests := fake.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 recorded request, got %d", len(requests))
+	}
+	if requests[0].Form.Get("To") != "+491512345678" {
+		t.Errorf("expected recorded To parameter, got '%s'", requests[0].Form.Get("To"))
+	}
+}
+
+func TestTwilioClient_InitiateCall_CallFlowNotConfigured(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client, err := NewTwilioClient(&Config{
+		Provider:   "twilio",
+		AccountSID: testAccountSID,
+		AuthToken:  testAuthToken,
+		FromNumber: testFromNumber,
+		APIBaseURL: fake.URL,
+	}, NewSessionManager())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.InitiateCall(context.Background(), "+491512345678", domain.CallOptions{Identity: "user-1"})
+	if !errors.Is(err, ErrVoIPServiceUnavailable) {
+		t.Errorf("expected ErrVoIPServiceUnavailable, got %v", err)
+	}
+
+	if len(fake.Requests()) != 0 {
+		t.Errorf("expected no provider requests, got %d", len(fake.Requests()))
+	}
+}
+
+func TestTwilioClient_SendDTMF(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClient(t, fake, "")
+
+	opts := domain.CallOptions{Identity: "user-1"}
+	session, err := client.InitiateCall(context.Background(), "+491512345678", opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := client.SendDTMF(context.Background(), session.SessionID, "1w23#", opts); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	call, _ := fake.Call(session.ProviderCallSID)
+	want := `<?xml version="1.0" encoding="UTF-8"?><Response><Play digits="1w23#"/>` +
+		`<Redirect method="POST">https://calls.example.com/api/voice/twiml?Bridge=client%3Auser-1&amp;Record=true&amp;Resume=true</Redirect></Response>`
+	if call.Twiml != want {
+		t.Errorf("expected twiml '%s', got '%s'", want, call.Twiml)
+	}
+
+	if err := client.SendDTMF(context.Background(), session.SessionID, "12a", opts); !errors.Is(err, domain.ErrInvalidDTMF) {
+		t.Errorf("expected ErrInvalidDTMF, got %v", err)
+	}
+
+	if err := fake.SetCallStatus(session.ProviderCallSID, "completed"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := client.SendDTMF(context.Background(), session.SessionID, "1", opts); !errors.Is(err, domain.ErrSessionEnded) {
+		t.Errorf("expected ErrSessionEnded for an ended call, got %v", err)
+	}
+}
+
+func TestTwilioClient_HoldResume(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClient(t, fake, "")
+
+	opts := domain.CallOptions{Identity: "user-1"}
+	session, err := client.InitiateCall(context.Background(), "+491512345678", opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := client.Hold(context.Background(), session.SessionID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	call, _ := fake.Call(session.ProviderCallSID)
+	wantTwiml := `<?xml version="1.0" encoding="UTF-8"?><Response><Play loop="0">` + defaultHoldMusicURL + `</Play></Response>`
+	if call.Twiml != wantTwiml {
+		t.Errorf("expected twiml '%s', got '%s'", wantTwiml, call.Twiml)
+	}
+
+	if err := client.Resume(context.Background(), session.SessionID, opts); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	call, _ = fake.Call(session.ProviderCallSID)
+	wantURL := "https://calls.example.com/api/voice/twiml?Bridge=client%3Auser-1&Record=true&Resume=true"
+	if call.URL != wantURL {
+		t.Errorf("expected call flow url '%s', got '%s'", wantURL, call.URL)
+	}
+
+	if err := client.Hold(context.Background(), "sess_missing"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound, got %v", err)
+	}
+}
+
+func TestTwilioClient_Transfer(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClient(t, fake, "")
+
+	opts := domain.CallOptions{Identity: "user-1"}
+	session, err := client.InitiateCall(context.Background(), "+491512345678", opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	target := domain.TransferTarget{PhoneNumber: "+14155550100"}
+	if err := client.Transfer(context.Background(), session.SessionID, target, "leg-1"); !errors.Is(err, ErrVoIPServiceUnavailable) {
+		t.Errorf("expected ErrVoIPServiceUnavailable without transfer callback url, got %v", err)
+	}
+
+	client.transferCallbackURL = "https://calls.example.com/api/voice/transfer"
+	if err := client.Transfer(context.Background(), session.SessionID, target, "leg-1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	call, _ := fake.Call(session.ProviderCallSID)
+	wantTwiml := `<?xml version="1.0" encoding="UTF-8"?><Response><Dial callerId="` + testFromNumber + `" method="POST"` +
+		` action="https://calls.example.com/api/voice/transfer?CallID=leg-1"><Number statusCallbackEvent="answered"` +
+		` statusCallbackMethod="POST" statusCallback="https://calls.example.com/api/voice/transfer/status?CallID=leg-1">` +
+		`+14155550100</Number></Dial></Response>`
+	if call.Twiml != wantTwiml {
+		t.Errorf("expected twiml '%s', got '%s'", wantTwiml, call.Twiml)
+	}
+}
+
+func TestTwilioClient_ConsultBridge(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClient(t, fake, "")
+
+	opts := domain.CallOptions{Identity: "user-1"}
+	session, err := client.InitiateCall(context.Background(), "+491512345678", opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	consult, err := client.Consult(context.Background(), domain.TransferTarget{UserID: "user-2"}, opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	consultCall, _ := fake.Call(consult.ProviderCallSID)
+	if consultCall.To != "client:user-2" {
+		t.Errorf("expected consultation call to client:user-2, got '%s'", consultCall.To)
+	}
+	if consult.PhoneNumber != "client:user-2" {
+		t.Errorf("expected consultation session for client:user-2, got '%s'", consult.PhoneNumber)
+	}
+
+	if err := client.Bridge(context.Background(), session.SessionID, consult.SessionID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	conference := "transfer-" + session.SessionID
+	call, _ := fake.Call(session.ProviderCallSID)
+	if !strings.Contains(call.Twiml, `startConferenceOnEnter="false"`) || !strings.Contains(call.Twiml, ">"+conference+"</Conference>") {
+		t.Errorf("expected held call to wait in conference %s, got '%s'", conference, call.Twiml)
+	}
+
+	consultCall, _ = fake.Call(consult.ProviderCallSID)
+	if !strings.Contains(consultCall.Twiml, `startConferenceOnEnter="true"`) || !strings.Contains(consultCall.Twiml, ">"+conference+"</Conference>") {
+		t.Errorf("expected consultation call to start conference %s, got '%s'", conference, consultCall.Twiml)
+	}
+}
+
+func TestTwilioClient_Consult_SessionNotSaved(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClientWithStore(t, fake, "", failingSessionStore{NewSessionManager()})
+
+	if _, err := client.Consult(context.Background(), domain.TransferTarget{UserID: "user-2"}, domain.CallOptions{Identity: "user-1"}); !errors.Is(err, ErrVoIPServiceUnavailable) {
+		t.Fatalf("expected ErrVoIPServiceUnavailable, got %v", err)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 1 || calls[0].To != "client:user-2" || calls[0].Status != "completed" {
+		t.Errorf("expected the untracked consultation call to be hung up, got %+v", calls)
+	}
+}
+
+func TestTwilioClient_Conference(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClient(t, fake, "")
+
+	opts := domain.CallOptions{Identity: "user-1"}
+	session, err := client.InitiateCall(context.Background(), "+491512345678", opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	host, err := client.HostConference(context.Background(), "conf-1", opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	hostCall, _ := fake.Call(host.ProviderCallSID)
+	if hostCall.To != "client:user-1" {
+		t.Errorf("expected host call to client:user-1, got '%s'", hostCall.To)
+	}
+	if !strings.Contains(hostCall.Twiml, `endConferenceOnExit="true">conference-conf-1</Conference>`) {
+		t.Errorf("expected host call to own conference-conf-1, got '%s'", hostCall.Twiml)
+	}
+
+	if err := client.JoinConference(context.Background(), session.SessionID, "conf-1", true); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	call, _ := fake.Call(session.ProviderCallSID)
+	if !strings.Contains(call.Twiml, `muted="true"`) || !strings.Contains(call.Twiml, ">conference-conf-1</Conference>") {
+		t.Errorf("expected call to join conference-conf-1 muted, got '%s'", call.Twiml)
+	}
+
+	if err := client.TerminateCall(context.Background(), host.SessionID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if hostCall, _ = fake.Call(host.ProviderCallSID); hostCall.Status != "completed" {
+		t.Errorf("expected host call to be completed, got '%s'", hostCall.Status)
+	}
+}
+
+func TestTwilioClient_HostConference_SessionNotSaved(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClientWithStore(t, fake, "", failingSessionStore{NewSessionManager()})
+
+	if _, err := client.HostConference(context.Background(), "conf-1", domain.CallOptions{Identity: "user-1"}); !errors.Is(err, ErrVoIPServiceUnavailable) {
+		t.Fatalf("expected ErrVoIPServiceUnavailable, got %v", err)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 1 || calls[0].Status != "completed" {
+		t.Errorf("expected the untracked host call to be hung up, got %+v", calls)
+	}
+}
+
+func TestTwilioClient_PlaceCallback(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClient(t, fake, "")
+
+	if _, err := client.PlaceCallback(context.Background(), "+491512345678", "call-1"); !errors.Is(err, ErrVoIPServiceUnavailable) {
+		t.Fatalf("expected ErrVoIPServiceUnavailable without a callback url, got %v", err)
+	}
+
+	client.callbackURL = "https://calls.example.com/api/voice/callback"
+
+	session, err := client.PlaceCallback(context.Background(), "+491512345678", "call-1")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	call, ok := fake.Call(session.ProviderCallSID)
+	if !ok {
+		t.Fatalf("expected twilio call %s to exist", session.ProviderCallSID)
+	}
+	if call.To != "+491512345678" || call.From != testFromNumber {
+		t.Errorf("expected call from %s to the caller, got '%s' -> '%s'", testFromNumber, call.From, call.To)
+	}
+	if call.URL != "https://calls.example.com/api/voice/callback?CallID=call-1" {
+		t.Errorf("unexpected callback url '%s'", call.URL)
+	}
+}
+
+func TestTwilioClient_CallerID(t *testing.T) {
+	fake := twiliotest.NewServer(testAccountSID, testAuthToken)
+	defer fake.Close()
+
+	client := newTestTwilioClient(t, fake, "")
+
+	session, err := client.InitiateCall(context.Background(), "+491512345678", domain.CallOptions{
+		Identity: "user-1",
+		CallerID: "+14155550100",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if call, _ := fake.Call(session.ProviderCallSID); call.From != "+14155550100" {
+		t.Errorf("expected call from the caller id, got '%s'", call.From)
+	}
+
+	if err := client.ReadOutCode(context.Background(), "+14155550100", "482913"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var verification twiliotest.Call
+	for _, call := range fake.Calls() {
+		if call.Twiml != "" {
+			verification = call
+		}
+	}
+	if verification.To != "+14155550100" || verification.From != testFromNumber {
+		t.Errorf("expected verification call from %s to the caller id, got '%s' -> '%s'", testFromNumber, verification.From, verification.To)
+	}
+	if !strings.Contains(verification.Twiml, "4, 8, 2, 9, 1, 3") {
+		t.Errorf("expected the code to be read out digit by digit, got '%s'", verification.Twiml)
+	}
+}
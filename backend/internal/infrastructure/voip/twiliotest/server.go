@@ -0,0 +1,408 @@
+// Package twiliotest provides an in-process stand-in for the subset of the
+// Twilio REST API used by the voip package: creating, updating and fetching
+// calls. It validates credentials, records every request and fires status
+// callbacks the way Twilio does.
+package twiliotest
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const apiVersion = "2010-04-01"
+
+var (
+	callsPathRe = regexp.MustCompile(`^/2010-04-01/Accounts/([^/]+)/Calls\.json$`)
+	callPathRe  = regexp.MustCompile(`^/2010-04-01/Accounts/([^/]+)/Calls/([^/]+)\.json$`)
+	e164Re      = regexp.MustCompile(`^\+[1-9]\d{6,14}$`)
+)
+
+type Request struct {
+	Method string
+	Path   string
+	Form   url.Values
+}
+
+type Call struct {
+	Sid            string
+	To             string
+	From           string
+	Status         string
+	URL            string
+	Twiml          string
+	StatusCallback string
+	Params         url.Values
+	DateCreated    time.Time
+	StartTime      time.Time
+	EndTime        time.Time
+}
+
+type Server struct {
+	*httptest.Server
+
+	AccountSID string
+	AuthToken  string
+
+	// StatusCallbackURL receives status callbacks for calls created without
+	// their own StatusCallback parameter.
+	StatusCallbackURL string
+
+	mu         sync.Mutex
+	calls      map[string]*Call
+	requests   []Request
+	seq        int
+	httpClient *http.Client
+}
+
+func NewServer(accountSID, authToken string) *Server {
+	s := &Server{
+		AccountSID: accountSID,
+		AuthToken:  authToken,
+		calls:      make(map[string]*Call),
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+func (s *Server) Call(sid string) (Call, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	call, ok := s.calls[sid]
+	if !ok {
+		return Call{}, false
+	}
+	return *call, true
+}
+
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calls := make([]Call, 0, len(s.calls))
+	for i := 1; i <= s.seq; i++ {
+		if call, ok := s.calls[callSid(i)]; ok {
+			calls = append(calls, *call)
+		}
+	}
+	return calls
+}
+
+// SetCallStatus moves a call to the given Twilio status (ringing,
+// in-progress, completed, busy, no-answer, failed, canceled) and delivers the
+// status callback synchronously.
+func (s *Server) SetCallStatus(sid, status string) error {
+	s.mu.Lock()
+	call, ok := s.calls[sid]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("call %s not found", sid)
+	}
+	snapshot := s.transition(call, status)
+	s.mu.Unlock()
+
+	return s.notify(snapshot)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, 20001, "invalid request body")
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Form: cloneValues(r.Form)})
+	s.mu.Unlock()
+
+	user, pass, ok := r.BasicAuth()
+	if !ok || user != s.AccountSID || pass != s.AuthToken {
+		writeError(w, http.StatusUnauthorized, 20003, "Authenticate")
+		return
+	}
+
+	if m := callsPathRe.FindStringSubmatch(r.URL.Path); m != nil {
+		if m[1] != s.AccountSID {
+			writeError(w, http.StatusForbidden, 20003, "Account mismatch")
+			return
+		}
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, 20004, "Method not allowed")
+			return
+		}
+		s.createCall(w, r)
+		return
+	}
+
+	if m := callPathRe.FindStringSubmatch(r.URL.Path); m != nil {
+		if m[1] != s.AccountSID {
+			writeError(w, http.StatusForbidden, 20003, "Account mismatch")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			s.fetchCall(w, m[2])
+		case http.MethodPost:
+			s.updateCall(w, r, m[2])
+		default:
+			writeError(w, http.StatusMethodNotAllowed, 20004, "Method not allowed")
+		}
+		return
+	}
+
+	writeError(w, http.StatusNotFound, 20404, "The requested resource was not found")
+}
+
+func (s *Server) createCall(w http.ResponseWriter, r *http.Request) {
+	to := r.PostForm.Get("To")
+	from := r.PostForm.Get("From")
+	if to == "" {
+		writeError(w, http.StatusBadRequest, 21201, "No 'To' number is specified")
+		return
+	}
+	if !strings.HasPrefix(to, "client:") && !strings.HasPrefix(to, "sip:") && !e164Re.MatchString(to) {
+		writeError(w, http.StatusBadRequest, 21211, fmt.Sprintf("The 'To' number %s is not a valid phone number.", to))
+		return
+	}
+	if from == "" {
+		writeError(w, http.StatusBadRequest, 21603, "A 'From' phone number is required.")
+		return
+	}
+	if r.PostForm.Get("Url") == "" && r.PostForm.Get("Twiml") == "" {
+		writeError(w, http.StatusBadRequest, 21205, "Url or Twiml parameter is required.")
+		return
+	}
+
+	s.mu.Lock()
+	s.seq++
+	call := &Call{
+		Sid:            callSid(s.seq),
+		To:             to,
+		From:           from,
+		Status:         "queued",
+		URL:            r.PostForm.Get("Url"),
+		Twiml:          r.PostForm.Get("Twiml"),
+		StatusCallback: r.PostForm.Get("StatusCallback"),
+		Params:         cloneValues(r.PostForm),
+		DateCreated:    time.Now().UTC(),
+	}
+	s.calls[call.Sid] = call
+	resp := s.resource(call)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (s *Server) fetchCall(w http.ResponseWriter, sid string) {
+	s.mu.Lock()
+	call, ok := s.calls[sid]
+	if !ok {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, 20404, fmt.Sprintf("The requested resource /%s/Accounts/%s/Calls/%s.json was not found", apiVersion, s.AccountSID, sid))
+		return
+	}
+	resp := s.resource(call)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) updateCall(w http.ResponseWriter, r *http.Request, sid string) {
+	s.mu.Lock()
+	call, ok := s.calls[sid]
+	if !ok {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, 20404, fmt.Sprintf("The requested resource /%s/Accounts/%s/Calls/%s.json was not found", apiVersion, s.AccountSID, sid))
+		return
+	}
+
+	if isFinal(call.Status) {
+		s.mu.Unlock()
+		writeError(w, http.StatusBadRequest, 21220, "Call is not in-progress. Cannot redirect.")
+		return
+	}
+
+	var snapshot *Call
+	switch status := r.PostForm.Get("Status"); status {
+	case "":
+	case "completed", "canceled":
+		snapshot = s.transition(call, status)
+	default:
+		s.mu.Unlock()
+		writeError(w, http.StatusBadRequest, 21218, fmt.Sprintf("Invalid call status %q", status))
+		return
+	}
+
+	if u := r.PostForm.Get("Url"); u != "" {
+		call.URL = u
+	}
+	if twiml := r.PostForm.Get("Twiml"); twiml != "" {
+		call.Twiml = twiml
+	}
+	resp := s.resource(call)
+	s.mu.Unlock()
+
+	if snapshot != nil {
+		_ = s.notify(snapshot)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) transition(call *Call, status string) *Call {
+	now := time.Now().UTC()
+	if status == "in-progress" && call.StartTime.IsZero() {
+		call.StartTime = now
+	}
+	if isFinal(status) && call.EndTime.IsZero() {
+		call.EndTime = now
+	}
+	call.Status = status
+
+	snapshot := *call
+	return &snapshot
+}
+
+func (s *Server) notify(call *Call) error {
+	callbackURL := call.StatusCallback
+	if callbackURL == "" {
+		callbackURL = s.StatusCallbackURL
+	}
+	if callbackURL == "" {
+		return nil
+	}
+
+	form := url.Values{}
+	form.Set("CallSid", call.Sid)
+	form.Set("AccountSid", s.AccountSID)
+	form.Set("From", call.From)
+	form.Set("To", call.To)
+	form.Set("Direction", "outbound-api")
+	form.Set("ApiVersion", apiVersion)
+	form.Set("CallStatus", call.Status)
+	form.Set("Timestamp", time.Now().UTC().Format(time.RFC1123Z))
+	if isFinal(call.Status) {
+		form.Set("CallDuration", strconv.Itoa(callDuration(call)))
+	}
+
+	req, err := http.NewRequest(http.MethodPost, callbackURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Twilio-Signature", s.signature(callbackURL, form))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("status callback returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// signature is the X-Twilio-Signature of a callback: HMAC-SHA1 keyed by the
+// auth token over the URL followed by the sorted form parameters.
+func (s *Server) signature(callbackURL string, form url.Values) string {
+	keys := make([]string, 0, len(form))
+	for key := range form {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	mac := hmac.New(sha1.New, []byte(s.AuthToken))
+	mac.Write([]byte(callbackURL))
+	for _, key := range keys {
+		for _, value := range form[key] {
+			mac.Write([]byte(key + value))
+		}
+	}
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Server) resource(call *Call) map[string]interface{} {
+	uri := fmt.Sprintf("/%s/Accounts/%s/Calls/%s.json", apiVersion, s.AccountSID, call.Sid)
+	resp := map[string]interface{}{
+		"sid":          call.Sid,
+		"account_sid":  s.AccountSID,
+		"to":           call.To,
+		"from":         call.From,
+		"status":       call.Status,
+		"direction":    "outbound-api",
+		"api_version":  apiVersion,
+		"date_created": call.DateCreated.Format(time.RFC1123Z),
+		"duration":     strconv.Itoa(callDuration(call)),
+		"uri":          uri,
+	}
+	if !call.StartTime.IsZero() {
+		resp["start_time"] = call.StartTime.Format(time.RFC1123Z)
+	}
+	if !call.EndTime.IsZero() {
+		resp["end_time"] = call.EndTime.Format(time.RFC1123Z)
+	}
+	return resp
+}
+
+func callDuration(call *Call) int {
+	if call.StartTime.IsZero() || call.EndTime.IsZero() {
+		return 0
+	}
+	return int(call.EndTime.Sub(call.StartTime).Seconds())
+}
+
+func isFinal(status string) bool {
+	switch status {
+	case "completed", "busy", "no-answer", "failed", "canceled":
+		return true
+	}
+	return false
+}
+
+func callSid(n int) string {
+	return fmt.Sprintf("CA%032x", n)
+}
+
+func cloneValues(v url.Values) url.Values {
+	out := make(url.Values, len(v))
+	for k, vals := range v {
+		out[k] = append([]string(nil), vals...)
+	}
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"code":      code,
+		"message":   message,
+		"more_info": fmt.Sprintf("https://www.twilio.com/docs/errors/%d", code),
+		"status":    status,
+	})
+}
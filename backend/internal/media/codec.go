@@ -0,0 +1,119 @@
+// Package media converts audio payloads between the codecs used on the
+// browser and carrier legs of a call, and produces the DTMF events and hold
+// music sent on the carrier leg.
+package media
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+var ErrUnsupportedCodec = errors.New("codec is not supported by the media gateway")
+
+// Decoder turns RTP payloads of one format into 16-bit mono PCM. ClockRate
+// is the PCM sample rate, which for all codecs here equals the RTP clock
+// rate.
+type Decoder interface {
+	Name() string
+	ClockRate() int
+	Decode(payload []byte) ([]int16, error)
+}
+
+// Codec is a Decoder that can also turn PCM back into payloads.
+type Codec interface {
+	Decoder
+	Encode(pcm []int16) ([]byte, error)
+}
+
+// streamDecoder is implemented by decoders that keep state from one payload
+// to the next. Every transcoder decodes with its own stream.
+type streamDecoder interface {
+	newStream() Decoder
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Decoder{
+		"pcmu": pcmu{},
+		"pcma": pcma{},
+		"opus": opusCodec{},
+	}
+)
+
+// RegisterCodec makes a codec available to transcoders, replacing any codec
+// with the same name. G.711 is built in both ways; Opus is built in for
+// decoding only, as there is no pure-Go Opus encoder.
+func RegisterCodec(c Codec) {
+	RegisterDecoder(c)
+}
+
+// RegisterDecoder makes a receive-only codec available to transcoders as a
+// source, replacing any codec with the same name.
+func RegisterDecoder(d Decoder) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[strings.ToLower(d.Name())] = d
+}
+
+// LookupCodec returns the registered codec with the given rtpmap name, if it
+// can both decode and encode.
+func LookupCodec(name string) (Codec, bool) {
+	d, ok := LookupDecoder(name)
+	if !ok {
+		return nil, false
+	}
+	c, ok := d.(Codec)
+	return c, ok
+}
+
+// LookupDecoder returns the registered codec with the given rtpmap name,
+// including receive-only ones.
+func LookupDecoder(name string) (Decoder, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	d, ok := codecs[strings.ToLower(name)]
+	return d, ok
+}
+
+type pcmu struct{}
+
+func (pcmu) Name() string   { return "PCMU" }
+func (pcmu) ClockRate() int { return 8000 }
+
+func (pcmu) Decode(payload []byte) ([]int16, error) {
+	pcm := make([]int16, len(payload))
+	for i, b := range payload {
+		pcm[i] = MulawToLinear(b)
+	}
+	return pcm, nil
+}
+
+func (pcmu) Encode(pcm []int16) ([]byte, error) {
+	payload := make([]byte, len(pcm))
+	for i, s := range pcm {
+		payload[i] = LinearToMulaw(s)
+	}
+	return payload, nil
+}
+
+type pcma struct{}
+
+func (pcma) Name() string   { return "PCMA" }
+func (pcma) ClockRate() int { return 8000 }
+
+func (pcma) Decode(payload []byte) ([]int16, error) {
+	pcm := make([]int16, len(payload))
+	for i, b := range payload {
+		pcm[i] = AlawToLinear(b)
+	}
+	return pcm, nil
+}
+
+func (pcma) Encode(pcm []int16) ([]byte, error) {
+	payload := make([]byte, len(pcm))
+	for i, s := range pcm {
+		payload[i] = LinearToAlaw(s)
+	}
+	return payload, nil
+}
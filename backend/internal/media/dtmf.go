@@ -0,0 +1,40 @@
+package media
+
+import "encoding/binary"
+
+// TelephoneEvent is the payload of an RFC 4733 telephone-event packet. All
+// packets of one event share the event's RTP timestamp; Duration grows in
+// clock-rate units until the packet that has End set.
+type TelephoneEvent struct {
+	Event    uint8
+	End      bool
+	Volume   uint8
+	Duration uint16
+}
+
+// Marshal encodes the event as the 4-byte payload of RFC 4733, section 2.3.
+func (e TelephoneEvent) Marshal() []byte {
+	payload := make([]byte, 4)
+	payload[0] = e.Event
+	payload[1] = e.Volume & 0x3F
+	if e.End {
+		payload[1] |= 0x80
+	}
+	binary.BigEndian.PutUint16(payload[2:], e.Duration)
+	return payload
+}
+
+// DTMFEvent returns the RFC 4733 event code of a keypad digit.
+func DTMFEvent(digit rune) (uint8, bool) {
+	switch {
+	case digit >= '0' && digit <= '9':
+		return uint8(digit - '0'), true
+	case digit == '*':
+		return 10, true
+	case digit == '#':
+		return 11, true
+	case digit >= 'A' && digit <= 'D':
+		return uint8(digit-'A') + 12, true
+	}
+	return 0, false
+}
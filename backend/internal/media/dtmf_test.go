@@ -0,0 +1,35 @@
+package media
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTelephoneEvent_Marshal(t *testing.T) {
+	got := TelephoneEvent{Event: 11, End: true, Volume: 10, Duration: 800}.Marshal()
+	want := []byte{0x0B, 0x8A, 0x03, 0x20}
+	if !bytes.Equal(got, want) {
+		t.Errorf("expected payload %x, got %x", want, got)
+	}
+
+	got = TelephoneEvent{Event: 5, Volume: 0xFF, Duration: 160}.Marshal()
+	want = []byte{0x05, 0x3F, 0x00, 0xA0}
+	if !bytes.Equal(got, want) {
+		t.Errorf("expected volume to be limited to 6 bits, got %x", got)
+	}
+}
+
+func TestDTMFEvent(t *testing.T) {
+	cases := map[rune]uint8{'0': 0, '7': 7, '9': 9, '*': 10, '#': 11, 'A': 12, 'D': 15}
+	for digit, want := range cases {
+		if got, ok := DTMFEvent(digit); !ok || got != want {
+			t.Errorf("digit %q: expected event %d, got %d (ok=%v)", digit, want, got, ok)
+		}
+	}
+
+	for _, digit := range []rune{'w', 'a', 'E', ' '} {
+		if _, ok := DTMFEvent(digit); ok {
+			t.Errorf("expected %q to have no event", digit)
+		}
+	}
+}
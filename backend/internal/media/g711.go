@@ -0,0 +1,87 @@
+package media
+
+// G.711 companding as specified in ITU-T G.711. Samples are 16-bit linear
+// PCM; the codecs carry the 14-bit (µ-law) and 13-bit (A-law) top bits.
+
+const (
+	mulawBias = 0x84
+	mulawClip = 32635
+)
+
+// LinearToMulaw compresses one 16-bit sample to µ-law.
+func LinearToMulaw(sample int16) byte {
+	s := int(sample)
+	sign := 0
+	if s < 0 {
+		s = -s
+		sign = 0x80
+	}
+	if s > mulawClip {
+		s = mulawClip
+	}
+	s += mulawBias
+
+	exponent := 7
+	for mask := 0x4000; s&mask == 0 && exponent > 0; mask >>= 1 {
+		exponent--
+	}
+	mantissa := (s >> (exponent + 3)) & 0x0F
+	return ^byte(sign | exponent<<4 | mantissa)
+}
+
+// MulawToLinear expands one µ-law byte to a 16-bit sample.
+func MulawToLinear(b byte) int16 {
+	b = ^b
+	exponent := int(b>>4) & 0x07
+	mantissa := int(b & 0x0F)
+	s := ((mantissa << 3) + mulawBias) << exponent
+	s -= mulawBias
+	if b&0x80 != 0 {
+		return int16(-s)
+	}
+	return int16(s)
+}
+
+// LinearToAlaw compresses one 16-bit sample to A-law.
+func LinearToAlaw(sample int16) byte {
+	s := int(sample) >> 3
+	sign := 0x80
+	if s < 0 {
+		s = -s - 1
+		sign = 0
+	}
+
+	var b int
+	if s < 32 {
+		b = s >> 1
+	} else {
+		exponent := 1
+		for v := s >> 5; v > 1 && exponent < 7; v >>= 1 {
+			exponent++
+		}
+		if s >= 4096 {
+			b = 0x7F
+		} else {
+			b = exponent<<4 | (s>>exponent)&0x0F
+		}
+	}
+	return byte(b|sign) ^ 0x55
+}
+
+// AlawToLinear expands one A-law byte to a 16-bit sample.
+func AlawToLinear(b byte) int16 {
+	b ^= 0x55
+	exponent := int(b>>4) & 0x07
+	mantissa := int(b & 0x0F)
+
+	var s int
+	if exponent == 0 {
+		s = mantissa<<4 + 8
+	} else {
+		s = (mantissa<<4 + 0x108) << (exponent - 1)
+	}
+	if b&0x80 == 0 {
+		return int16(-s)
+	}
+	return int16(s)
+}
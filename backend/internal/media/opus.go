@@ -0,0 +1,64 @@
+package media
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/pion/opus"
+)
+
+var ErrUnsupportedOpus = errors.New("opus packet cannot be decoded by the media gateway")
+
+// OpusFmtp are the Opus format parameters that keep a browser's encoder
+// within what the decoder here handles: speech of at most wideband at a
+// bitrate libopus codes in SILK-only mode. Leaving out useinbandfec and
+// stereo keeps their defaults of no in-band FEC and mono; browsers send 20 ms
+// frames unless asked otherwise. They are also left out because browsers
+// answer with their own values for them, and a differing value would stop
+// pion from matching the answered codec. CELT and hybrid packets are refused.
+const OpusFmtp = "maxplaybackrate=16000;maxaveragebitrate=24000"
+
+// opusUpsample is how many times the pion decoder repeats each sample when
+// it writes 16-bit PCM.
+const opusUpsample = 3
+
+// opusCodec decodes Opus with the pure-Go pion decoder. There is no pure-Go
+// encoder, so Opus can only be transcoded from, never to.
+type opusCodec struct{}
+
+func (opusCodec) Name() string   { return "opus" }
+func (opusCodec) ClockRate() int { return 48000 }
+
+// Decode decodes a single payload with a fresh decoder. Streams should be
+// decoded with newStream, which keeps the SILK state between frames.
+func (c opusCodec) Decode(payload []byte) ([]int16, error) {
+	return c.newStream().Decode(payload)
+}
+
+func (opusCodec) newStream() Decoder {
+	dec := opus.NewDecoder()
+	return &opusStream{dec: &dec, buf: make([]byte, 2*opusUpsample*320)}
+}
+
+type opusStream struct {
+	opusCodec
+	dec *opus.Decoder
+	buf []byte
+}
+
+func (s *opusStream) Decode(payload []byte) ([]int16, error) {
+	bandwidth, _, err := s.dec.Decode(payload, s.buf)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedOpus, err)
+	}
+
+	// The decoder writes the frame at its coded rate with every sample
+	// repeated; keep one of each and bring the frame to the RTP clock.
+	rate := bandwidth.SampleRate()
+	pcm := make([]int16, rate/50)
+	for i := range pcm {
+		pcm[i] = int16(binary.LittleEndian.Uint16(s.buf[2*opusUpsample*i:]))
+	}
+	return Resample(pcm, rate, s.ClockRate()), nil
+}
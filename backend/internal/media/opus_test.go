@@ -0,0 +1,61 @@
+package media
+
+import (
+	"errors"
+	"testing"
+)
+
+// silkWideband is one 20 ms mono SILK-only wideband Opus packet (TOC 0x48),
+// as browsers send it under OpusFmtp.
+var silkWideband = []byte{0x48, 0x83, 0xca, 0xde, 0x8a, 0xe5, 0x67, 0xd5, 0x1c, 0xac, 0xa2, 0x54, 0xfa, 0xff, 0xbf}
+
+func TestOpus_DecodesSILKFrame(t *testing.T) {
+	pcm, err := opusCodec{}.Decode(silkWideband)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pcm) != 960 {
+		t.Errorf("expected 20ms at 48kHz (960 samples), got %d", len(pcm))
+	}
+}
+
+func TestOpus_RefusesCELTFrame(t *testing.T) {
+	// TOC 0xf8: CELT-only fullband, 20 ms, one frame.
+	if _, err := (opusCodec{}).Decode([]byte{0xf8, 0xff, 0xfe}); !errors.Is(err, ErrUnsupportedOpus) {
+		t.Fatalf("expected ErrUnsupportedOpus, got %v", err)
+	}
+	if _, err := (opusCodec{}).Decode(nil); !errors.Is(err, ErrUnsupportedOpus) {
+		t.Fatalf("expected ErrUnsupportedOpus for an empty payload, got %v", err)
+	}
+}
+
+func TestTranscoder_OpusToPCMU(t *testing.T) {
+	tr, err := NewTranscoder("opus", "PCMU")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tr.Passthrough() {
+		t.Fatal("expected conversion from opus")
+	}
+
+	for i := 0; i < 3; i++ {
+		out, err := tr.Transcode(silkWideband)
+		if err != nil {
+			t.Fatalf("packet %d: unexpected error: %v", i, err)
+		}
+		if len(out) != 160 {
+			t.Fatalf("packet %d: expected 20ms at 8kHz (160 samples), got %d", i, len(out))
+		}
+	}
+	if ts := tr.Timestamp(960); ts != 160 {
+		t.Errorf("expected timestamp offset 160, got %d", ts)
+	}
+}
+
+func TestTranscoder_OpusStreamsAreSeparate(t *testing.T) {
+	a, _ := NewTranscoder("opus", "PCMU")
+	b, _ := NewTranscoder("opus", "PCMU")
+	if a.from == b.from {
+		t.Fatal("expected each transcoder to decode with its own opus stream")
+	}
+}
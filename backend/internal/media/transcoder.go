@@ -0,0 +1,72 @@
+package media
+
+import "strings"
+
+// Transcoder converts RTP payloads from one codec to another. Payloads pass
+// through untouched when both sides use the same codec.
+type Transcoder struct {
+	from Decoder
+	to   Codec
+}
+
+// NewTranscoder returns a transcoder between two registered codecs. The
+// source only needs to decode; the destination has to encode.
+func NewTranscoder(from, to string) (*Transcoder, error) {
+	src, ok := LookupDecoder(from)
+	if !ok {
+		return nil, ErrUnsupportedCodec
+	}
+	if s, ok := src.(streamDecoder); ok {
+		src = s.newStream()
+	}
+	dst, ok := LookupCodec(to)
+	if !ok {
+		return nil, ErrUnsupportedCodec
+	}
+	return &Transcoder{from: src, to: dst}, nil
+}
+
+// Passthrough reports whether payloads are forwarded without conversion.
+func (t *Transcoder) Passthrough() bool {
+	return strings.EqualFold(t.from.Name(), t.to.Name())
+}
+
+// Transcode converts one payload.
+func (t *Transcoder) Transcode(payload []byte) ([]byte, error) {
+	if t.Passthrough() {
+		return payload, nil
+	}
+	pcm, err := t.from.Decode(payload)
+	if err != nil {
+		return nil, err
+	}
+	return t.to.Encode(Resample(pcm, t.from.ClockRate(), t.to.ClockRate()))
+}
+
+// Timestamp maps an RTP timestamp offset on the source clock to the
+// destination clock.
+func (t *Transcoder) Timestamp(offset uint32) uint32 {
+	return uint32(uint64(offset) * uint64(t.to.ClockRate()) / uint64(t.from.ClockRate()))
+}
+
+// Resample converts PCM between sample rates by linear interpolation. It is
+// meant for telephony rates, where 48 kHz to 8 kHz is the common case.
+func Resample(pcm []int16, fromRate, toRate int) []int16 {
+	if fromRate == toRate || len(pcm) == 0 {
+		return pcm
+	}
+
+	n := len(pcm) * toRate / fromRate
+	out := make([]int16, n)
+	for i := range out {
+		pos := float64(i) * float64(fromRate) / float64(toRate)
+		j := int(pos)
+		if j >= len(pcm)-1 {
+			out[i] = pcm[len(pcm)-1]
+			continue
+		}
+		frac := pos - float64(j)
+		out[i] = int16(float64(pcm[j])*(1-frac) + float64(pcm[j+1])*frac)
+	}
+	return out
+}
@@ -0,0 +1,161 @@
+package media
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestG711_Silence(t *testing.T) {
+	if got := LinearToMulaw(0); got != 0xFF {
+		t.Errorf("expected µ-law silence 0xff, got %#x", got)
+	}
+	if got := LinearToAlaw(0); got != 0xD5 {
+		t.Errorf("expected A-law silence 0xd5, got %#x", got)
+	}
+}
+
+func TestG711_RoundTrip(t *testing.T) {
+	for _, s := range []int16{0, 1, -1, 100, -100, 1000, -1000, 12345, -12345, 32767, -32768} {
+		if got := MulawToLinear(LinearToMulaw(s)); abs(int(got)-int(s)) > quantStep(s) {
+			t.Errorf("µ-law %d decoded as %d", s, got)
+		}
+		if got := AlawToLinear(LinearToAlaw(s)); abs(int(got)-int(s)) > quantStep(s) {
+			t.Errorf("A-law %d decoded as %d", s, got)
+		}
+	}
+}
+
+func TestG711_EveryCodeIsStable(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		b := byte(i)
+		// 0x7f and 0xff both decode to µ-law zero, which re-encodes as 0xff.
+		if b != 0x7F {
+			if got := LinearToMulaw(MulawToLinear(b)); got != b {
+				t.Errorf("µ-law code %#x re-encoded as %#x", b, got)
+			}
+		}
+		if got := LinearToAlaw(AlawToLinear(b)); got != b {
+			t.Errorf("A-law code %#x re-encoded as %#x", b, got)
+		}
+	}
+}
+
+func TestTranscoder_PCMUToPCMA(t *testing.T) {
+	tr, err := NewTranscoder("PCMU", "pcma")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tr.Passthrough() {
+		t.Fatal("expected conversion between different codecs")
+	}
+
+	in := []int16{0, 500, -500, 8000, -8000}
+	ulaw, _ := pcmu{}.Encode(in)
+	alaw, err := tr.Transcode(ulaw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(alaw) != len(in) {
+		t.Fatalf("expected %d samples, got %d", len(in), len(alaw))
+	}
+	for i, b := range alaw {
+		if got := AlawToLinear(b); abs(int(got)-int(in[i])) > 2*quantStep(in[i]) {
+			t.Errorf("sample %d: expected ~%d, got %d", i, in[i], got)
+		}
+	}
+	if ts := tr.Timestamp(160); ts != 160 {
+		t.Errorf("expected unchanged timestamp offset, got %d", ts)
+	}
+}
+
+func TestTranscoder_Passthrough(t *testing.T) {
+	tr, err := NewTranscoder("PCMU", "PCMU")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	payload := []byte{1, 2, 3}
+	out, _ := tr.Transcode(payload)
+	if &out[0] != &payload[0] {
+		t.Error("expected payload to be forwarded as is")
+	}
+}
+
+func TestTranscoder_OpusIsReceiveOnly(t *testing.T) {
+	if _, err := NewTranscoder("PCMU", "opus"); !errors.Is(err, ErrUnsupportedCodec) {
+		t.Fatalf("expected ErrUnsupportedCodec, got %v", err)
+	}
+	if _, ok := LookupDecoder("opus"); !ok {
+		t.Fatal("expected opus to be registered for decoding")
+	}
+}
+
+func TestResample(t *testing.T) {
+	pcm := make([]int16, 960)
+	for i := range pcm {
+		pcm[i] = int16(i)
+	}
+	out := Resample(pcm, 48000, 8000)
+	if len(out) != 160 {
+		t.Fatalf("expected 160 samples, got %d", len(out))
+	}
+	if out[1] != 6 {
+		t.Errorf("expected second sample 6, got %d", out[1])
+	}
+
+	up := Resample(out, 8000, 48000)
+	if len(up) != 960 {
+		t.Fatalf("expected 960 samples, got %d", len(up))
+	}
+}
+
+func quantStep(s int16) int {
+	return abs(int(s))/16 + 16
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// wideband stands in for an externally registered 48 kHz codec such as Opus.
+type wideband struct{}
+
+func (wideband) Name() string   { return "L16-48" }
+func (wideband) ClockRate() int { return 48000 }
+
+func (wideband) Decode(payload []byte) ([]int16, error) {
+	pcm := make([]int16, len(payload)/2)
+	for i := range pcm {
+		pcm[i] = int16(payload[2*i])<<8 | int16(payload[2*i+1])
+	}
+	return pcm, nil
+}
+
+func (wideband) Encode(pcm []int16) ([]byte, error) {
+	payload := make([]byte, 2*len(pcm))
+	for i, s := range pcm {
+		payload[2*i], payload[2*i+1] = byte(s>>8), byte(s)
+	}
+	return payload, nil
+}
+
+func TestTranscoder_RegisteredWidebandCodec(t *testing.T) {
+	RegisterCodec(wideband{})
+
+	tr, err := NewTranscoder("l16-48", "PCMA")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := tr.Transcode(make([]byte, 2*960))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 160 {
+		t.Errorf("expected 20ms at 8kHz (160 samples), got %d", len(out))
+	}
+	if ts := tr.Timestamp(960); ts != 160 {
+		t.Errorf("expected timestamp offset 160, got %d", ts)
+	}
+}
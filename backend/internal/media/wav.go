@@ -0,0 +1,68 @@
+package media
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrInvalidWAV = errors.New("invalid wav file")
+
+// ReadWAV decodes a 16-bit mono PCM WAV file and returns its samples and
+// sample rate.
+func ReadWAV(r io.Reader) ([]int16, int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, 0, ErrInvalidWAV
+	}
+
+	var (
+		rate    int
+		hasFmt  bool
+		samples []int16
+	)
+	for chunks := data[12:]; len(chunks) >= 8; {
+		id := string(chunks[0:4])
+		size := int(binary.LittleEndian.Uint32(chunks[4:8]))
+		if size > len(chunks)-8 {
+			return nil, 0, ErrInvalidWAV
+		}
+		body := chunks[8 : 8+size]
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, 0, ErrInvalidWAV
+			}
+			format := binary.LittleEndian.Uint16(body[0:2])
+			channels := binary.LittleEndian.Uint16(body[2:4])
+			bits := binary.LittleEndian.Uint16(body[14:16])
+			if format != 1 || channels != 1 || bits != 16 {
+				return nil, 0, fmt.Errorf("%w: need 16-bit mono pcm, got format %d, %d channels, %d bits", ErrInvalidWAV, format, channels, bits)
+			}
+			rate = int(binary.LittleEndian.Uint32(body[4:8]))
+			hasFmt = true
+		case "data":
+			if !hasFmt {
+				return nil, 0, ErrInvalidWAV
+			}
+			samples = make([]int16, size/2)
+			for i := range samples {
+				samples[i] = int16(binary.LittleEndian.Uint16(body[2*i:]))
+			}
+			return samples, rate, nil
+		}
+
+		// Chunks are padded to an even size.
+		next := 8 + size + size%2
+		if next > len(chunks) {
+			break
+		}
+		chunks = chunks[next:]
+	}
+	return nil, 0, ErrInvalidWAV
+}
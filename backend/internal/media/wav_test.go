@@ -0,0 +1,72 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func testWAV(format, channels, bits uint16, rate uint32, samples []int16) []byte {
+	var body bytes.Buffer
+	body.WriteString("WAVE")
+
+	// A chunk readers must skip, with an odd size and its padding byte.
+	body.WriteString("LIST")
+	binary.Write(&body, binary.LittleEndian, uint32(3))
+	body.Write([]byte{1, 2, 3, 0})
+
+	body.WriteString("fmt ")
+	binary.Write(&body, binary.LittleEndian, uint32(16))
+	binary.Write(&body, binary.LittleEndian, format)
+	binary.Write(&body, binary.LittleEndian, channels)
+	binary.Write(&body, binary.LittleEndian, rate)
+	binary.Write(&body, binary.LittleEndian, rate*uint32(channels)*uint32(bits)/8)
+	binary.Write(&body, binary.LittleEndian, channels*bits/8)
+	binary.Write(&body, binary.LittleEndian, bits)
+
+	body.WriteString("data")
+	binary.Write(&body, binary.LittleEndian, uint32(2*len(samples)))
+	binary.Write(&body, binary.LittleEndian, samples)
+
+	var out bytes.Buffer
+	out.WriteString("RIFF")
+	binary.Write(&out, binary.LittleEndian, uint32(body.Len()))
+	out.Write(body.Bytes())
+	return out.Bytes()
+}
+
+func TestReadWAV(t *testing.T) {
+	want := []int16{0, 1000, -1000, 32767, -32768}
+
+	samples, rate, err := ReadWAV(bytes.NewReader(testWAV(1, 1, 16, 8000, want)))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if rate != 8000 {
+		t.Errorf("expected rate 8000, got %d", rate)
+	}
+	if len(samples) != len(want) {
+		t.Fatalf("expected %d samples, got %d", len(want), len(samples))
+	}
+	for i := range want {
+		if samples[i] != want[i] {
+			t.Errorf("sample %d: expected %d, got %d", i, want[i], samples[i])
+		}
+	}
+}
+
+func TestReadWAV_Invalid(t *testing.T) {
+	cases := map[string][]byte{
+		"not riff":  []byte("OggS0000WAVE"),
+		"stereo":    testWAV(1, 2, 16, 8000, []int16{0, 0}),
+		"8-bit":     testWAV(1, 1, 8, 8000, []int16{0}),
+		"float":     testWAV(3, 1, 16, 8000, []int16{0}),
+		"truncated": testWAV(1, 1, 16, 8000, []int16{1, 2, 3})[:60],
+	}
+	for name, data := range cases {
+		if _, _, err := ReadWAV(bytes.NewReader(data)); !errors.Is(err, ErrInvalidWAV) {
+			t.Errorf("%s: expected ErrInvalidWAV, got %v", name, err)
+		}
+	}
+}
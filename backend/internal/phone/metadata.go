@@ -0,0 +1,232 @@
+package phone
+
+// region is the numbering plan of one country. Number ranges are prefixes of
+// the national significant number: the digits after the country code,
+// without the trunk prefix.
+type region struct {
+	code        string
+	callingCode string
+	// intlPrefixes are dialled before a country code when calling abroad
+	// from the region.
+	intlPrefixes []string
+	// trunkPrefix is dialled before national numbers and dropped in E.164.
+	trunkPrefix string
+	// leading tells countries sharing a calling code apart. A region
+	// without it takes the numbers the others do not claim.
+	leading []string
+	// firstDigits are the digits a national number may start with.
+	// Empty means any digit but 0.
+	firstDigits    string
+	minLen, maxLen int
+
+	tollFree []string
+	premium  []string
+	mobile   []string
+	// fixedOrMobile marks plans where mobiles share the fixed ranges.
+	fixedOrMobile bool
+	// mobileLen, when set, makes every number of that length mobile.
+	mobileLen int
+	// carriers are the operators ranges were allocated to. With number
+	// portability this is only a hint.
+	carriers map[string]string
+}
+
+var nanpTollFree = []string{"800", "833", "844", "855", "866", "877", "888"}
+
+var regions = []*region{
+	{
+		code: "CA", callingCode: "1", intlPrefixes: []string{"011"}, trunkPrefix: "1",
+		leading: []string{
+			"204", "226", "236", "249", "250", "263", "289", "306", "343", "354", "365", "367", "368",
+			"382", "403", "416", "418", "428", "431", "437", "438", "450", "468", "474", "506", "514",
+			"519", "548", "579", "581", "584", "587", "604", "613", "639", "647", "672", "683", "705",
+			"709", "742", "753", "778", "780", "782", "807", "819", "825", "867", "873", "879", "902",
+			"905",
+		},
+		firstDigits: "23456789", minLen: 10, maxLen: 10,
+		tollFree: nanpTollFree, premium: []string{"900"}, fixedOrMobile: true,
+	},
+	{
+		code: "US", callingCode: "1", intlPrefixes: []string{"011"}, trunkPrefix: "1",
+		firstDigits: "23456789", minLen: 10, maxLen: 10,
+		tollFree: nanpTollFree, premium: []string{"900", "976"}, fixedOrMobile: true,
+	},
+	{
+		code: "KZ", callingCode: "7", intlPrefixes: []string{"810", "00"}, trunkPrefix: "8",
+		leading: []string{"6", "7"}, minLen: 10, maxLen: 10,
+		tollFree: []string{"800"}, mobile: []string{"70", "74", "75", "76", "77"},
+		carriers: map[string]string{
+			"700": "Altel", "708": "Altel",
+			"701": "Kcell", "702": "Kcell", "775": "Kcell", "778": "Kcell",
+			"705": "Beeline", "771": "Beeline", "776": "Beeline", "777": "Beeline",
+			"707": "Tele2", "747": "Tele2",
+		},
+	},
+	{
+		code: "RU", callingCode: "7", intlPrefixes: []string{"810"}, trunkPrefix: "8",
+		firstDigits: "3489", minLen: 10, maxLen: 10,
+		tollFree: []string{"800"}, premium: []string{"809"}, mobile: []string{"9"},
+		carriers: map[string]string{
+			"910": "MTS", "911": "MTS", "912": "MTS", "913": "MTS", "914": "MTS",
+			"915": "MTS", "916": "MTS", "917": "MTS", "918": "MTS", "919": "MTS",
+			"980": "MTS", "981": "MTS", "985": "MTS", "987": "MTS", "988": "MTS", "989": "MTS",
+			"903": "Beeline", "905": "Beeline", "906": "Beeline", "909": "Beeline",
+			"960": "Beeline", "961": "Beeline", "962": "Beeline", "963": "Beeline", "964": "Beeline",
+			"965": "Beeline", "966": "Beeline", "967": "Beeline", "968": "Beeline",
+			"920": "MegaFon", "921": "MegaFon", "922": "MegaFon", "923": "MegaFon", "924": "MegaFon",
+			"925": "MegaFon", "926": "MegaFon", "927": "MegaFon", "928": "MegaFon", "929": "MegaFon",
+			"930": "MegaFon", "931": "MegaFon", "932": "MegaFon", "933": "MegaFon", "937": "MegaFon",
+			"900": "Tele2", "901": "Tele2", "902": "Tele2", "904": "Tele2", "908": "Tele2",
+			"950": "Tele2", "951": "Tele2", "952": "Tele2", "953": "Tele2", "958": "Tele2", "977": "Tele2",
+		},
+	},
+	{
+		code: "DE", callingCode: "49", intlPrefixes: []string{"00"}, trunkPrefix: "0",
+		minLen: 6, maxLen: 13,
+		tollFree: []string{"800"}, premium: []string{"900"}, mobile: []string{"15", "16", "17"},
+		carriers: map[string]string{
+			"151": "Telekom", "160": "Telekom", "170": "Telekom", "171": "Telekom", "175": "Telekom",
+			"152": "Vodafone", "162": "Vodafone", "172": "Vodafone", "173": "Vodafone", "174": "Vodafone",
+			"155": "Telefónica", "157": "Telefónica", "159": "Telefónica", "163": "Telefónica",
+			"176": "Telefónica", "177": "Telefónica", "178": "Telefónica", "179": "Telefónica",
+		},
+	},
+	{
+		code: "GB", callingCode: "44", intlPrefixes: []string{"00"}, trunkPrefix: "0",
+		minLen: 9, maxLen: 10,
+		tollFree: []string{"800", "808"}, premium: []string{"90", "91"},
+		mobile: []string{"71", "72", "73", "74", "75", "77", "78", "79"},
+	},
+	{
+		code: "FR", callingCode: "33", intlPrefixes: []string{"00"}, trunkPrefix: "0",
+		minLen: 9, maxLen: 9,
+		tollFree: []string{"800", "805"}, premium: []string{"89"}, mobile: []string{"6", "7"},
+	},
+	{
+		// Italian fixed numbers keep their leading 0 in E.164.
+		code: "IT", callingCode: "39", intlPrefixes: []string{"00"},
+		firstDigits: "0389", minLen: 6, maxLen: 11,
+		tollFree: []string{"800", "803"}, premium: []string{"89"}, mobile: []string{"3"},
+		carriers: map[string]string{
+			"33": "TIM", "36": "TIM",
+			"34": "Vodafone",
+			"32": "WINDTRE", "38": "WINDTRE",
+			"35": "Iliad",
+		},
+	},
+	{
+		code: "ES", callingCode: "34", intlPrefixes: []string{"00"},
+		firstDigits: "6789", minLen: 9, maxLen: 9,
+		tollFree: []string{"900"}, premium: []string{"803", "806", "807", "905"}, mobile: []string{"6", "7"},
+	},
+	{
+		code: "NL", callingCode: "31", intlPrefixes: []string{"00"}, trunkPrefix: "0",
+		minLen: 9, maxLen: 9,
+		tollFree: []string{"800"}, premium: []string{"900", "906", "909"}, mobile: []string{"6"},
+	},
+	{
+		code: "CH", callingCode: "41", intlPrefixes: []string{"00"}, trunkPrefix: "0",
+		minLen: 9, maxLen: 9,
+		tollFree: []string{"800"}, premium: []string{"90"}, mobile: []string{"75", "76", "77", "78", "79"},
+	},
+	{
+		code: "AT", callingCode: "43", intlPrefixes: []string{"00"}, trunkPrefix: "0",
+		minLen: 4, maxLen: 13,
+		tollFree: []string{"800"}, premium: []string{"90", "93"}, mobile: []string{"65", "66", "67", "68", "69"},
+	},
+	{
+		code: "PL", callingCode: "48", intlPrefixes: []string{"00"},
+		minLen: 9, maxLen: 9,
+		tollFree: []string{"800"}, premium: []string{"70"},
+		mobile: []string{"45", "50", "51", "53", "57", "60", "66", "69", "72", "73", "78", "79", "88"},
+	},
+	{
+		code: "UA", callingCode: "380", intlPrefixes: []string{"00"}, trunkPrefix: "0",
+		minLen: 9, maxLen: 9,
+		tollFree: []string{"800"}, premium: []string{"900"},
+		mobile: []string{"39", "50", "63", "66", "67", "68", "73", "91", "92", "93", "94", "95", "96", "97", "98", "99"},
+		carriers: map[string]string{
+			"50": "Vodafone", "66": "Vodafone", "95": "Vodafone", "99": "Vodafone",
+			"67": "Kyivstar", "68": "Kyivstar", "96": "Kyivstar", "97": "Kyivstar", "98": "Kyivstar",
+			"63": "lifecell", "73": "lifecell", "93": "lifecell",
+		},
+	},
+	{
+		code: "BY", callingCode: "375", intlPrefixes: []string{"810"}, trunkPrefix: "8",
+		minLen: 9, maxLen: 9,
+		tollFree: []string{"800"}, mobile: []string{"25", "29", "33", "44"},
+		carriers: map[string]string{"25": "life:)", "33": "MTS", "44": "A1"},
+	},
+	{
+		code: "TR", callingCode: "90", intlPrefixes: []string{"00"}, trunkPrefix: "0",
+		minLen: 10, maxLen: 10,
+		tollFree: []string{"800"}, premium: []string{"900"}, mobile: []string{"5"},
+	},
+	{
+		code: "IL", callingCode: "972", intlPrefixes: []string{"00"}, trunkPrefix: "0",
+		minLen: 8, maxLen: 10,
+		tollFree: []string{"1800"}, premium: []string{"1900"}, mobile: []string{"5"},
+	},
+	{
+		code: "CN", callingCode: "86", intlPrefixes: []string{"00"}, trunkPrefix: "0",
+		minLen: 9, maxLen: 11,
+		tollFree: []string{"800"}, mobile: []string{"13", "14", "15", "16", "17", "18", "19"},
+		carriers: map[string]string{
+			"134": "China Mobile", "135": "China Mobile", "136": "China Mobile", "137": "China Mobile",
+			"138": "China Mobile", "139": "China Mobile", "150": "China Mobile", "151": "China Mobile",
+			"152": "China Mobile", "157": "China Mobile", "158": "China Mobile", "159": "China Mobile",
+			"182": "China Mobile", "183": "China Mobile", "187": "China Mobile", "188": "China Mobile",
+			"130": "China Unicom", "131": "China Unicom", "132": "China Unicom", "155": "China Unicom",
+			"156": "China Unicom", "185": "China Unicom", "186": "China Unicom",
+			"133": "China Telecom", "153": "China Telecom", "177": "China Telecom", "180": "China Telecom",
+			"181": "China Telecom", "189": "China Telecom",
+		},
+	},
+	{
+		code: "JP", callingCode: "81", intlPrefixes: []string{"010"}, trunkPrefix: "0",
+		minLen: 9, maxLen: 10,
+		tollFree: []string{"120", "800"}, premium: []string{"990"}, mobile: []string{"70", "80", "90"},
+	},
+	{
+		code: "IN", callingCode: "91", intlPrefixes: []string{"00"}, trunkPrefix: "0",
+		minLen: 10, maxLen: 10,
+		tollFree: []string{"1800"}, premium: []string{"1900"}, mobile: []string{"6", "7", "8", "9"},
+	},
+	{
+		code: "ID", callingCode: "62", intlPrefixes: []string{"001", "007", "008"}, trunkPrefix: "0",
+		minLen: 8, maxLen: 12,
+		tollFree: []string{"800"}, premium: []string{"809"}, mobile: []string{"8"},
+	},
+	{
+		code: "AU", callingCode: "61", intlPrefixes: []string{"0011"}, trunkPrefix: "0",
+		minLen: 9, maxLen: 9,
+		tollFree: []string{"1800"}, premium: []string{"190"}, mobile: []string{"4"},
+	},
+	{
+		// Brazilian mobiles are a 2-digit area code followed by a 9-digit
+		// subscriber number starting with 9.
+		code: "BR", callingCode: "55", intlPrefixes: []string{"00"}, trunkPrefix: "0",
+		minLen: 10, maxLen: 11,
+		tollFree: []string{"800"}, premium: []string{"900"}, mobileLen: 11,
+	},
+	{
+		// Mexico dropped its trunk prefixes and mobile ranges in 2019.
+		code: "MX", callingCode: "52", intlPrefixes: []string{"00"},
+		minLen: 10, maxLen: 10,
+		tollFree: []string{"800"}, premium: []string{"900"}, fixedOrMobile: true,
+	},
+}
+
+var (
+	regionsByCode        = map[string]*region{}
+	regionsByCallingCode = map[string][]*region{}
+)
+
+func init() {
+	for _, r := range regions {
+		regionsByCode[r.code] = r
+		// regions lists those with leading ranges before the one sharing
+		// their calling code, so it only takes what they leave.
+		regionsByCallingCode[r.callingCode] = append(regionsByCallingCode[r.callingCode], r)
+	}
+}
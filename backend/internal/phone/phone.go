@@ -0,0 +1,201 @@
+// Package phone parses phone numbers as people type them, normalizes them
+// to E.164 and describes what they are.
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidNumber = errors.New("invalid phone number")
+	ErrUnknownRegion = errors.New("unknown region")
+	// ErrRegionRequired is returned for a national number parsed without a
+	// default region.
+	ErrRegionRequired = errors.New("region is required for national numbers")
+)
+
+type Type string
+
+const (
+	TypeFixed    Type = "fixed"
+	TypeMobile   Type = "mobile"
+	TypeTollFree Type = "toll_free"
+	TypePremium  Type = "premium"
+	// TypeFixedOrMobile is used where mobiles share the fixed ranges, as in
+	// North America.
+	TypeFixedOrMobile Type = "fixed_or_mobile"
+	TypeUnknown       Type = "unknown"
+)
+
+type Number struct {
+	// E164 is the number as dialled: "+" and up to 15 digits.
+	E164 string
+	// Country is the ISO 3166-1 alpha-2 code, empty for calling codes
+	// without metadata.
+	Country     string
+	CallingCode string
+	// NationalNumber is the number after the calling code, without the
+	// trunk prefix.
+	NationalNumber string
+	Type           Type
+	// Carrier is the operator the number range was allocated to. Numbers
+	// move between operators, so it is only a hint.
+	Carrier string
+}
+
+// Parse reads an international number ("+49 151 2345678",
+// "0049 151 2345678") or, with a default region, a national one
+// ("8 (495) 123-45-67" in RU). Spaces, dashes, dots, slashes, brackets and
+// a "(0)" after the country code are ignored.
+func Parse(raw, defaultRegion string) (*Number, error) {
+	var def *region
+	if defaultRegion != "" {
+		def = regionsByCode[strings.ToUpper(defaultRegion)]
+		if def == nil {
+			return nil, ErrUnknownRegion
+		}
+	}
+
+	digits, plus, ok := clean(raw)
+	if !ok {
+		return nil, ErrInvalidNumber
+	}
+
+	if plus {
+		return international(digits)
+	}
+	if def == nil {
+		if rest, ok := strings.CutPrefix(digits, "00"); ok {
+			return international(rest)
+		}
+		return nil, ErrRegionRequired
+	}
+	for _, prefix := range def.intlPrefixes {
+		if rest, ok := strings.CutPrefix(digits, prefix); ok {
+			return international(rest)
+		}
+	}
+
+	// The trunk prefix is optional: "8 800 …" and "800 …" are both
+	// national numbers in Russia, so it is only dropped when what is left
+	// is still long enough.
+	national := digits
+	if rest, ok := strings.CutPrefix(national, def.trunkPrefix); ok && def.trunkPrefix != "" && len(rest) >= def.minLen {
+		national = rest
+	}
+	return international(def.callingCode + national)
+}
+
+// IsE164 reports whether s is already a normalized number.
+func IsE164(s string) bool {
+	n, err := Parse(s, "")
+	return err == nil && n.E164 == s
+}
+
+// clean drops the formatting people paste along with a number. It reports
+// whether the number started with "+".
+func clean(raw string) (string, bool, bool) {
+	s := strings.TrimSpace(raw)
+	s = strings.TrimPrefix(s, "tel:")
+	s = strings.ReplaceAll(s, "(0)", "")
+
+	plus := strings.HasPrefix(s, "+")
+	s = strings.TrimPrefix(s, "+")
+
+	var digits strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case strings.ContainsRune(" -./() ", r):
+		default:
+			return "", false, false
+		}
+	}
+	if digits.Len() == 0 {
+		return "", false, false
+	}
+	return digits.String(), plus, true
+}
+
+func international(digits string) (*Number, error) {
+	if len(digits) < 7 || len(digits) > 15 || digits[0] == '0' {
+		return nil, ErrInvalidNumber
+	}
+
+	for n := 1; n <= 3; n++ {
+		candidates := regionsByCallingCode[digits[:n]]
+		if len(candidates) == 0 {
+			continue
+		}
+		national := digits[n:]
+		r := pickRegion(candidates, national)
+		if !r.valid(national) {
+			return nil, ErrInvalidNumber
+		}
+		return &Number{
+			E164:           "+" + digits,
+			Country:        r.code,
+			CallingCode:    r.callingCode,
+			NationalNumber: national,
+			Type:           r.classify(national),
+			Carrier:        longestPrefix(r.carriers, national),
+		}, nil
+	}
+
+	// A calling code we have no plan for is accepted as written.
+	return &Number{E164: "+" + digits, Type: TypeUnknown}, nil
+}
+
+func pickRegion(candidates []*region, national string) *region {
+	for _, r := range candidates {
+		if r.leading == nil || hasAnyPrefix(national, r.leading) {
+			return r
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+func (r *region) valid(national string) bool {
+	if len(national) < r.minLen || len(national) > r.maxLen {
+		return false
+	}
+	first := r.firstDigits
+	if first == "" {
+		first = "123456789"
+	}
+	return strings.IndexByte(first, national[0]) >= 0
+}
+
+func (r *region) classify(national string) Type {
+	switch {
+	case hasAnyPrefix(national, r.tollFree):
+		return TypeTollFree
+	case hasAnyPrefix(national, r.premium):
+		return TypePremium
+	case r.fixedOrMobile:
+		return TypeFixedOrMobile
+	case hasAnyPrefix(national, r.mobile), r.mobileLen != 0 && len(national) == r.mobileLen:
+		return TypeMobile
+	}
+	return TypeFixed
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func longestPrefix(values map[string]string, s string) string {
+	for n := len(s); n > 0; n-- {
+		if value, ok := values[s[:n]]; ok {
+			return value
+		}
+	}
+	return ""
+}
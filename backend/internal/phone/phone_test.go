@@ -0,0 +1,82 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw, region string
+		e164        string
+		country     string
+		typ         Type
+		carrier     string
+	}{
+		{"+491512345678", "", "+491512345678", "DE", TypeMobile, "Telekom"},
+		{"0049 151 2345678", "", "+491512345678", "DE", TypeMobile, "Telekom"},
+		{"0151 2345678", "DE", "+491512345678", "DE", TypeMobile, "Telekom"},
+		{"+49 (0)30 123456", "", "+4930123456", "DE", TypeFixed, ""},
+		{"8 (495) 123-45-67", "RU", "+74951234567", "RU", TypeFixed, ""},
+		{"8 916 123-45-67", "ru", "+79161234567", "RU", TypeMobile, "MTS"},
+		{"8 800 555-35-35", "RU", "+78005553535", "RU", TypeTollFree, ""},
+		{"8 10 49 151 2345678", "RU", "+491512345678", "DE", TypeMobile, "Telekom"},
+		{"+7 701 123 4567", "", "+77011234567", "KZ", TypeMobile, "Kcell"},
+		{"(415) 555-0100", "US", "+14155550100", "US", TypeFixedOrMobile, ""},
+		{"1-800-555-0100", "US", "+18005550100", "US", TypeTollFree, ""},
+		{"+1 900 555 0100", "", "+19005550100", "US", TypePremium, ""},
+		{"011 44 7911 123456", "CA", "+447911123456", "GB", TypeMobile, ""},
+		{"+1 416 555 0100", "", "+14165550100", "CA", TypeFixedOrMobile, ""},
+		{"06 1234 5678", "IT", "+390612345678", "IT", TypeFixed, ""},
+		{"+5592987654321", "", "+5592987654321", "BR", TypeMobile, ""},
+		{"+55 11 3123 4567", "", "+551131234567", "BR", TypeFixed, ""},
+		{"tel:+86-138-0013-8000", "", "+8613800138000", "CN", TypeMobile, "China Mobile"},
+		{"+2421234567", "", "+2421234567", "", TypeUnknown, ""},
+	}
+	for _, tt := range tests {
+		n, err := Parse(tt.raw, tt.region)
+		if err != nil {
+			t.Errorf("%q in %q: expected no error, got %v", tt.raw, tt.region, err)
+			continue
+		}
+		if n.E164 != tt.e164 || n.Country != tt.country || n.Type != tt.typ || n.Carrier != tt.carrier {
+			t.Errorf("%q in %q: unexpected number %+v", tt.raw, tt.region, n)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		raw, region string
+		want        error
+	}{
+		{"", "", ErrInvalidNumber},
+		{"+", "", ErrInvalidNumber},
+		{"+49 151 CALLME", "", ErrInvalidNumber},
+		{"+44 7911 123456 ext 12", "", ErrInvalidNumber},
+		{"+7916123", "", ErrInvalidNumber},
+		{"+1 055 555 0100", "", ErrInvalidNumber},
+		{"+0491512345678", "", ErrInvalidNumber},
+		{"+1234567890123456", "", ErrInvalidNumber},
+		{"0151 2345678", "", ErrRegionRequired},
+		{"0151 2345678", "XX", ErrUnknownRegion},
+	}
+	for _, tt := range tests {
+		if _, err := Parse(tt.raw, tt.region); !errors.Is(err, tt.want) {
+			t.Errorf("%q in %q: expected %v, got %v", tt.raw, tt.region, tt.want, err)
+		}
+	}
+}
+
+func TestIsE164(t *testing.T) {
+	for _, s := range []string{"+491512345678", "+14155550100", "+2421234567"} {
+		if !IsE164(s) {
+			t.Errorf("%q: expected E.164", s)
+		}
+	}
+	for _, s := range []string{"+49 151 2345678", "0049151234567", "+7916123", ""} {
+		if IsE164(s) {
+			t.Errorf("%q: expected not E.164", s)
+		}
+	}
+}
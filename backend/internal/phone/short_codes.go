@@ -0,0 +1,138 @@
+package phone
+
+import (
+	"sort"
+	"strings"
+)
+
+type ShortCodeKind string
+
+const (
+	ShortCodeEmergency ShortCodeKind = "emergency"
+	// ShortCodeService covers non-emergency short numbers: directory
+	// enquiries, non-emergency police, health advice lines and the like.
+	ShortCodeService ShortCodeKind = "service"
+)
+
+// ShortCode is a number that only works dialled as is on a local network.
+type ShortCode struct {
+	Number string
+	// Country is the region the code belongs to. It is empty when no region
+	// was given and the code is used in several countries, like 112 or 911.
+	Country string
+	Kind    ShortCodeKind
+	// Service is what the code reaches: "general", "police", "fire",
+	// "ambulance", "directory"...
+	Service string
+}
+
+type shortCodeEntry struct {
+	kind    ShortCodeKind
+	service string
+}
+
+var (
+	emergencyGeneral   = shortCodeEntry{ShortCodeEmergency, "general"}
+	emergencyPolice    = shortCodeEntry{ShortCodeEmergency, "police"}
+	emergencyFire      = shortCodeEntry{ShortCodeEmergency, "fire"}
+	emergencyAmbulance = shortCodeEntry{ShortCodeEmergency, "ambulance"}
+	emergencyGas       = shortCodeEntry{ShortCodeEmergency, "gas"}
+	emergencyCrisis    = shortCodeEntry{ShortCodeEmergency, "crisis"}
+	emergencyCoast     = shortCodeEntry{ShortCodeEmergency, "coast_guard"}
+	emergencyTTY       = shortCodeEntry{ShortCodeEmergency, "text_relay"}
+
+	serviceDirectory = shortCodeEntry{ShortCodeService, "directory"}
+	servicePolice    = shortCodeEntry{ShortCodeService, "police_non_emergency"}
+	serviceHealth    = shortCodeEntry{ShortCodeService, "health"}
+	serviceInfo      = shortCodeEntry{ShortCodeService, "information"}
+	serviceRelay     = shortCodeEntry{ShortCodeService, "relay"}
+)
+
+var nanpShortCodes = map[string]shortCodeEntry{
+	"911": emergencyGeneral, "988": emergencyCrisis,
+	"211": serviceInfo, "311": serviceInfo, "411": serviceDirectory, "511": serviceInfo,
+	"711": serviceRelay, "811": serviceInfo,
+}
+
+var ex01ShortCodes = map[string]shortCodeEntry{
+	"112": emergencyGeneral, "101": emergencyFire, "102": emergencyPolice,
+	"103": emergencyAmbulance, "104": emergencyGas,
+}
+
+// shortCodes lists emergency and service short codes by region.
+var shortCodes = map[string]map[string]shortCodeEntry{
+	"US": nanpShortCodes,
+	"CA": nanpShortCodes,
+	"RU": ex01ShortCodes,
+	"KZ": ex01ShortCodes,
+	"UA": ex01ShortCodes,
+	"BY": ex01ShortCodes,
+	"DE": {"112": emergencyGeneral, "110": emergencyPolice, "116117": serviceHealth, "115": serviceInfo},
+	"GB": {"999": emergencyGeneral, "112": emergencyGeneral, "101": servicePolice, "111": serviceHealth, "105": serviceInfo},
+	"FR": {"112": emergencyGeneral, "15": emergencyAmbulance, "17": emergencyPolice, "18": emergencyFire, "114": emergencyTTY, "196": emergencyCoast},
+	"IT": {"112": emergencyGeneral, "113": emergencyPolice, "115": emergencyFire, "118": emergencyAmbulance, "1530": emergencyCoast},
+	"ES": {"112": emergencyGeneral, "091": emergencyPolice, "080": emergencyFire, "061": emergencyAmbulance, "024": emergencyCrisis},
+	"NL": {"112": emergencyGeneral},
+	"CH": {"112": emergencyGeneral, "117": emergencyPolice, "118": emergencyFire, "144": emergencyAmbulance, "143": emergencyCrisis},
+	"AT": {"112": emergencyGeneral, "122": emergencyFire, "133": emergencyPolice, "144": emergencyAmbulance},
+	"PL": {"112": emergencyGeneral, "997": emergencyPolice, "998": emergencyFire, "999": emergencyAmbulance},
+	"TR": {"112": emergencyGeneral, "155": emergencyPolice, "156": emergencyPolice, "110": emergencyFire},
+	"IL": {"112": emergencyGeneral, "100": emergencyPolice, "101": emergencyAmbulance, "102": emergencyFire},
+	"CN": {"110": emergencyPolice, "119": emergencyFire, "120": emergencyAmbulance, "122": emergencyPolice, "114": serviceDirectory},
+	"JP": {"110": emergencyPolice, "119": emergencyFire, "118": emergencyCoast, "104": serviceDirectory},
+	"IN": {"112": emergencyGeneral, "100": emergencyPolice, "101": emergencyFire, "102": emergencyAmbulance, "108": emergencyAmbulance},
+	"ID": {"112": emergencyGeneral, "110": emergencyPolice, "113": emergencyFire, "118": emergencyAmbulance, "119": emergencyAmbulance},
+	"AU": {"000": emergencyGeneral, "112": emergencyGeneral, "106": emergencyTTY, "131444": servicePolice},
+	"BR": {"112": emergencyGeneral, "190": emergencyPolice, "192": emergencyAmbulance, "193": emergencyFire},
+	"MX": {"911": emergencyGeneral, "089": servicePolice},
+}
+
+// universalEmergency are the codes mobile phones route to emergency services
+// in any GSM network, whatever the local number.
+var universalEmergency = []string{"112", "911"}
+
+// LookupShortCode reports whether raw is an emergency or service short code
+// of the region. Without a region, emergency codes of any country are
+// recognized, but not service codes, which are too easily confused with the
+// start of a number.
+func LookupShortCode(raw, region string) (*ShortCode, bool) {
+	digits, plus, ok := clean(raw)
+	if !ok || plus || len(digits) > 6 {
+		return nil, false
+	}
+
+	if region != "" {
+		region = strings.ToUpper(region)
+		if entry, ok := shortCodes[region][digits]; ok {
+			return &ShortCode{Number: digits, Country: region, Kind: entry.kind, Service: entry.service}, true
+		}
+		for _, code := range universalEmergency {
+			if digits == code {
+				return &ShortCode{Number: digits, Country: region, Kind: ShortCodeEmergency, Service: "general"}, true
+			}
+		}
+		return nil, false
+	}
+
+	var found *ShortCode
+	countries := make([]string, 0, len(shortCodes))
+	for country := range shortCodes {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+	for _, country := range countries {
+		entry, ok := shortCodes[country][digits]
+		if !ok || entry.kind != ShortCodeEmergency {
+			continue
+		}
+		if found == nil {
+			found = &ShortCode{Number: digits, Country: country, Kind: entry.kind, Service: entry.service}
+			continue
+		}
+		found.Country = ""
+		if found.Service != entry.service {
+			found.Service = "general"
+		}
+	}
+	return found, found != nil
+}
@@ -0,0 +1,49 @@
+package phone
+
+import "testing"
+
+func TestLookupShortCode(t *testing.T) {
+	tests := []struct {
+		raw, region string
+		country     string
+		kind        ShortCodeKind
+		service     string
+	}{
+		{"112", "DE", "DE", ShortCodeEmergency, "general"},
+		{"110", "de", "DE", ShortCodeEmergency, "police"},
+		{"911", "US", "US", ShortCodeEmergency, "general"},
+		{"411", "US", "US", ShortCodeService, "directory"},
+		{"103", "RU", "RU", ShortCodeEmergency, "ambulance"},
+		{"999", "GB", "GB", ShortCodeEmergency, "general"},
+		{"1 1 2", "FR", "FR", ShortCodeEmergency, "general"},
+		{"911", "RU", "RU", ShortCodeEmergency, "general"},
+		{"112", "", "", ShortCodeEmergency, "general"},
+		{"000", "", "AU", ShortCodeEmergency, "general"},
+	}
+	for _, tt := range tests {
+		code, ok := LookupShortCode(tt.raw, tt.region)
+		if !ok {
+			t.Errorf("%q in %q: expected a short code", tt.raw, tt.region)
+			continue
+		}
+		if code.Country != tt.country || code.Kind != tt.kind || code.Service != tt.service {
+			t.Errorf("%q in %q: unexpected short code %+v", tt.raw, tt.region, code)
+		}
+	}
+}
+
+func TestLookupShortCode_NotShortCode(t *testing.T) {
+	tests := []struct{ raw, region string }{
+		{"+112", ""},
+		{"+491512345678", ""},
+		{"0151 2345678", "DE"},
+		{"411", ""},
+		{"123", "DE"},
+		{"abc", "US"},
+	}
+	for _, tt := range tests {
+		if code, ok := LookupShortCode(tt.raw, tt.region); ok {
+			t.Errorf("%q in %q: expected no short code, got %+v", tt.raw, tt.region, code)
+		}
+	}
+}
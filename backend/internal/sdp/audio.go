@@ -0,0 +1,279 @@
+package sdp
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNoAudio               = errors.New("sdp has no active audio media section")
+	ErrUnsupportedProtocol   = errors.New("audio media section must use UDP/TLS/RTP/SAVPF")
+	ErrNoSupportedCodec      = errors.New("sdp offers no supported audio codec")
+	ErrMissingFingerprint    = errors.New("sdp has no dtls fingerprint")
+	ErrInvalidFingerprint    = errors.New("sdp has an invalid dtls fingerprint")
+	ErrMissingICECredentials = errors.New("sdp has no ice credentials")
+	ErrInvalidICECredentials = errors.New("sdp has invalid ice credentials")
+	ErrInvalidSetup          = errors.New("sdp answer must set a=setup to active or passive")
+)
+
+// Codec is an RTP payload format from an m= line together with its rtpmap
+// and fmtp attributes.
+type Codec struct {
+	PayloadType int
+	Name        string
+	ClockRate   int
+	Channels    int
+	Fmtp        string
+}
+
+// Well-known audio codecs. Names compare case-insensitively, as in rtpmap.
+var (
+	CodecOpus           = Codec{PayloadType: 111, Name: "opus", ClockRate: 48000, Channels: 2, Fmtp: "minptime=10;useinbandfec=1"}
+	CodecPCMU           = Codec{PayloadType: 0, Name: "PCMU", ClockRate: 8000}
+	CodecPCMA           = Codec{PayloadType: 8, Name: "PCMA", ClockRate: 8000}
+	CodecTelephoneEvent = Codec{PayloadType: 101, Name: "telephone-event", ClockRate: 8000, Fmtp: "0-16"}
+)
+
+// SupportedAudioCodecs are the codecs the platform can carry to the PSTN.
+// telephone-event is accepted alongside them but is not a voice codec.
+var SupportedAudioCodecs = []Codec{CodecOpus, CodecPCMU, CodecPCMA, CodecTelephoneEvent}
+
+// staticPayloadTypes are the RFC 3551 audio formats that may appear without
+// an rtpmap.
+var staticPayloadTypes = map[int]Codec{
+	0: CodecPCMU,
+	8: CodecPCMA,
+	9: {PayloadType: 9, Name: "G722", ClockRate: 8000},
+}
+
+var fingerprintSizes = map[string]int{
+	"sha-1":   20,
+	"sha-224": 28,
+	"sha-256": 32,
+	"sha-384": 48,
+	"sha-512": 64,
+}
+
+// Same reports whether two codecs describe the same format, ignoring the
+// payload type, which each side may choose freely.
+func (c Codec) Same(other Codec) bool {
+	channels := func(n int) int {
+		if n == 0 {
+			return 1
+		}
+		return n
+	}
+	return strings.EqualFold(c.Name, other.Name) &&
+		c.ClockRate == other.ClockRate &&
+		channels(c.Channels) == channels(other.Channels)
+}
+
+// Codecs resolves the payload types of the m= line in preference order.
+func (m *MediaDescription) Codecs() ([]Codec, error) {
+	rtpmaps := map[int]Codec{}
+	for _, value := range m.AttributeValues("rtpmap") {
+		codec, err := parseRtpmap(value)
+		if err != nil {
+			return nil, err
+		}
+		rtpmaps[codec.PayloadType] = codec
+	}
+
+	fmtps := map[int]string{}
+	for _, value := range m.AttributeValues("fmtp") {
+		pt, params, ok := strings.Cut(value, " ")
+		n, err := strconv.Atoi(pt)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("%w: invalid fmtp %q", ErrMalformed, value)
+		}
+		fmtps[n] = strings.TrimSpace(params)
+	}
+
+	codecs := make([]Codec, 0, len(m.Formats))
+	for _, format := range m.Formats {
+		pt, err := strconv.Atoi(format)
+		if err != nil || pt < 0 || pt > 127 {
+			return nil, fmt.Errorf("%w: invalid payload type %q", ErrMalformed, format)
+		}
+		codec, ok := rtpmaps[pt]
+		if !ok {
+			codec, ok = staticPayloadTypes[pt]
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: payload type %d has no rtpmap", ErrMalformed, pt)
+		}
+		codec.PayloadType = pt
+		codec.Fmtp = fmtps[pt]
+		codecs = append(codecs, codec)
+	}
+	return codecs, nil
+}
+
+func parseRtpmap(value string) (Codec, error) {
+	pt, encoding, ok := strings.Cut(value, " ")
+	n, err := strconv.Atoi(pt)
+	if !ok || err != nil {
+		return Codec{}, fmt.Errorf("%w: invalid rtpmap %q", ErrMalformed, value)
+	}
+	parts := strings.Split(strings.TrimSpace(encoding), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		return Codec{}, fmt.Errorf("%w: invalid rtpmap %q", ErrMalformed, value)
+	}
+	clockRate, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Codec{}, fmt.Errorf("%w: invalid clock rate in rtpmap %q", ErrMalformed, value)
+	}
+	codec := Codec{PayloadType: n, Name: parts[0], ClockRate: clockRate}
+	if len(parts) == 3 {
+		if codec.Channels, err = strconv.Atoi(parts[2]); err != nil {
+			return Codec{}, fmt.Errorf("%w: invalid channels in rtpmap %q", ErrMalformed, value)
+		}
+	}
+	return codec, nil
+}
+
+// Fingerprint is a DTLS certificate fingerprint (RFC 8122).
+type Fingerprint struct {
+	HashFunction string
+	Value        string
+}
+
+func (f Fingerprint) String() string {
+	return f.HashFunction + " " + f.Value
+}
+
+func ParseFingerprint(value string) (Fingerprint, error) {
+	hash, digest, ok := strings.Cut(strings.TrimSpace(value), " ")
+	hash = strings.ToLower(hash)
+	size, known := fingerprintSizes[hash]
+	if !ok || !known {
+		return Fingerprint{}, ErrInvalidFingerprint
+	}
+	octets := strings.Split(digest, ":")
+	if len(octets) != size {
+		return Fingerprint{}, ErrInvalidFingerprint
+	}
+	for _, octet := range octets {
+		if len(octet) != 2 {
+			return Fingerprint{}, ErrInvalidFingerprint
+		}
+		if _, err := hex.DecodeString(octet); err != nil {
+			return Fingerprint{}, ErrInvalidFingerprint
+		}
+	}
+	return Fingerprint{HashFunction: hash, Value: strings.ToUpper(digest)}, nil
+}
+
+// AudioParams are the negotiated parameters of an audio media section.
+type AudioParams struct {
+	// Codecs are the supported codecs the remote side accepted, in its
+	// preference order.
+	Codecs      []Codec
+	ICEUfrag    string
+	ICEPwd      string
+	Fingerprint Fingerprint
+	Setup       string
+}
+
+// ValidateAnswer checks a WebRTC answer to one of our audio offers and
+// returns the parameters of its first active audio section.
+func ValidateAnswer(raw string, supported []Codec) (*AudioParams, error) {
+	desc, err := Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	params, err := desc.audioParams(supported)
+	if err != nil {
+		return nil, err
+	}
+	if params.Setup != "active" && params.Setup != "passive" {
+		return nil, ErrInvalidSetup
+	}
+	return params, nil
+}
+
+// AudioMedia returns the first audio section that was not rejected (port 0).
+func (s *SessionDescription) AudioMedia() *MediaDescription {
+	for _, m := range s.Media {
+		if m.Type == "audio" && m.Port != 0 {
+			return m
+		}
+	}
+	return nil
+}
+
+func (s *SessionDescription) audioParams(supported []Codec) (*AudioParams, error) {
+	audio := s.AudioMedia()
+	if audio == nil {
+		return nil, ErrNoAudio
+	}
+	if audio.Protocol != "UDP/TLS/RTP/SAVPF" {
+		return nil, ErrUnsupportedProtocol
+	}
+
+	offered, err := audio.Codecs()
+	if err != nil {
+		return nil, err
+	}
+	params := &AudioParams{}
+	hasVoice := false
+	for _, codec := range offered {
+		for _, known := range supported {
+			if codec.Same(known) {
+				params.Codecs = append(params.Codecs, codec)
+				if !strings.EqualFold(codec.Name, CodecTelephoneEvent.Name) {
+					hasVoice = true
+				}
+				break
+			}
+		}
+	}
+	if !hasVoice {
+		return nil, ErrNoSupportedCodec
+	}
+
+	params.ICEUfrag = s.mediaOrSessionAttribute(audio, "ice-ufrag")
+	params.ICEPwd = s.mediaOrSessionAttribute(audio, "ice-pwd")
+	if params.ICEUfrag == "" || params.ICEPwd == "" {
+		return nil, ErrMissingICECredentials
+	}
+	// RFC 8839: ufrag is 4-256 and pwd 22-256 ice-chars.
+	if !isICEChars(params.ICEUfrag, 4) || !isICEChars(params.ICEPwd, 22) {
+		return nil, ErrInvalidICECredentials
+	}
+
+	fingerprint := s.mediaOrSessionAttribute(audio, "fingerprint")
+	if fingerprint == "" {
+		return nil, ErrMissingFingerprint
+	}
+	if params.Fingerprint, err = ParseFingerprint(fingerprint); err != nil {
+		return nil, err
+	}
+
+	params.Setup = s.mediaOrSessionAttribute(audio, "setup")
+	return params, nil
+}
+
+func (s *SessionDescription) mediaOrSessionAttribute(m *MediaDescription, key string) string {
+	if value, ok := m.Attribute(key); ok {
+		return value
+	}
+	value, _ := s.Attribute(key)
+	return value
+}
+
+func isICEChars(s string, minLen int) bool {
+	if len(s) < minLen || len(s) > 256 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '+' || c == '/') {
+			return false
+		}
+	}
+	return true
+}
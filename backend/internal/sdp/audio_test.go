@@ -0,0 +1,100 @@
+package sdp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const chromeAnswer = "v=0\r\n" +
+	"o=- 4611731400430051336 2 IN IP4 127.0.0.1\r\n" +
+	"s=-\r\n" +
+	"t=0 0\r\n" +
+	"a=group:BUNDLE 0\r\n" +
+	"a=msid-semantic: WMS\r\n" +
+	"m=audio 9 UDP/TLS/RTP/SAVPF 111 0 8 101\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=rtcp:9 IN IP4 0.0.0.0\r\n" +
+	"a=ice-ufrag:EsAw\r\n" +
+	"a=ice-pwd:P2uYro0UCOQ4zxjKXaWCBui1\r\n" +
+	"a=ice-options:trickle\r\n" +
+	"a=fingerprint:sha-256 D2:FA:0E:C3:22:59:5E:14:95:69:92:3D:13:B4:84:24:2C:C2:A2:C0:3E:FD:34:8E:5E:EA:6F:AF:52:CE:E6:0F\r\n" +
+	"a=setup:active\r\n" +
+	"a=mid:0\r\n" +
+	"a=sendrecv\r\n" +
+	"a=rtcp-mux\r\n" +
+	"a=rtpmap:111 opus/48000/2\r\n" +
+	"a=fmtp:111 minptime=10;useinbandfec=1\r\n" +
+	"a=rtpmap:0 PCMU/8000\r\n" +
+	"a=rtpmap:8 PCMA/8000\r\n" +
+	"a=rtpmap:101 telephone-event/8000\r\n"
+
+func TestValidateAnswer(t *testing.T) {
+	params, err := ValidateAnswer(chromeAnswer, SupportedAudioCodecs)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(params.Codecs) != 4 || params.Codecs[0].Name != "opus" || params.Codecs[0].Fmtp != "minptime=10;useinbandfec=1" {
+		t.Errorf("unexpected codecs: %+v", params.Codecs)
+	}
+	if params.ICEUfrag != "EsAw" || params.ICEPwd != "P2uYro0UCOQ4zxjKXaWCBui1" {
+		t.Errorf("unexpected ice credentials: %s %s", params.ICEUfrag, params.ICEPwd)
+	}
+	if params.Fingerprint.HashFunction != "sha-256" {
+		t.Errorf("expected sha-256 fingerprint, got %s", params.Fingerprint.HashFunction)
+	}
+	if params.Setup != "active" {
+		t.Errorf("expected setup 'active', got '%s'", params.Setup)
+	}
+}
+
+func TestValidateAnswer_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  string
+		wantErr error
+	}{
+		{"not sdp", "hello", ErrMalformed},
+		{"missing origin", strings.Replace(chromeAnswer, "o=- 4611731400430051336 2 IN IP4 127.0.0.1\r\n", "", 1), ErrMalformed},
+		{"unknown payload type", strings.Replace(chromeAnswer, "111 0 8 101", "111 0 8 101 96", 1), ErrMalformed},
+		{"rejected audio", strings.Replace(chromeAnswer, "m=audio 9", "m=audio 0", 1), ErrNoAudio},
+		{"video only", strings.Replace(chromeAnswer, "m=audio", "m=video", 1), ErrNoAudio},
+		{"plain rtp", strings.Replace(chromeAnswer, "UDP/TLS/RTP/SAVPF", "RTP/AVP", 1), ErrUnsupportedProtocol},
+		{"only telephone-event", strings.Replace(chromeAnswer, "111 0 8 101", "101", 1), ErrNoSupportedCodec},
+		{"missing fingerprint", removeLine(chromeAnswer, "a=fingerprint:"), ErrMissingFingerprint},
+		{"short fingerprint", strings.Replace(chromeAnswer, "D2:FA:0E:", "", 1), ErrInvalidFingerprint},
+		{"unknown hash", strings.Replace(chromeAnswer, "sha-256", "md5", 1), ErrInvalidFingerprint},
+		{"missing ice pwd", removeLine(chromeAnswer, "a=ice-pwd:"), ErrMissingICECredentials},
+		{"short ice pwd", strings.Replace(chromeAnswer, "P2uYro0UCOQ4zxjKXaWCBui1", "short", 1), ErrInvalidICECredentials},
+		{"actpass in answer", strings.Replace(chromeAnswer, "a=setup:active", "a=setup:actpass", 1), ErrInvalidSetup},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateAnswer(tt.answer, SupportedAudioCodecs)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateAnswer_SessionLevelCredentials(t *testing.T) {
+	answer := removeLine(removeLine(chromeAnswer, "a=ice-ufrag:"), "a=ice-pwd:")
+	answer = strings.Replace(answer, "a=group:BUNDLE 0\r\n", "a=group:BUNDLE 0\r\na=ice-ufrag:EsAw\r\na=ice-pwd:P2uYro0UCOQ4zxjKXaWCBui1\r\n", 1)
+
+	if _, err := ValidateAnswer(answer, SupportedAudioCodecs); err != nil {
+		t.Errorf("expected session-level ice credentials to be accepted, got %v", err)
+	}
+}
+
+func removeLine(sdp, prefix string) string {
+	var lines []string
+	for _, line := range strings.SplitAfter(sdp, "\r\n") {
+		if !strings.HasPrefix(line, prefix) {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "")
+}
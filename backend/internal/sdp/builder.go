@@ -0,0 +1,227 @@
+package sdp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const iceChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789+/"
+
+// DefaultCodecs is the codec list offered when none is configured.
+var DefaultCodecs = []Codec{CodecOpus, CodecPCMU, CodecPCMA, CodecTelephoneEvent}
+
+// ParseCodecList resolves a comma-separated list of codec names, such as
+// "opus,PCMU,telephone-event", to the well-known codecs, keeping the order.
+func ParseCodecList(list string) ([]Codec, error) {
+	if strings.TrimSpace(list) == "" {
+		return DefaultCodecs, nil
+	}
+
+	var codecs []Codec
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, known := range SupportedAudioCodecs {
+			if strings.EqualFold(name, known.Name) {
+				codecs = append(codecs, known)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unsupported codec %q", name)
+		}
+	}
+	return codecs, nil
+}
+
+// ICECredentials are the ICE username fragment and password of one offer.
+type ICECredentials struct {
+	Ufrag string
+	Pwd   string
+}
+
+// OfferBuilder produces WebRTC audio offers (RFC 8829) for one DTLS
+// certificate. Each offer gets its own session ID and ICE credentials.
+type OfferBuilder struct {
+	codecs      []Codec
+	fingerprint Fingerprint
+}
+
+// NewOfferBuilder creates a builder offering codecs in the given order. When
+// certificate (DER) is nil, a self-signed ECDSA certificate is generated, as
+// browsers do for their own DTLS identity.
+func NewOfferBuilder(codecs []Codec, certificate []byte) (*OfferBuilder, error) {
+	if len(codecs) == 0 {
+		codecs = DefaultCodecs
+	}
+
+	hasVoice := false
+	for _, codec := range codecs {
+		if !strings.EqualFold(codec.Name, CodecTelephoneEvent.Name) {
+			hasVoice = true
+		}
+	}
+	if !hasVoice {
+		return nil, errors.New("at least one voice codec is required")
+	}
+
+	if certificate == nil {
+		var err error
+		if certificate, err = generateCertificate(); err != nil {
+			return nil, fmt.Errorf("failed to generate dtls certificate: %w", err)
+		}
+	}
+
+	return &OfferBuilder{
+		codecs:      codecs,
+		fingerprint: CertificateFingerprint(certificate),
+	}, nil
+}
+
+func (b *OfferBuilder) Fingerprint() Fingerprint {
+	return b.fingerprint
+}
+
+// Offer builds a new audio offer. The returned credentials are the local ICE
+// credentials the offer advertises.
+func (b *OfferBuilder) Offer() (*SessionDescription, ICECredentials, error) {
+	sessionID, err := randomSessionID()
+	if err != nil {
+		return nil, ICECredentials{}, err
+	}
+	creds, err := NewICECredentials()
+	if err != nil {
+		return nil, ICECredentials{}, err
+	}
+
+	audio := &MediaDescription{
+		Type:       "audio",
+		Port:       9,
+		Protocol:   "UDP/TLS/RTP/SAVPF",
+		Connection: &Connection{NetworkType: "IN", AddressType: "IP4", Address: "0.0.0.0"},
+		Attributes: []Attribute{
+			{Key: "rtcp", Value: "9 IN IP4 0.0.0.0"},
+			{Key: "ice-ufrag", Value: creds.Ufrag},
+			{Key: "ice-pwd", Value: creds.Pwd},
+			{Key: "ice-options", Value: "trickle"},
+			{Key: "fingerprint", Value: b.fingerprint.String()},
+			{Key: "setup", Value: "actpass"},
+			{Key: "mid", Value: "0"},
+			{Key: "sendrecv"},
+			{Key: "rtcp-mux"},
+		},
+	}
+	for _, codec := range b.codecs {
+		audio.Formats = append(audio.Formats, strconv.Itoa(codec.PayloadType))
+		audio.Attributes = append(audio.Attributes, Attribute{Key: "rtpmap", Value: codec.rtpmap()})
+		if codec.Fmtp != "" {
+			audio.Attributes = append(audio.Attributes, Attribute{
+				Key:   "fmtp",
+				Value: strconv.Itoa(codec.PayloadType) + " " + codec.Fmtp,
+			})
+		}
+	}
+
+	desc := &SessionDescription{
+		Version: 0,
+		Origin: Origin{
+			Username:       "-",
+			SessionID:      sessionID,
+			SessionVersion: 2,
+			NetworkType:    "IN",
+			AddressType:    "IP4",
+			Address:        "127.0.0.1",
+		},
+		SessionName: "-",
+		Attributes: []Attribute{
+			{Key: "group", Value: "BUNDLE 0"},
+		},
+		Media: []*MediaDescription{audio},
+	}
+
+	return desc, creds, nil
+}
+
+func (c Codec) rtpmap() string {
+	value := strconv.Itoa(c.PayloadType) + " " + c.Name + "/" + strconv.Itoa(c.ClockRate)
+	if c.Channels > 1 {
+		value += "/" + strconv.Itoa(c.Channels)
+	}
+	return value
+}
+
+// NewICECredentials generates a random ufrag and password with the lengths
+// browsers use (RFC 8839 requires at least 24 and 128 bits of randomness).
+func NewICECredentials() (ICECredentials, error) {
+	ufrag, err := randomICEString(8)
+	if err != nil {
+		return ICECredentials{}, err
+	}
+	pwd, err := randomICEString(24)
+	if err != nil {
+		return ICECredentials{}, err
+	}
+	return ICECredentials{Ufrag: ufrag, Pwd: pwd}, nil
+}
+
+// CertificateFingerprint returns the sha-256 fingerprint of a DER
+// certificate in the a=fingerprint format.
+func CertificateFingerprint(der []byte) Fingerprint {
+	sum := sha256.Sum256(der)
+	octets := make([]string, len(sum))
+	for i, octet := range sum {
+		octets[i] = fmt.Sprintf("%02X", octet)
+	}
+	return Fingerprint{HashFunction: "sha-256", Value: strings.Join(octets, ":")}
+}
+
+func randomICEString(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i := range buf {
+		buf[i] = iceChars[int(buf[i])%len(iceChars)]
+	}
+	return string(buf), nil
+}
+
+// randomSessionID returns a random 62-bit origin session ID, kept below
+// 2^63 as RFC 8829 recommends.
+func randomSessionID() (uint64, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(buf[:]) >> 2, nil
+}
+
+func generateCertificate() ([]byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 63))
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "WebRTC"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(30 * 24 * time.Hour),
+	}
+	return x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+}
@@ -0,0 +1,98 @@
+package sdp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMarshal_RoundTrip(t *testing.T) {
+	desc, err := Parse(chromeAnswer)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got := desc.Marshal(); got != chromeAnswer {
+		t.Errorf("round trip changed the sdp:\n%s\nwant:\n%s", got, chromeAnswer)
+	}
+}
+
+func TestParse_AcceptsLF(t *testing.T) {
+	desc, err := Parse(strings.ReplaceAll(chromeAnswer, "\r\n", "\n"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if got := desc.Marshal(); got != chromeAnswer {
+		t.Errorf("expected LF input to serialize with CRLF, got:\n%s", got)
+	}
+}
+
+func TestOfferBuilder_Offer(t *testing.T) {
+	builder, err := NewOfferBuilder([]Codec{CodecPCMU, CodecOpus, CodecTelephoneEvent}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	offer, creds, err := builder.Offer()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	raw := offer.Marshal()
+	parsed, err := Parse(raw)
+	if err != nil {
+		t.Fatalf("expected generated offer to parse, got %v", err)
+	}
+	if parsed.Marshal() != raw {
+		t.Errorf("expected generated offer to round trip unchanged")
+	}
+
+	params, err := parsed.audioParams(SupportedAudioCodecs)
+	if err != nil {
+		t.Fatalf("expected generated offer to be a valid audio description, got %v", err)
+	}
+
+	if params.ICEUfrag != creds.Ufrag || params.ICEPwd != creds.Pwd {
+		t.Errorf("expected offer to carry returned ice credentials")
+	}
+	if params.Fingerprint != builder.Fingerprint() {
+		t.Errorf("expected fingerprint %s, got %s", builder.Fingerprint(), params.Fingerprint)
+	}
+	if params.Setup != "actpass" {
+		t.Errorf("expected offer setup 'actpass', got '%s'", params.Setup)
+	}
+
+	names := make([]string, 0, len(params.Codecs))
+	for _, codec := range params.Codecs {
+		names = append(names, codec.Name)
+	}
+	if strings.Join(names, ",") != "PCMU,opus,telephone-event" {
+		t.Errorf("expected configured codec order, got %v", names)
+	}
+	if params.Codecs[1].Channels != 2 || params.Codecs[1].Fmtp == "" {
+		t.Errorf("expected opus rtpmap and fmtp to round trip, got %+v", params.Codecs[1])
+	}
+
+	second, secondCreds, _ := builder.Offer()
+	if secondCreds == creds || second.Origin.SessionID == offer.Origin.SessionID {
+		t.Error("expected every offer to get fresh ice credentials and session id")
+	}
+}
+
+func TestParseCodecList(t *testing.T) {
+	codecs, err := ParseCodecList("PCMA, opus")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(codecs) != 2 || codecs[0].Name != "PCMA" || codecs[1].Name != "opus" {
+		t.Errorf("unexpected codecs: %+v", codecs)
+	}
+
+	if _, err := ParseCodecList("opus,G729"); err == nil {
+		t.Error("expected error for unsupported codec, got nil")
+	}
+
+	if _, err := NewOfferBuilder([]Codec{CodecTelephoneEvent}, nil); err == nil {
+		t.Error("expected error for offer without voice codec, got nil")
+	}
+}
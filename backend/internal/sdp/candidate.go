@@ -0,0 +1,135 @@
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidCandidate = errors.New("invalid ice candidate")
+
+// Candidate is an ICE candidate attribute as defined in RFC 8839 section 5.1.
+type Candidate struct {
+	Foundation string
+	Component  int
+	Transport  string
+	Priority   uint32
+	Address    string
+	Port       int
+	Type       string
+	RelAddress string
+	RelPort    int
+	Extensions map[string]string
+}
+
+var candidateTypes = map[string]bool{"host": true, "srflx": true, "prflx": true, "relay": true}
+
+// ParseCandidate parses a candidate line as browsers send it
+// ("candidate:..."), optionally with the "a=" prefix. Connection addresses
+// must be IP literals or mDNS host names (RFC 8839 allows FQDNs; browsers
+// only emit ".local" ones).
+func ParseCandidate(raw string) (*Candidate, error) {
+	value := strings.TrimSpace(raw)
+	value = strings.TrimPrefix(value, "a=")
+	value, ok := strings.CutPrefix(value, "candidate:")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing candidate: prefix", ErrInvalidCandidate)
+	}
+
+	fields := strings.Fields(value)
+	if len(fields) < 8 || fields[6] != "typ" {
+		return nil, fmt.Errorf("%w: expected foundation, component, transport, priority, address, port and typ", ErrInvalidCandidate)
+	}
+
+	c := &Candidate{
+		Foundation: fields[0],
+		Transport:  strings.ToLower(fields[2]),
+		Address:    fields[4],
+		Type:       fields[7],
+	}
+
+	if len(c.Foundation) > 32 || !isICEChars(c.Foundation, 1) {
+		return nil, fmt.Errorf("%w: foundation %q", ErrInvalidCandidate, c.Foundation)
+	}
+
+	component, err := strconv.Atoi(fields[1])
+	if err != nil || component < 1 || component > 256 || len(fields[1]) > 5 {
+		return nil, fmt.Errorf("%w: component %q", ErrInvalidCandidate, fields[1])
+	}
+	c.Component = component
+
+	if c.Transport != "udp" && c.Transport != "tcp" {
+		return nil, fmt.Errorf("%w: transport %q", ErrInvalidCandidate, fields[2])
+	}
+
+	priority, err := strconv.ParseUint(fields[3], 10, 32)
+	if err != nil || len(fields[3]) > 10 {
+		return nil, fmt.Errorf("%w: priority %q", ErrInvalidCandidate, fields[3])
+	}
+	c.Priority = uint32(priority)
+
+	if !isCandidateAddress(c.Address) {
+		return nil, fmt.Errorf("%w: address %q", ErrInvalidCandidate, c.Address)
+	}
+
+	if c.Port, err = parsePort(fields[5]); err != nil {
+		return nil, err
+	}
+
+	if !candidateTypes[c.Type] {
+		return nil, fmt.Errorf("%w: type %q", ErrInvalidCandidate, c.Type)
+	}
+
+	rest := fields[8:]
+	if len(rest) >= 2 && rest[0] == "raddr" {
+		if !isCandidateAddress(rest[1]) {
+			return nil, fmt.Errorf("%w: raddr %q", ErrInvalidCandidate, rest[1])
+		}
+		c.RelAddress = rest[1]
+		rest = rest[2:]
+	}
+	if len(rest) >= 2 && rest[0] == "rport" {
+		if c.RelPort, err = parsePort(rest[1]); err != nil {
+			return nil, err
+		}
+		rest = rest[2:]
+	}
+
+	if len(rest)%2 != 0 {
+		return nil, fmt.Errorf("%w: extension %q has no value", ErrInvalidCandidate, rest[len(rest)-1])
+	}
+	if len(rest) > 0 {
+		c.Extensions = make(map[string]string, len(rest)/2)
+		for i := 0; i < len(rest); i += 2 {
+			c.Extensions[rest[i]] = rest[i+1]
+		}
+	}
+
+	return c, nil
+}
+
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 0 || port > 65535 {
+		return 0, fmt.Errorf("%w: port %q", ErrInvalidCandidate, value)
+	}
+	return port, nil
+}
+
+func isCandidateAddress(address string) bool {
+	if net.ParseIP(address) != nil {
+		return true
+	}
+	host, ok := strings.CutSuffix(address, ".local")
+	if !ok || host == "" {
+		return false
+	}
+	for _, r := range host {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
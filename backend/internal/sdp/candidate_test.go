@@ -0,0 +1,57 @@
+package sdp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCandidate(t *testing.T) {
+	c, err := ParseCandidate("candidate:842163049 1 udp 1677729535 203.0.113.7 46154 typ srflx raddr 192.168.1.20 rport 46154 generation 0 ufrag EsAw network-cost 999")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if c.Component != 1 || c.Transport != "udp" || c.Priority != 1677729535 {
+		t.Errorf("unexpected candidate: %+v", c)
+	}
+	if c.Address != "203.0.113.7" || c.Port != 46154 || c.Type != "srflx" {
+		t.Errorf("unexpected candidate address: %+v", c)
+	}
+	if c.RelAddress != "192.168.1.20" || c.RelPort != 46154 {
+		t.Errorf("unexpected related address: %+v", c)
+	}
+	if c.Extensions["ufrag"] != "EsAw" || c.Extensions["generation"] != "0" {
+		t.Errorf("unexpected extensions: %v", c.Extensions)
+	}
+}
+
+func TestParseCandidate_Accepted(t *testing.T) {
+	for _, raw := range []string{
+		"candidate:1 1 UDP 2122260223 1f4712db-ea17-4bcf-a596-105139dfd8bf.local 54321 typ host",
+		"a=candidate:2 1 tcp 1518280447 2001:db8::1 9 typ host tcptype active",
+		"candidate:3 1 udp 41885439 198.51.100.1 3478 typ relay raddr 0.0.0.0 rport 0",
+	} {
+		if _, err := ParseCandidate(raw); err != nil {
+			t.Errorf("%q: expected no error, got %v", raw, err)
+		}
+	}
+}
+
+func TestParseCandidate_Invalid(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"1 1 udp 2122260223 192.168.1.20 54321 typ host",
+		"candidate:1 1 udp 2122260223 192.168.1.20 54321 host",
+		"candidate:1 0 udp 2122260223 192.168.1.20 54321 typ host",
+		"candidate:1 1 sctp 2122260223 192.168.1.20 54321 typ host",
+		"candidate:1 1 udp 99999999999 192.168.1.20 54321 typ host",
+		"candidate:1 1 udp 2122260223 example.com 54321 typ host",
+		"candidate:1 1 udp 2122260223 192.168.1.20 70000 typ host",
+		"candidate:1 1 udp 2122260223 192.168.1.20 54321 typ bogus",
+		"candidate:f@o 1 udp 2122260223 192.168.1.20 54321 typ host",
+		"candidate:1 1 udp 2122260223 192.168.1.20 54321 typ host generation",
+	} {
+		if _, err := ParseCandidate(raw); !errors.Is(err, ErrInvalidCandidate) {
+			t.Errorf("%q: expected ErrInvalidCandidate, got %v", raw, err)
+		}
+	}
+}
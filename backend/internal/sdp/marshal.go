@@ -0,0 +1,66 @@
+package sdp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Marshal serializes the description with CRLF line endings, in the line
+// order RFC 8866 requires.
+func (s *SessionDescription) Marshal() string {
+	var b strings.Builder
+
+	writeLine(&b, 'v', strconv.Itoa(s.Version))
+	writeLine(&b, 'o', strings.Join([]string{
+		s.Origin.Username,
+		strconv.FormatUint(s.Origin.SessionID, 10),
+		strconv.FormatUint(s.Origin.SessionVersion, 10),
+		s.Origin.NetworkType,
+		s.Origin.AddressType,
+		s.Origin.Address,
+	}, " "))
+	writeLine(&b, 's', s.SessionName)
+	if s.Connection != nil {
+		writeLine(&b, 'c', s.Connection.String())
+	}
+	writeLine(&b, 't', strconv.FormatUint(s.Timing.Start, 10)+" "+strconv.FormatUint(s.Timing.Stop, 10))
+	writeAttributes(&b, s.Attributes)
+
+	for _, m := range s.Media {
+		writeLine(&b, 'm', strings.Join(append([]string{
+			m.Type,
+			strconv.Itoa(m.Port),
+			m.Protocol,
+		}, m.Formats...), " "))
+		if m.Connection != nil {
+			writeLine(&b, 'c', m.Connection.String())
+		}
+		writeAttributes(&b, m.Attributes)
+	}
+
+	return b.String()
+}
+
+func (c *Connection) String() string {
+	return c.NetworkType + " " + c.AddressType + " " + c.Address
+}
+
+func (a Attribute) String() string {
+	if a.Value == "" {
+		return a.Key
+	}
+	return a.Key + ":" + a.Value
+}
+
+func writeAttributes(b *strings.Builder, attrs []Attribute) {
+	for _, attr := range attrs {
+		writeLine(b, 'a', attr.String())
+	}
+}
+
+func writeLine(b *strings.Builder, typ byte, value string) {
+	b.WriteByte(typ)
+	b.WriteByte('=')
+	b.WriteString(value)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,240 @@
+// Package sdp parses Session Description Protocol documents (RFC 8866) and
+// validates the subset WebRTC audio calls rely on: the audio media section,
+// its codecs, ICE credentials and the DTLS fingerprint.
+package sdp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrMalformed = errors.New("malformed sdp")
+
+type SessionDescription struct {
+	Version     int
+	Origin      Origin
+	SessionName string
+	Connection  *Connection
+	Timing      Timing
+	Attributes  []Attribute
+	Media       []*MediaDescription
+}
+
+type Origin struct {
+	Username       string
+	SessionID      uint64
+	SessionVersion uint64
+	NetworkType    string
+	AddressType    string
+	Address        string
+}
+
+type Connection struct {
+	NetworkType string
+	AddressType string
+	Address     string
+}
+
+type Timing struct {
+	Start uint64
+	Stop  uint64
+}
+
+// Attribute is an a= line. Flag attributes such as "a=rtcp-mux" have an
+// empty Value.
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+type MediaDescription struct {
+	Type       string
+	Port       int
+	Protocol   string
+	Formats    []string
+	Connection *Connection
+	Attributes []Attribute
+}
+
+// Attribute returns the value of the first session-level attribute with the
+// given key.
+func (s *SessionDescription) Attribute(key string) (string, bool) {
+	return findAttribute(s.Attributes, key)
+}
+
+// Attribute returns the value of the first media-level attribute with the
+// given key.
+func (m *MediaDescription) Attribute(key string) (string, bool) {
+	return findAttribute(m.Attributes, key)
+}
+
+// AttributeValues returns the values of every media-level attribute with
+// the given key.
+func (m *MediaDescription) AttributeValues(key string) []string {
+	var values []string
+	for _, attr := range m.Attributes {
+		if attr.Key == key {
+			values = append(values, attr.Value)
+		}
+	}
+	return values
+}
+
+func findAttribute(attrs []Attribute, key string) (string, bool) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// Parse reads an SDP document. Lines may end in CRLF or LF; unknown line
+// types are ignored as RFC 8866 requires.
+func Parse(raw string) (*SessionDescription, error) {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+
+	desc := &SessionDescription{}
+	var media *MediaDescription
+	seen := map[byte]bool{}
+
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+		if len(line) < 2 || line[1] != '=' {
+			return nil, fmt.Errorf("%w: line %d: expected <type>=<value>", ErrMalformed, i+1)
+		}
+		typ, value := line[0], line[2:]
+
+		if len(seen) == 0 && typ != 'v' {
+			return nil, fmt.Errorf("%w: first line must be v=", ErrMalformed)
+		}
+
+		var err error
+		switch typ {
+		case 'v':
+			desc.Version, err = strconv.Atoi(value)
+			if err == nil && desc.Version != 0 {
+				err = fmt.Errorf("unsupported version %d", desc.Version)
+			}
+		case 'o':
+			desc.Origin, err = parseOrigin(value)
+		case 's':
+			desc.SessionName = value
+		case 't':
+			desc.Timing, err = parseTiming(value)
+		case 'c':
+			var conn *Connection
+			conn, err = parseConnection(value)
+			if media != nil {
+				media.Connection = conn
+			} else {
+				desc.Connection = conn
+			}
+		case 'm':
+			media, err = parseMedia(value)
+			if err == nil {
+				desc.Media = append(desc.Media, media)
+			}
+		case 'a':
+			attr := parseAttribute(value)
+			if media != nil {
+				media.Attributes = append(media.Attributes, attr)
+			} else {
+				desc.Attributes = append(desc.Attributes, attr)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d (%c=): %v", ErrMalformed, i+1, typ, err)
+		}
+		seen[typ] = true
+	}
+
+	for _, required := range []byte{'v', 'o', 's', 't'} {
+		if !seen[required] {
+			return nil, fmt.Errorf("%w: missing %c= line", ErrMalformed, required)
+		}
+	}
+
+	return desc, nil
+}
+
+func parseOrigin(value string) (Origin, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 6 {
+		return Origin{}, errors.New("expected 6 fields")
+	}
+	sessionID, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return Origin{}, fmt.Errorf("invalid session id %q", fields[1])
+	}
+	sessionVersion, err := strconv.ParseUint(fields[2], 10, 64)
+	if err != nil {
+		return Origin{}, fmt.Errorf("invalid session version %q", fields[2])
+	}
+	return Origin{
+		Username:       fields[0],
+		SessionID:      sessionID,
+		SessionVersion: sessionVersion,
+		NetworkType:    fields[3],
+		AddressType:    fields[4],
+		Address:        fields[5],
+	}, nil
+}
+
+func parseTiming(value string) (Timing, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return Timing{}, errors.New("expected 2 fields")
+	}
+	start, err1 := strconv.ParseUint(fields[0], 10, 64)
+	stop, err2 := strconv.ParseUint(fields[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return Timing{}, errors.New("invalid time")
+	}
+	return Timing{Start: start, Stop: stop}, nil
+}
+
+func parseConnection(value string) (*Connection, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 3 {
+		return nil, errors.New("expected 3 fields")
+	}
+	return &Connection{
+		NetworkType: fields[0],
+		AddressType: fields[1],
+		Address:     fields[2],
+	}, nil
+}
+
+func parseMedia(value string) (*MediaDescription, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 4 {
+		return nil, errors.New("expected <media> <port> <proto> <fmt> ...")
+	}
+	portField := fields[1]
+	if i := strings.IndexByte(portField, '/'); i >= 0 {
+		portField = portField[:i]
+	}
+	port, err := strconv.Atoi(portField)
+	if err != nil || port < 0 || port > 65535 {
+		return nil, fmt.Errorf("invalid port %q", fields[1])
+	}
+	return &MediaDescription{
+		Type:     fields[0],
+		Port:     port,
+		Protocol: fields[2],
+		Formats:  fields[3:],
+	}, nil
+}
+
+func parseAttribute(value string) Attribute {
+	if i := strings.IndexByte(value, ':'); i >= 0 {
+		return Attribute{Key: value[:i], Value: value[i+1:]}
+	}
+	return Attribute{Key: value}
+}
@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/calls"
+	"github.com/gin-gonic/gin"
+)
+
+// CallControlHandler acts on a call in progress.
+type CallControlHandler struct {
+	sendDTMF     *calls.SendDTMFUseCase
+	hold         *calls.HoldCallUseCase
+	resume       *calls.ResumeCallUseCase
+	mute         *calls.MuteCallUseCase
+	transfer     *calls.TransferCallUseCase
+	complete     *calls.CompleteTransferUseCase
+	capabilities domain.VoIPCapabilities
+}
+
+func NewCallControlHandler(
+	sendDTMF *calls.SendDTMFUseCase,
+	hold *calls.HoldCallUseCase,
+	resume *calls.ResumeCallUseCase,
+	mute *calls.MuteCallUseCase,
+	transfer *calls.TransferCallUseCase,
+	complete *calls.CompleteTransferUseCase,
+	capabilities domain.VoIPCapabilities,
+) *CallControlHandler {
+	return &CallControlHandler{
+		sendDTMF:     sendDTMF,
+		hold:         hold,
+		resume:       resume,
+		mute:         mute,
+		transfer:     transfer,
+		complete:     complete,
+		capabilities: capabilities,
+	}
+}
+
+// Capabilities reports which call controls the configured provider supports.
+func (h *CallControlHandler) Capabilities(c *gin.Context) {
+	c.JSON(http.StatusOK, h.capabilities)
+}
+
+type SendDTMFRequest struct {
+	Digits string `json:"digits" binding:"required"`
+}
+
+// SendDTMF plays digits into an answered call. The response and the
+// call.dtmf event carry the digits masked.
+func (h *CallControlHandler) SendDTMF(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req SendDTMFRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "digits is required",
+		})
+		return
+	}
+
+	output, err := h.sendDTMF.Execute(c.Request.Context(), calls.SendDTMFInput{
+		UserID: userID,
+		CallID: c.Param("id"),
+		Digits: req.Digits,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidDTMF) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_dtmf",
+				"message": "digits may only contain 0-9, *, # and w, up to 64 characters",
+			})
+			return
+		}
+
+		c.JSON(callControlErrorStatus(err.Error()), gin.H{
+			"error":   "dtmf_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"call_id": output.CallID,
+		"digits":  output.Digits,
+	})
+}
+
+// Hold puts an answered call on hold; the called party hears hold music.
+func (h *CallControlHandler) Hold(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.hold.Execute(c.Request.Context(), calls.HoldCallInput{
+		UserID: userID,
+		CallID: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(callControlErrorStatus(err.Error()), gin.H{
+			"error":   "hold_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"call_id": output.CallID,
+		"status":  output.Status,
+	})
+}
+
+// Resume takes a call off hold.
+func (h *CallControlHandler) Resume(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.resume.Execute(c.Request.Context(), calls.ResumeCallInput{
+		UserID: userID,
+		CallID: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(callControlErrorStatus(err.Error()), gin.H{
+			"error":   "hold_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"call_id":       output.CallID,
+		"status":        output.Status,
+		"hold_duration": output.HoldDuration,
+	})
+}
+
+type MuteCallRequest struct {
+	Muted *bool `json:"muted" binding:"required"`
+}
+
+// Mute stops or restores the caller's audio towards the called party.
+func (h *CallControlHandler) Mute(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req MuteCallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "muted is required",
+		})
+		return
+	}
+
+	output, err := h.mute.Execute(c.Request.Context(), calls.MuteCallInput{
+		UserID: userID,
+		CallID: c.Param("id"),
+		Muted:  *req.Muted,
+	})
+	if err != nil {
+		c.JSON(callControlErrorStatus(err.Error()), gin.H{
+			"error":   "mute_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"call_id": output.CallID,
+		"muted":   output.Muted,
+	})
+}
+
+type TransferCallRequest struct {
+	Mode        string `json:"mode" binding:"required"`
+	PhoneNumber string `json:"phone_number"`
+	Email       string `json:"email"`
+}
+
+// Transfer hands the call over to a phone number or another user. A blind
+// transfer ends the caller's call; an attended one holds it and returns the
+// consultation call to answer.
+func (h *CallControlHandler) Transfer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req TransferCallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "mode is required",
+		})
+		return
+	}
+
+	output, err := h.transfer.Execute(c.Request.Context(), calls.TransferCallInput{
+		UserID:      userID,
+		CallID:      c.Param("id"),
+		Mode:        domain.TransferMode(req.Mode),
+		PhoneNumber: req.PhoneNumber,
+		TargetEmail: req.Email,
+	})
+	if err != nil {
+		var shortCode *domain.ShortCodeError
+		if errors.As(err, &shortCode) {
+			shortCodeError(c, shortCode)
+			return
+		}
+		c.JSON(callControlErrorStatus(err.Error()), gin.H{
+			"error":   "transfer_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transferResponse(output))
+}
+
+// CompleteTransfer connects the held call to the target of its consultation
+// call; :id is the consultation call.
+func (h *CallControlHandler) CompleteTransfer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.complete.Execute(c.Request.Context(), calls.CompleteTransferInput{
+		UserID: userID,
+		CallID: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(callControlErrorStatus(err.Error()), gin.H{
+			"error":   "transfer_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transferResponse(output))
+}
+
+func transferResponse(output *calls.TransferCallOutput) gin.H {
+	resp := gin.H{
+		"call_id":        output.CallID,
+		"parent_call_id": output.ParentCallID,
+		"mode":           output.Mode,
+		"status":         output.Status,
+	}
+	if output.SessionID != "" {
+		resp["session_id"] = output.SessionID
+		resp["sdp_offer"] = output.SDPOffer
+	}
+	return resp
+}
+
+func callControlErrorStatus(errorMsg string) int {
+	switch errorMsg {
+	case "call not found":
+		return http.StatusNotFound
+	case "unauthorized":
+		return http.StatusForbidden
+	case "call_id is required", "invalid transfer mode", "phone_number or email is required",
+		"invalid phone number", "cannot transfer a call to yourself":
+		return http.StatusBadRequest
+	case "transfer target not found":
+		return http.StatusNotFound
+	case "call already ended", "call is not active", "call is not on hold", "call has no webrtc session",
+		"call is not a consultation call", "call is in a conference":
+		return http.StatusConflict
+	case "dtmf is not supported", "hold is not supported", "mute is not supported", "transfer is not supported":
+		return http.StatusNotImplemented
+	case "failed to send dtmf", "failed to hold call", "failed to resume call", "failed to mute call",
+		"failed to transfer call":
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
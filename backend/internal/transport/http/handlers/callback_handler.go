@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/calls"
+	"github.com/gin-gonic/gin"
+)
+
+// CallbackHandler places callback calls, which ring the user's own phone
+// before the destination.
+type CallbackHandler struct {
+	callback *calls.CallbackCallUseCase
+}
+
+func NewCallbackHandler(callback *calls.CallbackCallUseCase) *CallbackHandler {
+	return &CallbackHandler{callback: callback}
+}
+
+type CallbackRequest struct {
+	CallerNumber string `json:"caller_number" binding:"required"`
+	PhoneNumber  string `json:"phone_number" binding:"required"`
+}
+
+func (h *CallbackHandler) Create(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req CallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "caller_number and phone_number are required",
+		})
+		return
+	}
+
+	output, err := h.callback.Execute(c.Request.Context(), calls.CallbackCallInput{
+		UserID:       userID,
+		CallerNumber: req.CallerNumber,
+		PhoneNumber:  req.PhoneNumber,
+	})
+	if err != nil {
+		var shortCode *domain.ShortCodeError
+		if errors.As(err, &shortCode) {
+			shortCodeError(c, shortCode)
+			return
+		}
+		c.JSON(callbackErrorStatus(err.Error()), gin.H{
+			"error":   "callback_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"call_id":       output.CallID,
+		"caller_number": output.CallerNumber,
+		"phone_number":  output.PhoneNumber,
+		"status":        output.Status,
+		"start_time":    output.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+	})
+}
+
+func callbackErrorStatus(errorMsg string) int {
+	switch errorMsg {
+	case "user_id is required", "caller_number and phone_number are required", "invalid phone number", "cannot call back the destination":
+		return http.StatusBadRequest
+	case "caller number is not verified":
+		return http.StatusForbidden
+	case "callback is not supported":
+		return http.StatusNotImplemented
+	case "failed to initiate call":
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/numbers"
+	"github.com/gin-gonic/gin"
+)
+
+// CallerIDsHandler manages the user's own numbers shown to the parties they
+// call.
+type CallerIDsHandler struct {
+	list       *numbers.ListCallerIDsUseCase
+	add        *numbers.AddCallerIDUseCase
+	verify     *numbers.VerifyCallerIDUseCase
+	setDefault *numbers.SetDefaultCallerIDUseCase
+	delete     *numbers.DeleteCallerIDUseCase
+}
+
+func NewCallerIDsHandler(
+	list *numbers.ListCallerIDsUseCase,
+	add *numbers.AddCallerIDUseCase,
+	verify *numbers.VerifyCallerIDUseCase,
+	setDefault *numbers.SetDefaultCallerIDUseCase,
+	delete *numbers.DeleteCallerIDUseCase,
+) *CallerIDsHandler {
+	return &CallerIDsHandler{
+		list:       list,
+		add:        add,
+		verify:     verify,
+		setDefault: setDefault,
+		delete:     delete,
+	}
+}
+
+type AddCallerIDRequest struct {
+	Number string `json:"number" binding:"required"`
+}
+
+type VerifyCallerIDRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+func (h *CallerIDsHandler) List(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.list.Execute(c.Request.Context(), numbers.ListCallerIDsInput{UserID: userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "caller_ids_fetch_error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+func (h *CallerIDsHandler) Add(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req AddCallerIDRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "number is required",
+		})
+		return
+	}
+
+	output, err := h.add.Execute(c.Request.Context(), numbers.AddCallerIDInput{
+		UserID: userID,
+		Number: req.Number,
+	})
+	if err != nil {
+		callerIDError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, output)
+}
+
+func (h *CallerIDsHandler) Verify(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req VerifyCallerIDRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "code is required",
+		})
+		return
+	}
+
+	output, err := h.verify.Execute(c.Request.Context(), numbers.VerifyCallerIDInput{
+		UserID:     userID,
+		CallerIDID: c.Param("id"),
+		Code:       req.Code,
+	})
+	if err != nil {
+		callerIDError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+func (h *CallerIDsHandler) SetDefault(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.setDefault.Execute(c.Request.Context(), numbers.CallerIDInput{
+		UserID:     userID,
+		CallerIDID: c.Param("id"),
+	})
+	if err != nil {
+		callerIDError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+func (h *CallerIDsHandler) Delete(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	err := h.delete.Execute(c.Request.Context(), numbers.CallerIDInput{
+		UserID:     userID,
+		CallerIDID: c.Param("id"),
+	})
+	if err != nil {
+		callerIDError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func callerIDError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	switch err.Error() {
+	case "user_id is required", "caller_id is required", "code is required", "invalid phone number",
+		"verification code expired", "invalid verification code", "premium rate numbers cannot be caller ids":
+		statusCode = http.StatusBadRequest
+	case "unauthorized", domain.ErrCallerIDNotVerified.Error():
+		statusCode = http.StatusForbidden
+	case "caller id not found":
+		statusCode = http.StatusNotFound
+	case "caller id already verified":
+		statusCode = http.StatusConflict
+	case "verification call already placed", "too many verification calls":
+		statusCode = http.StatusTooManyRequests
+	case "caller id verification is not supported":
+		statusCode = http.StatusNotImplemented
+	case "failed to place verification call":
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   "caller_id_failed",
+		"message": err.Error(),
+	})
+}
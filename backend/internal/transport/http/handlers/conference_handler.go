@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/calls"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/history"
+	"github.com/gin-gonic/gin"
+)
+
+// ConferenceHandler merges calls into conferences and manages their
+// participants.
+type ConferenceHandler struct {
+	create *calls.CreateConferenceUseCase
+	add    *calls.AddParticipantUseCase
+	remove *calls.RemoveParticipantUseCase
+	mute   *calls.MuteParticipantUseCase
+	end    *calls.EndConferenceUseCase
+	list   *history.ListConferencesUseCase
+	get    *history.GetConferenceUseCase
+}
+
+func NewConferenceHandler(
+	create *calls.CreateConferenceUseCase,
+	add *calls.AddParticipantUseCase,
+	remove *calls.RemoveParticipantUseCase,
+	mute *calls.MuteParticipantUseCase,
+	end *calls.EndConferenceUseCase,
+	list *history.ListConferencesUseCase,
+	get *history.GetConferenceUseCase,
+) *ConferenceHandler {
+	return &ConferenceHandler{
+		create: create,
+		add:    add,
+		remove: remove,
+		mute:   mute,
+		end:    end,
+		list:   list,
+		get:    get,
+	}
+}
+
+type CreateConferenceRequest struct {
+	CallIDs []string `json:"call_ids" binding:"required"`
+}
+
+// Create merges two or more answered calls into a new conference.
+func (h *ConferenceHandler) Create(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req CreateConferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "call_ids is required",
+		})
+		return
+	}
+
+	output, err := h.create.Execute(c.Request.Context(), calls.CreateConferenceInput{
+		UserID:  userID,
+		CallIDs: req.CallIDs,
+	})
+	if err != nil {
+		c.JSON(conferenceErrorStatus(err.Error()), gin.H{
+			"error":   "conference_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, conferenceResponse(output))
+}
+
+type AddParticipantRequest struct {
+	CallID string `json:"call_id" binding:"required"`
+}
+
+// AddParticipant joins another answered call to the conference.
+func (h *ConferenceHandler) AddParticipant(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req AddParticipantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "call_id is required",
+		})
+		return
+	}
+
+	output, err := h.add.Execute(c.Request.Context(), calls.ConferenceParticipantInput{
+		UserID:       userID,
+		ConferenceID: c.Param("id"),
+		CallID:       req.CallID,
+	})
+	if err != nil {
+		c.JSON(conferenceErrorStatus(err.Error()), gin.H{
+			"error":   "conference_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, conferenceResponse(output))
+}
+
+// RemoveParticipant hangs up a participant.
+func (h *ConferenceHandler) RemoveParticipant(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.remove.Execute(c.Request.Context(), calls.ConferenceParticipantInput{
+		UserID:       userID,
+		ConferenceID: c.Param("id"),
+		CallID:       c.Param("callId"),
+	})
+	if err != nil {
+		c.JSON(conferenceErrorStatus(err.Error()), gin.H{
+			"error":   "conference_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, conferenceResponse(output))
+}
+
+// MuteParticipant stops or restores a participant's audio towards the
+// conference.
+func (h *ConferenceHandler) MuteParticipant(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req MuteCallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "muted is required",
+		})
+		return
+	}
+
+	output, err := h.mute.Execute(c.Request.Context(), calls.MuteParticipantInput{
+		UserID:       userID,
+		ConferenceID: c.Param("id"),
+		CallID:       c.Param("callId"),
+		Muted:        *req.Muted,
+	})
+	if err != nil {
+		c.JSON(conferenceErrorStatus(err.Error()), gin.H{
+			"error":   "conference_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, conferenceResponse(output))
+}
+
+// End hangs up every participant and the host.
+func (h *ConferenceHandler) End(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.end.Execute(c.Request.Context(), calls.EndConferenceInput{
+		UserID:       userID,
+		ConferenceID: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(conferenceErrorStatus(err.Error()), gin.H{
+			"error":   "conference_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, conferenceResponse(output))
+}
+
+// List returns the user's conferences, newest first, with their
+// participants' calls and costs.
+func (h *ConferenceHandler) List(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	page := 1
+	if pageStr := c.Query("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	limit := 20
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	output, err := h.list.Execute(c.Request.Context(), history.ListConferencesInput{
+		UserID: userID,
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "history_fetch_error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// Get returns one conference with its participants' calls and costs.
+func (h *ConferenceHandler) Get(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	item, err := h.get.Execute(c.Request.Context(), history.GetConferenceInput{
+		UserID:       userID,
+		ConferenceID: c.Param("id"),
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errorType := "conference_fetch_error"
+		switch err.Error() {
+		case "conference not found":
+			statusCode = http.StatusNotFound
+			errorType = "conference_not_found"
+		case "unauthorized":
+			statusCode = http.StatusForbidden
+			errorType = "unauthorized"
+		case "conference_id is required":
+			statusCode = http.StatusBadRequest
+			errorType = "validation_error"
+		}
+		c.JSON(statusCode, gin.H{
+			"error":   errorType,
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func conferenceResponse(output *calls.ConferenceOutput) gin.H {
+	participants := make([]gin.H, 0, len(output.Participants))
+	for _, p := range output.Participants {
+		participants = append(participants, gin.H{
+			"call_id":      p.CallID,
+			"phone_number": p.PhoneNumber,
+			"status":       p.Status,
+			"muted":        p.Muted,
+		})
+	}
+	return gin.H{
+		"conference_id": output.ConferenceID,
+		"status":        output.Status,
+		"participants":  participants,
+	}
+}
+
+func conferenceErrorStatus(errorMsg string) int {
+	switch errorMsg {
+	case "conference not found":
+		return http.StatusNotFound
+	case "at least two calls are required", "call_ids must be distinct", "conference_id is required":
+		return http.StatusBadRequest
+	case "conference already ended", "call is already in a conference", "call is not in the conference":
+		return http.StatusConflict
+	case "conference is not supported":
+		return http.StatusNotImplemented
+	case "failed to join conference", "failed to mute participant", "failed to terminate call":
+		return http.StatusServiceUnavailable
+	}
+	return callControlErrorStatus(errorMsg)
+}
@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/calls"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = 30 * time.Second
+
+	sseTickPeriod      = 1 * time.Second
+	sseKeepAlivePeriod = 15 * time.Second
+)
+
+type EventsHandler struct {
+	subscriber domain.EventSubscriber
+	streamCall *calls.StreamCallUseCase
+	upgrader   websocket.Upgrader
+}
+
+func NewEventsHandler(subscriber domain.EventSubscriber, streamCall *calls.StreamCallUseCase) *EventsHandler {
+	return &EventsHandler{
+		subscriber: subscriber,
+		streamCall: streamCall,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+type wsMessage struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// WebSocket streams the user's call events. Clients resume after a reconnect
+// by passing the last event ID they received in last_event_id.
+func (h *EventsHandler) WebSocket(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	sub, err := h.subscriber.Subscribe(userID, c.Query("last_event_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "invalid last_event_id",
+		})
+		return
+	}
+	defer sub.Close()
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		slog.Warn("websocket upgrade failed", "error", err, "user_id", userID)
+		return
+	}
+	defer conn.Close()
+
+	slog.Info("websocket connected", "user_id", userID)
+
+	pings := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go h.readLoop(conn, pings, done)
+
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			if !ok {
+				h.closeConn(conn, websocket.CloseTryAgainLater, "event stream lagged, resume with last_event_id")
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-pings:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteJSON(wsMessage{Type: "pong", Timestamp: time.Now()}); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		case <-done:
+			slog.Info("websocket disconnected", "user_id", userID)
+			return
+		}
+	}
+}
+
+// readLoop keeps the read deadline fresh on pongs and answers application
+// level {"type":"ping"} heartbeats, which browsers can send but protocol
+// pings they cannot.
+func (h *EventsHandler) readLoop(conn *websocket.Conn, pings chan<- struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	conn.SetReadLimit(1024)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(wsPongWait))
+
+		var msg wsMessage
+		if json.Unmarshal(data, &msg) == nil && msg.Type == "ping" {
+			select {
+			case pings <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (h *EventsHandler) closeConn(conn *websocket.Conn, code int, reason string) {
+	conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(wsWriteWait))
+}
+
+// CallStream is the Server-Sent Events counterpart of WebSocket for a single
+// call, for clients behind proxies that break WebSockets. It sends status
+// events with IDs, so EventSource resumes via Last-Event-ID, plus duration
+// ticks while the call is active, and ends once the call is finished.
+func (h *EventsHandler) CallStream(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	output, err := h.streamCall.Execute(c.Request.Context(), calls.StreamCallInput{
+		UserID:      userID,
+		CallID:      c.Param("id"),
+		LastEventID: lastEventID,
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errorMsg := err.Error()
+
+		if errorMsg == "call not found" {
+			statusCode = http.StatusNotFound
+		} else if errorMsg == "unauthorized" {
+			statusCode = http.StatusForbidden
+		} else if errorMsg == "call_id is required" || errorMsg == "invalid event id" {
+			statusCode = http.StatusBadRequest
+		}
+
+		c.JSON(statusCode, gin.H{
+			"error":   "call_stream_failed",
+			"message": errorMsg,
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	call := output.Call
+	if lastEventID == "" || output.Subscription == nil {
+		h.writeSSE(c, "", &domain.CallEvent{
+			Type:       domain.CallEventStatusChanged,
+			CallID:     call.ID,
+			Status:     call.Status,
+			Duration:   call.Duration,
+			OccurredAt: time.Now(),
+		})
+	}
+	if output.Subscription == nil {
+		return
+	}
+	sub := output.Subscription
+	defer sub.Close()
+
+	ticker := time.NewTicker(sseTickPeriod)
+	defer ticker.Stop()
+	keepAlive := time.NewTicker(sseKeepAlivePeriod)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			if event.CallID != call.ID {
+				continue
+			}
+			h.writeSSE(c, event.ID, event)
+			if event.Type != domain.CallEventStatusChanged {
+				continue
+			}
+			call.Status = event.Status
+			if call.Status.IsTerminal() {
+				return
+			}
+		case <-ticker.C:
+			if call.Status == domain.CallStatusActive {
+				h.writeSSE(c, "", &domain.CallEvent{
+					Type:       domain.CallEventDurationTick,
+					CallID:     call.ID,
+					Status:     call.Status,
+					Duration:   int(time.Since(call.StartTime).Seconds()),
+					OccurredAt: time.Now(),
+				})
+			}
+		case <-keepAlive.C:
+			c.Writer.WriteString(": keep-alive\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+func (h *EventsHandler) writeSSE(c *gin.Context, id string, event *domain.CallEvent) {
+	c.Render(-1, sse.Event{
+		Id:    id,
+		Event: string(event.Type),
+		Data:  event,
+	})
+	c.Writer.Flush()
+}
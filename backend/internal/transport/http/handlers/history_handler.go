@@ -11,10 +11,14 @@ import (
 
 type HistoryHandler struct {
 	list *history.ListHistoryUseCase
+	get  *history.GetCallUseCase
 }
 
-func NewHistoryHandler(list *history.ListHistoryUseCase) *HistoryHandler {
-	return &HistoryHandler{list: list}
+func NewHistoryHandler(list *history.ListHistoryUseCase, get *history.GetCallUseCase) *HistoryHandler {
+	return &HistoryHandler{
+		list: list,
+		get:  get,
+	}
 }
 
 func (h *HistoryHandler) List(c *gin.Context) {
@@ -73,3 +77,42 @@ func (h *HistoryHandler) List(c *gin.Context) {
 	
 	c.JSON(http.StatusOK, output)
 }
+
+// Get returns one call with its recordings.
+func (h *HistoryHandler) Get(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	detail, err := h.get.Execute(c.Request.Context(), history.GetCallInput{
+		UserID: userID,
+		CallID: c.Param("id"),
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errorType := "call_fetch_error"
+		switch err.Error() {
+		case "call not found":
+			statusCode = http.StatusNotFound
+			errorType = "call_not_found"
+		case "unauthorized":
+			statusCode = http.StatusForbidden
+			errorType = "unauthorized"
+		case "call_id is required":
+			statusCode = http.StatusBadRequest
+			errorType = "validation_error"
+		}
+		c.JSON(statusCode, gin.H{
+			"error":   errorType,
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/numbers"
+	"github.com/gin-gonic/gin"
+)
+
+type NumbersHandler struct {
+	list  *numbers.ListNumbersUseCase
+	parse *numbers.ParseNumberUseCase
+}
+
+func NewNumbersHandler(list *numbers.ListNumbersUseCase, parse *numbers.ParseNumberUseCase) *NumbersHandler {
+	return &NumbersHandler{list: list, parse: parse}
+}
+
+func (h *NumbersHandler) List(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.list.Execute(c.Request.Context(), numbers.ListNumbersInput{UserID: userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "numbers_fetch_error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+type ParseNumberRequest struct {
+	Number string `json:"number" binding:"required"`
+	Region string `json:"region"`
+}
+
+func (h *NumbersHandler) Parse(c *gin.Context) {
+	var req ParseNumberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "number is required",
+		})
+		return
+	}
+
+	output, err := h.parse.Execute(c.Request.Context(), numbers.ParseNumberInput{
+		Number: req.Number,
+		Region: req.Region,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "number_parse_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/recordings"
+	"github.com/gin-gonic/gin"
+)
+
+type RecordingsHandler struct {
+	audio *recordings.GetAudioUseCase
+	save  *recordings.SaveRecordingUseCase
+}
+
+func NewRecordingsHandler(audio *recordings.GetAudioUseCase, save *recordings.SaveRecordingUseCase) *RecordingsHandler {
+	return &RecordingsHandler{
+		audio: audio,
+		save:  save,
+	}
+}
+
+// Audio streams a call recording with range support.
+func (h *RecordingsHandler) Audio(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.audio.Execute(c.Request.Context(), recordings.GetAudioInput{
+		UserID:      userID,
+		CallID:      c.Param("id"),
+		RecordingID: c.Param("recordingId"),
+	})
+	if err != nil {
+		c.JSON(recordingErrorStatus(err.Error()), gin.H{
+			"error":   "recording_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+	defer output.Audio.Close()
+
+	c.Header("Content-Type", output.Recording.ContentType)
+	c.Header("Cache-Control", "private, no-store")
+	http.ServeContent(c.Writer, c.Request, "", output.Recording.CreatedAt, output.Audio)
+}
+
+// RecordingStatus is the recordingStatusCallback of a recorded <Dial>.
+// Twilio retries on non-2xx, so only failures worth retrying return 500.
+func (h *RecordingsHandler) RecordingStatus(c *gin.Context) {
+	callSid := c.PostForm("CallSid")
+	recordingSid := c.PostForm("RecordingSid")
+	recordingStatus := c.PostForm("RecordingStatus")
+	slog.Info("call recording status from Twilio",
+		"CallSid", callSid,
+		"RecordingSid", recordingSid,
+		"RecordingStatus", recordingStatus,
+		"CallId", c.Query("CallId"))
+
+	if recordingStatus != "completed" {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	duration, _ := strconv.Atoi(c.PostForm("RecordingDuration"))
+	_, err := h.save.Execute(c.Request.Context(), recordings.SaveRecordingInput{
+		ProviderCallSID: callSid,
+		CallID:          c.Query("CallId"),
+		Identity:        c.Query("Identity"),
+		RecordingSID:    recordingSid,
+		RecordingURL:    c.PostForm("RecordingUrl"),
+		Duration:        duration,
+	})
+	if err != nil {
+		status := recordingErrorStatus(err.Error())
+		if status >= http.StatusInternalServerError {
+			slog.Error("failed to save call recording", "error", err, "RecordingSid", recordingSid)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		slog.Warn("call recording rejected", "error", err, "RecordingSid", recordingSid)
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func recordingErrorStatus(errorMsg string) int {
+	switch errorMsg {
+	case "recording not found", "call not found":
+		return http.StatusNotFound
+	case "unauthorized":
+		return http.StatusForbidden
+	case "recording_id is required", "provider_call_sid is required", "invalid recording_sid", "recording_url is required", "recording too large":
+		return http.StatusBadRequest
+	case "recording is not configured":
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
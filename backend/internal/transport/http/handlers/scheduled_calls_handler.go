@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/calls"
+	"github.com/gin-gonic/gin"
+)
+
+// ScheduledCallsHandler manages calls planned for a later time.
+type ScheduledCallsHandler struct {
+	schedule   *calls.ScheduleCallUseCase
+	list       *calls.ListScheduledCallsUseCase
+	get        *calls.GetScheduledCallUseCase
+	reschedule *calls.RescheduleCallUseCase
+	delete     *calls.DeleteScheduledCallUseCase
+}
+
+func NewScheduledCallsHandler(
+	schedule *calls.ScheduleCallUseCase,
+	list *calls.ListScheduledCallsUseCase,
+	get *calls.GetScheduledCallUseCase,
+	reschedule *calls.RescheduleCallUseCase,
+	delete *calls.DeleteScheduledCallUseCase,
+) *ScheduledCallsHandler {
+	return &ScheduledCallsHandler{
+		schedule:   schedule,
+		list:       list,
+		get:        get,
+		reschedule: reschedule,
+		delete:     delete,
+	}
+}
+
+type ScheduleCallRequest struct {
+	PhoneNumber  string `json:"phone_number" binding:"required"`
+	ScheduledAt  string `json:"scheduled_at" binding:"required"`
+	TimeZone     string `json:"time_zone"`
+	Mode         string `json:"mode"`
+	CallerNumber string `json:"caller_number"`
+}
+
+func (r ScheduleCallRequest) input(userID string) calls.ScheduleCallInput {
+	return calls.ScheduleCallInput{
+		UserID:       userID,
+		PhoneNumber:  r.PhoneNumber,
+		Mode:         r.Mode,
+		CallerNumber: r.CallerNumber,
+		ScheduledAt:  r.ScheduledAt,
+		TimeZone:     r.TimeZone,
+	}
+}
+
+func (h *ScheduledCallsHandler) Create(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req ScheduleCallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "phone_number and scheduled_at are required",
+		})
+		return
+	}
+
+	output, err := h.schedule.Execute(c.Request.Context(), req.input(userID))
+	if err != nil {
+		scheduledCallError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, output)
+}
+
+func (h *ScheduledCallsHandler) List(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.list.Execute(c.Request.Context(), calls.ListScheduledCallsInput{UserID: userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "scheduled_calls_fetch_error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+func (h *ScheduledCallsHandler) Get(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.get.Execute(c.Request.Context(), calls.ScheduledCallInput{
+		UserID:          userID,
+		ScheduledCallID: c.Param("id"),
+	})
+	if err != nil {
+		scheduledCallError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+func (h *ScheduledCallsHandler) Update(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req ScheduleCallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "phone_number and scheduled_at are required",
+		})
+		return
+	}
+
+	output, err := h.reschedule.Execute(c.Request.Context(), calls.RescheduleCallInput{
+		ScheduledCallID:   c.Param("id"),
+		ScheduleCallInput: req.input(userID),
+	})
+	if err != nil {
+		scheduledCallError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+func (h *ScheduledCallsHandler) Delete(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	err := h.delete.Execute(c.Request.Context(), calls.ScheduledCallInput{
+		UserID:          userID,
+		ScheduledCallID: c.Param("id"),
+	})
+	if err != nil {
+		scheduledCallError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func scheduledCallError(c *gin.Context, err error) {
+	var shortCode *domain.ShortCodeError
+	if errors.As(err, &shortCode) {
+		shortCodeError(c, shortCode)
+		return
+	}
+
+	statusCode := http.StatusInternalServerError
+	switch err.Error() {
+	case "user_id is required", "scheduled_call_id is required", "phone_number and scheduled_at are required",
+		"invalid phone number", "mode must be notify or callback", "caller_number is required",
+		"cannot call back the destination", "time_zone is required", "invalid time zone", "invalid scheduled_at",
+		"scheduled time does not exist in time zone", "scheduled time is in the past", "scheduled time is too far ahead":
+		statusCode = http.StatusBadRequest
+	case "unauthorized", "caller number is not verified":
+		statusCode = http.StatusForbidden
+	case "scheduled call not found":
+		statusCode = http.StatusNotFound
+	case domain.ErrScheduledCallNotPending.Error():
+		statusCode = http.StatusConflict
+	case "callback is not supported":
+		statusCode = http.StatusNotImplemented
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   "scheduled_call_failed",
+		"message": err.Error(),
+	})
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/settings"
+	"github.com/gin-gonic/gin"
+)
+
+// SettingsHandler manages the user's preferences.
+type SettingsHandler struct {
+	getQuietHours    *settings.GetQuietHoursUseCase
+	updateQuietHours *settings.UpdateQuietHoursUseCase
+	resetQuietHours  *settings.ResetQuietHoursUseCase
+}
+
+func NewSettingsHandler(
+	getQuietHours *settings.GetQuietHoursUseCase,
+	updateQuietHours *settings.UpdateQuietHoursUseCase,
+	resetQuietHours *settings.ResetQuietHoursUseCase,
+) *SettingsHandler {
+	return &SettingsHandler{
+		getQuietHours:    getQuietHours,
+		updateQuietHours: updateQuietHours,
+		resetQuietHours:  resetQuietHours,
+	}
+}
+
+type UpdateQuietHoursRequest struct {
+	Mode  string `json:"mode" binding:"required"`
+	Start string `json:"start" binding:"required"`
+	End   string `json:"end" binding:"required"`
+}
+
+func (h *SettingsHandler) QuietHours(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.getQuietHours.Execute(c.Request.Context(), settings.GetQuietHoursInput{UserID: userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "settings_fetch_error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+func (h *SettingsHandler) UpdateQuietHours(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req UpdateQuietHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "mode, start and end are required",
+		})
+		return
+	}
+
+	output, err := h.updateQuietHours.Execute(c.Request.Context(), settings.UpdateQuietHoursInput{
+		UserID: userID,
+		Mode:   req.Mode,
+		Start:  req.Start,
+		End:    req.End,
+	})
+	if err != nil {
+		settingsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+func (h *SettingsHandler) ResetQuietHours(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.resetQuietHours.Execute(c.Request.Context(), settings.ResetQuietHoursInput{UserID: userID})
+	if err != nil {
+		settingsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+func settingsError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	switch err.Error() {
+	case "user_id is required", "invalid quiet hours":
+		statusCode = http.StatusBadRequest
+	}
+
+	c.JSON(statusCode, gin.H{
+		"error":   "settings_failed",
+		"message": err.Error(),
+	})
+}
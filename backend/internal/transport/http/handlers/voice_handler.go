@@ -1,31 +1,56 @@
 package handlers
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/phone"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/calls"
 	"github.com/gin-gonic/gin"
 )
 
-var e164Re = regexp.MustCompile(`^\+[1-9]\d{6,14}$`)
+var (
+	clientIdentityRe = regexp.MustCompile(`^[A-Za-z0-9_.@-]{1,121}$`)
+	sipURIRe         = regexp.MustCompile(`^sip:[^\s<>"]+$`)
+)
 
 type VoiceHandler struct {
 	tokenGenerator     TokenGenerator
 	voicePublicBaseURL string
 	dialCallerID       string
+	inboundRingTimeout int
+	recordingPolicy    *domain.RecordingPolicy
+	shortCodes         *calls.ShortCodeGuard
+	updateStatus       *calls.UpdateCallStatusUseCase
+	receive            *calls.ReceiveCallUseCase
+	bridgeCallback     *calls.BridgeCallbackUseCase
+	callerID           *calls.GetCallerIDUseCase
+	linkCall           *calls.LinkProviderCallUseCase
 }
 
 type TokenGenerator interface {
 	GetToken(identity string, ttlSec int) (string, error)
 }
 
-func NewVoiceHandler(tokenGenerator TokenGenerator, voicePublicBaseURL, dialCallerID string) *VoiceHandler {
+func NewVoiceHandler(tokenGenerator TokenGenerator, voicePublicBaseURL, dialCallerID string, inboundRingTimeout int, recordingPolicy *domain.RecordingPolicy, shortCodes *calls.ShortCodeGuard, updateStatus *calls.UpdateCallStatusUseCase, receive *calls.ReceiveCallUseCase, bridgeCallback *calls.BridgeCallbackUseCase, callerID *calls.GetCallerIDUseCase, linkCall *calls.LinkProviderCallUseCase) *VoiceHandler {
 	return &VoiceHandler{
 		tokenGenerator:     tokenGenerator,
 		voicePublicBaseURL: voicePublicBaseURL,
 		dialCallerID:       dialCallerID,
+		inboundRingTimeout: inboundRingTimeout,
+		recordingPolicy:    recordingPolicy,
+		shortCodes:         shortCodes,
+		updateStatus:       updateStatus,
+		receive:            receive,
+		bridgeCallback:     bridgeCallback,
+		callerID:           callerID,
+		linkCall:           linkCall,
 	}
 }
 
@@ -57,33 +82,468 @@ func (h *VoiceHandler) Token(c *gin.Context) {
 }
 
 func (h *VoiceHandler) TwiML(c *gin.Context) {
+	if bridge := c.Query("Bridge"); bridge != "" {
+		h.bridgeTwiML(c, bridge)
+		return
+	}
+
 	to := c.PostForm("To")
 	if to == "" {
 		to = c.Query("To")
 	}
-	slog.Info("twiml request from Twilio", "To", to, "method", c.Request.Method)
-	if to == "" || !e164Re.MatchString(to) {
-		slog.Warn("twiml invalid or missing To", "To", to)
-		c.Data(http.StatusOK, "application/xml", []byte(`<?xml version="1.0" encoding="UTF-8"?><Response><Say language="en-US">Invalid or missing phone number.</Say><Hangup/></Response>`))
+	region := c.PostForm("Region")
+	if region == "" {
+		region = c.Query("Region")
+	}
+	slog.Info("twiml request from Twilio", "To", to, "Region", region, "method", c.Request.Method)
+	// Short codes are checked again here, since the client dials whatever
+	// it connects with. The audit entry is linked to the call when known.
+	shortCode, err := h.shortCodes.Check(c.Request.Context(), calls.ShortCodeAttempt{
+		UserID:      strings.TrimPrefix(c.PostForm("From"), "client:"),
+		PhoneNumber: to,
+		Region:      region,
+		CallID:      c.PostForm("CallId"),
+	})
+	var refused *domain.ShortCodeError
+	if errors.As(err, &refused) {
+		c.Data(http.StatusOK, "application/xml", []byte(`<?xml version="1.0" encoding="UTF-8"?><Response><Say language="en-US">`+escapeXML(refused.Guidance())+`</Say><Hangup/></Response>`))
+		return
+	}
+	if shortCode != nil {
+		to = shortCode.Number
+	} else {
+		// Voice SDK clients may connect with the number as the user typed
+		// it, so it is normalized the same way as at /api/calls/initiate.
+		parsed, err := phone.Parse(to, region)
+		if to == "" || err != nil {
+			slog.Warn("twiml invalid or missing To", "To", to, "error", err)
+			c.Data(http.StatusOK, "application/xml", []byte(`<?xml version="1.0" encoding="UTF-8"?><Response><Say language="en-US">Invalid or missing phone number.</Say><Hangup/></Response>`))
+			return
+		}
+		to = parsed.E164
+	}
+	// Voice SDK clients pass Record and the CallId from /api/calls/initiate
+	// as connect parameters. The provider call is linked to our call here,
+	// so status callbacks find it; the recording callback still carries the
+	// call and the client identity.
+	record, announce := h.recording(c.Query("Record") == "true" || c.PostForm("Record") == "true", to)
+	callback := url.Values{}
+	callerID := ""
+	if callID := c.PostForm("CallId"); callID != "" && strings.HasPrefix(c.PostForm("From"), "client:") {
+		identity := strings.TrimPrefix(c.PostForm("From"), "client:")
+		callback.Set("CallId", callID)
+		callback.Set("Identity", identity)
+		h.linkProviderCall(c, callID, identity)
+		callerID = h.callCallerID(c, callID, identity)
+	}
+
+	// The consent announcement has to reach the called party, so it runs on
+	// the <Number> leg once it answers.
+	number := `<Number>`
+	if announce {
+		number = `<Number url="` + escapeXML(h.voiceURL("/api/voice/recording/consent")) + `">`
+	}
+
+	c.Header("Content-Type", "application/xml; charset=utf-8")
+	c.String(http.StatusOK, h.dialTwiML(callerID, "", number+escapeXML(to)+`</Number>`, record, callback))
+	slog.Info("twiml returned Dial", "To", to, "record", record, "caller_id", callerID)
+}
+
+// linkProviderCall stores the CallSid of a Voice SDK call on our call. The
+// call is dialled either way; without the link its status is only updated
+// by the client.
+func (h *VoiceHandler) linkProviderCall(c *gin.Context, callID, identity string) {
+	callSid := c.PostForm("CallSid")
+	if h.linkCall == nil || callSid == "" {
 		return
 	}
-	escaped := escapeXML(to)
+	err := h.linkCall.Execute(c.Request.Context(), calls.LinkProviderCallInput{
+		UserID:          identity,
+		CallID:          callID,
+		ProviderCallSID: callSid,
+	})
+	if err != nil {
+		slog.Warn("twiml failed to link provider call", "CallId", callID, "CallSid", callSid, "error", err)
+	}
+}
+
+// callCallerID is the verified caller ID picked for the user's call at
+// /api/calls/initiate. Empty dials from the provider's own number.
+func (h *VoiceHandler) callCallerID(c *gin.Context, callID, identity string) string {
+	if h.callerID == nil {
+		return ""
+	}
+	callerID, err := h.callerID.Execute(c.Request.Context(), calls.GetCallerIDInput{
+		UserID: identity,
+		CallID: callID,
+	})
+	if err != nil {
+		slog.Warn("twiml caller id lookup failed", "CallId", callID, "error", err)
+		return ""
+	}
+	return callerID
+}
+
+// bridgeTwiML answers REST-originated calls: once the destination picks up,
+// it is connected to the bridge target passed in the call flow URL.
+func (h *VoiceHandler) bridgeTwiML(c *gin.Context, bridge string) {
+	answeredBy := c.PostForm("AnsweredBy")
+	slog.Info("twiml bridge request from Twilio",
+		"Bridge", bridge,
+		"CallSid", c.PostForm("CallSid"),
+		"AnsweredBy", answeredBy)
+
+	if answeredBy == "fax" {
+		c.Data(http.StatusOK, "application/xml", []byte(hangupTwiML))
+		return
+	}
+	// An answering machine gets nobody to talk to; what it plays is kept as a
+	// voicemail on the call instead.
+	if strings.HasPrefix(answeredBy, "machine") {
+		c.Header("Content-Type", "application/xml; charset=utf-8")
+		c.String(http.StatusOK, h.voicemailTwiML(""))
+		return
+	}
+
+	noun, ok := bridgeNoun(bridge)
+	if !ok {
+		slog.Warn("twiml invalid bridge target", "Bridge", bridge)
+		c.Data(http.StatusOK, "application/xml", []byte(`<?xml version="1.0" encoding="UTF-8"?><Response><Say language="en-US">The call could not be connected.</Say><Hangup/></Response>`))
+		return
+	}
+
+	// The destination answered this call, so it hears the announcement
+	// before being bridged. Resume re-bridges a call after DTMF was played
+	// into it; the destination has heard the announcement then.
+	record, announce := h.recording(c.Query("Record") == "true", c.PostForm("To"))
+	before := ""
+	if announce && c.Query("Resume") != "true" {
+		before = recordingAnnouncement
+	}
+
+	c.Header("Content-Type", "application/xml; charset=utf-8")
+	c.String(http.StatusOK, h.dialTwiML("", before, noun, record, nil))
+	slog.Info("twiml returned bridge Dial", "Bridge", bridge, "record", record)
+}
+
+// recording applies the destination's recording policy to a requested
+// recording: whether to record, and whether the called party must hear the
+// consent announcement first.
+func (h *VoiceHandler) recording(requested bool, destination string) (record, announce bool) {
+	if !requested {
+		return false, false
+	}
+	switch h.recordingPolicy.Rule(destination) {
+	case domain.RecordingForbidden:
+		slog.Warn("recording skipped, not allowed for destination", "To", destination)
+		return false, false
+	case domain.RecordingConsent:
+		return true, true
+	}
+	return true, false
+}
+
+// RecordingConsent is the TwiML the called party hears before a recorded
+// Voice SDK call is connected.
+func (h *VoiceHandler) RecordingConsent(c *gin.Context) {
+	c.Data(http.StatusOK, "application/xml", []byte(`<?xml version="1.0" encoding="UTF-8"?><Response>`+recordingAnnouncement+`</Response>`))
+}
+
+// dialTwiML dials from callerID, falling back to the configured
+// VOIP_FROM_NUMBER when it is empty.
+func (h *VoiceHandler) dialTwiML(callerID, before, noun string, record bool, recordingCallback url.Values) string {
 	var dialAttrs []string
-	if h.dialCallerID != "" && e164Re.MatchString(h.dialCallerID) {
-		dialAttrs = append(dialAttrs, `callerId="`+escapeXML(h.dialCallerID)+`"`)
+	if callerID == "" {
+		callerID = h.dialCallerID
+	}
+	if callerID != "" && phone.IsE164(callerID) {
+		dialAttrs = append(dialAttrs, `callerId="`+escapeXML(callerID)+`"`)
 	}
 	if h.voicePublicBaseURL != "" {
 		statusURL := strings.TrimSuffix(h.voicePublicBaseURL, "/") + "/api/voice/status"
 		dialAttrs = append(dialAttrs, `statusCallback="`+escapeXML(statusURL)+`"`, `statusCallbackEvent="initiated ringing answered completed"`)
 	}
+	if record {
+		callbackURL := h.voiceURL("/api/voice/recording")
+		if len(recordingCallback) > 0 {
+			callbackURL += "?" + recordingCallback.Encode()
+		}
+		dialAttrs = append(dialAttrs,
+			`record="record-from-answer-dual"`,
+			`recordingStatusCallback="`+escapeXML(callbackURL)+`"`,
+			`recordingStatusCallbackEvent="completed"`)
+	}
 	dialAttrStr := ""
 	if len(dialAttrs) > 0 {
 		dialAttrStr = " " + strings.Join(dialAttrs, " ")
 	}
-	twiml := `<?xml version="1.0" encoding="UTF-8"?><Response><Dial` + dialAttrStr + `><Number>` + escaped + `</Number></Dial></Response>`
+	return `<?xml version="1.0" encoding="UTF-8"?><Response>` + before + `<Dial` + dialAttrStr + `>` + noun + `</Dial></Response>`
+}
+
+func bridgeNoun(target string) (string, bool) {
+	switch {
+	case strings.HasPrefix(target, "client:"):
+		identity := strings.TrimPrefix(target, "client:")
+		if !clientIdentityRe.MatchString(identity) {
+			return "", false
+		}
+		return `<Client>` + escapeXML(identity) + `</Client>`, true
+	case strings.HasPrefix(target, "sip:"):
+		if !sipURIRe.MatchString(target) {
+			return "", false
+		}
+		return `<Sip>` + escapeXML(target) + `</Sip>`, true
+	case phone.IsE164(target):
+		return `<Number>` + escapeXML(target) + `</Number>`, true
+	}
+	return "", false
+}
+
+const (
+	notInServiceTwiML = `<?xml version="1.0" encoding="UTF-8"?><Response><Say language="en-US">The number you have called is not in service.</Say><Hangup/></Response>`
+	notConnectedTwiML = `<?xml version="1.0" encoding="UTF-8"?><Response><Say language="en-US">The call could not be connected.</Say><Hangup/></Response>`
+	hangupTwiML       = `<?xml version="1.0" encoding="UTF-8"?><Response><Hangup/></Response>`
+
+	voicemailPrompt = "The person you are calling is not available. Please leave a message after the tone."
+
+	recordingAnnouncement = `<Say language="en-US">This call is being recorded.</Say>`
+)
+
+// Inbound answers calls to a user's number (the number's Voice URL in
+// Twilio) by ringing the owner's browser client. If the browser does not pick
+// up within the ring timeout, InboundFallback takes a voicemail.
+func (h *VoiceHandler) Inbound(c *gin.Context) {
+	callSid := c.PostForm("CallSid")
+	to := c.PostForm("To")
+	from := c.PostForm("From")
+	slog.Info("inbound call from Twilio", "CallSid", callSid, "To", to, "From", from)
+
+	if h.receive == nil {
+		c.Data(http.StatusOK, "application/xml", []byte(notInServiceTwiML))
+		return
+	}
+
+	output, err := h.receive.Execute(c.Request.Context(), calls.ReceiveCallInput{
+		To:              to,
+		From:            from,
+		ProviderCallSID: callSid,
+	})
+	if err != nil {
+		if err.Error() == "number not assigned" {
+			c.Data(http.StatusOK, "application/xml", []byte(notInServiceTwiML))
+			return
+		}
+		slog.Error("failed to receive inbound call", "error", err, "CallSid", callSid)
+		c.Data(http.StatusOK, "application/xml", []byte(notConnectedTwiML))
+		return
+	}
+
+	if !clientIdentityRe.MatchString(output.UserID) {
+		slog.Error("number owner is not a valid client identity", "user_id", output.UserID)
+		c.Data(http.StatusOK, "application/xml", []byte(notConnectedTwiML))
+		return
+	}
+
+	dialAttrs := []string{
+		`action="` + escapeXML(h.voiceURL("/api/voice/inbound/fallback")) + `"`,
+		`timeout="` + strconv.Itoa(h.ringTimeout()) + `"`,
+	}
+	// The caller's number is shown in the browser, as on a phone.
+	if phone.IsE164(from) {
+		dialAttrs = append(dialAttrs, `callerId="`+escapeXML(from)+`"`)
+	}
+	client := `<Client statusCallbackEvent="answered" statusCallback="` + escapeXML(h.voiceURL("/api/voice/inbound/status")) + `">` + escapeXML(output.UserID) + `</Client>`
+
+	c.Header("Content-Type", "application/xml; charset=utf-8")
+	c.String(http.StatusOK, `<?xml version="1.0" encoding="UTF-8"?><Response><Dial `+strings.Join(dialAttrs, " ")+`>`+client+`</Dial></Response>`)
+	slog.Info("twiml returned inbound Dial", "CallSid", callSid, "call_id", output.CallID)
+}
+
+// InboundStatus follows the browser leg of an inbound call. Twilio reports it
+// under the leg's own CallSid; the call is found by ParentCallSid.
+func (h *VoiceHandler) InboundStatus(c *gin.Context) {
+	parentCallSid := c.PostForm("ParentCallSid")
+	callStatus := c.PostForm("CallStatus")
+	slog.Info("inbound leg status from Twilio", "ParentCallSid", parentCallSid, "CallStatus", callStatus)
+
+	if h.updateStatus != nil && parentCallSid != "" && (callStatus == "in-progress" || callStatus == "answered") {
+		_, err := h.updateStatus.Execute(c.Request.Context(), calls.UpdateCallStatusInput{
+			ProviderCallSID: parentCallSid,
+			Status:          domain.CallStatusActive,
+		})
+		if err != nil && err.Error() != "call not found" {
+			slog.Error("failed to apply inbound leg status", "error", err, "ParentCallSid", parentCallSid)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// InboundFallback runs when the <Dial> to the browser ends. An answered call
+// is finished; otherwise the browser was offline, busy or did not pick up in
+// time, and the caller can leave a voicemail.
+func (h *VoiceHandler) InboundFallback(c *gin.Context) {
+	callSid := c.PostForm("CallSid")
+	dialCallStatus := c.PostForm("DialCallStatus")
+	slog.Info("inbound dial finished", "CallSid", callSid, "DialCallStatus", dialCallStatus)
+
+	status, ok := callStatusFromProvider(dialCallStatus)
+	if h.updateStatus != nil && callSid != "" && ok {
+		duration, _ := strconv.Atoi(c.PostForm("DialCallDuration"))
+		_, err := h.updateStatus.Execute(c.Request.Context(), calls.UpdateCallStatusInput{
+			ProviderCallSID: callSid,
+			Status:          status,
+			Duration:        duration,
+		})
+		if err != nil && err.Error() != "call not found" {
+			slog.Error("failed to apply inbound dial status", "error", err, "CallSid", callSid)
+		}
+	}
+
+	c.Header("Content-Type", "application/xml; charset=utf-8")
+	switch dialCallStatus {
+	case "no-answer", "busy", "failed":
+		c.String(http.StatusOK, h.voicemailTwiML(voicemailPrompt))
+	default:
+		c.String(http.StatusOK, hangupTwiML)
+	}
+}
+
+// TransferStatus marks a blind transfer leg active once its target answers.
+func (h *VoiceHandler) TransferStatus(c *gin.Context) {
+	callID := c.Query("CallID")
+	callStatus := c.PostForm("CallStatus")
+	slog.Info("transfer leg status from Twilio", "CallID", callID, "CallStatus", callStatus)
+
+	if h.updateStatus != nil && callID != "" && (callStatus == "in-progress" || callStatus == "answered") {
+		_, err := h.updateStatus.Execute(c.Request.Context(), calls.UpdateCallStatusInput{
+			CallID: callID,
+			Status: domain.CallStatusActive,
+		})
+		if err != nil && err.Error() != "call not found" {
+			slog.Error("failed to apply transfer leg status", "error", err, "CallID", callID)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TransferFinished runs when the <Dial> of a blind transfer ends. The leg
+// gets its final status and duration, and the called party's call ends too.
+func (h *VoiceHandler) TransferFinished(c *gin.Context) {
+	callID := c.Query("CallID")
+	dialCallStatus := c.PostForm("DialCallStatus")
+	slog.Info("transfer dial finished", "CallID", callID, "DialCallStatus", dialCallStatus)
+
+	status, ok := callStatusFromProvider(dialCallStatus)
+	if h.updateStatus != nil && callID != "" && ok {
+		duration, _ := strconv.Atoi(c.PostForm("DialCallDuration"))
+		_, err := h.updateStatus.Execute(c.Request.Context(), calls.UpdateCallStatusInput{
+			CallID:   callID,
+			Status:   status,
+			Duration: duration,
+		})
+		if err != nil && err.Error() != "call not found" {
+			slog.Error("failed to apply transfer dial status", "error", err, "CallID", callID)
+		}
+	}
+
+	c.Header("Content-Type", "application/xml; charset=utf-8")
+	c.String(http.StatusOK, hangupTwiML)
+}
+
+// Callback runs when the caller's phone answers a callback call. The
+// destination is dialled as a new leg, showing the caller's own verified
+// number, and the leg's status is followed by CallbackStatus.
+func (h *VoiceHandler) Callback(c *gin.Context) {
+	callID := c.Query("CallID")
+	slog.Info("callback call answered", "CallID", callID, "CallSid", c.PostForm("CallSid"))
+
+	if h.bridgeCallback == nil {
+		c.Data(http.StatusOK, "application/xml", []byte(notConnectedTwiML))
+		return
+	}
+
+	output, err := h.bridgeCallback.Execute(c.Request.Context(), calls.BridgeCallbackInput{CallID: callID})
+	if err != nil {
+		slog.Warn("failed to bridge callback call", "error", err, "CallID", callID)
+		c.Data(http.StatusOK, "application/xml", []byte(notConnectedTwiML))
+		return
+	}
+
+	statusURL := h.voiceURL("/api/voice/callback/status") + "?" + url.Values{"CallID": {output.CallID}}.Encode()
 	c.Header("Content-Type", "application/xml; charset=utf-8")
-	c.String(http.StatusOK, twiml)
-	slog.Info("twiml returned Dial", "To", to)
+	c.String(http.StatusOK, `<?xml version="1.0" encoding="UTF-8"?><Response>`+
+		`<Say language="en-US">Connecting your call.</Say>`+
+		`<Dial callerId="`+escapeXML(output.CallerNumber)+`">`+
+		`<Number statusCallbackEvent="initiated ringing answered completed" statusCallbackMethod="POST" statusCallback="`+escapeXML(statusURL)+`">`+
+		escapeXML(output.PhoneNumber)+`</Number></Dial></Response>`)
+	slog.Info("twiml returned callback Dial", "CallID", callID, "leg_id", output.CallID)
+}
+
+// CallbackStatus follows the destination leg of a callback call.
+func (h *VoiceHandler) CallbackStatus(c *gin.Context) {
+	callID := c.Query("CallID")
+	callStatus := c.PostForm("CallStatus")
+	slog.Info("callback leg status from Twilio", "CallID", callID, "CallStatus", callStatus)
+
+	status, ok := callStatusFromProvider(callStatus)
+	if h.updateStatus != nil && callID != "" && ok {
+		duration, _ := strconv.Atoi(c.PostForm("CallDuration"))
+		_, err := h.updateStatus.Execute(c.Request.Context(), calls.UpdateCallStatusInput{
+			CallID:   callID,
+			Status:   status,
+			Duration: duration,
+		})
+		if err != nil && err.Error() != "call not found" {
+			slog.Error("failed to apply callback leg status", "error", err, "CallID", callID)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// voicemailTwiML records a message. The recording is saved from its status
+// callback once Twilio has stored it; the <Record> action only ends the call,
+// as without an action Twilio would request the current TwiML again.
+func (h *VoiceHandler) voicemailTwiML(prompt string) string {
+	say := ""
+	if prompt != "" {
+		say = `<Say language="en-US">` + escapeXML(prompt) + `</Say>`
+	}
+	return `<?xml version="1.0" encoding="UTF-8"?><Response>` + say +
+		`<Record maxLength="120" playBeep="true" finishOnKey="#"` +
+		` action="` + escapeXML(h.voiceURL("/api/voice/hangup")) + `"` +
+		` recordingStatusCallback="` + escapeXML(h.voiceURL("/api/voice/voicemail")) + `"` +
+		` recordingStatusCallbackEvent="completed"/>` +
+		`<Hangup/></Response>`
+}
+
+// Hangup ends the call; it is the action of the voicemail <Record>.
+func (h *VoiceHandler) Hangup(c *gin.Context) {
+	c.Data(http.StatusOK, "application/xml", []byte(hangupTwiML))
+}
+
+// voiceURL makes a callback URL absolute when the public base URL is known;
+// Twilio resolves relative URLs against the current TwiML request.
+func (h *VoiceHandler) voiceURL(path string) string {
+	if h.voicePublicBaseURL == "" {
+		return path
+	}
+	return strings.TrimSuffix(h.voicePublicBaseURL, "/") + path
+}
+
+func (h *VoiceHandler) ringTimeout() int {
+	if h.inboundRingTimeout <= 0 {
+		return 20
+	}
+	return h.inboundRingTimeout
 }
 
 func (h *VoiceHandler) VoiceStatusCallback(c *gin.Context) {
@@ -96,9 +556,49 @@ func (h *VoiceHandler) VoiceStatusCallback(c *gin.Context) {
 		"DialCallStatus", dialCallStatus,
 		"CallStatus", callStatus,
 		"To", to)
+
+	// <Dial> callbacks of Voice SDK calls are about the dialled leg, whose
+	// parent is the provider call linked at /api/voice/twiml.
+	if parent := c.PostForm("ParentCallSid"); parent != "" {
+		callSid = parent
+	}
+
+	status, ok := callStatusFromProvider(callStatus)
+	if h.updateStatus != nil && callSid != "" && ok {
+		duration, _ := strconv.Atoi(c.PostForm("CallDuration"))
+		_, err := h.updateStatus.Execute(c.Request.Context(), calls.UpdateCallStatusInput{
+			ProviderCallSID: callSid,
+			Status:          status,
+			Duration:        duration,
+		})
+		if err != nil && err.Error() != "call not found" {
+			slog.Error("failed to apply voice status callback", "error", err, "CallSid", callSid)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+	}
+
 	c.Status(http.StatusNoContent)
 }
 
+// callStatusFromProvider maps Twilio call statuses, which the mock provider
+// also emits, to call statuses.
+func callStatusFromProvider(callStatus string) (domain.CallStatus, bool) {
+	switch strings.ToLower(callStatus) {
+	case "queued", "initiated", "ringing":
+		return domain.CallStatusConnecting, true
+	case "in-progress", "answered":
+		return domain.CallStatusActive, true
+	case "completed":
+		return domain.CallStatusCompleted, true
+	case "busy", "no-answer", "failed":
+		return domain.CallStatusFailed, true
+	case "canceled":
+		return domain.CallStatusCanceled, true
+	}
+	return "", false
+}
+
 func escapeXML(s string) string {
 	const (
 		amp  = "&amp;"
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/voicemail"
+	"github.com/gin-gonic/gin"
+)
+
+type VoicemailHandler struct {
+	list     *voicemail.ListVoicemailUseCase
+	audio    *voicemail.GetAudioUseCase
+	markRead *voicemail.MarkReadUseCase
+	delete   *voicemail.DeleteVoicemailUseCase
+	save     *voicemail.SaveVoicemailUseCase
+}
+
+func NewVoicemailHandler(list *voicemail.ListVoicemailUseCase, audio *voicemail.GetAudioUseCase, markRead *voicemail.MarkReadUseCase, delete *voicemail.DeleteVoicemailUseCase, save *voicemail.SaveVoicemailUseCase) *VoicemailHandler {
+	return &VoicemailHandler{
+		list:     list,
+		audio:    audio,
+		markRead: markRead,
+		delete:   delete,
+		save:     save,
+	}
+}
+
+func (h *VoicemailHandler) List(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.list.Execute(c.Request.Context(), voicemail.ListVoicemailInput{UserID: userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "voicemail_fetch_error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// Audio streams the recording. Range requests are supported, so the browser
+// can seek in an <audio> element.
+func (h *VoicemailHandler) Audio(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.audio.Execute(c.Request.Context(), voicemail.GetAudioInput{
+		UserID:      userID,
+		VoicemailID: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(voicemailErrorStatus(err.Error()), gin.H{
+			"error":   "voicemail_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+	defer output.Audio.Close()
+
+	c.Header("Content-Type", output.Voicemail.ContentType)
+	c.Header("Cache-Control", "private, no-store")
+	http.ServeContent(c.Writer, c.Request, "", output.Voicemail.CreatedAt, output.Audio)
+}
+
+func (h *VoicemailHandler) MarkRead(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	item, err := h.markRead.Execute(c.Request.Context(), voicemail.MarkReadInput{
+		UserID:      userID,
+		VoicemailID: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(voicemailErrorStatus(err.Error()), gin.H{
+			"error":   "voicemail_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *VoicemailHandler) Delete(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	err := h.delete.Execute(c.Request.Context(), voicemail.DeleteVoicemailInput{
+		UserID:      userID,
+		VoicemailID: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(voicemailErrorStatus(err.Error()), gin.H{
+			"error":   "voicemail_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RecordingStatus is the recordingStatusCallback of the voicemail <Record>.
+// Twilio retries on non-2xx, so only failures worth retrying return 500.
+func (h *VoicemailHandler) RecordingStatus(c *gin.Context) {
+	callSid := c.PostForm("CallSid")
+	recordingSid := c.PostForm("RecordingSid")
+	recordingStatus := c.PostForm("RecordingStatus")
+	slog.Info("voicemail recording status from Twilio",
+		"CallSid", callSid,
+		"RecordingSid", recordingSid,
+		"RecordingStatus", recordingStatus)
+
+	if recordingStatus != "completed" {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	duration, _ := strconv.Atoi(c.PostForm("RecordingDuration"))
+	_, err := h.save.Execute(c.Request.Context(), voicemail.SaveVoicemailInput{
+		ProviderCallSID: callSid,
+		RecordingSID:    recordingSid,
+		RecordingURL:    c.PostForm("RecordingUrl"),
+		Duration:        duration,
+	})
+	if err != nil {
+		status := voicemailErrorStatus(err.Error())
+		if status >= http.StatusInternalServerError {
+			slog.Error("failed to save voicemail", "error", err, "RecordingSid", recordingSid)
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		slog.Warn("voicemail recording rejected", "error", err, "RecordingSid", recordingSid)
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func voicemailErrorStatus(errorMsg string) int {
+	switch errorMsg {
+	case "voicemail not found", "call not found":
+		return http.StatusNotFound
+	case "unauthorized":
+		return http.StatusForbidden
+	case "voicemail_id is required", "provider_call_sid is required", "invalid recording_sid", "recording_url is required", "recording too large":
+		return http.StatusBadRequest
+	case "voicemail is not configured":
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -1,24 +1,57 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/sdp"
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/use_cases/calls"
 	"github.com/gin-gonic/gin"
 )
 
 type WebRTCHandler struct {
-	initiate  *calls.InitiateCallUseCase
-	terminate *calls.TerminateCallUseCase
+	initiate       *calls.InitiateCallUseCase
+	terminate      *calls.TerminateCallUseCase
+	answer         *calls.AnswerCallUseCase
+	addCandidate   *calls.AddCandidateUseCase
+	listCandidates *calls.ListCandidatesUseCase
+	iceConfig      ICEConfigProvider
 }
 
-func NewWebRTCHandler(initiate *calls.InitiateCallUseCase, terminate *calls.TerminateCallUseCase) *WebRTCHandler {
+type ICEConfigProvider interface {
+	WebRTCConfig(userID string) (*domain.WebRTCConfig, error)
+}
+
+func NewWebRTCHandler(initiate *calls.InitiateCallUseCase, terminate *calls.TerminateCallUseCase, answer *calls.AnswerCallUseCase, addCandidate *calls.AddCandidateUseCase, listCandidates *calls.ListCandidatesUseCase, iceConfig ICEConfigProvider) *WebRTCHandler {
 	return &WebRTCHandler{
-		initiate:  initiate,
-		terminate: terminate,
+		initiate:       initiate,
+		terminate:      terminate,
+		answer:         answer,
+		addCandidate:   addCandidate,
+		listCandidates: listCandidates,
+		iceConfig:      iceConfig,
 	}
 }
 
+// sdpErrorCodes gives each SDP validation failure its own error code, so the
+// client can tell which part of its answer was rejected.
+var sdpErrorCodes = []struct {
+	err  error
+	code string
+}{
+	{sdp.ErrNoAudio, "sdp_no_audio"},
+	{sdp.ErrUnsupportedProtocol, "sdp_unsupported_protocol"},
+	{sdp.ErrNoSupportedCodec, "sdp_unsupported_codec"},
+	{sdp.ErrMissingFingerprint, "sdp_missing_fingerprint"},
+	{sdp.ErrInvalidFingerprint, "sdp_invalid_fingerprint"},
+	{sdp.ErrMissingICECredentials, "sdp_missing_ice_credentials"},
+	{sdp.ErrInvalidICECredentials, "sdp_invalid_ice_credentials"},
+	{sdp.ErrInvalidSetup, "sdp_invalid_setup"},
+	{sdp.ErrMalformed, "sdp_malformed"},
+}
+
 func (h *WebRTCHandler) Initiate(c *gin.Context) {
 	userID := c.GetString("userID")
 	if userID == "" {
@@ -31,6 +64,11 @@ func (h *WebRTCHandler) Initiate(c *gin.Context) {
 
 	var req struct {
 		PhoneNumber string `json:"phone_number" binding:"required"`
+		Region      string `json:"region"`
+		Record      bool   `json:"record"`
+		CallerID    string `json:"caller_id"`
+		// ConfirmOffHours places the call in the destination's quiet hours.
+		ConfirmOffHours bool `json:"confirm_off_hours"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -43,13 +81,37 @@ func (h *WebRTCHandler) Initiate(c *gin.Context) {
 	output, err := h.initiate.Execute(c.Request.Context(), calls.InitiateCallInput{
 		UserID:      userID,
 		PhoneNumber: req.PhoneNumber,
+		Region:      req.Region,
+		Record:      req.Record,
+		CallerID:    req.CallerID,
+		// Only needed in confirm mode; warn mode reports off hours in the response.
+		ConfirmOffHours: req.ConfirmOffHours,
 	})
 	if err != nil {
+		var offHours *domain.OffHoursError
+		if errors.As(err, &offHours) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":      "off_hours_confirmation_required",
+				"message":    err.Error(),
+				"local_time": offHours.LocalTime.Format(time.RFC3339),
+				"time_zone":  offHours.TimeZone,
+			})
+			return
+		}
+		var shortCode *domain.ShortCodeError
+		if errors.As(err, &shortCode) {
+			shortCodeError(c, shortCode)
+			return
+		}
+
 		statusCode := http.StatusInternalServerError
 		errorMsg := err.Error()
 
-		if errorMsg == "phone_number is required" || errorMsg == "user_id is required" || errorMsg == "invalid phone number" {
+		if errorMsg == "phone_number is required" || errorMsg == "user_id is required" || errorMsg == "invalid phone number" || errorMsg == domain.ErrRecordingForbidden.Error() ||
+			errorMsg == "region is required for national numbers" || errorMsg == "unknown region" {
 			statusCode = http.StatusBadRequest
+		} else if errorMsg == domain.ErrCallerIDNotVerified.Error() {
+			statusCode = http.StatusForbidden
 		} else if errorMsg == "failed to initiate call" {
 			statusCode = http.StatusServiceUnavailable
 		}
@@ -62,18 +124,58 @@ func (h *WebRTCHandler) Initiate(c *gin.Context) {
 	}
 
 	resp := gin.H{
-		"call_id":    output.CallID,
-		"session_id": output.SessionID,
-		"sdp_offer":  output.SDPOffer,
-		"status":     output.Status,
-		"start_time": output.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+		"call_id":      output.CallID,
+		"session_id":   output.SessionID,
+		"sdp_offer":    output.SDPOffer,
+		"status":       output.Status,
+		"start_time":   output.StartTime.Format(time.RFC3339),
+		"record":       output.Record,
+		"phone_number": output.PhoneNumber,
+	}
+	if output.Number != nil {
+		resp["number_type"] = output.Number.Type
+		if output.Number.Country != "" {
+			resp["country"] = output.Number.Country
+		}
+	}
+	if output.ShortCode != nil {
+		resp["short_code"] = gin.H{
+			"kind":    output.ShortCode.Kind,
+			"service": output.ShortCode.Service,
+		}
 	}
 	if output.VoiceToken != "" {
 		resp["voice_token"] = output.VoiceToken
 	}
+	if output.CallerID != "" {
+		resp["caller_id"] = output.CallerID
+	}
+	if output.OffHours != nil {
+		resp["off_hours"] = gin.H{
+			"local_time": output.OffHours.LocalTime.Format(time.RFC3339),
+			"time_zone":  output.OffHours.TimeZone,
+		}
+	}
 	c.JSON(http.StatusOK, resp)
 }
 
+// shortCodeError tells the user why an emergency or service short code was
+// not dialled and where to dial it instead.
+func shortCodeError(c *gin.Context, err *domain.ShortCodeError) {
+	resp := gin.H{
+		"error":    "emergency_not_supported",
+		"message":  err.Error(),
+		"guidance": err.Guidance(),
+		"number":   err.Number,
+		"kind":     err.Kind,
+		"service":  err.Service,
+	}
+	if err.Country != "" {
+		resp["country"] = err.Country
+	}
+	c.JSON(http.StatusBadRequest, resp)
+}
+
 func (h *WebRTCHandler) Terminate(c *gin.Context) {
 	userID := c.GetString("userID")
 	if userID == "" {
@@ -109,6 +211,8 @@ func (h *WebRTCHandler) Terminate(c *gin.Context) {
 			statusCode = http.StatusForbidden
 		} else if errorMsg == "call_id is required" {
 			statusCode = http.StatusBadRequest
+		} else if errorMsg == "failed to terminate call" {
+			statusCode = http.StatusServiceUnavailable
 		}
 
 		c.JSON(statusCode, gin.H{
@@ -125,3 +229,191 @@ func (h *WebRTCHandler) Terminate(c *gin.Context) {
 	})
 }
 
+func (h *WebRTCHandler) Answer(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req struct {
+		SDPAnswer string `json:"sdp_answer" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "sdp_answer is required",
+		})
+		return
+	}
+
+	output, err := h.answer.Execute(c.Request.Context(), calls.AnswerCallInput{
+		UserID:    userID,
+		CallID:    c.Param("id"),
+		SDPAnswer: req.SDPAnswer,
+	})
+	if err != nil {
+		for _, e := range sdpErrorCodes {
+			if errors.Is(err, e.err) {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error":   e.code,
+					"message": err.Error(),
+				})
+				return
+			}
+		}
+
+		statusCode := http.StatusInternalServerError
+		errorMsg := err.Error()
+
+		if errorMsg == "call not found" {
+			statusCode = http.StatusNotFound
+		} else if errorMsg == "unauthorized" {
+			statusCode = http.StatusForbidden
+		} else if errorMsg == "call_id is required" || errorMsg == "sdp_answer is required" {
+			statusCode = http.StatusBadRequest
+		} else if errorMsg == "call already ended" || errorMsg == "call has no webrtc session" {
+			statusCode = http.StatusConflict
+		} else if errorMsg == "sdp answer is not supported" {
+			statusCode = http.StatusNotImplemented
+		} else if errorMsg == "failed to apply sdp answer" {
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		c.JSON(statusCode, gin.H{
+			"error":   "call_answer_failed",
+			"message": errorMsg,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"call_id": output.CallID,
+		"status":  output.Status,
+		"codec":   output.Codec,
+	})
+}
+
+// AddCandidate accepts a trickled browser candidate in RTCIceCandidateInit
+// form. An empty candidate signals end-of-candidates.
+func (h *WebRTCHandler) AddCandidate(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	var req domain.ICECandidate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "candidate must be an RTCIceCandidateInit object",
+		})
+		return
+	}
+
+	output, err := h.addCandidate.Execute(c.Request.Context(), calls.AddCandidateInput{
+		UserID:    userID,
+		CallID:    c.Param("id"),
+		Candidate: req,
+	})
+	if err != nil {
+		if errors.Is(err, sdp.ErrInvalidCandidate) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_ice_candidate",
+				"message": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(candidateErrorStatus(err.Error()), gin.H{
+			"error":   "ice_candidate_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"call_id":           output.CallID,
+		"end_of_candidates": output.EndOfCandidates,
+	})
+}
+
+// Candidates returns the server's candidates gathered so far, for clients
+// that missed call.ice_candidate events.
+func (h *WebRTCHandler) Candidates(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	output, err := h.listCandidates.Execute(c.Request.Context(), calls.ListCandidatesInput{
+		UserID: userID,
+		CallID: c.Param("id"),
+	})
+	if err != nil {
+		c.JSON(candidateErrorStatus(err.Error()), gin.H{
+			"error":   "ice_candidate_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{
+		"call_id":           output.CallID,
+		"candidates":        output.Candidates,
+		"end_of_candidates": output.EndOfCandidates,
+	})
+}
+
+func candidateErrorStatus(errorMsg string) int {
+	switch errorMsg {
+	case "call not found":
+		return http.StatusNotFound
+	case "unauthorized":
+		return http.StatusForbidden
+	case "call_id is required":
+		return http.StatusBadRequest
+	case "call already ended", "call has no webrtc session", "end of candidates already signalled":
+		return http.StatusConflict
+	case "trickle ice is not supported":
+		return http.StatusNotImplemented
+	case "failed to add ice candidate", "failed to get candidates":
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+func (h *WebRTCHandler) Config(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "unauthorized",
+			"message": "Authentication required",
+		})
+		return
+	}
+
+	config, err := h.iceConfig.WebRTCConfig(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "webrtc_config_failed",
+			"message": "Failed to build WebRTC configuration",
+		})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, config)
+}
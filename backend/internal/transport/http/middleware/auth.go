@@ -36,3 +36,18 @@ func Auth(jwtService JWTService) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// StreamAuth authenticates long-lived streams. Browsers cannot set headers on
+// WebSocket and EventSource requests, so the token may also be passed in the
+// access_token query parameter.
+func StreamAuth(jwtService JWTService) gin.HandlerFunc {
+	auth := Auth(jwtService)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query("access_token"); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		auth(c)
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	twilioclient "github.com/twilio/twilio-go/client"
+)
+
+// TwilioSignature rejects voice webhooks whose X-Twilio-Signature does not
+// match the request. Twilio signs the URL it was configured with, so behind a
+// proxy the public base URL must be set; otherwise the URL is rebuilt from
+// the Host and X-Forwarded-Proto headers.
+func TwilioSignature(authToken, publicBaseURL string) gin.HandlerFunc {
+	validator := twilioclient.NewRequestValidator(authToken)
+	return func(c *gin.Context) {
+		signature := c.GetHeader("X-Twilio-Signature")
+		if authToken == "" || signature == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "missing twilio signature"})
+			return
+		}
+
+		if err := c.Request.ParseForm(); err != nil {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "invalid twilio signature"})
+			return
+		}
+		params := make(map[string]string, len(c.Request.PostForm))
+		for key, values := range c.Request.PostForm {
+			params[key] = values[0]
+		}
+
+		if !validator.Validate(webhookURL(c.Request, publicBaseURL), params, signature) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "invalid twilio signature"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func webhookURL(r *http.Request, publicBaseURL string) string {
+	if publicBaseURL != "" {
+		return strings.TrimSuffix(publicBaseURL, "/") + r.URL.RequestURI()
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = strings.TrimSpace(strings.Split(proto, ",")[0])
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
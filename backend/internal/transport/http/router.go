@@ -7,22 +7,49 @@ import (
 )
 
 type Router struct {
-	auth       *handlers.AuthHandler
-	calls      *handlers.CallsHandler
-	webrtc     *handlers.WebRTCHandler
-	voice      *handlers.VoiceHandler
-	history    *handlers.HistoryHandler
-	jwtService middleware.JWTService
+	auth        *handlers.AuthHandler
+	calls       *handlers.CallsHandler
+	webrtc      *handlers.WebRTCHandler
+	control     *handlers.CallControlHandler
+	callback    *handlers.CallbackHandler
+	scheduled   *handlers.ScheduledCallsHandler
+	conferences *handlers.ConferenceHandler
+	voice       *handlers.VoiceHandler
+	history     *handlers.HistoryHandler
+	events      *handlers.EventsHandler
+	numbers     *handlers.NumbersHandler
+	callerIDs   *handlers.CallerIDsHandler
+	settings    *handlers.SettingsHandler
+	voicemail   *handlers.VoicemailHandler
+	recordings  *handlers.RecordingsHandler
+	jwtService  middleware.JWTService
+	// voiceAuthToken and voicePublicBaseURL check the signature of
+	// provider webhooks under /api/voice.
+	voiceAuthToken     string
+	voicePublicBaseURL string
 }
 
-func NewRouter(auth *handlers.AuthHandler, calls *handlers.CallsHandler, webrtc *handlers.WebRTCHandler, voice *handlers.VoiceHandler, history *handlers.HistoryHandler, jwtService middleware.JWTService) *Router {
+func NewRouter(auth *handlers.AuthHandler, calls *handlers.CallsHandler, webrtc *handlers.WebRTCHandler, control *handlers.CallControlHandler, callback *handlers.CallbackHandler, scheduled *handlers.ScheduledCallsHandler, conferences *handlers.ConferenceHandler, voice *handlers.VoiceHandler, history *handlers.HistoryHandler, events *handlers.EventsHandler, numbers *handlers.NumbersHandler, callerIDs *handlers.CallerIDsHandler, settings *handlers.SettingsHandler, voicemail *handlers.VoicemailHandler, recordings *handlers.RecordingsHandler, jwtService middleware.JWTService, voiceAuthToken, voicePublicBaseURL string) *Router {
 	return &Router{
-		auth:       auth,
-		calls:      calls,
-		webrtc:     webrtc,
-		voice:      voice,
-		history:    history,
-		jwtService: jwtService,
+		auth:        auth,
+		calls:       calls,
+		webrtc:      webrtc,
+		control:     control,
+		callback:    callback,
+		scheduled:   scheduled,
+		conferences: conferences,
+		voice:       voice,
+		history:     history,
+		events:      events,
+		numbers:     numbers,
+		callerIDs:   callerIDs,
+		settings:    settings,
+		voicemail:   voicemail,
+		recordings:  recordings,
+		jwtService:  jwtService,
+
+		voiceAuthToken:     voiceAuthToken,
+		voicePublicBaseURL: voicePublicBaseURL,
 	}
 }
 
@@ -47,18 +74,102 @@ func (r *Router) Setup(engine *gin.Engine) {
 			callsGroup.POST("", r.calls.Create)
 			callsGroup.PUT("/:id", r.calls.Update)
 			callsGroup.GET("/history", r.history.List)
+			callsGroup.GET("/capabilities", r.control.Capabilities)
+			callsGroup.GET("/:id", r.history.Get)
+			callsGroup.GET("/:id/recordings/:recordingId/audio", r.recordings.Audio)
 			callsGroup.POST("/initiate", r.webrtc.Initiate)
+			callsGroup.POST("/callback", r.callback.Create)
 			callsGroup.POST("/terminate", r.webrtc.Terminate)
+			callsGroup.POST("/:id/answer", r.webrtc.Answer)
+			callsGroup.POST("/:id/candidates", r.webrtc.AddCandidate)
+			callsGroup.GET("/:id/candidates", r.webrtc.Candidates)
+			callsGroup.POST("/:id/dtmf", r.control.SendDTMF)
+			callsGroup.POST("/:id/hold", r.control.Hold)
+			callsGroup.POST("/:id/resume", r.control.Resume)
+			callsGroup.POST("/:id/mute", r.control.Mute)
+			callsGroup.POST("/:id/transfer", r.control.Transfer)
+			callsGroup.POST("/:id/transfer/complete", r.control.CompleteTransfer)
+		}
+
+		scheduledGroup := api.Group("/scheduled-calls")
+		scheduledGroup.Use(middleware.Auth(r.jwtService))
+		{
+			scheduledGroup.POST("", r.scheduled.Create)
+			scheduledGroup.GET("", r.scheduled.List)
+			scheduledGroup.GET("/:id", r.scheduled.Get)
+			scheduledGroup.PUT("/:id", r.scheduled.Update)
+			scheduledGroup.DELETE("/:id", r.scheduled.Delete)
+		}
+
+		conferencesGroup := api.Group("/conferences")
+		conferencesGroup.Use(middleware.Auth(r.jwtService))
+		{
+			conferencesGroup.POST("", r.conferences.Create)
+			conferencesGroup.GET("", r.conferences.List)
+			conferencesGroup.GET("/:id", r.conferences.Get)
+			conferencesGroup.POST("/:id/participants", r.conferences.AddParticipant)
+			conferencesGroup.DELETE("/:id/participants/:callId", r.conferences.RemoveParticipant)
+			conferencesGroup.POST("/:id/participants/:callId/mute", r.conferences.MuteParticipant)
+			conferencesGroup.POST("/:id/end", r.conferences.End)
+		}
+
+		api.GET("/numbers", middleware.Auth(r.jwtService), r.numbers.List)
+		api.POST("/numbers/parse", middleware.Auth(r.jwtService), r.numbers.Parse)
+
+		callerIDsGroup := api.Group("/caller-ids")
+		callerIDsGroup.Use(middleware.Auth(r.jwtService))
+		{
+			callerIDsGroup.GET("", r.callerIDs.List)
+			callerIDsGroup.POST("", r.callerIDs.Add)
+			callerIDsGroup.POST("/:id/verify", r.callerIDs.Verify)
+			callerIDsGroup.POST("/:id/default", r.callerIDs.SetDefault)
+			callerIDsGroup.DELETE("/:id", r.callerIDs.Delete)
+		}
+
+		settingsGroup := api.Group("/settings")
+		settingsGroup.Use(middleware.Auth(r.jwtService))
+		{
+			settingsGroup.GET("/quiet-hours", r.settings.QuietHours)
+			settingsGroup.PUT("/quiet-hours", r.settings.UpdateQuietHours)
+			settingsGroup.DELETE("/quiet-hours", r.settings.ResetQuietHours)
+		}
+
+		voicemailGroup := api.Group("/voicemail")
+		voicemailGroup.Use(middleware.Auth(r.jwtService))
+		{
+			voicemailGroup.GET("", r.voicemail.List)
+			voicemailGroup.GET("/:id/audio", r.voicemail.Audio)
+			voicemailGroup.POST("/:id/read", r.voicemail.MarkRead)
+			voicemailGroup.DELETE("/:id", r.voicemail.Delete)
 		}
 
+		api.GET("/webrtc/config", middleware.Auth(r.jwtService), r.webrtc.Config)
+		api.GET("/ws", middleware.StreamAuth(r.jwtService), r.events.WebSocket)
+		api.GET("/calls/:id/stream", middleware.StreamAuth(r.jwtService), r.events.CallStream)
+
 		if r.voice != nil {
 			api.POST("/voice/token", middleware.Auth(r.jwtService), r.voice.Token)
 		}
 	}
 
 	if r.voice != nil {
-		engine.GET("/api/voice/twiml", r.voice.TwiML)
-		engine.POST("/api/voice/twiml", r.voice.TwiML)
-		engine.POST("/api/voice/status", r.voice.VoiceStatusCallback)
+		voiceGroup := engine.Group("/api/voice")
+		voiceGroup.Use(middleware.TwilioSignature(r.voiceAuthToken, r.voicePublicBaseURL))
+		{
+			voiceGroup.GET("/twiml", r.voice.TwiML)
+			voiceGroup.POST("/twiml", r.voice.TwiML)
+			voiceGroup.POST("/status", r.voice.VoiceStatusCallback)
+			voiceGroup.POST("/inbound", r.voice.Inbound)
+			voiceGroup.POST("/inbound/status", r.voice.InboundStatus)
+			voiceGroup.POST("/inbound/fallback", r.voice.InboundFallback)
+			voiceGroup.POST("/hangup", r.voice.Hangup)
+			voiceGroup.POST("/transfer", r.voice.TransferFinished)
+			voiceGroup.POST("/transfer/status", r.voice.TransferStatus)
+			voiceGroup.POST("/callback", r.voice.Callback)
+			voiceGroup.POST("/callback/status", r.voice.CallbackStatus)
+			voiceGroup.POST("/voicemail", r.voicemail.RecordingStatus)
+			voiceGroup.POST("/recording", r.recordings.RecordingStatus)
+			voiceGroup.POST("/recording/consent", r.voice.RecordingConsent)
+		}
 	}
 }
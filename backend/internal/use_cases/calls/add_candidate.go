@@ -0,0 +1,119 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/sdp"
+)
+
+type AddCandidateInput struct {
+	UserID    string
+	CallID    string
+	Candidate domain.ICECandidate
+}
+
+type AddCandidateOutput struct {
+	CallID          string
+	EndOfCandidates bool
+}
+
+type AddCandidateUseCase struct {
+	callRepo    domain.CallRepository
+	voipService domain.VoIPService
+}
+
+func NewAddCandidateUseCase(callRepo domain.CallRepository, voipService domain.VoIPService) *AddCandidateUseCase {
+	return &AddCandidateUseCase{
+		callRepo:    callRepo,
+		voipService: voipService,
+	}
+}
+
+// Execute validates a trickled browser candidate and hands it to the call's
+// VoIP session. An empty candidate signals end-of-candidates.
+func (uc *AddCandidateUseCase) Execute(ctx context.Context, input AddCandidateInput) (*AddCandidateOutput, error) {
+	trickler, ok := uc.voipService.(domain.ICETrickler)
+	if !ok {
+		return nil, errors.New("trickle ice is not supported")
+	}
+
+	call, err := ownedWebRTCCall(ctx, uc.callRepo, input.CallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if call.Status.IsTerminal() {
+		return nil, errors.New("call already ended")
+	}
+
+	if !input.Candidate.IsEndOfCandidates() {
+		if _, err := sdp.ParseCandidate(input.Candidate.Candidate); err != nil {
+			slog.Warn("invalid ice candidate", "error", err, "call_id", call.ID)
+			return nil, err
+		}
+	}
+
+	if err := trickler.AddICECandidate(ctx, call.SessionID, input.Candidate); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSessionNotFound), errors.Is(err, domain.ErrSessionEnded):
+			return nil, errors.New("call already ended")
+		case errors.Is(err, domain.ErrCandidatesComplete):
+			return nil, errors.New("end of candidates already signalled")
+		}
+		slog.Error("failed to pass ice candidate to voip session",
+			"error", err,
+			"call_id", call.ID,
+			"session_id", call.SessionID)
+		return nil, errors.New("failed to add ice candidate")
+	}
+
+	slog.Debug("ice candidate added",
+		"call_id", call.ID,
+		"session_id", call.SessionID,
+		"end_of_candidates", input.Candidate.IsEndOfCandidates())
+
+	return &AddCandidateOutput{
+		CallID:          call.ID,
+		EndOfCandidates: input.Candidate.IsEndOfCandidates(),
+	}, nil
+}
+
+// ownedWebRTCCall loads a call that belongs to the user and has a WebRTC
+// session to exchange candidates with.
+func ownedWebRTCCall(ctx context.Context, callRepo domain.CallRepository, callID, userID string) (*domain.Call, error) {
+	if callID == "" {
+		return nil, errors.New("call_id is required")
+	}
+
+	if userID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	call, err := callRepo.GetByID(ctx, callID)
+	if err != nil {
+		slog.Error("failed to get call", "error", err, "call_id", callID)
+		return nil, errors.New("failed to get call")
+	}
+
+	if call == nil {
+		return nil, errors.New("call not found")
+	}
+
+	if call.UserID != userID {
+		slog.Warn("unauthorized call access",
+			"call_id", callID,
+			"user_id", userID,
+			"call_user_id", call.UserID)
+		return nil, errors.New("unauthorized")
+	}
+
+	// A callback call rings the user's phone, not the browser.
+	if call.SessionID == "" || call.SessionID == "voice_sdk" || call.CallbackDestination != "" {
+		return nil, errors.New("call has no webrtc session")
+	}
+
+	return call, nil
+}
@@ -0,0 +1,138 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/sdp"
+)
+
+const testCandidate = "candidate:842163049 1 udp 1677729535 203.0.113.7 46154 typ srflx raddr 10.0.0.5 rport 46154 generation 0 ufrag EsAw"
+
+type mockVoIPServiceForCandidates struct {
+	mockVoIPServiceForTerminate
+	candidateError error
+	sessionID      string
+	candidates     []domain.ICECandidate
+}
+
+func (m *mockVoIPServiceForCandidates) AddICECandidate(ctx context.Context, sessionID string, candidate domain.ICECandidate) error {
+	if m.candidateError != nil {
+		return m.candidateError
+	}
+	m.sessionID = sessionID
+	m.candidates = append(m.candidates, candidate)
+	return nil
+}
+
+func TestAddCandidateUseCase_Execute_Success(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newAnswerTestCall()}
+	mockVoIP := &mockVoIPServiceForCandidates{}
+
+	uc := NewAddCandidateUseCase(mockRepo, mockVoIP)
+
+	output, err := uc.Execute(context.Background(), AddCandidateInput{
+		UserID:    "user-1",
+		CallID:    "call-1",
+		Candidate: domain.ICECandidate{Candidate: testCandidate, SDPMid: "0"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.EndOfCandidates {
+		t.Error("expected a regular candidate")
+	}
+	if mockVoIP.sessionID != "sess_1" || len(mockVoIP.candidates) != 1 {
+		t.Errorf("expected candidate to reach session 'sess_1', got %q %v", mockVoIP.sessionID, mockVoIP.candidates)
+	}
+}
+
+func TestAddCandidateUseCase_Execute_EndOfCandidates(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newAnswerTestCall()}
+	mockVoIP := &mockVoIPServiceForCandidates{}
+
+	uc := NewAddCandidateUseCase(mockRepo, mockVoIP)
+
+	output, err := uc.Execute(context.Background(), AddCandidateInput{
+		UserID: "user-1",
+		CallID: "call-1",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !output.EndOfCandidates {
+		t.Error("expected end of candidates")
+	}
+	if len(mockVoIP.candidates) != 1 || !mockVoIP.candidates[0].IsEndOfCandidates() {
+		t.Errorf("expected end-of-candidates marker to reach the session, got %v", mockVoIP.candidates)
+	}
+}
+
+func TestAddCandidateUseCase_Execute_InvalidCandidate(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newAnswerTestCall()}
+	mockVoIP := &mockVoIPServiceForCandidates{}
+
+	uc := NewAddCandidateUseCase(mockRepo, mockVoIP)
+
+	_, err := uc.Execute(context.Background(), AddCandidateInput{
+		UserID:    "user-1",
+		CallID:    "call-1",
+		Candidate: domain.ICECandidate{Candidate: "candidate:1 1 udp 1 203.0.113.7 46154 typ bogus"},
+	})
+	if !errors.Is(err, sdp.ErrInvalidCandidate) {
+		t.Fatalf("expected ErrInvalidCandidate, got %v", err)
+	}
+
+	if len(mockVoIP.candidates) != 0 {
+		t.Error("expected invalid candidate not to reach the session")
+	}
+}
+
+func TestAddCandidateUseCase_Execute_NotSupported(t *testing.T) {
+	uc := NewAddCandidateUseCase(&mockCallRepositoryForTerminate{call: newAnswerTestCall()}, &mockVoIPServiceForTerminate{})
+
+	_, err := uc.Execute(context.Background(), AddCandidateInput{
+		UserID:    "user-1",
+		CallID:    "call-1",
+		Candidate: domain.ICECandidate{Candidate: testCandidate, SDPMid: "0"},
+	})
+	if err == nil || err.Error() != "trickle ice is not supported" {
+		t.Errorf("expected 'trickle ice is not supported' error, got %v", err)
+	}
+}
+
+func TestAddCandidateUseCase_Execute_Errors(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    AddCandidateInput
+		call     *domain.Call
+		voipErr  error
+		expected string
+	}{
+		{"missing call id", AddCandidateInput{UserID: "user-1"}, newAnswerTestCall(), nil, "call_id is required"},
+		{"not found", AddCandidateInput{UserID: "user-1", CallID: "call-1"}, nil, nil, "call not found"},
+		{"other user", AddCandidateInput{UserID: "user-2", CallID: "call-1"}, newAnswerTestCall(), nil, "unauthorized"},
+		{"ended call", AddCandidateInput{UserID: "user-1", CallID: "call-1"}, &domain.Call{ID: "call-1", UserID: "user-1", SessionID: "sess_1", Status: domain.CallStatusCompleted}, nil, "call already ended"},
+		{"voice sdk call", AddCandidateInput{UserID: "user-1", CallID: "call-1"}, &domain.Call{ID: "call-1", UserID: "user-1", SessionID: "voice_sdk", Status: domain.CallStatusActive}, nil, "call has no webrtc session"},
+		{"session gone", AddCandidateInput{UserID: "user-1", CallID: "call-1"}, newAnswerTestCall(), domain.ErrSessionNotFound, "call already ended"},
+		{"already complete", AddCandidateInput{UserID: "user-1", CallID: "call-1"}, newAnswerTestCall(), domain.ErrCandidatesComplete, "end of candidates already signalled"},
+		{"voip failure", AddCandidateInput{UserID: "user-1", CallID: "call-1"}, newAnswerTestCall(), errors.New("boom"), "failed to add ice candidate"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := NewAddCandidateUseCase(
+				&mockCallRepositoryForTerminate{call: tc.call},
+				&mockVoIPServiceForCandidates{candidateError: tc.voipErr},
+			)
+
+			_, err := uc.Execute(context.Background(), tc.input)
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("expected error '%s', got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/sdp"
+)
+
+type AnswerCallInput struct {
+	UserID    string
+	CallID    string
+	SDPAnswer string
+}
+
+type AnswerCallOutput struct {
+	CallID string
+	Status string
+	Codec  string
+}
+
+type AnswerCallUseCase struct {
+	callRepo    domain.CallRepository
+	voipService domain.VoIPService
+	events      domain.EventPublisher
+}
+
+func NewAnswerCallUseCase(callRepo domain.CallRepository, voipService domain.VoIPService, events domain.EventPublisher) *AnswerCallUseCase {
+	return &AnswerCallUseCase{
+		callRepo:    callRepo,
+		voipService: voipService,
+		events:      events,
+	}
+}
+
+// Execute validates the browser's SDP answer and activates the call. SDP
+// problems are returned as the sdp package errors so callers can tell the
+// client exactly what is wrong.
+func (uc *AnswerCallUseCase) Execute(ctx context.Context, input AnswerCallInput) (*AnswerCallOutput, error) {
+	acceptor, ok := uc.voipService.(domain.AnswerAcceptor)
+	if !ok {
+		return nil, errors.New("sdp answer is not supported")
+	}
+
+	if input.CallID == "" {
+		return nil, errors.New("call_id is required")
+	}
+
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	if input.SDPAnswer == "" {
+		return nil, errors.New("sdp_answer is required")
+	}
+
+	call, err := uc.callRepo.GetByID(ctx, input.CallID)
+	if err != nil {
+		slog.Error("failed to get call", "error", err, "call_id", input.CallID)
+		return nil, errors.New("failed to get call")
+	}
+
+	if call == nil {
+		return nil, errors.New("call not found")
+	}
+
+	if call.UserID != input.UserID {
+		slog.Warn("unauthorized call answer attempt",
+			"call_id", input.CallID,
+			"user_id", input.UserID,
+			"call_user_id", call.UserID)
+		return nil, errors.New("unauthorized")
+	}
+
+	if call.Status.IsTerminal() {
+		return nil, errors.New("call already ended")
+	}
+
+	if call.SessionID == "" || call.SessionID == "voice_sdk" {
+		return nil, errors.New("call has no webrtc session")
+	}
+
+	params, err := sdp.ValidateAnswer(input.SDPAnswer, offeredCodecs(call.SDPOffer))
+	if err != nil {
+		slog.Warn("invalid sdp answer", "error", err, "call_id", call.ID)
+		return nil, err
+	}
+
+	if err := acceptor.AcceptAnswer(ctx, call.SessionID, input.SDPAnswer); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) || errors.Is(err, domain.ErrSessionEnded) {
+			return nil, errors.New("call already ended")
+		}
+		slog.Error("failed to pass sdp answer to voip session",
+			"error", err,
+			"call_id", call.ID,
+			"session_id", call.SessionID)
+		return nil, errors.New("failed to apply sdp answer")
+	}
+
+	call.SDPAnswer = input.SDPAnswer
+	call.Status = domain.CallStatusActive
+
+	if err := uc.callRepo.Update(ctx, call); err != nil {
+		slog.Error("failed to update call", "error", err, "call_id", call.ID)
+		return nil, errors.New("failed to update call")
+	}
+
+	slog.Info("sdp answer accepted",
+		"call_id", call.ID,
+		"session_id", call.SessionID,
+		"codec", params.Codecs[0].Name)
+
+	publishCallStatus(ctx, uc.events, call)
+
+	return &AnswerCallOutput{
+		CallID: call.ID,
+		Status: string(call.Status),
+		Codec:  params.Codecs[0].Name,
+	}, nil
+}
+
+// offeredCodecs limits the answer to the codecs we offered. Calls whose offer
+// cannot be read fall back to every supported codec.
+func offeredCodecs(offer string) []sdp.Codec {
+	desc, err := sdp.Parse(offer)
+	if err != nil {
+		return sdp.SupportedAudioCodecs
+	}
+	audio := desc.AudioMedia()
+	if audio == nil {
+		return sdp.SupportedAudioCodecs
+	}
+	codecs, err := audio.Codecs()
+	if err != nil || len(codecs) == 0 {
+		return sdp.SupportedAudioCodecs
+	}
+	return codecs
+}
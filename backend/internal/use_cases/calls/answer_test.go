@@ -0,0 +1,151 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/sdp"
+)
+
+const testSDPAnswer = "v=0\r\n" +
+	"o=- 4611731400430051336 2 IN IP4 127.0.0.1\r\n" +
+	"s=-\r\n" +
+	"t=0 0\r\n" +
+	"m=audio 9 UDP/TLS/RTP/SAVPF 111 0\r\n" +
+	"c=IN IP4 0.0.0.0\r\n" +
+	"a=ice-ufrag:EsAw\r\n" +
+	"a=ice-pwd:P2uYro0UCOQ4zxjKXaWCBui1\r\n" +
+	"a=fingerprint:sha-256 D2:FA:0E:C3:22:59:5E:14:95:69:92:3D:13:B4:84:24:2C:C2:A2:C0:3E:FD:34:8E:5E:EA:6F:AF:52:CE:E6:0F\r\n" +
+	"a=setup:active\r\n" +
+	"a=mid:0\r\n" +
+	"a=rtpmap:111 opus/48000/2\r\n" +
+	"a=rtpmap:0 PCMU/8000\r\n"
+
+type mockVoIPServiceForAnswer struct {
+	mockVoIPServiceForTerminate
+	answerError error
+	sessionID   string
+	sdpAnswer   string
+}
+
+func (m *mockVoIPServiceForAnswer) AcceptAnswer(ctx context.Context, sessionID string, sdpAnswer string) error {
+	if m.answerError != nil {
+		return m.answerError
+	}
+	m.sessionID = sessionID
+	m.sdpAnswer = sdpAnswer
+	return nil
+}
+
+func newAnswerTestCall() *domain.Call {
+	return &domain.Call{
+		ID:        "call-1",
+		UserID:    "user-1",
+		SessionID: "sess_1",
+		Status:    domain.CallStatusConnecting,
+	}
+}
+
+func TestAnswerCallUseCase_Execute_Success(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newAnswerTestCall()}
+	mockVoIP := &mockVoIPServiceForAnswer{}
+	events := &mockEventPublisher{}
+
+	uc := NewAnswerCallUseCase(mockRepo, mockVoIP, events)
+
+	output, err := uc.Execute(context.Background(), AnswerCallInput{
+		UserID:    "user-1",
+		CallID:    "call-1",
+		SDPAnswer: testSDPAnswer,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.Status != "active" || output.Codec != "opus" {
+		t.Errorf("unexpected output: %+v", output)
+	}
+
+	if mockVoIP.sessionID != "sess_1" || mockVoIP.sdpAnswer != testSDPAnswer {
+		t.Error("expected answer to be passed to the voip session")
+	}
+
+	if mockRepo.updatedCall == nil || mockRepo.updatedCall.SDPAnswer != testSDPAnswer {
+		t.Error("expected sdp answer to be stored on the call")
+	}
+
+	if len(events.events) != 1 || events.events[0].Status != domain.CallStatusActive {
+		t.Errorf("expected active status event, got %+v", events.events)
+	}
+}
+
+func TestAnswerCallUseCase_Execute_InvalidSDP(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newAnswerTestCall()}
+	mockVoIP := &mockVoIPServiceForAnswer{}
+
+	uc := NewAnswerCallUseCase(mockRepo, mockVoIP, nil)
+
+	_, err := uc.Execute(context.Background(), AnswerCallInput{
+		UserID:    "user-1",
+		CallID:    "call-1",
+		SDPAnswer: strings.Replace(testSDPAnswer, "a=setup:active", "a=setup:actpass", 1),
+	})
+	if !errors.Is(err, sdp.ErrInvalidSetup) {
+		t.Fatalf("expected ErrInvalidSetup, got %v", err)
+	}
+
+	if mockVoIP.sdpAnswer != "" || mockRepo.updatedCall != nil {
+		t.Error("expected invalid answer not to be applied")
+	}
+}
+
+func TestAnswerCallUseCase_Execute_Unauthorized(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newAnswerTestCall()}
+
+	uc := NewAnswerCallUseCase(mockRepo, &mockVoIPServiceForAnswer{}, nil)
+
+	_, err := uc.Execute(context.Background(), AnswerCallInput{
+		UserID:    "other-user",
+		CallID:    "call-1",
+		SDPAnswer: testSDPAnswer,
+	})
+	if err == nil || err.Error() != "unauthorized" {
+		t.Errorf("expected 'unauthorized' error, got %v", err)
+	}
+}
+
+func TestAnswerCallUseCase_Execute_NotSupported(t *testing.T) {
+	uc := NewAnswerCallUseCase(&mockCallRepositoryForTerminate{call: newAnswerTestCall()}, &mockVoIPServiceForTerminate{}, nil)
+
+	_, err := uc.Execute(context.Background(), AnswerCallInput{
+		UserID:    "user-1",
+		CallID:    "call-1",
+		SDPAnswer: testSDPAnswer,
+	})
+	if err == nil || err.Error() != "sdp answer is not supported" {
+		t.Errorf("expected 'sdp answer is not supported' error, got %v", err)
+	}
+}
+
+func TestAnswerCallUseCase_Execute_SessionEnded(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newAnswerTestCall()}
+	mockVoIP := &mockVoIPServiceForAnswer{answerError: domain.ErrSessionEnded}
+
+	uc := NewAnswerCallUseCase(mockRepo, mockVoIP, nil)
+
+	_, err := uc.Execute(context.Background(), AnswerCallInput{
+		UserID:    "user-1",
+		CallID:    "call-1",
+		SDPAnswer: testSDPAnswer,
+	})
+	if err == nil || err.Error() != "call already ended" {
+		t.Errorf("expected 'call already ended' error, got %v", err)
+	}
+
+	if mockRepo.updatedCall != nil {
+		t.Error("expected call not to be updated")
+	}
+}
@@ -0,0 +1,221 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/phone"
+)
+
+type CallbackCallInput struct {
+	UserID string
+	// CallerNumber is the user's own phone, rung first; PhoneNumber is the
+	// destination it is connected to.
+	CallerNumber string
+	PhoneNumber  string
+}
+
+type CallbackCallOutput struct {
+	CallID       string
+	CallerNumber string
+	PhoneNumber  string
+	Status       string
+	StartTime    time.Time
+}
+
+// CallbackCallUseCase places a callback call, for users whose connection is
+// too poor for a browser call: the user's verified phone rings first and,
+// once answered, BridgeCallbackUseCase dials the destination. Each leg is a
+// call record of its own, so both show up in history with their costs.
+type CallbackCallUseCase struct {
+	callRepo    domain.CallRepository
+	callerIDs   domain.CallerIDRepository
+	voipService domain.VoIPService
+	events      domain.EventPublisher
+	shortCodes  *ShortCodeGuard
+}
+
+func NewCallbackCallUseCase(callRepo domain.CallRepository, callerIDs domain.CallerIDRepository, voipService domain.VoIPService, events domain.EventPublisher, shortCodes *ShortCodeGuard) *CallbackCallUseCase {
+	return &CallbackCallUseCase{
+		callRepo:    callRepo,
+		callerIDs:   callerIDs,
+		voipService: voipService,
+		events:      events,
+		shortCodes:  shortCodes,
+	}
+}
+
+func (uc *CallbackCallUseCase) Execute(ctx context.Context, input CallbackCallInput) (*CallbackCallOutput, error) {
+	placer, ok := uc.voipService.(domain.CallbackPlacer)
+	if !ok {
+		return nil, errors.New("callback is not supported")
+	}
+
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	if input.CallerNumber == "" || input.PhoneNumber == "" {
+		return nil, errors.New("caller_number and phone_number are required")
+	}
+
+	// The user's phone is one of their caller IDs, which are stored in
+	// E.164; the destination is vetted like one dialled from the browser.
+	callerNumber, err := phone.Parse(input.CallerNumber, "")
+	if err != nil {
+		return nil, domain.ErrInvalidPhoneNumber
+	}
+	input.CallerNumber = callerNumber.E164
+
+	input.PhoneNumber, err = uc.shortCodes.internationalDestination(ctx, ShortCodeAttempt{
+		UserID:      input.UserID,
+		PhoneNumber: input.PhoneNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if input.CallerNumber == input.PhoneNumber {
+		return nil, errors.New("cannot call back the destination")
+	}
+
+	callerID, err := uc.callerIDs.GetByUserIDAndNumber(ctx, input.UserID, input.CallerNumber)
+	if err != nil {
+		slog.Error("failed to get caller id", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to get caller id")
+	}
+
+	if callerID == nil || !callerID.Verified() {
+		return nil, errors.New("caller number is not verified")
+	}
+
+	call := &domain.Call{
+		UserID:              input.UserID,
+		PhoneNumber:         input.CallerNumber,
+		StartTime:           time.Now(),
+		Status:              domain.CallStatusConnecting,
+		Direction:           domain.CallDirectionOutbound,
+		CallbackDestination: input.PhoneNumber,
+	}
+
+	if err := uc.callRepo.Create(ctx, call); err != nil {
+		slog.Error("failed to create call record", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to create call record")
+	}
+
+	session, err := placer.PlaceCallback(ctx, input.CallerNumber, call.ID)
+	if err != nil {
+		call.Status = domain.CallStatusFailed
+		if updateErr := uc.callRepo.Update(ctx, call); updateErr != nil {
+			slog.Error("failed to update call", "error", updateErr, "call_id", call.ID)
+		}
+		publishCallStatus(ctx, uc.events, call)
+
+		if errors.Is(err, domain.ErrInvalidPhoneNumber) {
+			return nil, err
+		}
+		slog.Error("failed to place callback call", "error", err, "call_id", call.ID)
+		return nil, errors.New("failed to initiate call")
+	}
+
+	call.SessionID = session.SessionID
+	call.ProviderCallSID = session.ProviderCallSID
+	if err := uc.callRepo.Update(ctx, call); err != nil {
+		slog.Error("failed to update call", "error", err, "call_id", call.ID)
+		return nil, errors.New("failed to update call")
+	}
+
+	slog.Info("callback call placed",
+		"call_id", call.ID,
+		"user_id", input.UserID,
+		"session_id", call.SessionID,
+		"phone", input.PhoneNumber)
+
+	publishCallStatus(ctx, uc.events, call)
+
+	return &CallbackCallOutput{
+		CallID:       call.ID,
+		CallerNumber: call.PhoneNumber,
+		PhoneNumber:  call.CallbackDestination,
+		Status:       string(call.Status),
+		StartTime:    call.StartTime,
+	}, nil
+}
+
+type BridgeCallbackInput struct {
+	// CallID is the first leg of the callback call, which has just been
+	// answered.
+	CallID string
+}
+
+type BridgeCallbackOutput struct {
+	// CallID is the new leg to the destination.
+	CallID       string
+	CallerNumber string
+	PhoneNumber  string
+}
+
+// BridgeCallbackUseCase records the second leg of a callback call once the
+// user has answered the first, for the provider to dial the destination.
+type BridgeCallbackUseCase struct {
+	callRepo domain.CallRepository
+	events   domain.EventPublisher
+}
+
+func NewBridgeCallbackUseCase(callRepo domain.CallRepository, events domain.EventPublisher) *BridgeCallbackUseCase {
+	return &BridgeCallbackUseCase{
+		callRepo: callRepo,
+		events:   events,
+	}
+}
+
+func (uc *BridgeCallbackUseCase) Execute(ctx context.Context, input BridgeCallbackInput) (*BridgeCallbackOutput, error) {
+	if input.CallID == "" {
+		return nil, errors.New("call_id is required")
+	}
+
+	call, err := uc.callRepo.GetByID(ctx, input.CallID)
+	if err != nil {
+		slog.Error("failed to get call", "error", err, "call_id", input.CallID)
+		return nil, errors.New("failed to get call")
+	}
+
+	if call == nil {
+		return nil, errors.New("call not found")
+	}
+
+	if call.CallbackDestination == "" {
+		return nil, errors.New("call is not a callback call")
+	}
+
+	if call.Status.IsTerminal() {
+		return nil, errors.New("call already ended")
+	}
+
+	leg := &domain.Call{
+		UserID:       call.UserID,
+		PhoneNumber:  call.CallbackDestination,
+		StartTime:    time.Now(),
+		Status:       domain.CallStatusConnecting,
+		Direction:    domain.CallDirectionOutbound,
+		ParentCallID: call.ID,
+	}
+
+	if err := uc.callRepo.Create(ctx, leg); err != nil {
+		slog.Error("failed to create call record", "error", err, "user_id", call.UserID)
+		return nil, errors.New("failed to create call record")
+	}
+
+	slog.Info("callback call bridged", "call_id", call.ID, "leg_id", leg.ID)
+
+	publishCallStatus(ctx, uc.events, leg)
+
+	return &BridgeCallbackOutput{
+		CallID:       leg.ID,
+		CallerNumber: call.PhoneNumber,
+		PhoneNumber:  leg.PhoneNumber,
+	}, nil
+}
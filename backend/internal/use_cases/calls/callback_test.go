@@ -0,0 +1,219 @@
+package calls
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockCallerIDRepository struct {
+	callerIDs []*domain.CallerID
+}
+
+func (m *mockCallerIDRepository) GetByUserIDAndNumber(ctx context.Context, userID, number string) (*domain.CallerID, error) {
+	for _, callerID := range m.callerIDs {
+		if callerID.UserID == userID && callerID.Number == number {
+			return callerID, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockCallerIDRepository) Create(ctx context.Context, callerID *domain.CallerID) error {
+	m.callerIDs = append(m.callerIDs, callerID)
+	return nil
+}
+
+func (m *mockCallerIDRepository) Update(ctx context.Context, callerID *domain.CallerID) error {
+	return nil
+}
+
+func (m *mockCallerIDRepository) GetByID(ctx context.Context, id string) (*domain.CallerID, error) {
+	for _, callerID := range m.callerIDs {
+		if callerID.ID == id {
+			return callerID, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockCallerIDRepository) GetDefault(ctx context.Context, userID string) (*domain.CallerID, error) {
+	for _, callerID := range m.callerIDs {
+		if callerID.UserID == userID && callerID.IsDefault {
+			return callerID, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockCallerIDRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.CallerID, error) {
+	var callerIDs []*domain.CallerID
+	for _, callerID := range m.callerIDs {
+		if callerID.UserID == userID {
+			callerIDs = append(callerIDs, callerID)
+		}
+	}
+	return callerIDs, nil
+}
+
+func (m *mockCallerIDRepository) SetDefault(ctx context.Context, userID, id string) error {
+	for _, callerID := range m.callerIDs {
+		if callerID.UserID == userID {
+			callerID.IsDefault = callerID.ID == id
+		}
+	}
+	return nil
+}
+
+func (m *mockCallerIDRepository) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+type mockVoIPServiceForCallback struct {
+	mockVoIPService
+	callerNumber string
+	callID       string
+}
+
+func (m *mockVoIPServiceForCallback) PlaceCallback(ctx context.Context, callerNumber, callID string) (*domain.CallSession, error) {
+	m.callerNumber = callerNumber
+	m.callID = callID
+	return &domain.CallSession{SessionID: "sess_cb", ProviderCallSID: "CA_cb"}, nil
+}
+
+func newCallbackTestCallerIDs() *mockCallerIDRepository {
+	verifiedAt := time.Now()
+	return &mockCallerIDRepository{callerIDs: []*domain.CallerID{
+		{ID: "cid-1", UserID: "user-1", Number: "+14155550100", VerifiedAt: &verifiedAt},
+		{ID: "cid-2", UserID: "user-1", Number: "+14155550101"},
+	}}
+}
+
+func TestCallbackCallUseCase_Execute_Success(t *testing.T) {
+	callRepo := newMockCallRepositoryForTransfer()
+	voip := &mockVoIPServiceForCallback{}
+	events := &mockEventPublisher{}
+	uc := NewCallbackCallUseCase(callRepo, newCallbackTestCallerIDs(), voip, events, NewShortCodeGuard(nil, nil))
+
+	output, err := uc.Execute(context.Background(), CallbackCallInput{
+		UserID:       "user-1",
+		CallerNumber: "+14155550100",
+		PhoneNumber:  "+491512345678",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if voip.callerNumber != "+14155550100" || voip.callID != output.CallID {
+		t.Errorf("expected the caller's phone to be rung for %s, got %s for %s", output.CallID, voip.callerNumber, voip.callID)
+	}
+
+	call := callRepo.calls[output.CallID]
+	if call.PhoneNumber != "+14155550100" || call.CallbackDestination != "+491512345678" {
+		t.Errorf("expected a leg to the caller bound for the destination, got %s -> %s", call.PhoneNumber, call.CallbackDestination)
+	}
+	if call.SessionID != "sess_cb" || call.ProviderCallSID != "CA_cb" {
+		t.Errorf("expected the provider call to be stored, got %s/%s", call.SessionID, call.ProviderCallSID)
+	}
+	if len(events.events) != 1 || events.events[0].Status != domain.CallStatusConnecting {
+		t.Errorf("expected one connecting event, got %+v", events.events)
+	}
+}
+
+func TestCallbackCallUseCase_Execute_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input CallbackCallInput
+		want  string
+	}{
+		{"unverified", CallbackCallInput{UserID: "user-1", CallerNumber: "+14155550101", PhoneNumber: "+491512345678"}, "caller number is not verified"},
+		{"unknown", CallbackCallInput{UserID: "user-1", CallerNumber: "+14155550199", PhoneNumber: "+491512345678"}, "caller number is not verified"},
+		{"other user", CallbackCallInput{UserID: "user-2", CallerNumber: "+14155550100", PhoneNumber: "+491512345678"}, "caller number is not verified"},
+		{"same number", CallbackCallInput{UserID: "user-1", CallerNumber: "+14155550100", PhoneNumber: "+14155550100"}, "cannot call back the destination"},
+		{"invalid", CallbackCallInput{UserID: "user-1", CallerNumber: "+14155550100", PhoneNumber: "0049151"}, domain.ErrInvalidPhoneNumber.Error()},
+		{"emergency", CallbackCallInput{UserID: "user-1", CallerNumber: "+14155550100", PhoneNumber: "112"}, domain.ErrEmergencyNotSupported.Error()},
+		{"missing", CallbackCallInput{UserID: "user-1", PhoneNumber: "+491512345678"}, "caller_number and phone_number are required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			callRepo := newMockCallRepositoryForTransfer()
+			voip := &mockVoIPServiceForCallback{}
+			uc := NewCallbackCallUseCase(callRepo, newCallbackTestCallerIDs(), voip, nil, NewShortCodeGuard(nil, nil))
+
+			_, err := uc.Execute(context.Background(), tt.input)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("expected '%s', got %v", tt.want, err)
+			}
+			if len(callRepo.created) != 0 || voip.callID != "" {
+				t.Error("expected no call to be placed")
+			}
+		})
+	}
+}
+
+func TestCallbackCallUseCase_Execute_NotSupported(t *testing.T) {
+	uc := NewCallbackCallUseCase(newMockCallRepositoryForTransfer(), newCallbackTestCallerIDs(), &mockVoIPService{}, nil, NewShortCodeGuard(nil, nil))
+
+	_, err := uc.Execute(context.Background(), CallbackCallInput{
+		UserID:       "user-1",
+		CallerNumber: "+14155550100",
+		PhoneNumber:  "+491512345678",
+	})
+	if err == nil || err.Error() != "callback is not supported" {
+		t.Errorf("expected 'callback is not supported', got %v", err)
+	}
+}
+
+func TestBridgeCallbackUseCase_Execute(t *testing.T) {
+	call := &domain.Call{
+		ID:                  "call-1",
+		UserID:              "user-1",
+		PhoneNumber:         "+14155550100",
+		Status:              domain.CallStatusActive,
+		SessionID:           "sess_cb",
+		CallbackDestination: "+491512345678",
+	}
+	callRepo := newMockCallRepositoryForTransfer(call)
+	events := &mockEventPublisher{}
+	uc := NewBridgeCallbackUseCase(callRepo, events)
+
+	output, err := uc.Execute(context.Background(), BridgeCallbackInput{CallID: "call-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.CallerNumber != "+14155550100" || output.PhoneNumber != "+491512345678" {
+		t.Errorf("expected the destination to be dialled from the caller's number, got %+v", output)
+	}
+
+	leg := callRepo.calls[output.CallID]
+	if leg.ParentCallID != "call-1" || leg.PhoneNumber != "+491512345678" || leg.UserID != "user-1" {
+		t.Errorf("expected a leg to the destination linked to call-1, got %+v", leg)
+	}
+	if len(events.events) != 1 || events.events[0].CallID != output.CallID {
+		t.Errorf("expected an event for the leg, got %+v", events.events)
+	}
+}
+
+func TestBridgeCallbackUseCase_Execute_Rejected(t *testing.T) {
+	plain := &domain.Call{ID: "call-1", UserID: "user-1", Status: domain.CallStatusActive, SessionID: "sess_1"}
+	ended := &domain.Call{ID: "call-2", UserID: "user-1", Status: domain.CallStatusCompleted, CallbackDestination: "+491512345678"}
+	callRepo := newMockCallRepositoryForTransfer(plain, ended)
+	uc := NewBridgeCallbackUseCase(callRepo, nil)
+
+	for callID, want := range map[string]string{
+		"call-1":  "call is not a callback call",
+		"call-2":  "call already ended",
+		"missing": "call not found",
+	} {
+		if _, err := uc.Execute(context.Background(), BridgeCallbackInput{CallID: callID}); err == nil || err.Error() != want {
+			t.Errorf("%s: expected '%s', got %v", callID, want, err)
+		}
+	}
+	if len(callRepo.created) != 0 {
+		t.Error("expected no leg to be created")
+	}
+}
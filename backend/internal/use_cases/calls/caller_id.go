@@ -0,0 +1,46 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type GetCallerIDInput struct {
+	UserID string
+	CallID string
+}
+
+// GetCallerIDUseCase returns the caller ID chosen for a call when it was
+// initiated, for the provider to dial Voice SDK calls from it.
+type GetCallerIDUseCase struct {
+	callRepo domain.CallRepository
+}
+
+func NewGetCallerIDUseCase(callRepo domain.CallRepository) *GetCallerIDUseCase {
+	return &GetCallerIDUseCase{callRepo: callRepo}
+}
+
+func (uc *GetCallerIDUseCase) Execute(ctx context.Context, input GetCallerIDInput) (string, error) {
+	if input.CallID == "" {
+		return "", errors.New("call_id is required")
+	}
+
+	call, err := uc.callRepo.GetByID(ctx, input.CallID)
+	if err != nil {
+		slog.Error("failed to get call", "error", err, "call_id", input.CallID)
+		return "", errors.New("failed to get call")
+	}
+
+	if call == nil {
+		return "", errors.New("call not found")
+	}
+
+	if call.UserID != input.UserID {
+		return "", errors.New("unauthorized")
+	}
+
+	return call.CallerID, nil
+}
@@ -0,0 +1,534 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type ConferenceParticipant struct {
+	CallID      string
+	PhoneNumber string
+	Status      string
+	Muted       bool
+}
+
+type ConferenceOutput struct {
+	ConferenceID string
+	Status       string
+	Participants []ConferenceParticipant
+}
+
+type CreateConferenceInput struct {
+	UserID  string
+	CallIDs []string
+}
+
+// CreateConferenceUseCase merges answered calls of a user into a conference.
+// The provider connects the user's browser to the conference as its host;
+// each call's called party joins as a participant and hears everyone else.
+type CreateConferenceUseCase struct {
+	callRepo       domain.CallRepository
+	conferenceRepo domain.ConferenceRepository
+	voipService    domain.VoIPService
+	events         domain.EventPublisher
+}
+
+func NewCreateConferenceUseCase(callRepo domain.CallRepository, conferenceRepo domain.ConferenceRepository, voipService domain.VoIPService, events domain.EventPublisher) *CreateConferenceUseCase {
+	return &CreateConferenceUseCase{
+		callRepo:       callRepo,
+		conferenceRepo: conferenceRepo,
+		voipService:    voipService,
+		events:         events,
+	}
+}
+
+func (uc *CreateConferenceUseCase) Execute(ctx context.Context, input CreateConferenceInput) (*ConferenceOutput, error) {
+	conferencer, ok := uc.voipService.(domain.Conferencer)
+	if !ok {
+		return nil, errors.New("conference is not supported")
+	}
+
+	if len(input.CallIDs) < 2 {
+		return nil, errors.New("at least two calls are required")
+	}
+
+	seen := make(map[string]bool, len(input.CallIDs))
+	calls := make([]*domain.Call, 0, len(input.CallIDs))
+	for _, callID := range input.CallIDs {
+		if seen[callID] {
+			return nil, errors.New("call_ids must be distinct")
+		}
+		seen[callID] = true
+
+		call, err := conferenceCandidate(ctx, uc.callRepo, callID, input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+
+	conference := &domain.Conference{
+		UserID:    input.UserID,
+		Status:    domain.ConferenceStatusActive,
+		StartTime: time.Now(),
+	}
+
+	if err := uc.conferenceRepo.Create(ctx, conference); err != nil {
+		slog.Error("failed to create conference", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to create conference")
+	}
+
+	host, err := conferencer.HostConference(ctx, conference.ID, domain.CallOptions{Identity: input.UserID})
+	if err != nil {
+		slog.Error("failed to host conference", "error", err, "conference_id", conference.ID)
+		if endErr := endConference(ctx, uc.conferenceRepo, uc.voipService, conference); endErr != nil {
+			slog.Error("failed to end conference", "error", endErr, "conference_id", conference.ID)
+		}
+		return nil, errors.New("failed to create conference")
+	}
+
+	conference.HostSessionID = host.SessionID
+	if err := uc.conferenceRepo.Update(ctx, conference); err != nil {
+		slog.Error("failed to update conference", "error", err, "conference_id", conference.ID)
+		return nil, errors.New("failed to update conference")
+	}
+
+	slog.Info("conference created",
+		"conference_id", conference.ID,
+		"user_id", input.UserID,
+		"host_session_id", host.SessionID)
+
+	// A call that fails to join stays connected to the caller; the ones
+	// joined so far keep the conference going.
+	for i, call := range calls {
+		if err := joinConference(ctx, uc.callRepo, conferencer, uc.events, conference, call); err != nil {
+			if i == 0 {
+				if endErr := endConference(ctx, uc.conferenceRepo, uc.voipService, conference); endErr != nil {
+					slog.Error("failed to end conference", "error", endErr, "conference_id", conference.ID)
+				}
+			}
+			return nil, err
+		}
+	}
+
+	return conferenceOutput(ctx, uc.conferenceRepo, conference)
+}
+
+type ConferenceParticipantInput struct {
+	UserID       string
+	ConferenceID string
+	CallID       string
+}
+
+// AddParticipantUseCase joins another answered call to an active conference.
+type AddParticipantUseCase struct {
+	callRepo       domain.CallRepository
+	conferenceRepo domain.ConferenceRepository
+	voipService    domain.VoIPService
+	events         domain.EventPublisher
+}
+
+func NewAddParticipantUseCase(callRepo domain.CallRepository, conferenceRepo domain.ConferenceRepository, voipService domain.VoIPService, events domain.EventPublisher) *AddParticipantUseCase {
+	return &AddParticipantUseCase{
+		callRepo:       callRepo,
+		conferenceRepo: conferenceRepo,
+		voipService:    voipService,
+		events:         events,
+	}
+}
+
+func (uc *AddParticipantUseCase) Execute(ctx context.Context, input ConferenceParticipantInput) (*ConferenceOutput, error) {
+	conferencer, ok := uc.voipService.(domain.Conferencer)
+	if !ok {
+		return nil, errors.New("conference is not supported")
+	}
+
+	conference, err := activeConference(ctx, uc.conferenceRepo, input.ConferenceID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	call, err := conferenceCandidate(ctx, uc.callRepo, input.CallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := joinConference(ctx, uc.callRepo, conferencer, uc.events, conference, call); err != nil {
+		return nil, err
+	}
+
+	return conferenceOutput(ctx, uc.conferenceRepo, conference)
+}
+
+// RemoveParticipantUseCase hangs up a participant. The conference ends with
+// its last participant.
+type RemoveParticipantUseCase struct {
+	callRepo       domain.CallRepository
+	conferenceRepo domain.ConferenceRepository
+	voipService    domain.VoIPService
+	events         domain.EventPublisher
+	holdPolicy     domain.HoldPolicy
+}
+
+func NewRemoveParticipantUseCase(callRepo domain.CallRepository, conferenceRepo domain.ConferenceRepository, voipService domain.VoIPService, events domain.EventPublisher, holdPolicy domain.HoldPolicy) *RemoveParticipantUseCase {
+	return &RemoveParticipantUseCase{
+		callRepo:       callRepo,
+		conferenceRepo: conferenceRepo,
+		voipService:    voipService,
+		events:         events,
+		holdPolicy:     holdPolicy,
+	}
+}
+
+func (uc *RemoveParticipantUseCase) Execute(ctx context.Context, input ConferenceParticipantInput) (*ConferenceOutput, error) {
+	conference, err := activeConference(ctx, uc.conferenceRepo, input.ConferenceID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	call, err := conferenceParticipant(ctx, uc.callRepo, conference, input.CallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := hangUpParticipant(ctx, uc.callRepo, uc.voipService, uc.events, uc.holdPolicy, call); err != nil {
+		return nil, err
+	}
+
+	if err := endConferenceIfEmpty(ctx, uc.conferenceRepo, uc.voipService, conference); err != nil {
+		return nil, err
+	}
+
+	return conferenceOutput(ctx, uc.conferenceRepo, conference)
+}
+
+type MuteParticipantInput struct {
+	UserID       string
+	ConferenceID string
+	CallID       string
+	Muted        bool
+}
+
+// MuteParticipantUseCase keeps a participant's audio from the conference,
+// or lets it through again.
+type MuteParticipantUseCase struct {
+	callRepo       domain.CallRepository
+	conferenceRepo domain.ConferenceRepository
+	voipService    domain.VoIPService
+	events         domain.EventPublisher
+}
+
+func NewMuteParticipantUseCase(callRepo domain.CallRepository, conferenceRepo domain.ConferenceRepository, voipService domain.VoIPService, events domain.EventPublisher) *MuteParticipantUseCase {
+	return &MuteParticipantUseCase{
+		callRepo:       callRepo,
+		conferenceRepo: conferenceRepo,
+		voipService:    voipService,
+		events:         events,
+	}
+}
+
+func (uc *MuteParticipantUseCase) Execute(ctx context.Context, input MuteParticipantInput) (*ConferenceOutput, error) {
+	conferencer, ok := uc.voipService.(domain.Conferencer)
+	if !ok {
+		return nil, errors.New("conference is not supported")
+	}
+
+	conference, err := activeConference(ctx, uc.conferenceRepo, input.ConferenceID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	call, err := conferenceParticipant(ctx, uc.callRepo, conference, input.CallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if call.Muted != input.Muted {
+		if err := conferencer.JoinConference(ctx, call.SessionID, conference.ID, input.Muted); err != nil {
+			return nil, controlError(err, call, "failed to mute participant")
+		}
+
+		call.Muted = input.Muted
+
+		if err := uc.callRepo.Update(ctx, call); err != nil {
+			slog.Error("failed to update call", "error", err, "call_id", call.ID)
+			return nil, errors.New("failed to update call")
+		}
+
+		slog.Info("conference participant mute changed",
+			"conference_id", conference.ID,
+			"call_id", call.ID,
+			"muted", call.Muted)
+
+		publishCallStatus(ctx, uc.events, call)
+	}
+
+	return conferenceOutput(ctx, uc.conferenceRepo, conference)
+}
+
+type EndConferenceInput struct {
+	UserID       string
+	ConferenceID string
+}
+
+// EndConferenceUseCase hangs up every participant and the host. Ending an
+// ended conference is a no-op.
+type EndConferenceUseCase struct {
+	callRepo       domain.CallRepository
+	conferenceRepo domain.ConferenceRepository
+	voipService    domain.VoIPService
+	events         domain.EventPublisher
+	holdPolicy     domain.HoldPolicy
+}
+
+func NewEndConferenceUseCase(callRepo domain.CallRepository, conferenceRepo domain.ConferenceRepository, voipService domain.VoIPService, events domain.EventPublisher, holdPolicy domain.HoldPolicy) *EndConferenceUseCase {
+	return &EndConferenceUseCase{
+		callRepo:       callRepo,
+		conferenceRepo: conferenceRepo,
+		voipService:    voipService,
+		events:         events,
+		holdPolicy:     holdPolicy,
+	}
+}
+
+func (uc *EndConferenceUseCase) Execute(ctx context.Context, input EndConferenceInput) (*ConferenceOutput, error) {
+	conference, err := ownedConference(ctx, uc.conferenceRepo, input.ConferenceID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if conference.Status == domain.ConferenceStatusActive {
+		participants, err := listParticipants(ctx, uc.conferenceRepo, conference)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, call := range participants {
+			if call.Status.IsTerminal() {
+				continue
+			}
+			if err := hangUpParticipant(ctx, uc.callRepo, uc.voipService, uc.events, uc.holdPolicy, call); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := endConference(ctx, uc.conferenceRepo, uc.voipService, conference); err != nil {
+			return nil, err
+		}
+	}
+
+	return conferenceOutput(ctx, uc.conferenceRepo, conference)
+}
+
+func ownedConference(ctx context.Context, conferenceRepo domain.ConferenceRepository, conferenceID, userID string) (*domain.Conference, error) {
+	if conferenceID == "" {
+		return nil, errors.New("conference_id is required")
+	}
+
+	if userID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	conference, err := conferenceRepo.GetByID(ctx, conferenceID)
+	if err != nil {
+		slog.Error("failed to get conference", "error", err, "conference_id", conferenceID)
+		return nil, errors.New("failed to get conference")
+	}
+
+	if conference == nil {
+		return nil, errors.New("conference not found")
+	}
+
+	if conference.UserID != userID {
+		slog.Warn("unauthorized conference access",
+			"conference_id", conferenceID,
+			"user_id", userID,
+			"conference_user_id", conference.UserID)
+		return nil, errors.New("unauthorized")
+	}
+
+	return conference, nil
+}
+
+func activeConference(ctx context.Context, conferenceRepo domain.ConferenceRepository, conferenceID, userID string) (*domain.Conference, error) {
+	conference, err := ownedConference(ctx, conferenceRepo, conferenceID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if conference.Status != domain.ConferenceStatusActive {
+		return nil, errors.New("conference already ended")
+	}
+	return conference, nil
+}
+
+// conferenceCandidate loads an answered call that may join a conference.
+func conferenceCandidate(ctx context.Context, callRepo domain.CallRepository, callID, userID string) (*domain.Call, error) {
+	call, err := ownedWebRTCCall(ctx, callRepo, callID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := liveCall(call); err != nil {
+		return nil, err
+	}
+
+	if call.ConferenceID != "" {
+		return nil, errors.New("call is already in a conference")
+	}
+	return call, nil
+}
+
+// conferenceParticipant loads a live participant of the conference.
+func conferenceParticipant(ctx context.Context, callRepo domain.CallRepository, conference *domain.Conference, callID, userID string) (*domain.Call, error) {
+	call, err := ownedWebRTCCall(ctx, callRepo, callID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if call.ConferenceID != conference.ID {
+		return nil, errors.New("call is not in the conference")
+	}
+
+	if err := liveCall(call); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// outsideConference rejects call controls that would take a participant
+// out of its conference.
+func outsideConference(call *domain.Call) error {
+	if call.ConferenceID != "" {
+		return errors.New("call is in a conference")
+	}
+	return nil
+}
+
+// joinConference moves the called party of a call into the conference. A
+// held call is taken off hold; the caller's mute no longer applies.
+func joinConference(ctx context.Context, callRepo domain.CallRepository, conferencer domain.Conferencer, events domain.EventPublisher, conference *domain.Conference, call *domain.Call) error {
+	if err := conferencer.JoinConference(ctx, call.SessionID, conference.ID, false); err != nil {
+		return controlError(err, call, "failed to join conference")
+	}
+
+	call.ConferenceID = conference.ID
+	call.Muted = false
+	if call.Status == domain.CallStatusOnHold {
+		call.EndHold(time.Now())
+		call.Status = domain.CallStatusActive
+	}
+
+	if err := callRepo.Update(ctx, call); err != nil {
+		slog.Error("failed to update call", "error", err, "call_id", call.ID)
+		return errors.New("failed to update call")
+	}
+
+	slog.Info("call joined conference", "conference_id", conference.ID, "call_id", call.ID)
+
+	publishCallStatus(ctx, events, call)
+	return nil
+}
+
+func hangUpParticipant(ctx context.Context, callRepo domain.CallRepository, voipService domain.VoIPService, events domain.EventPublisher, holdPolicy domain.HoldPolicy, call *domain.Call) error {
+	if err := voipService.TerminateCall(ctx, call.SessionID); err != nil &&
+		!errors.Is(err, domain.ErrSessionNotFound) && !errors.Is(err, domain.ErrSessionEnded) {
+		slog.Error("failed to terminate voip session",
+			"error", err,
+			"call_id", call.ID,
+			"session_id", call.SessionID)
+		return errors.New("failed to terminate call")
+	}
+
+	holdPolicy.Apply(call, int(time.Since(call.StartTime).Seconds()), time.Now())
+	call.Status = domain.CallStatusCompleted
+
+	if err := callRepo.Update(ctx, call); err != nil {
+		slog.Error("failed to update call", "error", err, "call_id", call.ID)
+		return errors.New("failed to update call")
+	}
+
+	slog.Info("conference participant hung up", "conference_id", call.ConferenceID, "call_id", call.ID)
+
+	publishCallStatus(ctx, events, call)
+	return nil
+}
+
+// endConferenceIfEmpty ends an active conference that has no live
+// participants left.
+func endConferenceIfEmpty(ctx context.Context, conferenceRepo domain.ConferenceRepository, voipService domain.VoIPService, conference *domain.Conference) error {
+	if conference.Status != domain.ConferenceStatusActive {
+		return nil
+	}
+
+	participants, err := listParticipants(ctx, conferenceRepo, conference)
+	if err != nil {
+		return err
+	}
+
+	for _, call := range participants {
+		if !call.Status.IsTerminal() {
+			return nil
+		}
+	}
+
+	return endConference(ctx, conferenceRepo, voipService, conference)
+}
+
+// endConference hangs up the host and closes the conference.
+func endConference(ctx context.Context, conferenceRepo domain.ConferenceRepository, voipService domain.VoIPService, conference *domain.Conference) error {
+	if voipService != nil && conference.HostSessionID != "" {
+		if err := voipService.TerminateCall(ctx, conference.HostSessionID); err != nil &&
+			!errors.Is(err, domain.ErrSessionNotFound) && !errors.Is(err, domain.ErrSessionEnded) {
+			slog.Warn("failed to hang up conference host",
+				"error", err,
+				"conference_id", conference.ID,
+				"session_id", conference.HostSessionID)
+		}
+	}
+
+	conference.End(time.Now())
+
+	if err := conferenceRepo.Update(ctx, conference); err != nil {
+		slog.Error("failed to update conference", "error", err, "conference_id", conference.ID)
+		return errors.New("failed to update conference")
+	}
+
+	slog.Info("conference ended", "conference_id", conference.ID, "duration", conference.Duration)
+	return nil
+}
+
+func listParticipants(ctx context.Context, conferenceRepo domain.ConferenceRepository, conference *domain.Conference) ([]*domain.Call, error) {
+	participants, err := conferenceRepo.ListParticipants(ctx, conference.ID)
+	if err != nil {
+		slog.Error("failed to list conference participants", "error", err, "conference_id", conference.ID)
+		return nil, errors.New("failed to get conference")
+	}
+	return participants, nil
+}
+
+func conferenceOutput(ctx context.Context, conferenceRepo domain.ConferenceRepository, conference *domain.Conference) (*ConferenceOutput, error) {
+	participants, err := listParticipants(ctx, conferenceRepo, conference)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ConferenceOutput{
+		ConferenceID: conference.ID,
+		Status:       string(conference.Status),
+		Participants: make([]ConferenceParticipant, 0, len(participants)),
+	}
+	for _, call := range participants {
+		output.Participants = append(output.Participants, ConferenceParticipant{
+			CallID:      call.ID,
+			PhoneNumber: call.PhoneNumber,
+			Status:      string(call.Status),
+			Muted:       call.Muted,
+		})
+	}
+	return output, nil
+}
@@ -0,0 +1,338 @@
+package calls
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockConferenceRepository struct {
+	calls       *mockCallRepositoryForTransfer
+	conferences map[string]*domain.Conference
+}
+
+func newMockConferenceRepository(calls *mockCallRepositoryForTransfer, conferences ...*domain.Conference) *mockConferenceRepository {
+	m := &mockConferenceRepository{calls: calls, conferences: make(map[string]*domain.Conference)}
+	for _, conference := range conferences {
+		m.conferences[conference.ID] = conference
+	}
+	return m
+}
+
+func (m *mockConferenceRepository) Create(ctx context.Context, conference *domain.Conference) error {
+	conference.ID = "conf-1"
+	m.conferences[conference.ID] = conference
+	return nil
+}
+
+func (m *mockConferenceRepository) Update(ctx context.Context, conference *domain.Conference) error {
+	m.conferences[conference.ID] = conference
+	return nil
+}
+
+func (m *mockConferenceRepository) GetByID(ctx context.Context, id string) (*domain.Conference, error) {
+	return m.conferences[id], nil
+}
+
+func (m *mockConferenceRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Conference, error) {
+	return nil, nil
+}
+
+func (m *mockConferenceRepository) ListParticipants(ctx context.Context, conferenceID string) ([]*domain.Call, error) {
+	var participants []*domain.Call
+	for _, call := range m.calls.calls {
+		if call.ConferenceID == conferenceID {
+			participants = append(participants, call)
+		}
+	}
+	sort.Slice(participants, func(i, j int) bool { return participants[i].ID < participants[j].ID })
+	return participants, nil
+}
+
+type mockVoIPServiceForConference struct {
+	mockVoIPServiceForHold
+	joinError  error
+	joined     map[string]bool
+	terminated []string
+}
+
+func (m *mockVoIPServiceForConference) HostConference(ctx context.Context, conferenceID string, opts domain.CallOptions) (*domain.CallSession, error) {
+	m.opts = opts
+	return &domain.CallSession{SessionID: "sess_host", ProviderCallSID: "CAhost"}, nil
+}
+
+func (m *mockVoIPServiceForConference) JoinConference(ctx context.Context, sessionID, conferenceID string, muted bool) error {
+	if m.joinError != nil {
+		return m.joinError
+	}
+	if m.joined == nil {
+		m.joined = make(map[string]bool)
+	}
+	m.joined[sessionID] = muted
+	return nil
+}
+
+func (m *mockVoIPServiceForConference) TerminateCall(ctx context.Context, sessionID string) error {
+	m.terminated = append(m.terminated, sessionID)
+	return nil
+}
+
+func newConferenceTestCalls() []*domain.Call {
+	first := newDTMFTestCall()
+	first.PhoneNumber = "+491512345678"
+	first.StartTime = time.Now().Add(-time.Minute)
+
+	second := newConsultTestCall()
+	second.ID = "call-2"
+	second.ParentCallID = ""
+	second.TransferMode = ""
+	second.StartTime = time.Now().Add(-30 * time.Second)
+	return []*domain.Call{first, second}
+}
+
+// newConferenceTestRepos puts both test calls into an active conference.
+func newConferenceTestRepos() (*mockCallRepositoryForTransfer, *mockConferenceRepository) {
+	calls := newConferenceTestCalls()
+	for _, call := range calls {
+		call.ConferenceID = "conf-1"
+	}
+	callRepo := newMockCallRepositoryForTransfer(calls...)
+	conferenceRepo := newMockConferenceRepository(callRepo, &domain.Conference{
+		ID:            "conf-1",
+		UserID:        "user-1",
+		Status:        domain.ConferenceStatusActive,
+		HostSessionID: "sess_host",
+		StartTime:     time.Now().Add(-20 * time.Second),
+	})
+	return callRepo, conferenceRepo
+}
+
+func TestCreateConferenceUseCase_Execute_Success(t *testing.T) {
+	calls := newConferenceTestCalls()
+	now := time.Now()
+	calls[1].Status = domain.CallStatusOnHold
+	calls[1].HeldAt = &now
+	calls[1].Muted = true
+	callRepo := newMockCallRepositoryForTransfer(calls...)
+	conferenceRepo := newMockConferenceRepository(callRepo)
+	mockVoIP := &mockVoIPServiceForConference{}
+	events := &mockEventPublisher{}
+
+	uc := NewCreateConferenceUseCase(callRepo, conferenceRepo, mockVoIP, events)
+
+	output, err := uc.Execute(context.Background(), CreateConferenceInput{
+		UserID:  "user-1",
+		CallIDs: []string{"call-1", "call-2"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.ConferenceID != "conf-1" || output.Status != "active" || len(output.Participants) != 2 {
+		t.Fatalf("unexpected output: %+v", output)
+	}
+
+	conference := conferenceRepo.conferences["conf-1"]
+	if conference.HostSessionID != "sess_host" || mockVoIP.opts.Identity != "user-1" {
+		t.Errorf("expected the caller's browser to host the conference, got session '%s' identity '%s'", conference.HostSessionID, mockVoIP.opts.Identity)
+	}
+
+	if muted, ok := mockVoIP.joined["sess_2"]; !ok || muted {
+		t.Errorf("expected sess_2 to join unmuted, got %v", mockVoIP.joined)
+	}
+
+	held := callRepo.calls["call-2"]
+	if held.Status != domain.CallStatusActive || held.HeldAt != nil || held.Muted || held.ConferenceID != "conf-1" {
+		t.Errorf("expected the held call to join active and unmuted, got %+v", held)
+	}
+
+	if len(events.events) != 2 || events.events[0].ConferenceID != "conf-1" {
+		t.Errorf("expected a conference event per call, got %+v", events.events)
+	}
+}
+
+func TestCreateConferenceUseCase_Execute_Invalid(t *testing.T) {
+	calls := newConferenceTestCalls()
+	calls[1].ConferenceID = "conf-0"
+	callRepo := newMockCallRepositoryForTransfer(calls...)
+	conferenceRepo := newMockConferenceRepository(callRepo)
+
+	tests := []struct {
+		name    string
+		voip    domain.VoIPService
+		callIDs []string
+		want    string
+	}{
+		{"not supported", &mockVoIPServiceForHold{}, []string{"call-1", "call-2"}, "conference is not supported"},
+		{"one call", &mockVoIPServiceForConference{}, []string{"call-1"}, "at least two calls are required"},
+		{"duplicate", &mockVoIPServiceForConference{}, []string{"call-1", "call-1"}, "call_ids must be distinct"},
+		{"in a conference", &mockVoIPServiceForConference{}, []string{"call-1", "call-2"}, "call is already in a conference"},
+	}
+	for _, tt := range tests {
+		uc := NewCreateConferenceUseCase(callRepo, conferenceRepo, tt.voip, nil)
+
+		_, err := uc.Execute(context.Background(), CreateConferenceInput{UserID: "user-1", CallIDs: tt.callIDs})
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%s: expected error '%s', got %v", tt.name, tt.want, err)
+		}
+	}
+
+	if len(conferenceRepo.conferences) != 0 {
+		t.Error("expected no conference to be created")
+	}
+}
+
+func TestMuteParticipantUseCase_Execute(t *testing.T) {
+	callRepo, conferenceRepo := newConferenceTestRepos()
+	mockVoIP := &mockVoIPServiceForConference{}
+
+	uc := NewMuteParticipantUseCase(callRepo, conferenceRepo, mockVoIP, nil)
+
+	output, err := uc.Execute(context.Background(), MuteParticipantInput{
+		UserID:       "user-1",
+		ConferenceID: "conf-1",
+		CallID:       "call-2",
+		Muted:        true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if muted, ok := mockVoIP.joined["sess_2"]; !ok || !muted {
+		t.Errorf("expected sess_2 to rejoin muted, got %v", mockVoIP.joined)
+	}
+
+	if !output.Participants[1].Muted || output.Participants[0].Muted {
+		t.Errorf("expected only call-2 to be muted, got %+v", output.Participants)
+	}
+}
+
+func TestMuteParticipantUseCase_Execute_NotInConference(t *testing.T) {
+	callRepo, conferenceRepo := newConferenceTestRepos()
+	callRepo.calls["call-2"].ConferenceID = ""
+
+	uc := NewMuteParticipantUseCase(callRepo, conferenceRepo, &mockVoIPServiceForConference{}, nil)
+
+	_, err := uc.Execute(context.Background(), MuteParticipantInput{
+		UserID:       "user-1",
+		ConferenceID: "conf-1",
+		CallID:       "call-2",
+		Muted:        true,
+	})
+	if err == nil || err.Error() != "call is not in the conference" {
+		t.Errorf("expected error 'call is not in the conference', got %v", err)
+	}
+}
+
+func TestRemoveParticipantUseCase_Execute_LastParticipantEndsConference(t *testing.T) {
+	callRepo, conferenceRepo := newConferenceTestRepos()
+	mockVoIP := &mockVoIPServiceForConference{}
+
+	uc := NewRemoveParticipantUseCase(callRepo, conferenceRepo, mockVoIP, nil, domain.HoldTimeIncluded)
+
+	output, err := uc.Execute(context.Background(), ConferenceParticipantInput{UserID: "user-1", ConferenceID: "conf-1", CallID: "call-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.Status != "active" || callRepo.calls["call-1"].Status != domain.CallStatusCompleted {
+		t.Fatalf("expected call-1 to end and the conference to go on, got %+v", output)
+	}
+
+	output, err = uc.Execute(context.Background(), ConferenceParticipantInput{UserID: "user-1", ConferenceID: "conf-1", CallID: "call-2"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.Status != "ended" {
+		t.Errorf("expected the conference to end with its last participant, got '%s'", output.Status)
+	}
+
+	if len(mockVoIP.terminated) != 3 || mockVoIP.terminated[2] != "sess_host" {
+		t.Errorf("expected both participants and the host to be hung up, got %v", mockVoIP.terminated)
+	}
+
+	if conference := conferenceRepo.conferences["conf-1"]; conference.EndTime == nil || conference.Duration < 20 {
+		t.Errorf("expected the conference to record its duration, got %+v", conference)
+	}
+}
+
+func TestEndConferenceUseCase_Execute(t *testing.T) {
+	callRepo, conferenceRepo := newConferenceTestRepos()
+	callRepo.calls["call-1"].Status = domain.CallStatusCompleted
+	mockVoIP := &mockVoIPServiceForConference{}
+	events := &mockEventPublisher{}
+
+	uc := NewEndConferenceUseCase(callRepo, conferenceRepo, mockVoIP, events, domain.HoldTimeIncluded)
+
+	output, err := uc.Execute(context.Background(), EndConferenceInput{UserID: "user-1", ConferenceID: "conf-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.Status != "ended" || callRepo.calls["call-2"].Status != domain.CallStatusCompleted {
+		t.Errorf("expected the conference and call-2 to end, got %+v", output)
+	}
+
+	if len(mockVoIP.terminated) != 2 || mockVoIP.terminated[0] != "sess_2" || mockVoIP.terminated[1] != "sess_host" {
+		t.Errorf("expected the live participant and the host to be hung up, got %v", mockVoIP.terminated)
+	}
+
+	if _, err := uc.Execute(context.Background(), EndConferenceInput{UserID: "user-1", ConferenceID: "conf-1"}); err != nil {
+		t.Errorf("expected ending an ended conference to succeed, got %v", err)
+	}
+
+	if len(mockVoIP.terminated) != 2 || len(events.events) != 1 {
+		t.Errorf("expected ending again to change nothing, got %v", mockVoIP.terminated)
+	}
+}
+
+func TestEndConferenceUseCase_Execute_Unauthorized(t *testing.T) {
+	callRepo, conferenceRepo := newConferenceTestRepos()
+
+	uc := NewEndConferenceUseCase(callRepo, conferenceRepo, &mockVoIPServiceForConference{}, nil, domain.HoldTimeIncluded)
+
+	_, err := uc.Execute(context.Background(), EndConferenceInput{UserID: "user-2", ConferenceID: "conf-1"})
+	if err == nil || err.Error() != "unauthorized" {
+		t.Errorf("expected error 'unauthorized', got %v", err)
+	}
+}
+
+func TestUpdateCallStatusUseCase_Execute_ClosesEmptyConference(t *testing.T) {
+	callRepo, conferenceRepo := newConferenceTestRepos()
+	callRepo.calls["call-1"].Status = domain.CallStatusCompleted
+	callRepo.calls["call-2"].ProviderCallSID = "CA2"
+	mockVoIP := &mockVoIPServiceForConference{}
+
+	uc := NewUpdateCallStatusUseCase(callRepo, conferenceRepo, mockVoIP, nil, domain.HoldTimeIncluded)
+
+	_, err := uc.Execute(context.Background(), UpdateCallStatusInput{
+		CallID: "call-2",
+		Status: domain.CallStatusCompleted,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if conference := conferenceRepo.conferences["conf-1"]; conference.Status != domain.ConferenceStatusEnded {
+		t.Errorf("expected the conference to end, got '%s'", conference.Status)
+	}
+
+	if len(mockVoIP.terminated) != 1 || mockVoIP.terminated[0] != "sess_host" {
+		t.Errorf("expected the host to be hung up, got %v", mockVoIP.terminated)
+	}
+}
+
+func TestHoldCallUseCase_Execute_ConferenceParticipant(t *testing.T) {
+	callRepo, _ := newConferenceTestRepos()
+
+	uc := NewHoldCallUseCase(callRepo, &mockVoIPServiceForConference{}, nil)
+
+	_, err := uc.Execute(context.Background(), HoldCallInput{UserID: "user-1", CallID: "call-1"})
+	if err == nil || err.Error() != "call is in a conference" {
+		t.Errorf("expected error 'call is in a conference', got %v", err)
+	}
+}
@@ -15,11 +15,13 @@ type EndCallInput struct {
 }
 
 type EndCallUseCase struct {
-	callRepo domain.CallRepository
+	callRepo   domain.CallRepository
+	events     domain.EventPublisher
+	holdPolicy domain.HoldPolicy
 }
 
-func NewEndCallUseCase(callRepo domain.CallRepository) *EndCallUseCase {
-	return &EndCallUseCase{callRepo: callRepo}
+func NewEndCallUseCase(callRepo domain.CallRepository, events domain.EventPublisher, holdPolicy domain.HoldPolicy) *EndCallUseCase {
+	return &EndCallUseCase{callRepo: callRepo, events: events, holdPolicy: holdPolicy}
 }
 
 func (uc *EndCallUseCase) Execute(ctx context.Context, input EndCallInput) error {
@@ -41,8 +43,8 @@ func (uc *EndCallUseCase) Execute(ctx context.Context, input EndCallInput) error
 		return errors.New("unauthorized")
 	}
 
-	duration := int(time.Since(call.StartTime).Seconds())
-	call.Duration = duration
+	uc.holdPolicy.Apply(call, int(time.Since(call.StartTime).Seconds()), time.Now())
+	duration := call.Duration
 	call.Status = domain.CallStatusCompleted
 
 	if err := uc.callRepo.Update(ctx, call); err != nil {
@@ -52,5 +54,7 @@ func (uc *EndCallUseCase) Execute(ctx context.Context, input EndCallInput) error
 
 	slog.Info("call ended", "call_id", call.ID, "user_id", input.UserID, "duration", duration)
 
+	publishCallStatus(ctx, uc.events, call)
+
 	return nil
 }
@@ -0,0 +1,32 @@
+package calls
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+// publishCallStatus tells the call owner's clients about the call's current
+// status. Delivery is best effort: a failed publish never fails the use case.
+func publishCallStatus(ctx context.Context, events domain.EventPublisher, call *domain.Call) {
+	if events == nil {
+		return
+	}
+
+	event := &domain.CallEvent{
+		Type:         domain.CallEventStatusChanged,
+		UserID:       call.UserID,
+		CallID:       call.ID,
+		Status:       call.Status,
+		Duration:     call.Duration,
+		OccurredAt:   time.Now(),
+		Muted:        call.Muted,
+		ParentCallID: call.ParentCallID,
+		ConferenceID: call.ConferenceID,
+	}
+	if err := events.Publish(ctx, event); err != nil {
+		slog.Warn("failed to publish call event", "error", err, "call_id", call.ID)
+	}
+}
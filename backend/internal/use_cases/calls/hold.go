@@ -0,0 +1,196 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type HoldCallInput struct {
+	UserID string
+	CallID string
+}
+
+type HoldCallOutput struct {
+	CallID string
+	Status string
+}
+
+// HoldCallUseCase puts an answered call on hold. Holding a held call is a
+// no-op.
+type HoldCallUseCase struct {
+	callRepo    domain.CallRepository
+	voipService domain.VoIPService
+	events      domain.EventPublisher
+}
+
+func NewHoldCallUseCase(callRepo domain.CallRepository, voipService domain.VoIPService, events domain.EventPublisher) *HoldCallUseCase {
+	return &HoldCallUseCase{
+		callRepo:    callRepo,
+		voipService: voipService,
+		events:      events,
+	}
+}
+
+func (uc *HoldCallUseCase) Execute(ctx context.Context, input HoldCallInput) (*HoldCallOutput, error) {
+	holder, ok := uc.voipService.(domain.CallHolder)
+	if !ok {
+		return nil, errors.New("hold is not supported")
+	}
+
+	call, err := ownedWebRTCCall(ctx, uc.callRepo, input.CallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if call.Status == domain.CallStatusOnHold {
+		return &HoldCallOutput{CallID: call.ID, Status: string(call.Status)}, nil
+	}
+
+	if err := liveCall(call); err != nil {
+		return nil, err
+	}
+
+	if err := outsideConference(call); err != nil {
+		return nil, err
+	}
+
+	if err := putOnHold(ctx, uc.callRepo, holder, uc.events, call); err != nil {
+		return nil, err
+	}
+
+	return &HoldCallOutput{
+		CallID: call.ID,
+		Status: string(call.Status),
+	}, nil
+}
+
+type ResumeCallInput struct {
+	UserID string
+	CallID string
+}
+
+type ResumeCallOutput struct {
+	CallID string
+	Status string
+	// HoldDuration is the call's total time on hold, in seconds.
+	HoldDuration int
+}
+
+// ResumeCallUseCase reconnects a held call. Resuming an active call is a
+// no-op.
+type ResumeCallUseCase struct {
+	callRepo    domain.CallRepository
+	voipService domain.VoIPService
+	events      domain.EventPublisher
+}
+
+func NewResumeCallUseCase(callRepo domain.CallRepository, voipService domain.VoIPService, events domain.EventPublisher) *ResumeCallUseCase {
+	return &ResumeCallUseCase{
+		callRepo:    callRepo,
+		voipService: voipService,
+		events:      events,
+	}
+}
+
+func (uc *ResumeCallUseCase) Execute(ctx context.Context, input ResumeCallInput) (*ResumeCallOutput, error) {
+	holder, ok := uc.voipService.(domain.CallHolder)
+	if !ok {
+		return nil, errors.New("hold is not supported")
+	}
+
+	call, err := ownedWebRTCCall(ctx, uc.callRepo, input.CallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if call.Status == domain.CallStatusActive {
+		return &ResumeCallOutput{CallID: call.ID, Status: string(call.Status), HoldDuration: call.HoldDuration}, nil
+	}
+
+	if call.Status.IsTerminal() {
+		return nil, errors.New("call already ended")
+	}
+
+	if call.Status != domain.CallStatusOnHold {
+		return nil, errors.New("call is not on hold")
+	}
+
+	err = holder.Resume(ctx, call.SessionID, domain.CallOptions{
+		Identity: call.UserID,
+		Record:   call.Record,
+	})
+	if err != nil {
+		return nil, controlError(err, call, "failed to resume call")
+	}
+
+	call.EndHold(time.Now())
+	call.Status = domain.CallStatusActive
+
+	if err := uc.callRepo.Update(ctx, call); err != nil {
+		slog.Error("failed to update call", "error", err, "call_id", call.ID)
+		return nil, errors.New("failed to update call")
+	}
+
+	slog.Info("call resumed",
+		"call_id", call.ID,
+		"session_id", call.SessionID,
+		"hold_duration", call.HoldDuration)
+
+	publishCallStatus(ctx, uc.events, call)
+
+	return &ResumeCallOutput{
+		CallID:       call.ID,
+		Status:       string(call.Status),
+		HoldDuration: call.HoldDuration,
+	}, nil
+}
+
+// putOnHold holds an active call and records it.
+func putOnHold(ctx context.Context, callRepo domain.CallRepository, holder domain.CallHolder, events domain.EventPublisher, call *domain.Call) error {
+	if err := holder.Hold(ctx, call.SessionID); err != nil {
+		return controlError(err, call, "failed to hold call")
+	}
+
+	now := time.Now()
+	call.Status = domain.CallStatusOnHold
+	call.HeldAt = &now
+
+	if err := callRepo.Update(ctx, call); err != nil {
+		slog.Error("failed to update call", "error", err, "call_id", call.ID)
+		return errors.New("failed to update call")
+	}
+
+	slog.Info("call on hold", "call_id", call.ID, "session_id", call.SessionID)
+
+	publishCallStatus(ctx, events, call)
+	return nil
+}
+
+// liveCall checks that a call has been answered and not ended yet.
+func liveCall(call *domain.Call) error {
+	if call.Status.IsTerminal() {
+		return errors.New("call already ended")
+	}
+
+	if call.Status != domain.CallStatusActive && call.Status != domain.CallStatusOnHold {
+		return errors.New("call is not active")
+	}
+	return nil
+}
+
+// controlError maps a VoIP session error from a call control to the use
+// case error, logging failures as failure.
+func controlError(err error, call *domain.Call, failure string) error {
+	if errors.Is(err, domain.ErrSessionNotFound) || errors.Is(err, domain.ErrSessionEnded) {
+		return errors.New("call already ended")
+	}
+	slog.Error(failure,
+		"error", err,
+		"call_id", call.ID,
+		"session_id", call.SessionID)
+	return errors.New(failure)
+}
@@ -0,0 +1,204 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockVoIPServiceForHold struct {
+	mockVoIPServiceForDTMF
+	holdError   error
+	resumeError error
+	muteError   error
+	held        string
+	resumed     string
+	muted       *bool
+	opts        domain.CallOptions
+}
+
+func (m *mockVoIPServiceForHold) Hold(ctx context.Context, sessionID string) error {
+	if m.holdError != nil {
+		return m.holdError
+	}
+	m.held = sessionID
+	return nil
+}
+
+func (m *mockVoIPServiceForHold) Resume(ctx context.Context, sessionID string, opts domain.CallOptions) error {
+	if m.resumeError != nil {
+		return m.resumeError
+	}
+	m.resumed = sessionID
+	m.opts = opts
+	return nil
+}
+
+func (m *mockVoIPServiceForHold) SetMuted(ctx context.Context, sessionID string, muted bool) error {
+	if m.muteError != nil {
+		return m.muteError
+	}
+	m.muted = &muted
+	return nil
+}
+
+func newHeldTestCall() *domain.Call {
+	call := newDTMFTestCall()
+	heldAt := time.Now().Add(-30 * time.Second)
+	call.Status = domain.CallStatusOnHold
+	call.HeldAt = &heldAt
+	call.HoldDuration = 10
+	return call
+}
+
+func TestHoldCallUseCase_Execute_Success(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newDTMFTestCall()}
+	mockVoIP := &mockVoIPServiceForHold{}
+	events := &mockEventPublisher{}
+
+	uc := NewHoldCallUseCase(mockRepo, mockVoIP, events)
+
+	output, err := uc.Execute(context.Background(), HoldCallInput{UserID: "user-1", CallID: "call-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if mockVoIP.held != "sess_1" {
+		t.Errorf("expected session sess_1 on hold, got '%s'", mockVoIP.held)
+	}
+	if output.Status != string(domain.CallStatusOnHold) {
+		t.Errorf("expected status on_hold, got '%s'", output.Status)
+	}
+	if mockRepo.updatedCall == nil || mockRepo.updatedCall.HeldAt == nil {
+		t.Fatal("expected call updated with hold start")
+	}
+
+	if len(events.events) != 1 || events.events[0].Status != domain.CallStatusOnHold {
+		t.Errorf("expected on_hold event, got %+v", events.events)
+	}
+}
+
+func TestHoldCallUseCase_Execute_AlreadyHeld(t *testing.T) {
+	mockVoIP := &mockVoIPServiceForHold{}
+	uc := NewHoldCallUseCase(&mockCallRepositoryForTerminate{call: newHeldTestCall()}, mockVoIP, nil)
+
+	output, err := uc.Execute(context.Background(), HoldCallInput{UserID: "user-1", CallID: "call-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if output.Status != string(domain.CallStatusOnHold) || mockVoIP.held != "" {
+		t.Errorf("expected no-op hold, got status %s held %s", output.Status, mockVoIP.held)
+	}
+}
+
+func TestHoldCallUseCase_Execute_Errors(t *testing.T) {
+	voiceSDK := newDTMFTestCall()
+	voiceSDK.SessionID = "voice_sdk"
+	ringing := newDTMFTestCall()
+	ringing.Status = domain.CallStatusConnecting
+	ended := newDTMFTestCall()
+	ended.Status = domain.CallStatusCompleted
+
+	cases := []struct {
+		name      string
+		call      *domain.Call
+		holdError error
+		expected  string
+	}{
+		{"not found", nil, nil, "call not found"},
+		{"voice sdk call", voiceSDK, nil, "call has no webrtc session"},
+		{"not answered", ringing, nil, "call is not active"},
+		{"ended", ended, nil, "call already ended"},
+		{"session gone", newDTMFTestCall(), domain.ErrSessionEnded, "call already ended"},
+		{"provider failure", newDTMFTestCall(), errors.New("provider down"), "failed to hold call"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			events := &mockEventPublisher{}
+			uc := NewHoldCallUseCase(&mockCallRepositoryForTerminate{call: tc.call}, &mockVoIPServiceForHold{holdError: tc.holdError}, events)
+
+			_, err := uc.Execute(context.Background(), HoldCallInput{UserID: "user-1", CallID: "call-1"})
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("expected error '%s', got %v", tc.expected, err)
+			}
+			if len(events.events) != 0 {
+				t.Errorf("expected no event, got %+v", events.events)
+			}
+		})
+	}
+}
+
+func TestHoldCallUseCase_Execute_NotSupported(t *testing.T) {
+	uc := NewHoldCallUseCase(&mockCallRepositoryForTerminate{call: newDTMFTestCall()}, &mockVoIPServiceForDTMF{}, nil)
+
+	_, err := uc.Execute(context.Background(), HoldCallInput{UserID: "user-1", CallID: "call-1"})
+	if err == nil || err.Error() != "hold is not supported" {
+		t.Errorf("expected hold is not supported error, got %v", err)
+	}
+}
+
+func TestResumeCallUseCase_Execute_Success(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newHeldTestCall()}
+	mockVoIP := &mockVoIPServiceForHold{}
+	events := &mockEventPublisher{}
+
+	uc := NewResumeCallUseCase(mockRepo, mockVoIP, events)
+
+	output, err := uc.Execute(context.Background(), ResumeCallInput{UserID: "user-1", CallID: "call-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if mockVoIP.resumed != "sess_1" {
+		t.Errorf("expected session sess_1 resumed, got '%s'", mockVoIP.resumed)
+	}
+	if mockVoIP.opts.Identity != "user-1" || !mockVoIP.opts.Record {
+		t.Errorf("expected call options of the recorded call, got %+v", mockVoIP.opts)
+	}
+	if output.Status != string(domain.CallStatusActive) {
+		t.Errorf("expected status active, got '%s'", output.Status)
+	}
+	if output.HoldDuration < 40 {
+		t.Errorf("expected hold duration of at least 40s, got %d", output.HoldDuration)
+	}
+	if mockRepo.updatedCall == nil || mockRepo.updatedCall.HeldAt != nil {
+		t.Error("expected call updated with hold ended")
+	}
+
+	if len(events.events) != 1 || events.events[0].Status != domain.CallStatusActive {
+		t.Errorf("expected active event, got %+v", events.events)
+	}
+}
+
+func TestResumeCallUseCase_Execute_Errors(t *testing.T) {
+	ringing := newDTMFTestCall()
+	ringing.Status = domain.CallStatusConnecting
+	ended := newHeldTestCall()
+	ended.Status = domain.CallStatusCompleted
+
+	cases := []struct {
+		name        string
+		call        *domain.Call
+		resumeError error
+		expected    string
+	}{
+		{"not found", nil, nil, "call not found"},
+		{"not held", ringing, nil, "call is not on hold"},
+		{"ended", ended, nil, "call already ended"},
+		{"session gone", newHeldTestCall(), domain.ErrSessionNotFound, "call already ended"},
+		{"provider failure", newHeldTestCall(), errors.New("provider down"), "failed to resume call"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := NewResumeCallUseCase(&mockCallRepositoryForTerminate{call: tc.call}, &mockVoIPServiceForHold{resumeError: tc.resumeError}, nil)
+
+			_, err := uc.Execute(context.Background(), ResumeCallInput{UserID: "user-1", CallID: "call-1"})
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("expected error '%s', got %v", tc.expected, err)
+			}
+		})
+	}
+}
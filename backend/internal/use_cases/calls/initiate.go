@@ -4,17 +4,27 @@ import (
 	"context"
 	"errors"
 	"log/slog"
-	"regexp"
 	"time"
 
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/phone"
 )
 
-var e164Re = regexp.MustCompile(`^\+[1-9]\d{6,14}$`)
-
 type InitiateCallInput struct {
-	UserID      string
+	UserID string
+	// PhoneNumber may be written as people type it; national numbers need
+	// Region.
 	PhoneNumber string
+	// Region is the ISO 3166-1 country code national numbers are read in,
+	// and the one short codes are looked up for.
+	Region string
+	// Record asks for this call to be recorded.
+	Record bool
+	// CallerID is one of the user's verified numbers to show to the called
+	// party; empty uses the user's default caller ID, if any.
+	CallerID string
+	// ConfirmOffHours places the call even in the destination's quiet hours.
+	ConfirmOffHours bool
 }
 
 type InitiateCallOutput struct {
@@ -24,6 +34,17 @@ type InitiateCallOutput struct {
 	Status     string
 	StartTime  time.Time
 	VoiceToken string
+	// PhoneNumber is the number dialled: E.164, or a short code routed to
+	// the carrier.
+	PhoneNumber string
+	// Number describes PhoneNumber; it is nil for a short code.
+	Number    *phone.Number
+	ShortCode *phone.ShortCode
+	Record    bool
+	CallerID  string
+	// OffHours is set when the call was placed in the destination's quiet
+	// hours.
+	OffHours *OffHours
 }
 
 type VoiceTokenGenerator interface {
@@ -32,15 +53,34 @@ type VoiceTokenGenerator interface {
 
 type InitiateCallUseCase struct {
 	callRepo       domain.CallRepository
+	callerIDs      domain.CallerIDRepository
 	voipService    domain.VoIPService
 	tokenGenerator VoiceTokenGenerator
+	events         domain.EventPublisher
+	recording      *domain.RecordingPolicy
+	quietHours     *QuietHoursGuard
+	shortCodes     *ShortCodeGuard
 }
 
-func NewInitiateCallUseCase(callRepo domain.CallRepository, voipService domain.VoIPService, tokenGenerator VoiceTokenGenerator) *InitiateCallUseCase {
+// InitiateCallGuards are the checks a number passes before it is dialled.
+// QuietHours and ShortCodes are required; a nil Recording policy asks for
+// consent everywhere.
+type InitiateCallGuards struct {
+	Recording  *domain.RecordingPolicy
+	QuietHours *QuietHoursGuard
+	ShortCodes *ShortCodeGuard
+}
+
+func NewInitiateCallUseCase(callRepo domain.CallRepository, callerIDs domain.CallerIDRepository, voipService domain.VoIPService, tokenGenerator VoiceTokenGenerator, events domain.EventPublisher, guards InitiateCallGuards) *InitiateCallUseCase {
 	return &InitiateCallUseCase{
 		callRepo:       callRepo,
+		callerIDs:      callerIDs,
 		voipService:    voipService,
 		tokenGenerator: tokenGenerator,
+		events:         events,
+		recording:      guards.Recording,
+		quietHours:     guards.QuietHours,
+		shortCodes:     guards.ShortCodes,
 	}
 }
 
@@ -53,8 +93,30 @@ func (uc *InitiateCallUseCase) Execute(ctx context.Context, input InitiateCallIn
 		return nil, errors.New("phone_number is required")
 	}
 
-	if !e164Re.MatchString(input.PhoneNumber) {
-		return nil, domain.ErrInvalidPhoneNumber
+	number, shortCode, err := uc.shortCodes.Destination(ctx, ShortCodeAttempt{
+		UserID:      input.UserID,
+		PhoneNumber: input.PhoneNumber,
+		Region:      input.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	input.PhoneNumber = dialledNumber(number, shortCode)
+
+	// A caller who must record for compliance learns before dialling that
+	// the destination does not allow it.
+	if input.Record && uc.recording.Rule(input.PhoneNumber) == domain.RecordingForbidden {
+		return nil, domain.ErrRecordingForbidden
+	}
+
+	offHours, err := uc.quietHours.Check(ctx, input.UserID, input.PhoneNumber, input.ConfirmOffHours)
+	if err != nil {
+		return nil, err
+	}
+
+	callerID, err := uc.resolveCallerID(ctx, input.UserID, input.CallerID)
+	if err != nil {
+		return nil, err
 	}
 
 	if uc.tokenGenerator != nil {
@@ -66,6 +128,8 @@ func (uc *InitiateCallUseCase) Execute(ctx context.Context, input InitiateCallIn
 			Status:      domain.CallStatusConnecting,
 			SessionID:   "voice_sdk",
 			SDPOffer:    "",
+			Record:      input.Record,
+			CallerID:    callerID,
 		}
 		if err := uc.callRepo.Create(ctx, call); err != nil {
 			slog.Error("failed to create call record", "error", err, "user_id", input.UserID)
@@ -77,17 +141,28 @@ func (uc *InitiateCallUseCase) Execute(ctx context.Context, input InitiateCallIn
 			return nil, errors.New("failed to generate voice token")
 		}
 		slog.Info("call initiated with voice sdk", "call_id", call.ID, "user_id", input.UserID, "phone", input.PhoneNumber)
+		publishCallStatus(ctx, uc.events, call)
 		return &InitiateCallOutput{
-			CallID:     call.ID,
-			SessionID:  call.SessionID,
-			SDPOffer:   "",
-			Status:     string(call.Status),
-			StartTime:  call.StartTime,
-			VoiceToken: token,
+			CallID:      call.ID,
+			SessionID:   call.SessionID,
+			SDPOffer:    "",
+			Status:      string(call.Status),
+			StartTime:   call.StartTime,
+			VoiceToken:  token,
+			PhoneNumber: input.PhoneNumber,
+			Number:      number,
+			ShortCode:   shortCode,
+			Record:      input.Record,
+			CallerID:    callerID,
+			OffHours:    offHours,
 		}, nil
 	}
 
-	session, err := uc.voipService.InitiateCall(ctx, input.PhoneNumber)
+	session, err := uc.voipService.InitiateCall(ctx, input.PhoneNumber, domain.CallOptions{
+		Identity: input.UserID,
+		Record:   input.Record,
+		CallerID: callerID,
+	})
 	if err != nil {
 		if errors.Is(err, domain.ErrInvalidPhoneNumber) {
 			return nil, err
@@ -100,13 +175,16 @@ func (uc *InitiateCallUseCase) Execute(ctx context.Context, input InitiateCallIn
 	}
 
 	call := &domain.Call{
-		UserID:      input.UserID,
-		PhoneNumber: input.PhoneNumber,
-		StartTime:   time.Now(),
-		Duration:    0,
-		Status:      domain.CallStatusConnecting,
-		SessionID:   session.SessionID,
-		SDPOffer:    session.SDPOffer,
+		UserID:          input.UserID,
+		PhoneNumber:     input.PhoneNumber,
+		StartTime:       time.Now(),
+		Duration:        0,
+		Status:          domain.CallStatusConnecting,
+		SessionID:       session.SessionID,
+		ProviderCallSID: session.ProviderCallSID,
+		SDPOffer:        session.SDPOffer,
+		Record:          input.Record,
+		CallerID:        callerID,
 	}
 
 	if err := uc.callRepo.Create(ctx, call); err != nil {
@@ -123,12 +201,53 @@ func (uc *InitiateCallUseCase) Execute(ctx context.Context, input InitiateCallIn
 		"session_id", session.SessionID,
 		"phone", input.PhoneNumber)
 
+	publishCallStatus(ctx, uc.events, call)
+
 	return &InitiateCallOutput{
-		CallID:    call.ID,
-		SessionID: session.SessionID,
-		SDPOffer:  session.SDPOffer,
-		Status:    string(call.Status),
-		StartTime: call.StartTime,
+		CallID:      call.ID,
+		SessionID:   session.SessionID,
+		SDPOffer:    session.SDPOffer,
+		Status:      string(call.Status),
+		StartTime:   call.StartTime,
+		PhoneNumber: input.PhoneNumber,
+		Number:      number,
+		ShortCode:   shortCode,
+		Record:      input.Record,
+		CallerID:    callerID,
+		OffHours:    offHours,
 	}, nil
 }
 
+// resolveCallerID returns the number to show to the called party: the one
+// asked for, which must be the user's verified number, or else the user's
+// default. Empty leaves the provider's own number.
+func (uc *InitiateCallUseCase) resolveCallerID(ctx context.Context, userID, number string) (string, error) {
+	if uc.callerIDs == nil {
+		if number != "" {
+			return "", domain.ErrCallerIDNotVerified
+		}
+		return "", nil
+	}
+
+	var callerID *domain.CallerID
+	var err error
+	if number != "" {
+		callerID, err = uc.callerIDs.GetByUserIDAndNumber(ctx, userID, number)
+	} else {
+		callerID, err = uc.callerIDs.GetDefault(ctx, userID)
+	}
+	if err != nil {
+		slog.Error("failed to get caller id", "error", err, "user_id", userID)
+		return "", errors.New("failed to get caller id")
+	}
+
+	if callerID == nil || !callerID.Verified() {
+		if number != "" {
+			return "", domain.ErrCallerIDNotVerified
+		}
+		return "", nil
+	}
+
+	return callerID.Number, nil
+}
+
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/phone"
 )
 
 type mockCallRepository struct {
@@ -32,16 +33,22 @@ func (m *mockCallRepository) GetByID(ctx context.Context, id string) (*domain.Ca
 	return nil, nil
 }
 
-func (m *mockCallRepository) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Call, error) {
+func (m *mockCallRepository) GetByProviderCallSID(ctx context.Context, providerCallSID string) (*domain.Call, error) {
+	return nil, nil
+}
+
+func (m *mockCallRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Call, error) {
 	return nil, nil
 }
 
 type mockVoIPService struct {
 	initiateError error
 	session       *domain.CallSession
+	opts          domain.CallOptions
 }
 
-func (m *mockVoIPService) InitiateCall(ctx context.Context, phoneNumber string) (*domain.CallSession, error) {
+func (m *mockVoIPService) InitiateCall(ctx context.Context, phoneNumber string, opts domain.CallOptions) (*domain.CallSession, error) {
+	m.opts = opts
 	if m.initiateError != nil {
 		return nil, m.initiateError
 	}
@@ -56,20 +63,30 @@ func (m *mockVoIPService) GetSessionStatus(ctx context.Context, sessionID string
 	return domain.SessionStatusActive, nil
 }
 
+// newInitiateTestGuards places calls at any hour and refuses every short
+// code.
+func newInitiateTestGuards() InitiateCallGuards {
+	return InitiateCallGuards{
+		QuietHours: NewQuietHoursGuard(nil, domain.QuietHours{Mode: domain.QuietHoursOff}),
+		ShortCodes: NewShortCodeGuard(nil, nil),
+	}
+}
+
 func TestInitiateCallUseCase_Execute_Success(t *testing.T) {
 	mockRepo := &mockCallRepository{}
 	mockVoIP := &mockVoIPService{
 		session: &domain.CallSession{
-			SessionID:   "test-session-id",
-			PhoneNumber: "+491512345678",
-			SDPOffer:    "test-sdp-offer",
-			Status:      domain.SessionStatusInitialized,
-			CreatedAt:   time.Now(),
-			ExpiresAt:   time.Now().Add(5 * time.Minute),
+			SessionID:       "test-session-id",
+			ProviderCallSID: "CA123",
+			PhoneNumber:     "+491512345678",
+			SDPOffer:        "test-sdp-offer",
+			Status:          domain.SessionStatusInitialized,
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(5 * time.Minute),
 		},
 	}
 
-	uc := NewInitiateCallUseCase(mockRepo, mockVoIP, nil)
+	uc := NewInitiateCallUseCase(mockRepo, nil, mockVoIP, nil, nil, newInitiateTestGuards())
 
 	input := InitiateCallInput{
 		UserID:      "test-user-id",
@@ -109,13 +126,17 @@ func TestInitiateCallUseCase_Execute_Success(t *testing.T) {
 	if mockRepo.createdCall.SessionID != "test-session-id" {
 		t.Errorf("expected created call session_id 'test-session-id', got '%s'", mockRepo.createdCall.SessionID)
 	}
+
+	if mockRepo.createdCall.ProviderCallSID != "CA123" {
+		t.Errorf("expected created call provider_call_sid 'CA123', got '%s'", mockRepo.createdCall.ProviderCallSID)
+	}
 }
 
 func TestInitiateCallUseCase_Execute_MissingUserID(t *testing.T) {
 	mockRepo := &mockCallRepository{}
 	mockVoIP := &mockVoIPService{}
 
-	uc := NewInitiateCallUseCase(mockRepo, mockVoIP, nil)
+	uc := NewInitiateCallUseCase(mockRepo, nil, mockVoIP, nil, nil, newInitiateTestGuards())
 
 	input := InitiateCallInput{
 		UserID:      "",
@@ -141,7 +162,7 @@ func TestInitiateCallUseCase_Execute_MissingPhoneNumber(t *testing.T) {
 	mockRepo := &mockCallRepository{}
 	mockVoIP := &mockVoIPService{}
 
-	uc := NewInitiateCallUseCase(mockRepo, mockVoIP, nil)
+	uc := NewInitiateCallUseCase(mockRepo, nil, mockVoIP, nil, nil, newInitiateTestGuards())
 
 	input := InitiateCallInput{
 		UserID:      "test-user-id",
@@ -169,7 +190,7 @@ func TestInitiateCallUseCase_Execute_VoIPFailure(t *testing.T) {
 		initiateError: errors.New("voip service unavailable"),
 	}
 
-	uc := NewInitiateCallUseCase(mockRepo, mockVoIP, nil)
+	uc := NewInitiateCallUseCase(mockRepo, nil, mockVoIP, nil, nil, newInitiateTestGuards())
 
 	input := InitiateCallInput{
 		UserID:      "test-user-id",
@@ -206,7 +227,7 @@ func TestInitiateCallUseCase_Execute_RepositoryFailure(t *testing.T) {
 		},
 	}
 
-	uc := NewInitiateCallUseCase(mockRepo, mockVoIP, nil)
+	uc := NewInitiateCallUseCase(mockRepo, nil, mockVoIP, nil, nil, newInitiateTestGuards())
 
 	input := InitiateCallInput{
 		UserID:      "test-user-id",
@@ -245,7 +266,7 @@ func TestInitiateCallUseCase_Execute_VoiceSDK_Success(t *testing.T) {
 	mockVoIP := &mockVoIPService{}
 	tokenGen := &mockVoiceTokenGenerator{token: "test-voice-token"}
 
-	uc := NewInitiateCallUseCase(mockRepo, mockVoIP, tokenGen)
+	uc := NewInitiateCallUseCase(mockRepo, nil, mockVoIP, tokenGen, nil, newInitiateTestGuards())
 
 	input := InitiateCallInput{
 		UserID:      "test-user-id",
@@ -271,3 +292,151 @@ func TestInitiateCallUseCase_Execute_VoiceSDK_Success(t *testing.T) {
 		t.Errorf("expected created call with session_id voice_sdk")
 	}
 }
+
+func TestInitiateCallUseCase_Execute_Record(t *testing.T) {
+	mockRepo := &mockCallRepository{}
+	mockVoIP := &mockVoIPService{session: &domain.CallSession{SessionID: "test-session-id", ProviderCallSID: "CA123"}}
+	policy, err := domain.NewRecordingPolicy([]string{"+86:forbid", "*:consent"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	guards := newInitiateTestGuards()
+	guards.Recording = policy
+	uc := NewInitiateCallUseCase(mockRepo, nil, mockVoIP, nil, nil, guards)
+
+	output, err := uc.Execute(context.Background(), InitiateCallInput{
+		UserID:      "test-user-id",
+		PhoneNumber: "+491512345678",
+		Record:      true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !output.Record || !mockVoIP.opts.Record {
+		t.Errorf("expected recording to be requested from the provider, got output=%v opts=%+v", output.Record, mockVoIP.opts)
+	}
+}
+
+func TestInitiateCallUseCase_Execute_RecordForbidden(t *testing.T) {
+	mockRepo := &mockCallRepository{}
+	mockVoIP := &mockVoIPService{session: &domain.CallSession{SessionID: "test-session-id"}}
+	policy, _ := domain.NewRecordingPolicy([]string{"+86:forbid", "*:consent"})
+
+	guards := newInitiateTestGuards()
+	guards.Recording = policy
+	uc := NewInitiateCallUseCase(mockRepo, nil, mockVoIP, nil, nil, guards)
+
+	_, err := uc.Execute(context.Background(), InitiateCallInput{
+		UserID:      "test-user-id",
+		PhoneNumber: "+8613800138000",
+		Record:      true,
+	})
+	if !errors.Is(err, domain.ErrRecordingForbidden) {
+		t.Errorf("expected ErrRecordingForbidden, got %v", err)
+	}
+	if mockRepo.createdCall != nil {
+		t.Error("expected no call to be created")
+	}
+
+	if _, err := uc.Execute(context.Background(), InitiateCallInput{UserID: "test-user-id", PhoneNumber: "+8613800138000"}); err != nil {
+		t.Errorf("expected unrecorded call to be allowed, got %v", err)
+	}
+}
+
+func TestInitiateCallUseCase_Execute_CallerID(t *testing.T) {
+	callerIDs := newCallbackTestCallerIDs()
+	callerIDs.callerIDs[0].IsDefault = true
+
+	tests := []struct {
+		name     string
+		userID   string
+		callerID string
+		want     string
+		wantErr  error
+	}{
+		{"default", "user-1", "", "+14155550100", nil},
+		{"chosen", "user-1", "+14155550100", "+14155550100", nil},
+		{"no default", "user-2", "", "", nil},
+		{"unverified", "user-1", "+14155550101", "", domain.ErrCallerIDNotVerified},
+		{"other user", "user-2", "+14155550100", "", domain.ErrCallerIDNotVerified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockCallRepository{}
+			mockVoIP := &mockVoIPService{session: &domain.CallSession{SessionID: "sess_1"}}
+			uc := NewInitiateCallUseCase(mockRepo, callerIDs, mockVoIP, nil, nil, newInitiateTestGuards())
+
+			output, err := uc.Execute(context.Background(), InitiateCallInput{
+				UserID:      tt.userID,
+				PhoneNumber: "+491512345678",
+				CallerID:    tt.callerID,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				if mockRepo.createdCall != nil {
+					t.Error("expected no call to be created")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if output.CallerID != tt.want || mockVoIP.opts.CallerID != tt.want || mockRepo.createdCall.CallerID != tt.want {
+				t.Errorf("expected caller id %q, got output %q, provider %q, call %q",
+					tt.want, output.CallerID, mockVoIP.opts.CallerID, mockRepo.createdCall.CallerID)
+			}
+		})
+	}
+}
+
+func TestInitiateCallUseCase_Execute_NormalizesNumber(t *testing.T) {
+	mockRepo := &mockCallRepository{}
+	mockVoIP := &mockVoIPService{session: &domain.CallSession{SessionID: "test-session-id", ProviderCallSID: "CA123"}}
+	uc := NewInitiateCallUseCase(mockRepo, nil, mockVoIP, nil, nil, newInitiateTestGuards())
+
+	output, err := uc.Execute(context.Background(), InitiateCallInput{
+		UserID:      "test-user-id",
+		PhoneNumber: "8 (916) 123-45-67",
+		Region:      "RU",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if mockRepo.createdCall.PhoneNumber != "+79161234567" {
+		t.Errorf("expected the call to +79161234567, got %q", mockRepo.createdCall.PhoneNumber)
+	}
+	if output.Number == nil || output.Number.Country != "RU" || output.Number.Type != phone.TypeMobile {
+		t.Errorf("unexpected number: %+v", output.Number)
+	}
+}
+
+func TestInitiateCallUseCase_Execute_InvalidNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   InitiateCallInput
+		wantErr error
+	}{
+		{"too short for the country", InitiateCallInput{UserID: "u", PhoneNumber: "+7916123"}, domain.ErrInvalidPhoneNumber},
+		{"letters", InitiateCallInput{UserID: "u", PhoneNumber: "+49 151 CALLME"}, domain.ErrInvalidPhoneNumber},
+		{"national without region", InitiateCallInput{UserID: "u", PhoneNumber: "0151 2345678"}, phone.ErrRegionRequired},
+		{"unknown region", InitiateCallInput{UserID: "u", PhoneNumber: "0151 2345678", Region: "XX"}, phone.ErrUnknownRegion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockCallRepository{}
+			uc := NewInitiateCallUseCase(mockRepo, nil, &mockVoIPService{}, nil, nil, newInitiateTestGuards())
+			_, err := uc.Execute(context.Background(), tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if mockRepo.createdCall != nil {
+				t.Error("expected no call to be created")
+			}
+		})
+	}
+}
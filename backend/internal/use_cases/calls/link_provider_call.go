@@ -0,0 +1,71 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type LinkProviderCallInput struct {
+	UserID          string
+	CallID          string
+	ProviderCallSID string
+}
+
+// LinkProviderCallUseCase stores the provider call of a Voice SDK call, which
+// only becomes known when the provider asks for its TwiML, so that status
+// callbacks find the call.
+type LinkProviderCallUseCase struct {
+	callRepo domain.CallRepository
+}
+
+func NewLinkProviderCallUseCase(callRepo domain.CallRepository) *LinkProviderCallUseCase {
+	return &LinkProviderCallUseCase{callRepo: callRepo}
+}
+
+func (uc *LinkProviderCallUseCase) Execute(ctx context.Context, input LinkProviderCallInput) error {
+	if input.CallID == "" {
+		return errors.New("call_id is required")
+	}
+
+	if input.ProviderCallSID == "" {
+		return errors.New("provider_call_sid is required")
+	}
+
+	call, err := uc.callRepo.GetByID(ctx, input.CallID)
+	if err != nil {
+		slog.Error("failed to get call", "error", err, "call_id", input.CallID)
+		return errors.New("failed to get call")
+	}
+
+	if call == nil {
+		return errors.New("call not found")
+	}
+
+	if call.UserID != input.UserID {
+		return errors.New("unauthorized")
+	}
+
+	// A call is linked once; a client reconnecting with an old CallId must
+	// not take over another provider call's callbacks.
+	if call.ProviderCallSID == input.ProviderCallSID {
+		return nil
+	}
+	if call.ProviderCallSID != "" {
+		return errors.New("call is already linked")
+	}
+	if call.Status.IsTerminal() {
+		return errors.New("call is already finished")
+	}
+
+	call.ProviderCallSID = input.ProviderCallSID
+	if err := uc.callRepo.Update(ctx, call); err != nil {
+		slog.Error("failed to update call", "error", err, "call_id", call.ID)
+		return errors.New("failed to update call")
+	}
+
+	slog.Info("voice sdk call linked to provider call", "call_id", call.ID, "provider_call_sid", call.ProviderCallSID)
+	return nil
+}
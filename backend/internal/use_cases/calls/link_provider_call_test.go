@@ -0,0 +1,76 @@
+package calls
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+func TestLinkProviderCallUseCase_Execute(t *testing.T) {
+	mockRepo := &mockCallRepositoryForUpdateStatus{
+		call: &domain.Call{
+			ID:        "call-1",
+			UserID:    "user-1",
+			SessionID: "voice_sdk",
+			Status:    domain.CallStatusConnecting,
+		},
+	}
+
+	uc := NewLinkProviderCallUseCase(mockRepo)
+
+	err := uc.Execute(context.Background(), LinkProviderCallInput{
+		UserID:          "user-1",
+		CallID:          "call-1",
+		ProviderCallSID: "CA123",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if mockRepo.updatedCall == nil || mockRepo.updatedCall.ProviderCallSID != "CA123" {
+		t.Fatalf("expected provider call sid to be stored, got %+v", mockRepo.updatedCall)
+	}
+
+	// Status callbacks for the provider call now find the call.
+	updateUC := NewUpdateCallStatusUseCase(mockRepo, nil, nil, &mockEventPublisher{}, domain.HoldTimeIncluded)
+	output, err := updateUC.Execute(context.Background(), UpdateCallStatusInput{
+		ProviderCallSID: "CA123",
+		Status:          domain.CallStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if output.Status != "active" {
+		t.Errorf("expected status 'active', got '%s'", output.Status)
+	}
+}
+
+func TestLinkProviderCallUseCase_Execute_Rejected(t *testing.T) {
+	tests := []struct {
+		name string
+		call *domain.Call
+		want string
+	}{
+		{"other user", &domain.Call{ID: "call-1", UserID: "user-2", Status: domain.CallStatusConnecting}, "unauthorized"},
+		{"already linked", &domain.Call{ID: "call-1", UserID: "user-1", ProviderCallSID: "CA999", Status: domain.CallStatusConnecting}, "call is already linked"},
+		{"finished", &domain.Call{ID: "call-1", UserID: "user-1", Status: domain.CallStatusCompleted}, "call is already finished"},
+		{"missing", nil, "call not found"},
+	}
+	for _, tt := range tests {
+		mockRepo := &mockCallRepositoryForUpdateStatus{call: tt.call}
+		uc := NewLinkProviderCallUseCase(mockRepo)
+
+		err := uc.Execute(context.Background(), LinkProviderCallInput{
+			UserID:          "user-1",
+			CallID:          "call-1",
+			ProviderCallSID: "CA123",
+		})
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%s: expected error '%s', got %v", tt.name, tt.want, err)
+		}
+		if mockRepo.updatedCall != nil {
+			t.Errorf("%s: expected call not to be updated", tt.name)
+		}
+	}
+}
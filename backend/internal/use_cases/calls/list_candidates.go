@@ -0,0 +1,63 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type ListCandidatesInput struct {
+	UserID string
+	CallID string
+}
+
+type ListCandidatesOutput struct {
+	CallID          string
+	Candidates      []domain.ICECandidate
+	EndOfCandidates bool
+}
+
+type ListCandidatesUseCase struct {
+	callRepo domain.CallRepository
+	sessions domain.SessionStore
+}
+
+func NewListCandidatesUseCase(callRepo domain.CallRepository, sessions domain.SessionStore) *ListCandidatesUseCase {
+	return &ListCandidatesUseCase{
+		callRepo: callRepo,
+		sessions: sessions,
+	}
+}
+
+// Execute returns our candidates gathered so far, so a client that connected
+// its event stream late can catch up on what was already pushed.
+func (uc *ListCandidatesUseCase) Execute(ctx context.Context, input ListCandidatesInput) (*ListCandidatesOutput, error) {
+	call, err := ownedWebRTCCall(ctx, uc.callRepo, input.CallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := uc.sessions.Get(ctx, call.SessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, errors.New("call already ended")
+		}
+		slog.Error("failed to get voip session", "error", err, "call_id", call.ID, "session_id", call.SessionID)
+		return nil, errors.New("failed to get candidates")
+	}
+
+	candidates := make([]domain.ICECandidate, 0, len(session.LocalCandidates))
+	for _, candidate := range session.LocalCandidates {
+		if !candidate.IsEndOfCandidates() {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return &ListCandidatesOutput{
+		CallID:          call.ID,
+		Candidates:      candidates,
+		EndOfCandidates: domain.CandidatesComplete(session.LocalCandidates),
+	}, nil
+}
@@ -0,0 +1,88 @@
+package calls
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockSessionStoreForCandidates struct {
+	session *domain.CallSession
+}
+
+func (m *mockSessionStoreForCandidates) Save(ctx context.Context, session *domain.CallSession) error {
+	return nil
+}
+
+func (m *mockSessionStoreForCandidates) Get(ctx context.Context, sessionID string) (*domain.CallSession, error) {
+	if m.session == nil || m.session.SessionID != sessionID {
+		return nil, domain.ErrSessionNotFound
+	}
+	return m.session, nil
+}
+
+func (m *mockSessionStoreForCandidates) UpdateStatus(ctx context.Context, sessionID string, status domain.SessionStatus) (*domain.CallSession, error) {
+	return nil, nil
+}
+
+func (m *mockSessionStoreForCandidates) AddCandidate(ctx context.Context, sessionID string, origin domain.CandidateOrigin, candidate domain.ICECandidate) (*domain.CallSession, error) {
+	return nil, nil
+}
+
+func (m *mockSessionStoreForCandidates) SetAnswer(ctx context.Context, sessionID, sdpAnswer string) (*domain.CallSession, error) {
+	return nil, nil
+}
+
+func (m *mockSessionStoreForCandidates) Delete(ctx context.Context, sessionID string) error {
+	return nil
+}
+
+func (m *mockSessionStoreForCandidates) Close() error {
+	return nil
+}
+
+func TestListCandidatesUseCase_Execute_Success(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newAnswerTestCall()}
+	sessions := &mockSessionStoreForCandidates{session: &domain.CallSession{
+		SessionID:        "sess_1",
+		LocalCandidates:  []domain.ICECandidate{{Candidate: testCandidate}, {}},
+		RemoteCandidates: []domain.ICECandidate{{Candidate: testCandidate}},
+	}}
+
+	uc := NewListCandidatesUseCase(mockRepo, sessions)
+
+	output, err := uc.Execute(context.Background(), ListCandidatesInput{UserID: "user-1", CallID: "call-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(output.Candidates) != 1 || output.Candidates[0].Candidate != testCandidate {
+		t.Errorf("expected only the local candidate, got %v", output.Candidates)
+	}
+	if !output.EndOfCandidates {
+		t.Error("expected end of candidates")
+	}
+}
+
+func TestListCandidatesUseCase_Execute_SessionGone(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newAnswerTestCall()}
+
+	uc := NewListCandidatesUseCase(mockRepo, &mockSessionStoreForCandidates{})
+
+	_, err := uc.Execute(context.Background(), ListCandidatesInput{UserID: "user-1", CallID: "call-1"})
+	if err == nil || err.Error() != "call already ended" {
+		t.Errorf("expected error 'call already ended', got %v", err)
+	}
+}
+
+func TestListCandidatesUseCase_Execute_Unauthorized(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newAnswerTestCall()}
+
+	uc := NewListCandidatesUseCase(mockRepo, &mockSessionStoreForCandidates{})
+
+	_, err := uc.Execute(context.Background(), ListCandidatesInput{UserID: "user-2", CallID: "call-1"})
+	if err == nil || err.Error() != "unauthorized" {
+		t.Errorf("expected error 'unauthorized', got %v", err)
+	}
+}
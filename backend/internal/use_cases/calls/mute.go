@@ -0,0 +1,76 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type MuteCallInput struct {
+	UserID string
+	CallID string
+	Muted  bool
+}
+
+type MuteCallOutput struct {
+	CallID string
+	Muted  bool
+}
+
+// MuteCallUseCase keeps the caller's audio from the called party, or lets it
+// through again, on the server side.
+type MuteCallUseCase struct {
+	callRepo    domain.CallRepository
+	voipService domain.VoIPService
+	events      domain.EventPublisher
+}
+
+func NewMuteCallUseCase(callRepo domain.CallRepository, voipService domain.VoIPService, events domain.EventPublisher) *MuteCallUseCase {
+	return &MuteCallUseCase{
+		callRepo:    callRepo,
+		voipService: voipService,
+		events:      events,
+	}
+}
+
+func (uc *MuteCallUseCase) Execute(ctx context.Context, input MuteCallInput) (*MuteCallOutput, error) {
+	muter, ok := uc.voipService.(domain.CallMuter)
+	if !ok {
+		return nil, errors.New("mute is not supported")
+	}
+
+	call, err := ownedWebRTCCall(ctx, uc.callRepo, input.CallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := liveCall(call); err != nil {
+		return nil, err
+	}
+
+	if call.Muted == input.Muted {
+		return &MuteCallOutput{CallID: call.ID, Muted: call.Muted}, nil
+	}
+
+	if err := muter.SetMuted(ctx, call.SessionID, input.Muted); err != nil {
+		return nil, controlError(err, call, "failed to mute call")
+	}
+
+	call.Muted = input.Muted
+
+	if err := uc.callRepo.Update(ctx, call); err != nil {
+		slog.Error("failed to update call", "error", err, "call_id", call.ID)
+		return nil, errors.New("failed to update call")
+	}
+
+	slog.Info("call mute changed", "call_id", call.ID, "session_id", call.SessionID, "muted", call.Muted)
+
+	publishCallStatus(ctx, uc.events, call)
+
+	return &MuteCallOutput{
+		CallID: call.ID,
+		Muted:  call.Muted,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+func TestMuteCallUseCase_Execute_Success(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newHeldTestCall()}
+	mockVoIP := &mockVoIPServiceForHold{}
+	events := &mockEventPublisher{}
+
+	uc := NewMuteCallUseCase(mockRepo, mockVoIP, events)
+
+	output, err := uc.Execute(context.Background(), MuteCallInput{UserID: "user-1", CallID: "call-1", Muted: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if mockVoIP.muted == nil || !*mockVoIP.muted {
+		t.Error("expected session muted")
+	}
+	if !output.Muted || mockRepo.updatedCall == nil || !mockRepo.updatedCall.Muted {
+		t.Error("expected call updated as muted")
+	}
+
+	if len(events.events) != 1 || !events.events[0].Muted {
+		t.Errorf("expected muted event, got %+v", events.events)
+	}
+}
+
+func TestMuteCallUseCase_Execute_Unchanged(t *testing.T) {
+	mockVoIP := &mockVoIPServiceForHold{}
+	uc := NewMuteCallUseCase(&mockCallRepositoryForTerminate{call: newDTMFTestCall()}, mockVoIP, nil)
+
+	output, err := uc.Execute(context.Background(), MuteCallInput{UserID: "user-1", CallID: "call-1", Muted: false})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if output.Muted || mockVoIP.muted != nil {
+		t.Error("expected no-op unmute")
+	}
+}
+
+func TestMuteCallUseCase_Execute_Errors(t *testing.T) {
+	ringing := newDTMFTestCall()
+	ringing.Status = domain.CallStatusConnecting
+	ended := newDTMFTestCall()
+	ended.Status = domain.CallStatusFailed
+
+	cases := []struct {
+		name     string
+		call     *domain.Call
+		voip     domain.VoIPService
+		expected string
+	}{
+		{"not supported", newDTMFTestCall(), &mockVoIPServiceForDTMF{}, "mute is not supported"},
+		{"not found", nil, &mockVoIPServiceForHold{}, "call not found"},
+		{"not answered", ringing, &mockVoIPServiceForHold{}, "call is not active"},
+		{"ended", ended, &mockVoIPServiceForHold{}, "call already ended"},
+		{"provider failure", newDTMFTestCall(), &mockVoIPServiceForHold{muteError: errors.New("provider down")}, "failed to mute call"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := NewMuteCallUseCase(&mockCallRepositoryForTerminate{call: tc.call}, tc.voip, nil)
+
+			_, err := uc.Execute(context.Background(), MuteCallInput{UserID: "user-1", CallID: "call-1", Muted: true})
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("expected error '%s', got %v", tc.expected, err)
+			}
+		})
+	}
+}
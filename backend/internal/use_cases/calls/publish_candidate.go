@@ -0,0 +1,52 @@
+package calls
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type PublishCandidateUseCase struct {
+	callRepo domain.CallRepository
+	events   domain.EventPublisher
+}
+
+func NewPublishCandidateUseCase(callRepo domain.CallRepository, events domain.EventPublisher) *PublishCandidateUseCase {
+	return &PublishCandidateUseCase{
+		callRepo: callRepo,
+		events:   events,
+	}
+}
+
+// Execute pushes one of our trickled candidates to the call owner. The
+// candidate is already stored in the session, so a candidate gathered before
+// the call record exists is not lost: the client fetches it with
+// ListCandidatesUseCase once it knows the call ID.
+func (uc *PublishCandidateUseCase) Execute(ctx context.Context, session *domain.CallSession, candidate domain.ICECandidate) {
+	if uc.events == nil || session == nil || session.ProviderCallSID == "" {
+		return
+	}
+
+	call, err := uc.callRepo.GetByProviderCallSID(ctx, session.ProviderCallSID)
+	if err != nil {
+		slog.Warn("failed to find call for ice candidate", "error", err, "session_id", session.SessionID)
+		return
+	}
+	if call == nil || call.Status.IsTerminal() {
+		return
+	}
+
+	event := &domain.CallEvent{
+		Type:       domain.CallEventICECandidate,
+		UserID:     call.UserID,
+		CallID:     call.ID,
+		Status:     call.Status,
+		OccurredAt: time.Now(),
+		Candidate:  &candidate,
+	}
+	if err := uc.events.Publish(ctx, event); err != nil {
+		slog.Warn("failed to publish ice candidate", "error", err, "call_id", call.ID)
+	}
+}
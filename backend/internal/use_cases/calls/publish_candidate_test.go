@@ -0,0 +1,43 @@
+package calls
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+func TestPublishCandidateUseCase_Execute(t *testing.T) {
+	mockRepo := &mockCallRepositoryForUpdateStatus{call: &domain.Call{
+		ID:              "call-1",
+		UserID:          "user-1",
+		ProviderCallSID: "gw_sess_1",
+		Status:          domain.CallStatusConnecting,
+	}}
+	events := &mockEventPublisher{}
+
+	uc := NewPublishCandidateUseCase(mockRepo, events)
+	uc.Execute(context.Background(), &domain.CallSession{SessionID: "gw_sess_1", ProviderCallSID: "gw_sess_1"}, domain.ICECandidate{Candidate: testCandidate})
+
+	if len(events.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.Type != domain.CallEventICECandidate || event.UserID != "user-1" || event.CallID != "call-1" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if event.Candidate == nil || event.Candidate.Candidate != testCandidate {
+		t.Errorf("expected candidate in event, got %v", event.Candidate)
+	}
+}
+
+func TestPublishCandidateUseCase_Execute_UnknownCall(t *testing.T) {
+	events := &mockEventPublisher{}
+
+	uc := NewPublishCandidateUseCase(&mockCallRepositoryForUpdateStatus{}, events)
+	uc.Execute(context.Background(), &domain.CallSession{SessionID: "gw_sess_1", ProviderCallSID: "gw_sess_1"}, domain.ICECandidate{})
+
+	if len(events.events) != 0 {
+		t.Errorf("expected no events before the call is recorded, got %d", len(events.events))
+	}
+}
@@ -0,0 +1,92 @@
+package calls
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/phone"
+)
+
+// OffHours is the destination's local time, reported when a call goes
+// through in its quiet hours.
+type OffHours struct {
+	LocalTime time.Time
+	TimeZone  string
+}
+
+// QuietHoursGuard keeps calls from waking people: it works out the
+// destination's local time from its number and applies the caller's quiet
+// hours, or the platform defaults.
+type QuietHoursGuard struct {
+	settings domain.UserSettingsRepository
+	defaults domain.QuietHours
+	now      func() time.Time
+}
+
+func NewQuietHoursGuard(settings domain.UserSettingsRepository, defaults domain.QuietHours) *QuietHoursGuard {
+	return &QuietHoursGuard{
+		settings: settings,
+		defaults: defaults,
+		now:      time.Now,
+	}
+}
+
+// Check returns nil when the call may go ahead quietly. In warn mode a call
+// in quiet hours returns its OffHours; in confirm mode it fails with
+// *domain.OffHoursError unless the caller confirmed it. A number whose area
+// code does not settle its zone, such as a Russian mobile, is treated as in
+// quiet hours when any of its country's zones is. Toll-free numbers, which
+// are not tied to a place, and numbers of countries whose zones are not
+// known are never held up.
+func (g *QuietHoursGuard) Check(ctx context.Context, userID, phoneNumber string, confirmed bool) (*OffHours, error) {
+	quiet := g.quietHours(ctx, userID)
+	if quiet.Mode == domain.QuietHoursOff {
+		return nil, nil
+	}
+
+	if number, err := phone.Parse(phoneNumber, ""); err == nil && number.Type == phone.TypeTollFree {
+		return nil, nil
+	}
+
+	var loc *time.Location
+	var local time.Time
+	now := g.now()
+	for _, zone := range domain.DestinationTimeZones(phoneNumber) {
+		if quiet.Contains(now.In(zone)) {
+			loc, local = zone, now.In(zone)
+			break
+		}
+	}
+	if loc == nil {
+		return nil, nil
+	}
+
+	if quiet.Mode == domain.QuietHoursConfirm && !confirmed {
+		slog.Info("call held for quiet hours", "user_id", userID, "phone", phoneNumber, "local_time", local.Format("15:04"))
+		return nil, &domain.OffHoursError{LocalTime: local, TimeZone: loc.String()}
+	}
+
+	slog.Info("call placed in quiet hours", "user_id", userID, "phone", phoneNumber, "local_time", local.Format("15:04"), "confirmed", confirmed)
+	return &OffHours{LocalTime: local, TimeZone: loc.String()}, nil
+}
+
+// quietHours falls back to the defaults when the user's settings cannot be
+// read, rather than blocking the call.
+func (g *QuietHoursGuard) quietHours(ctx context.Context, userID string) domain.QuietHours {
+	if g.settings == nil {
+		return g.defaults
+	}
+
+	settings, err := g.settings.Get(ctx, userID)
+	if err != nil {
+		slog.Warn("failed to get user settings", "error", err, "user_id", userID)
+		return g.defaults
+	}
+
+	if settings == nil || settings.QuietHours == nil {
+		return g.defaults
+	}
+	return *settings.QuietHours
+}
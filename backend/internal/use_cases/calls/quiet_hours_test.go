@@ -0,0 +1,112 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockUserSettingsRepository struct {
+	settings map[string]*domain.UserSettings
+}
+
+func (m *mockUserSettingsRepository) Get(ctx context.Context, userID string) (*domain.UserSettings, error) {
+	return m.settings[userID], nil
+}
+
+func (m *mockUserSettingsRepository) Save(ctx context.Context, settings *domain.UserSettings) error {
+	m.settings[settings.UserID] = settings
+	return nil
+}
+
+// newQuietHoursTestGuard confirms calls in 22:00-08:00 by default; it is
+// 02:00 UTC, which is 04:00 in Berlin and 22:00 in New York.
+func newQuietHoursTestGuard(settings *mockUserSettingsRepository) *QuietHoursGuard {
+	defaults, _ := domain.ParseQuietHours("confirm", "22:00-08:00")
+	guard := NewQuietHoursGuard(settings, defaults)
+	guard.now = func() time.Time { return time.Date(2026, 7, 1, 2, 0, 0, 0, time.UTC) }
+	return guard
+}
+
+func TestQuietHoursGuard_Check(t *testing.T) {
+	warn := &domain.QuietHours{Mode: domain.QuietHoursWarn, Start: 22 * 60, End: 8 * 60}
+	off := &domain.QuietHours{Mode: domain.QuietHoursOff}
+	settings := &mockUserSettingsRepository{settings: map[string]*domain.UserSettings{
+		"user-warn": {UserID: "user-warn", QuietHours: warn},
+		"user-off":  {UserID: "user-off", QuietHours: off},
+	}}
+	guard := newQuietHoursTestGuard(settings)
+
+	_, err := guard.Check(context.Background(), "user-1", "+491512345678", false)
+	var offHoursErr *domain.OffHoursError
+	if !errors.As(err, &offHoursErr) || offHoursErr.TimeZone != "Europe/Berlin" || offHoursErr.LocalTime.Hour() != 4 {
+		t.Fatalf("expected the call to Berlin at 04:00 to need confirmation, got %v", err)
+	}
+
+	offHours, err := guard.Check(context.Background(), "user-1", "+491512345678", true)
+	if err != nil || offHours == nil || offHours.TimeZone != "Europe/Berlin" {
+		t.Errorf("expected a confirmed call to go through with its local time, got %+v, %v", offHours, err)
+	}
+
+	offHours, err = guard.Check(context.Background(), "user-warn", "+491512345678", false)
+	if err != nil || offHours == nil {
+		t.Errorf("expected a warning in warn mode, got %+v, %v", offHours, err)
+	}
+
+	if offHours, err := guard.Check(context.Background(), "user-off", "+491512345678", false); err != nil || offHours != nil {
+		t.Errorf("expected no check when quiet hours are off, got %+v, %v", offHours, err)
+	}
+
+	if offHours, err := guard.Check(context.Background(), "user-1", "+81312345678", false); err != nil || offHours != nil {
+		t.Errorf("expected 11:00 in Tokyo to be fine, got %+v, %v", offHours, err)
+	}
+
+	if offHours, err := guard.Check(context.Background(), "user-1", "+2421234567", false); err != nil || offHours != nil {
+		t.Errorf("expected a number with no known zone to go through, got %+v, %v", offHours, err)
+	}
+
+	// A Russian mobile may be anywhere from Kaliningrad (04:00) to
+	// Kamchatka (14:00); it is held because some of those zones are quiet.
+	if _, err := guard.Check(context.Background(), "user-1", "+79161234567", false); !errors.As(err, &offHoursErr) {
+		t.Errorf("expected a Russian mobile to need confirmation, got %v", err)
+	}
+
+	// 22:00 in New York, 19:00 in Los Angeles: an unlisted US area code is
+	// held for the eastern zones.
+	if _, err := guard.Check(context.Background(), "user-1", "+12345550100", false); !errors.As(err, &offHoursErr) {
+		t.Errorf("expected an unlisted US area code to need confirmation, got %v", err)
+	}
+
+	if offHours, err := guard.Check(context.Background(), "user-1", "+18005550100", false); err != nil || offHours != nil {
+		t.Errorf("expected a toll-free number to go through, got %+v, %v", offHours, err)
+	}
+}
+
+func TestInitiateCallUseCase_Execute_QuietHours(t *testing.T) {
+	guard := newQuietHoursTestGuard(&mockUserSettingsRepository{settings: map[string]*domain.UserSettings{}})
+
+	mockRepo := &mockCallRepository{}
+	mockVoIP := &mockVoIPService{session: &domain.CallSession{SessionID: "sess_1"}}
+	guards := newInitiateTestGuards()
+	guards.QuietHours = guard
+	uc := NewInitiateCallUseCase(mockRepo, nil, mockVoIP, nil, nil, guards)
+
+	_, err := uc.Execute(context.Background(), InitiateCallInput{UserID: "user-1", PhoneNumber: "+491512345678"})
+	if !errors.Is(err, domain.ErrOffHours) {
+		t.Fatalf("expected ErrOffHours, got %v", err)
+	}
+	if mockRepo.createdCall != nil {
+		t.Error("expected no call to be created")
+	}
+
+	output, err := uc.Execute(context.Background(), InitiateCallInput{UserID: "user-1", PhoneNumber: "+491512345678", ConfirmOffHours: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if output.OffHours == nil || output.OffHours.LocalTime.Format("15:04") != "04:00" {
+		t.Errorf("expected the local time to be reported, got %+v", output.OffHours)
+	}
+}
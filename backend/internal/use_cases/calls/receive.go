@@ -0,0 +1,103 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type ReceiveCallInput struct {
+	// To is the dialled provider number, From is the caller.
+	To              string
+	From            string
+	ProviderCallSID string
+}
+
+type ReceiveCallOutput struct {
+	CallID string
+	// UserID owns the dialled number; it is also the Voice SDK identity
+	// that should ring.
+	UserID string
+}
+
+// ReceiveCallUseCase records a PSTN call to a user's number so that it shows
+// up in the owner's history and events before the browser rings.
+type ReceiveCallUseCase struct {
+	phoneNumbers domain.PhoneNumberRepository
+	callRepo     domain.CallRepository
+	events       domain.EventPublisher
+}
+
+func NewReceiveCallUseCase(phoneNumbers domain.PhoneNumberRepository, callRepo domain.CallRepository, events domain.EventPublisher) *ReceiveCallUseCase {
+	return &ReceiveCallUseCase{
+		phoneNumbers: phoneNumbers,
+		callRepo:     callRepo,
+		events:       events,
+	}
+}
+
+func (uc *ReceiveCallUseCase) Execute(ctx context.Context, input ReceiveCallInput) (*ReceiveCallOutput, error) {
+	if input.ProviderCallSID == "" {
+		return nil, errors.New("provider_call_sid is required")
+	}
+
+	if input.To == "" {
+		return nil, errors.New("to is required")
+	}
+
+	// The provider retries the webhook when it times out; the call is
+	// already recorded then.
+	existing, err := uc.callRepo.GetByProviderCallSID(ctx, input.ProviderCallSID)
+	if err != nil {
+		slog.Error("failed to get call", "error", err, "provider_call_sid", input.ProviderCallSID)
+		return nil, errors.New("failed to get call")
+	}
+	if existing != nil {
+		return &ReceiveCallOutput{CallID: existing.ID, UserID: existing.UserID}, nil
+	}
+
+	number, err := uc.phoneNumbers.GetByNumber(ctx, input.To)
+	if err != nil {
+		slog.Error("failed to get phone number", "error", err, "number", input.To)
+		return nil, errors.New("failed to get phone number")
+	}
+	if number == nil {
+		slog.Warn("inbound call to unassigned number", "number", input.To, "provider_call_sid", input.ProviderCallSID)
+		return nil, errors.New("number not assigned")
+	}
+
+	from := input.From
+	if from == "" {
+		from = "anonymous"
+	}
+
+	call := &domain.Call{
+		UserID:          number.UserID,
+		PhoneNumber:     from,
+		StartTime:       time.Now(),
+		Status:          domain.CallStatusConnecting,
+		SessionID:       "voice_sdk",
+		ProviderCallSID: input.ProviderCallSID,
+		Direction:       domain.CallDirectionInbound,
+	}
+	if err := uc.callRepo.Create(ctx, call); err != nil {
+		slog.Error("failed to create call record", "error", err, "user_id", number.UserID)
+		return nil, errors.New("failed to create call record")
+	}
+
+	slog.Info("inbound call received",
+		"call_id", call.ID,
+		"user_id", call.UserID,
+		"number", input.To,
+		"provider_call_sid", input.ProviderCallSID)
+
+	publishCallStatus(ctx, uc.events, call)
+
+	return &ReceiveCallOutput{
+		CallID: call.ID,
+		UserID: call.UserID,
+	}, nil
+}
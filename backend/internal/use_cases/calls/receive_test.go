@@ -0,0 +1,118 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockPhoneNumberRepository struct {
+	numbers []*domain.PhoneNumber
+	err     error
+}
+
+func (m *mockPhoneNumberRepository) GetByNumber(ctx context.Context, number string) (*domain.PhoneNumber, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, n := range m.numbers {
+		if n.Number == number {
+			return n, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockPhoneNumberRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.PhoneNumber, error) {
+	return nil, nil
+}
+
+type mockCallRepositoryForReceive struct {
+	mockCallRepositoryForUpdateStatus
+	created *domain.Call
+}
+
+func (m *mockCallRepositoryForReceive) Create(ctx context.Context, call *domain.Call) error {
+	call.ID = "call-1"
+	m.created = call
+	return nil
+}
+
+func TestReceiveCallUseCase_Execute_Success(t *testing.T) {
+	numbers := &mockPhoneNumberRepository{numbers: []*domain.PhoneNumber{{Number: "+4930123456", UserID: "user-1"}}}
+	mockRepo := &mockCallRepositoryForReceive{}
+	events := &mockEventPublisher{}
+
+	uc := NewReceiveCallUseCase(numbers, mockRepo, events)
+
+	output, err := uc.Execute(context.Background(), ReceiveCallInput{
+		To:              "+4930123456",
+		From:            "+491512345678",
+		ProviderCallSID: "CA123",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.CallID != "call-1" || output.UserID != "user-1" {
+		t.Errorf("unexpected output: %+v", output)
+	}
+
+	call := mockRepo.created
+	if call == nil {
+		t.Fatal("expected call to be created")
+	}
+	if call.Direction != domain.CallDirectionInbound || call.PhoneNumber != "+491512345678" || call.ProviderCallSID != "CA123" || call.Status != domain.CallStatusConnecting {
+		t.Errorf("unexpected call: %+v", call)
+	}
+
+	if len(events.events) != 1 || events.events[0].UserID != "user-1" {
+		t.Errorf("expected owner to be notified, got %+v", events.events)
+	}
+}
+
+func TestReceiveCallUseCase_Execute_Retry(t *testing.T) {
+	numbers := &mockPhoneNumberRepository{numbers: []*domain.PhoneNumber{{Number: "+4930123456", UserID: "user-1"}}}
+	mockRepo := &mockCallRepositoryForUpdateStatus{call: &domain.Call{ID: "call-1", UserID: "user-1", ProviderCallSID: "CA123"}}
+	events := &mockEventPublisher{}
+
+	uc := NewReceiveCallUseCase(numbers, mockRepo, events)
+
+	output, err := uc.Execute(context.Background(), ReceiveCallInput{To: "+4930123456", ProviderCallSID: "CA123"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.CallID != "call-1" {
+		t.Errorf("expected existing call, got '%s'", output.CallID)
+	}
+	if len(events.events) != 0 {
+		t.Error("expected no event for a retried webhook")
+	}
+}
+
+func TestReceiveCallUseCase_Execute_Errors(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    ReceiveCallInput
+		numbers  *mockPhoneNumberRepository
+		expected string
+	}{
+		{"missing call sid", ReceiveCallInput{To: "+4930123456"}, &mockPhoneNumberRepository{}, "provider_call_sid is required"},
+		{"missing to", ReceiveCallInput{ProviderCallSID: "CA123"}, &mockPhoneNumberRepository{}, "to is required"},
+		{"unassigned number", ReceiveCallInput{To: "+4930123456", ProviderCallSID: "CA123"}, &mockPhoneNumberRepository{}, "number not assigned"},
+		{"repository failure", ReceiveCallInput{To: "+4930123456", ProviderCallSID: "CA123"}, &mockPhoneNumberRepository{err: errors.New("db down")}, "failed to get phone number"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := NewReceiveCallUseCase(tc.numbers, &mockCallRepositoryForUpdateStatus{}, &mockEventPublisher{})
+
+			_, err := uc.Execute(context.Background(), tc.input)
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("expected error '%s', got %v", tc.expected, err)
+			}
+		})
+	}
+}
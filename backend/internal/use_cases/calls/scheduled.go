@@ -0,0 +1,506 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/phone"
+)
+
+const (
+	// maxScheduleAhead bounds how far ahead a call may be scheduled.
+	maxScheduleAhead = 365 * 24 * time.Hour
+	// scheduledCallGrace is how late a scheduled call may still fire, for
+	// calls that came due while no replica was running. Later ones are
+	// marked missed.
+	scheduledCallGrace = 10 * time.Minute
+	// scheduledCallClaimTimeout is how long a replica may take to fire a
+	// claimed call. A call firing for longer was left behind by a replica
+	// that stopped; it is failed rather than fired a second time.
+	scheduledCallClaimTimeout = 5 * time.Minute
+	scheduledCallBatch        = 50
+)
+
+// localTimeLayouts are the wall-clock formats accepted for scheduled_at,
+// read in the request's time zone.
+var localTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04"}
+
+type ScheduledCallItem struct {
+	ID           string     `json:"id"`
+	PhoneNumber  string     `json:"phoneNumber"`
+	CallerNumber string     `json:"callerNumber,omitempty"`
+	Mode         string     `json:"mode"`
+	ScheduledAt  time.Time  `json:"scheduledAt"`
+	LocalTime    string     `json:"localTime"`
+	TimeZone     string     `json:"timeZone"`
+	Status       string     `json:"status"`
+	FiredAt      *time.Time `json:"firedAt,omitempty"`
+	CallID       string     `json:"callId,omitempty"`
+	Error        string     `json:"error,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+func scheduledCallItem(scheduled *domain.ScheduledCall) *ScheduledCallItem {
+	return &ScheduledCallItem{
+		ID:           scheduled.ID,
+		PhoneNumber:  scheduled.PhoneNumber,
+		CallerNumber: scheduled.CallerNumber,
+		Mode:         string(scheduled.Mode),
+		ScheduledAt:  scheduled.ScheduledAt.UTC(),
+		LocalTime:    scheduled.LocalTime().Format(time.RFC3339),
+		TimeZone:     scheduled.TimeZone,
+		Status:       string(scheduled.Status),
+		FiredAt:      scheduled.FiredAt,
+		CallID:       scheduled.CallID,
+		Error:        scheduled.Error,
+		CreatedAt:    scheduled.CreatedAt,
+	}
+}
+
+type ScheduleCallInput struct {
+	UserID      string
+	PhoneNumber string
+	// Mode is notify or callback; CallerNumber is the user's verified phone
+	// rung first in callback mode.
+	Mode         string
+	CallerNumber string
+	// ScheduledAt is a wall-clock time such as 2026-10-20T09:30 read in
+	// TimeZone, or an RFC 3339 time with an offset.
+	ScheduledAt string
+	TimeZone    string
+}
+
+// ScheduleCallUseCase plans a call for a later time. The scheduler fires it
+// through FireScheduledCallsUseCase.
+type ScheduleCallUseCase struct {
+	scheduled   domain.ScheduledCallRepository
+	callerIDs   domain.CallerIDRepository
+	voipService domain.VoIPService
+	shortCodes  *ShortCodeGuard
+}
+
+func NewScheduleCallUseCase(scheduled domain.ScheduledCallRepository, callerIDs domain.CallerIDRepository, voipService domain.VoIPService, shortCodes *ShortCodeGuard) *ScheduleCallUseCase {
+	return &ScheduleCallUseCase{
+		scheduled:   scheduled,
+		callerIDs:   callerIDs,
+		voipService: voipService,
+		shortCodes:  shortCodes,
+	}
+}
+
+func (uc *ScheduleCallUseCase) Execute(ctx context.Context, input ScheduleCallInput) (*ScheduledCallItem, error) {
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	scheduled := &domain.ScheduledCall{
+		UserID: input.UserID,
+		Status: domain.ScheduledCallStatusPending,
+	}
+	if err := applyScheduleInput(ctx, scheduled, input, uc.callerIDs, uc.voipService, uc.shortCodes, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.scheduled.Create(ctx, scheduled); err != nil {
+		slog.Error("failed to create scheduled call", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to create scheduled call")
+	}
+
+	slog.Info("call scheduled",
+		"scheduled_call_id", scheduled.ID,
+		"user_id", input.UserID,
+		"mode", scheduled.Mode,
+		"scheduled_at", scheduled.ScheduledAt)
+
+	return scheduledCallItem(scheduled), nil
+}
+
+type ListScheduledCallsInput struct {
+	UserID string
+}
+
+type ListScheduledCallsOutput struct {
+	ScheduledCalls []*ScheduledCallItem `json:"scheduledCalls"`
+}
+
+type ListScheduledCallsUseCase struct {
+	scheduled domain.ScheduledCallRepository
+}
+
+func NewListScheduledCallsUseCase(scheduled domain.ScheduledCallRepository) *ListScheduledCallsUseCase {
+	return &ListScheduledCallsUseCase{scheduled: scheduled}
+}
+
+func (uc *ListScheduledCallsUseCase) Execute(ctx context.Context, input ListScheduledCallsInput) (*ListScheduledCallsOutput, error) {
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	scheduled, err := uc.scheduled.ListByUserID(ctx, input.UserID)
+	if err != nil {
+		slog.Error("failed to get scheduled calls", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to get scheduled calls")
+	}
+
+	items := make([]*ScheduledCallItem, 0, len(scheduled))
+	for _, s := range scheduled {
+		items = append(items, scheduledCallItem(s))
+	}
+
+	return &ListScheduledCallsOutput{ScheduledCalls: items}, nil
+}
+
+type ScheduledCallInput struct {
+	UserID          string
+	ScheduledCallID string
+}
+
+type GetScheduledCallUseCase struct {
+	scheduled domain.ScheduledCallRepository
+}
+
+func NewGetScheduledCallUseCase(scheduled domain.ScheduledCallRepository) *GetScheduledCallUseCase {
+	return &GetScheduledCallUseCase{scheduled: scheduled}
+}
+
+func (uc *GetScheduledCallUseCase) Execute(ctx context.Context, input ScheduledCallInput) (*ScheduledCallItem, error) {
+	scheduled, err := ownedScheduledCall(ctx, uc.scheduled, input.ScheduledCallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scheduledCallItem(scheduled), nil
+}
+
+type RescheduleCallInput struct {
+	ScheduledCallID string
+	ScheduleCallInput
+}
+
+// RescheduleCallUseCase replaces the details of a pending scheduled call.
+type RescheduleCallUseCase struct {
+	scheduled   domain.ScheduledCallRepository
+	callerIDs   domain.CallerIDRepository
+	voipService domain.VoIPService
+	shortCodes  *ShortCodeGuard
+}
+
+func NewRescheduleCallUseCase(scheduled domain.ScheduledCallRepository, callerIDs domain.CallerIDRepository, voipService domain.VoIPService, shortCodes *ShortCodeGuard) *RescheduleCallUseCase {
+	return &RescheduleCallUseCase{
+		scheduled:   scheduled,
+		callerIDs:   callerIDs,
+		voipService: voipService,
+		shortCodes:  shortCodes,
+	}
+}
+
+func (uc *RescheduleCallUseCase) Execute(ctx context.Context, input RescheduleCallInput) (*ScheduledCallItem, error) {
+	scheduled, err := ownedScheduledCall(ctx, uc.scheduled, input.ScheduledCallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if scheduled.Status != domain.ScheduledCallStatusPending {
+		return nil, domain.ErrScheduledCallNotPending
+	}
+
+	if err := applyScheduleInput(ctx, scheduled, input.ScheduleCallInput, uc.callerIDs, uc.voipService, uc.shortCodes, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.scheduled.Update(ctx, scheduled); err != nil {
+		if errors.Is(err, domain.ErrScheduledCallNotPending) {
+			return nil, err
+		}
+		slog.Error("failed to update scheduled call", "error", err, "scheduled_call_id", scheduled.ID)
+		return nil, errors.New("failed to update scheduled call")
+	}
+
+	slog.Info("call rescheduled", "scheduled_call_id", scheduled.ID, "scheduled_at", scheduled.ScheduledAt)
+
+	return scheduledCallItem(scheduled), nil
+}
+
+// DeleteScheduledCallUseCase cancels a pending scheduled call or removes a
+// finished one from the list.
+type DeleteScheduledCallUseCase struct {
+	scheduled domain.ScheduledCallRepository
+}
+
+func NewDeleteScheduledCallUseCase(scheduled domain.ScheduledCallRepository) *DeleteScheduledCallUseCase {
+	return &DeleteScheduledCallUseCase{scheduled: scheduled}
+}
+
+func (uc *DeleteScheduledCallUseCase) Execute(ctx context.Context, input ScheduledCallInput) error {
+	scheduled, err := ownedScheduledCall(ctx, uc.scheduled, input.ScheduledCallID, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.scheduled.Delete(ctx, scheduled.ID); err != nil {
+		if errors.Is(err, domain.ErrScheduledCallNotPending) {
+			return err
+		}
+		slog.Error("failed to delete scheduled call", "error", err, "scheduled_call_id", scheduled.ID)
+		return errors.New("failed to delete scheduled call")
+	}
+
+	slog.Info("scheduled call deleted", "scheduled_call_id", scheduled.ID, "user_id", input.UserID)
+	return nil
+}
+
+// FireScheduledCallsUseCase fires the scheduled calls that are due. Every
+// replica runs it periodically; the repository hands each call to one of
+// them only.
+type FireScheduledCallsUseCase struct {
+	scheduled domain.ScheduledCallRepository
+	callback  *CallbackCallUseCase
+	events    domain.EventDeliverer
+}
+
+func NewFireScheduledCallsUseCase(scheduled domain.ScheduledCallRepository, callback *CallbackCallUseCase, events domain.EventDeliverer) *FireScheduledCallsUseCase {
+	return &FireScheduledCallsUseCase{
+		scheduled: scheduled,
+		callback:  callback,
+		events:    events,
+	}
+}
+
+// Execute returns how many scheduled calls were fired.
+func (uc *FireScheduledCallsUseCase) Execute(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	stale, err := uc.scheduled.FailStale(ctx, now.Add(-scheduledCallClaimTimeout))
+	if err != nil {
+		slog.Error("failed to fail stale scheduled calls", "error", err)
+	} else if stale > 0 {
+		slog.Warn("interrupted scheduled calls failed", "count", stale)
+	}
+
+	due, err := uc.scheduled.ClaimDue(ctx, now, scheduledCallBatch)
+	if err != nil {
+		slog.Error("failed to claim scheduled calls", "error", err)
+		return 0, err
+	}
+
+	fired := 0
+	for _, scheduled := range due {
+		uc.fire(ctx, scheduled, now)
+		if err := uc.scheduled.Finish(ctx, scheduled); err != nil {
+			slog.Error("failed to finish scheduled call", "error", err, "scheduled_call_id", scheduled.ID)
+		}
+		if scheduled.Status == domain.ScheduledCallStatusFired {
+			fired++
+		}
+	}
+
+	return fired, nil
+}
+
+func (uc *FireScheduledCallsUseCase) fire(ctx context.Context, scheduled *domain.ScheduledCall, now time.Time) {
+	if now.Sub(scheduled.ScheduledAt) > scheduledCallGrace {
+		slog.Warn("scheduled call missed", "scheduled_call_id", scheduled.ID, "scheduled_at", scheduled.ScheduledAt)
+		scheduled.Status = domain.ScheduledCallStatusMissed
+		return
+	}
+
+	switch scheduled.Mode {
+	case domain.ScheduledCallModeCallback:
+		output, err := uc.callback.Execute(ctx, CallbackCallInput{
+			UserID:       scheduled.UserID,
+			CallerNumber: scheduled.CallerNumber,
+			PhoneNumber:  scheduled.PhoneNumber,
+		})
+		if err != nil {
+			slog.Warn("scheduled callback call failed", "error", err, "scheduled_call_id", scheduled.ID)
+			scheduled.Status = domain.ScheduledCallStatusFailed
+			scheduled.Error = err.Error()
+			return
+		}
+		scheduled.CallID = output.CallID
+	default:
+		if uc.events == nil {
+			scheduled.Status = domain.ScheduledCallStatusFailed
+			scheduled.Error = "no client to notify"
+			return
+		}
+		event := &domain.CallEvent{
+			Type:            domain.CallEventScheduled,
+			UserID:          scheduled.UserID,
+			OccurredAt:      now,
+			ScheduledCallID: scheduled.ID,
+			PhoneNumber:     scheduled.PhoneNumber,
+		}
+		delivered, err := uc.events.Deliver(ctx, event)
+		if err != nil {
+			slog.Warn("failed to publish call event", "error", err, "scheduled_call_id", scheduled.ID)
+			scheduled.Status = domain.ScheduledCallStatusFailed
+			scheduled.Error = "failed to notify client"
+			return
+		}
+		// Events reach only the clients connected to this replica. With
+		// none of the user's here, the call goes back to pending and the
+		// next run, on whichever replica claims it, tries again until the
+		// grace period is over and the call is missed.
+		if delivered == 0 {
+			slog.Debug("no client to notify of scheduled call", "scheduled_call_id", scheduled.ID, "user_id", scheduled.UserID)
+			scheduled.Status = domain.ScheduledCallStatusPending
+			return
+		}
+	}
+
+	scheduled.Status = domain.ScheduledCallStatusFired
+	scheduled.FiredAt = &now
+	slog.Info("scheduled call fired", "scheduled_call_id", scheduled.ID, "mode", scheduled.Mode, "call_id", scheduled.CallID)
+}
+
+// applyScheduleInput validates input and copies it onto scheduled. The
+// destination is vetted now, so the user learns at once that it cannot be
+// dialled, and again when the call fires.
+func applyScheduleInput(ctx context.Context, scheduled *domain.ScheduledCall, input ScheduleCallInput, callerIDs domain.CallerIDRepository, voipService domain.VoIPService, shortCodes *ShortCodeGuard, now time.Time) error {
+	if input.PhoneNumber == "" || input.ScheduledAt == "" {
+		return errors.New("phone_number and scheduled_at are required")
+	}
+
+	phoneNumber, err := shortCodes.internationalDestination(ctx, ShortCodeAttempt{
+		UserID:      scheduled.UserID,
+		PhoneNumber: input.PhoneNumber,
+	})
+	if err != nil {
+		return err
+	}
+	input.PhoneNumber = phoneNumber
+
+	mode := domain.ScheduledCallMode(input.Mode)
+	if mode == "" {
+		mode = domain.ScheduledCallModeNotify
+	}
+	if !mode.Valid() {
+		return errors.New("mode must be notify or callback")
+	}
+
+	scheduledAt, timeZone, err := parseScheduleTime(input.ScheduledAt, input.TimeZone)
+	if err != nil {
+		return err
+	}
+
+	if !scheduledAt.After(now) {
+		return errors.New("scheduled time is in the past")
+	}
+
+	if scheduledAt.Sub(now) > maxScheduleAhead {
+		return errors.New("scheduled time is too far ahead")
+	}
+
+	callerNumber := ""
+	if mode == domain.ScheduledCallModeCallback {
+		if _, ok := voipService.(domain.CallbackPlacer); !ok {
+			return errors.New("callback is not supported")
+		}
+
+		if input.CallerNumber == "" {
+			return errors.New("caller_number is required")
+		}
+
+		parsed, err := phone.Parse(input.CallerNumber, "")
+		if err != nil {
+			return domain.ErrInvalidPhoneNumber
+		}
+		input.CallerNumber = parsed.E164
+
+		if input.CallerNumber == input.PhoneNumber {
+			return errors.New("cannot call back the destination")
+		}
+
+		callerID, err := callerIDs.GetByUserIDAndNumber(ctx, scheduled.UserID, input.CallerNumber)
+		if err != nil {
+			slog.Error("failed to get caller id", "error", err, "user_id", scheduled.UserID)
+			return errors.New("failed to get caller id")
+		}
+
+		if callerID == nil || !callerID.Verified() {
+			return errors.New("caller number is not verified")
+		}
+		callerNumber = input.CallerNumber
+	}
+
+	scheduled.PhoneNumber = input.PhoneNumber
+	scheduled.CallerNumber = callerNumber
+	scheduled.Mode = mode
+	scheduled.ScheduledAt = scheduledAt
+	scheduled.TimeZone = timeZone
+	return nil
+}
+
+// parseScheduleTime reads value as a wall-clock time in timeZone, or as an
+// RFC 3339 time, which defaults timeZone to UTC. A wall-clock time skipped
+// by a daylight saving change does not exist in the zone and is rejected;
+// one that occurs twice is the first occurrence.
+func parseScheduleTime(value, timeZone string) (time.Time, string, error) {
+	if timeZone == "Local" {
+		return time.Time{}, "", errors.New("invalid time zone")
+	}
+
+	instant, rfcErr := time.Parse(time.RFC3339, value)
+	if rfcErr == nil && timeZone == "" {
+		timeZone = "UTC"
+	}
+
+	if timeZone == "" {
+		return time.Time{}, "", errors.New("time_zone is required")
+	}
+
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.Time{}, "", errors.New("invalid time zone")
+	}
+
+	if rfcErr == nil {
+		return instant.UTC(), timeZone, nil
+	}
+
+	for _, layout := range localTimeLayouts {
+		wall, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+
+		local := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, loc)
+		if local.Hour() != wall.Hour() || local.Minute() != wall.Minute() || local.Day() != wall.Day() {
+			return time.Time{}, "", errors.New("scheduled time does not exist in time zone")
+		}
+		return local.UTC(), timeZone, nil
+	}
+
+	return time.Time{}, "", errors.New("invalid scheduled_at")
+}
+
+func ownedScheduledCall(ctx context.Context, scheduled domain.ScheduledCallRepository, id, userID string) (*domain.ScheduledCall, error) {
+	if id == "" {
+		return nil, errors.New("scheduled_call_id is required")
+	}
+
+	if userID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	s, err := scheduled.GetByID(ctx, id)
+	if err != nil {
+		slog.Error("failed to get scheduled call", "error", err, "scheduled_call_id", id)
+		return nil, errors.New("failed to get scheduled call")
+	}
+
+	if s == nil {
+		return nil, errors.New("scheduled call not found")
+	}
+
+	if s.UserID != userID {
+		slog.Warn("unauthorized scheduled call access", "scheduled_call_id", id, "user_id", userID)
+		return nil, errors.New("unauthorized")
+	}
+
+	return s, nil
+}
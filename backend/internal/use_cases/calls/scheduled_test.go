@@ -0,0 +1,277 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockScheduledCallRepository struct {
+	scheduled map[string]*domain.ScheduledCall
+	finished  []*domain.ScheduledCall
+}
+
+func newMockScheduledCallRepository(scheduled ...*domain.ScheduledCall) *mockScheduledCallRepository {
+	m := &mockScheduledCallRepository{scheduled: map[string]*domain.ScheduledCall{}}
+	for _, s := range scheduled {
+		m.scheduled[s.ID] = s
+	}
+	return m
+}
+
+func (m *mockScheduledCallRepository) Create(ctx context.Context, scheduled *domain.ScheduledCall) error {
+	scheduled.ID = "sc-new"
+	m.scheduled[scheduled.ID] = scheduled
+	return nil
+}
+
+func (m *mockScheduledCallRepository) Update(ctx context.Context, scheduled *domain.ScheduledCall) error {
+	if m.scheduled[scheduled.ID].Status != domain.ScheduledCallStatusPending {
+		return domain.ErrScheduledCallNotPending
+	}
+	return nil
+}
+
+func (m *mockScheduledCallRepository) GetByID(ctx context.Context, id string) (*domain.ScheduledCall, error) {
+	return m.scheduled[id], nil
+}
+
+func (m *mockScheduledCallRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.ScheduledCall, error) {
+	return nil, nil
+}
+
+func (m *mockScheduledCallRepository) Delete(ctx context.Context, id string) error {
+	delete(m.scheduled, id)
+	return nil
+}
+
+func (m *mockScheduledCallRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*domain.ScheduledCall, error) {
+	var due []*domain.ScheduledCall
+	for _, s := range m.scheduled {
+		if s.Status == domain.ScheduledCallStatusPending && !s.ScheduledAt.After(now) {
+			s.Status = domain.ScheduledCallStatusFiring
+			s.ClaimedAt = &now
+			due = append(due, s)
+		}
+	}
+	return due, nil
+}
+
+func (m *mockScheduledCallRepository) Finish(ctx context.Context, scheduled *domain.ScheduledCall) error {
+	m.finished = append(m.finished, scheduled)
+	return nil
+}
+
+func (m *mockScheduledCallRepository) FailStale(ctx context.Context, claimedBefore time.Time) (int, error) {
+	return 0, nil
+}
+
+// mockEventDeliverer delivers events only while the user is subscribed.
+type mockEventDeliverer struct {
+	mockEventPublisher
+	subscribed bool
+}
+
+func (m *mockEventDeliverer) Deliver(ctx context.Context, event *domain.CallEvent) (int, error) {
+	if !m.subscribed {
+		return 0, nil
+	}
+	m.events = append(m.events, event)
+	return 1, nil
+}
+
+func TestParseScheduleTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		timeZone string
+		want     time.Time
+		wantZone string
+		wantErr  string
+	}{
+		{"wall clock", "2026-07-01T09:30", "Europe/Berlin", time.Date(2026, 7, 1, 7, 30, 0, 0, time.UTC), "Europe/Berlin", ""},
+		{"wall clock seconds", "2026-01-15T09:30:00", "America/New_York", time.Date(2026, 1, 15, 14, 30, 0, 0, time.UTC), "America/New_York", ""},
+		{"offset", "2026-07-01T09:30:00+02:00", "", time.Date(2026, 7, 1, 7, 30, 0, 0, time.UTC), "UTC", ""},
+		{"offset with zone", "2026-07-01T09:30:00+02:00", "Asia/Tokyo", time.Date(2026, 7, 1, 7, 30, 0, 0, time.UTC), "Asia/Tokyo", ""},
+		{"dst gap", "2026-03-29T02:30", "Europe/Berlin", time.Time{}, "", "scheduled time does not exist in time zone"},
+		{"missing zone", "2026-07-01T09:30", "", time.Time{}, "", "time_zone is required"},
+		{"unknown zone", "2026-07-01T09:30", "Mars/Olympus", time.Time{}, "", "invalid time zone"},
+		{"local zone", "2026-07-01T09:30", "Local", time.Time{}, "", "invalid time zone"},
+		{"garbage", "tomorrow", "UTC", time.Time{}, "", "invalid scheduled_at"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, zone, err := parseScheduleTime(tt.value, tt.timeZone)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("expected '%s', got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !got.Equal(tt.want) || zone != tt.wantZone {
+				t.Errorf("expected %s in %s, got %s in %s", tt.want, tt.wantZone, got, zone)
+			}
+		})
+	}
+}
+
+func TestScheduleCallUseCase_Execute(t *testing.T) {
+	repo := newMockScheduledCallRepository()
+	uc := NewScheduleCallUseCase(repo, newCallbackTestCallerIDs(), &mockVoIPServiceForCallback{}, NewShortCodeGuard(nil, nil))
+
+	at := time.Now().Add(24 * time.Hour).In(mustLoadLocation(t, "Asia/Tokyo"))
+	item, err := uc.Execute(context.Background(), ScheduleCallInput{
+		UserID:       "user-1",
+		PhoneNumber:  "+491512345678",
+		Mode:         "callback",
+		CallerNumber: "+14155550100",
+		ScheduledAt:  at.Format("2006-01-02T15:04"),
+		TimeZone:     "Asia/Tokyo",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	scheduled := repo.scheduled[item.ID]
+	if scheduled.Status != domain.ScheduledCallStatusPending || scheduled.Mode != domain.ScheduledCallModeCallback {
+		t.Errorf("expected a pending callback call, got %+v", scheduled)
+	}
+	if item.LocalTime[:16] != at.Format("2006-01-02T15:04") || item.ScheduledAt.Location() != time.UTC {
+		t.Errorf("expected %s local time, got %s (%s)", at.Format("2006-01-02T15:04"), item.LocalTime, item.ScheduledAt)
+	}
+}
+
+func TestScheduleCallUseCase_Execute_Invalid(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	tests := []struct {
+		name  string
+		input ScheduleCallInput
+		want  string
+	}{
+		{"past", ScheduleCallInput{UserID: "user-1", PhoneNumber: "+491512345678", ScheduledAt: "2020-01-01T09:00", TimeZone: "UTC"}, "scheduled time is in the past"},
+		{"too far", ScheduleCallInput{UserID: "user-1", PhoneNumber: "+491512345678", ScheduledAt: time.Now().AddDate(2, 0, 0).Format(time.RFC3339)}, "scheduled time is too far ahead"},
+		{"mode", ScheduleCallInput{UserID: "user-1", PhoneNumber: "+491512345678", Mode: "sms", ScheduledAt: future}, "mode must be notify or callback"},
+		{"unverified", ScheduleCallInput{UserID: "user-1", PhoneNumber: "+491512345678", Mode: "callback", CallerNumber: "+14155550101", ScheduledAt: future}, "caller number is not verified"},
+		{"no caller", ScheduleCallInput{UserID: "user-1", PhoneNumber: "+491512345678", Mode: "callback", ScheduledAt: future}, "caller_number is required"},
+		{"invalid", ScheduleCallInput{UserID: "user-1", PhoneNumber: "0049151", ScheduledAt: future}, domain.ErrInvalidPhoneNumber.Error()},
+		{"emergency", ScheduleCallInput{UserID: "user-1", PhoneNumber: "112", ScheduledAt: future}, domain.ErrEmergencyNotSupported.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockScheduledCallRepository()
+			uc := NewScheduleCallUseCase(repo, newCallbackTestCallerIDs(), &mockVoIPServiceForCallback{}, NewShortCodeGuard(nil, nil))
+
+			_, err := uc.Execute(context.Background(), tt.input)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("expected '%s', got %v", tt.want, err)
+			}
+			if len(repo.scheduled) != 0 {
+				t.Error("expected nothing to be scheduled")
+			}
+		})
+	}
+}
+
+func TestRescheduleCallUseCase_Execute_NotPending(t *testing.T) {
+	fired := &domain.ScheduledCall{ID: "sc-1", UserID: "user-1", Status: domain.ScheduledCallStatusFired}
+	uc := NewRescheduleCallUseCase(newMockScheduledCallRepository(fired), newCallbackTestCallerIDs(), &mockVoIPService{}, NewShortCodeGuard(nil, nil))
+
+	_, err := uc.Execute(context.Background(), RescheduleCallInput{
+		ScheduledCallID: "sc-1",
+		ScheduleCallInput: ScheduleCallInput{
+			UserID:      "user-1",
+			PhoneNumber: "+491512345678",
+			ScheduledAt: time.Now().Add(time.Hour).Format(time.RFC3339),
+		},
+	})
+	if !errors.Is(err, domain.ErrScheduledCallNotPending) {
+		t.Errorf("expected ErrScheduledCallNotPending, got %v", err)
+	}
+}
+
+func TestFireScheduledCallsUseCase_Execute(t *testing.T) {
+	now := time.Now()
+	notify := &domain.ScheduledCall{ID: "sc-notify", UserID: "user-1", PhoneNumber: "+491512345678", Mode: domain.ScheduledCallModeNotify, ScheduledAt: now.Add(-time.Second), Status: domain.ScheduledCallStatusPending}
+	callback := &domain.ScheduledCall{ID: "sc-callback", UserID: "user-1", PhoneNumber: "+491512345678", CallerNumber: "+14155550100", Mode: domain.ScheduledCallModeCallback, ScheduledAt: now.Add(-time.Second), Status: domain.ScheduledCallStatusPending}
+	missed := &domain.ScheduledCall{ID: "sc-missed", UserID: "user-1", PhoneNumber: "+491512345678", Mode: domain.ScheduledCallModeNotify, ScheduledAt: now.Add(-time.Hour), Status: domain.ScheduledCallStatusPending}
+	later := &domain.ScheduledCall{ID: "sc-later", UserID: "user-1", PhoneNumber: "+491512345678", Mode: domain.ScheduledCallModeNotify, ScheduledAt: now.Add(time.Hour), Status: domain.ScheduledCallStatusPending}
+	repo := newMockScheduledCallRepository(notify, callback, missed, later)
+
+	callRepo := newMockCallRepositoryForTransfer()
+	voip := &mockVoIPServiceForCallback{}
+	events := &mockEventDeliverer{subscribed: true}
+	uc := NewFireScheduledCallsUseCase(repo, NewCallbackCallUseCase(callRepo, newCallbackTestCallerIDs(), voip, events, NewShortCodeGuard(nil, nil)), events)
+
+	fired, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if fired != 2 || len(repo.finished) != 3 {
+		t.Fatalf("expected 2 fired of 3 claimed, got %d of %d", fired, len(repo.finished))
+	}
+
+	if notify.Status != domain.ScheduledCallStatusFired || notify.FiredAt == nil {
+		t.Errorf("expected the notify call to fire, got %+v", notify)
+	}
+	if callback.Status != domain.ScheduledCallStatusFired || callback.CallID == "" || voip.callerNumber != "+14155550100" {
+		t.Errorf("expected a callback call to be placed, got %+v", callback)
+	}
+	if missed.Status != domain.ScheduledCallStatusMissed {
+		t.Errorf("expected the late call to be missed, got %s", missed.Status)
+	}
+	if later.Status != domain.ScheduledCallStatusPending {
+		t.Errorf("expected the later call to stay pending, got %s", later.Status)
+	}
+
+	reminded := false
+	for _, event := range events.events {
+		if event.Type == domain.CallEventScheduled && event.ScheduledCallID == "sc-notify" {
+			reminded = true
+		}
+	}
+	if !reminded {
+		t.Error("expected a call.scheduled event for the notify call")
+	}
+}
+
+func TestFireScheduledCallsUseCase_Execute_NoClient(t *testing.T) {
+	now := time.Now()
+	notify := &domain.ScheduledCall{ID: "sc-notify", UserID: "user-1", PhoneNumber: "+491512345678", Mode: domain.ScheduledCallModeNotify, ScheduledAt: now.Add(-time.Second), Status: domain.ScheduledCallStatusPending}
+	repo := newMockScheduledCallRepository(notify)
+	events := &mockEventDeliverer{}
+	uc := NewFireScheduledCallsUseCase(repo, nil, events)
+
+	fired, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if fired != 0 || notify.Status != domain.ScheduledCallStatusPending || notify.FiredAt != nil {
+		t.Fatalf("expected the undelivered reminder to stay pending, got %d fired, %+v", fired, notify)
+	}
+
+	// A client connects before the next run.
+	events.subscribed = true
+	if fired, _ := uc.Execute(context.Background()); fired != 1 || notify.Status != domain.ScheduledCallStatusFired {
+		t.Errorf("expected the reminder to fire once delivered, got %d fired, %s", fired, notify.Status)
+	}
+	if len(events.events) != 1 {
+		t.Errorf("expected one call.scheduled event, got %d", len(events.events))
+	}
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", name, err)
+	}
+	return loc
+}
@@ -0,0 +1,125 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type SendDTMFInput struct {
+	UserID string
+	CallID string
+	// Digits are 0-9, * and #; "w" pauses for half a second.
+	Digits string
+}
+
+type SendDTMFOutput struct {
+	CallID string
+	// Digits is the sent sequence, masked.
+	Digits string
+}
+
+// SendDTMFUseCase plays digits into an answered call, e.g. to navigate an
+// IVR. Voice SDK calls have no server-side session; the browser sends their
+// digits itself. Sent digits are kept, masked, in the audit log of the call.
+type SendDTMFUseCase struct {
+	callRepo    domain.CallRepository
+	voipService domain.VoIPService
+	audit       domain.AuditRepository
+	events      domain.EventPublisher
+}
+
+func NewSendDTMFUseCase(callRepo domain.CallRepository, voipService domain.VoIPService, audit domain.AuditRepository, events domain.EventPublisher) *SendDTMFUseCase {
+	return &SendDTMFUseCase{
+		callRepo:    callRepo,
+		voipService: voipService,
+		audit:       audit,
+		events:      events,
+	}
+}
+
+func (uc *SendDTMFUseCase) Execute(ctx context.Context, input SendDTMFInput) (*SendDTMFOutput, error) {
+	sender, ok := uc.voipService.(domain.DTMFSender)
+	if !ok {
+		return nil, errors.New("dtmf is not supported")
+	}
+
+	if err := domain.ValidateDTMF(input.Digits); err != nil {
+		return nil, err
+	}
+
+	call, err := ownedWebRTCCall(ctx, uc.callRepo, input.CallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if call.Status.IsTerminal() {
+		return nil, errors.New("call already ended")
+	}
+
+	if call.Status != domain.CallStatusActive {
+		return nil, errors.New("call is not active")
+	}
+
+	if err := outsideConference(call); err != nil {
+		return nil, err
+	}
+
+	masked := domain.MaskDTMF(input.Digits)
+
+	err = sender.SendDTMF(ctx, call.SessionID, input.Digits, domain.CallOptions{
+		Identity: call.UserID,
+		Record:   call.Record,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) || errors.Is(err, domain.ErrSessionEnded) {
+			return nil, errors.New("call already ended")
+		}
+		slog.Error("failed to send dtmf",
+			"error", err,
+			"call_id", call.ID,
+			"session_id", call.SessionID,
+			"digits", masked)
+		return nil, errors.New("failed to send dtmf")
+	}
+
+	slog.Info("dtmf sent", "call_id", call.ID, "session_id", call.SessionID, "digits", masked)
+
+	// The digits are already sent, so a failed write only loses them from
+	// the call's history.
+	if uc.audit != nil {
+		event := &domain.AuditEvent{
+			UserID:      call.UserID,
+			Type:        domain.AuditCallDTMF,
+			PhoneNumber: call.PhoneNumber,
+			Detail:      masked,
+			CallID:      call.ID,
+		}
+		if err := uc.audit.Create(ctx, event); err != nil {
+			slog.Error("failed to record audit event", "error", err, "type", event.Type, "call_id", call.ID)
+		}
+	}
+
+	if uc.events != nil {
+		event := &domain.CallEvent{
+			Type:       domain.CallEventDTMF,
+			UserID:     call.UserID,
+			CallID:     call.ID,
+			Status:     call.Status,
+			Duration:   call.Duration,
+			OccurredAt: time.Now(),
+			DTMF:       masked,
+		}
+		if err := uc.events.Publish(ctx, event); err != nil {
+			slog.Warn("failed to publish call event", "error", err, "call_id", call.ID)
+		}
+	}
+
+	return &SendDTMFOutput{
+		CallID: call.ID,
+		Digits: masked,
+	}, nil
+}
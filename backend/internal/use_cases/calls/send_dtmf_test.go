@@ -0,0 +1,139 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockVoIPServiceForDTMF struct {
+	mockVoIPServiceForTerminate
+	dtmfError error
+	sessionID string
+	digits    string
+	opts      domain.CallOptions
+}
+
+func (m *mockVoIPServiceForDTMF) SendDTMF(ctx context.Context, sessionID string, digits string, opts domain.CallOptions) error {
+	if m.dtmfError != nil {
+		return m.dtmfError
+	}
+	m.sessionID = sessionID
+	m.digits = digits
+	m.opts = opts
+	return nil
+}
+
+func newDTMFTestCall() *domain.Call {
+	call := newAnswerTestCall()
+	call.Status = domain.CallStatusActive
+	call.Record = true
+	return call
+}
+
+func TestSendDTMFUseCase_Execute_Success(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newDTMFTestCall()}
+	mockVoIP := &mockVoIPServiceForDTMF{}
+	audit := &mockAuditRepository{}
+	events := &mockEventPublisher{}
+
+	uc := NewSendDTMFUseCase(mockRepo, mockVoIP, audit, events)
+
+	output, err := uc.Execute(context.Background(), SendDTMFInput{
+		UserID: "user-1",
+		CallID: "call-1",
+		Digits: "1234ww#",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if mockVoIP.sessionID != "sess_1" || mockVoIP.digits != "1234ww#" {
+		t.Errorf("unexpected dtmf sent: session=%s digits=%s", mockVoIP.sessionID, mockVoIP.digits)
+	}
+	if mockVoIP.opts.Identity != "user-1" || !mockVoIP.opts.Record {
+		t.Errorf("expected call options of the recorded call, got %+v", mockVoIP.opts)
+	}
+
+	if output.Digits != "••••ww•" {
+		t.Errorf("expected masked digits, got '%s'", output.Digits)
+	}
+
+	if len(events.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events.events))
+	}
+	event := events.events[0]
+	if event.Type != domain.CallEventDTMF || event.CallID != "call-1" || event.DTMF != "••••ww•" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+
+	recorded, _ := audit.ListByCallID(context.Background(), "call-1", domain.AuditCallDTMF)
+	if len(recorded) != 1 || recorded[0].Detail != "••••ww•" || recorded[0].UserID != "user-1" {
+		t.Errorf("expected the masked digits to be kept for the call, got %+v", recorded)
+	}
+}
+
+func TestSendDTMFUseCase_Execute_Errors(t *testing.T) {
+	voiceSDK := newDTMFTestCall()
+	voiceSDK.SessionID = "voice_sdk"
+	ringing := newDTMFTestCall()
+	ringing.Status = domain.CallStatusConnecting
+	ended := newDTMFTestCall()
+	ended.Status = domain.CallStatusCompleted
+
+	cases := []struct {
+		name      string
+		call      *domain.Call
+		digits    string
+		dtmfError error
+		expected  string
+	}{
+		{"invalid digits", newDTMFTestCall(), "12a", nil, "invalid dtmf digits"},
+		{"empty digits", newDTMFTestCall(), "", nil, "invalid dtmf digits"},
+		{"not found", nil, "1", nil, "call not found"},
+		{"voice sdk call", voiceSDK, "1", nil, "call has no webrtc session"},
+		{"not answered", ringing, "1", nil, "call is not active"},
+		{"ended", ended, "1", nil, "call already ended"},
+		{"session gone", newDTMFTestCall(), "1", domain.ErrSessionNotFound, "call already ended"},
+		{"provider failure", newDTMFTestCall(), "1", errors.New("provider down"), "failed to send dtmf"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			audit := &mockAuditRepository{}
+			events := &mockEventPublisher{}
+			uc := NewSendDTMFUseCase(&mockCallRepositoryForTerminate{call: tc.call}, &mockVoIPServiceForDTMF{dtmfError: tc.dtmfError}, audit, events)
+
+			_, err := uc.Execute(context.Background(), SendDTMFInput{UserID: "user-1", CallID: "call-1", Digits: tc.digits})
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("expected error '%s', got %v", tc.expected, err)
+			}
+			if len(events.events) != 0 || len(audit.events) != 0 {
+				t.Errorf("expected no event, got %+v %+v", events.events, audit.events)
+			}
+		})
+	}
+}
+
+func TestSendDTMFUseCase_Execute_Unauthorized(t *testing.T) {
+	mockVoIP := &mockVoIPServiceForDTMF{}
+	uc := NewSendDTMFUseCase(&mockCallRepositoryForTerminate{call: newDTMFTestCall()}, mockVoIP, nil, nil)
+
+	_, err := uc.Execute(context.Background(), SendDTMFInput{UserID: "user-2", CallID: "call-1", Digits: "1"})
+	if err == nil || err.Error() != "unauthorized" {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+	if mockVoIP.digits != "" {
+		t.Error("expected no digits to be sent")
+	}
+}
+
+func TestSendDTMFUseCase_Execute_NotSupported(t *testing.T) {
+	uc := NewSendDTMFUseCase(&mockCallRepositoryForTerminate{call: newDTMFTestCall()}, &mockVoIPServiceForTerminate{}, nil, nil)
+
+	_, err := uc.Execute(context.Background(), SendDTMFInput{UserID: "user-1", CallID: "call-1", Digits: "1"})
+	if err == nil || err.Error() != "dtmf is not supported" {
+		t.Errorf("expected 'dtmf is not supported', got %v", err)
+	}
+}
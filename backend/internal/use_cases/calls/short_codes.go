@@ -0,0 +1,131 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/phone"
+)
+
+// ShortCodeGuard stops emergency and service short codes, which cannot be
+// reached through an international number, before they are dialled. Codes
+// the carrier is set up to route go through as dialled.
+type ShortCodeGuard struct {
+	routing *domain.EmergencyRouting
+	audit   domain.AuditRepository
+}
+
+func NewShortCodeGuard(routing *domain.EmergencyRouting, audit domain.AuditRepository) *ShortCodeGuard {
+	return &ShortCodeGuard{routing: routing, audit: audit}
+}
+
+// ShortCodeAttempt is a number about to be dialled. Region is where the user
+// dials from, which decides what a short code means.
+type ShortCodeAttempt struct {
+	UserID      string
+	PhoneNumber string
+	Region      string
+	CallID      string
+}
+
+// Lookup returns nil for numbers that are not short codes, the short code
+// when the carrier routes it, and *domain.ShortCodeError otherwise.
+func (g *ShortCodeGuard) Lookup(phoneNumber, region string) (*phone.ShortCode, error) {
+	code, ok := phone.LookupShortCode(phoneNumber, region)
+	if !ok {
+		return nil, nil
+	}
+
+	if g.routing.Routes(code.Country, code.Number) {
+		return code, nil
+	}
+	return nil, &domain.ShortCodeError{
+		Number:  code.Number,
+		Country: code.Country,
+		Kind:    string(code.Kind),
+		Service: code.Service,
+	}
+}
+
+// Check is Lookup that records every short code, refused or routed, in the
+// audit log. A failed audit write is logged and does not change the outcome.
+func (g *ShortCodeGuard) Check(ctx context.Context, attempt ShortCodeAttempt) (*phone.ShortCode, error) {
+	code, err := g.Lookup(attempt.PhoneNumber, attempt.Region)
+	if code == nil && err == nil {
+		return nil, nil
+	}
+
+	event := &domain.AuditEvent{
+		UserID: attempt.UserID,
+		CallID: attempt.CallID,
+	}
+	if code != nil {
+		event.Type = domain.AuditShortCodeRouted
+		event.PhoneNumber = code.Number
+		event.Country = code.Country
+		event.Detail = string(code.Kind) + ": " + code.Service
+		slog.Warn("short code routed to carrier", "user_id", attempt.UserID, "number", code.Number, "country", code.Country, "service", code.Service)
+	} else {
+		refused := err.(*domain.ShortCodeError)
+		event.Type = domain.AuditShortCodeRefused
+		event.PhoneNumber = refused.Number
+		event.Country = refused.Country
+		event.Detail = refused.Kind + ": " + refused.Service
+		slog.Warn("short code refused", "user_id", attempt.UserID, "number", refused.Number, "country", refused.Country, "service", refused.Service)
+	}
+
+	if g.audit != nil {
+		if auditErr := g.audit.Create(ctx, event); auditErr != nil {
+			slog.Error("failed to record audit event", "error", auditErr, "type", event.Type, "user_id", attempt.UserID)
+		}
+	}
+	return code, err
+}
+
+// Destination vets a number about to be dialled. Short codes come first: 112
+// is no valid number anywhere, and the user should learn why it cannot be
+// dialled. Anything else must parse as a phone number. On success exactly one
+// of the number and the routed short code is set.
+func (g *ShortCodeGuard) Destination(ctx context.Context, attempt ShortCodeAttempt) (*phone.Number, *phone.ShortCode, error) {
+	code, err := g.Check(ctx, attempt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if code != nil {
+		return nil, code, nil
+	}
+
+	number, err := phone.Parse(attempt.PhoneNumber, attempt.Region)
+	if err != nil {
+		if errors.Is(err, phone.ErrInvalidNumber) {
+			return nil, nil, domain.ErrInvalidPhoneNumber
+		}
+		return nil, nil, err
+	}
+	return number, nil, nil
+}
+
+// dialledNumber is the number Destination settled on: E.164, or the short
+// code as the carrier routes it.
+func dialledNumber(number *phone.Number, code *phone.ShortCode) string {
+	if code != nil {
+		return code.Number
+	}
+	return number.E164
+}
+
+// internationalDestination is Destination for the requests that take no
+// region: there a national number is simply invalid. It returns the number
+// to dial.
+func (g *ShortCodeGuard) internationalDestination(ctx context.Context, attempt ShortCodeAttempt) (string, error) {
+	number, code, err := g.Destination(ctx, attempt)
+	if errors.Is(err, phone.ErrRegionRequired) {
+		return "", domain.ErrInvalidPhoneNumber
+	}
+	if err != nil {
+		return "", err
+	}
+	return dialledNumber(number, code), nil
+}
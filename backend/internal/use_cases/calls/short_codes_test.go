@@ -0,0 +1,127 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockAuditRepository struct {
+	events []*domain.AuditEvent
+	err    error
+}
+
+func (m *mockAuditRepository) Create(ctx context.Context, event *domain.AuditEvent) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockAuditRepository) Count(ctx context.Context, filter domain.AuditFilter) (int, error) {
+	return 0, nil
+}
+
+func (m *mockAuditRepository) ListByCallID(ctx context.Context, callID string, eventType domain.AuditEventType) ([]*domain.AuditEvent, error) {
+	var events []*domain.AuditEvent
+	for _, event := range m.events {
+		if event.CallID == callID && event.Type == eventType {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func newShortCodeTestGuard(t *testing.T, audit domain.AuditRepository, routes ...string) *ShortCodeGuard {
+	t.Helper()
+	routing, err := domain.NewEmergencyRouting(routes)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return NewShortCodeGuard(routing, audit)
+}
+
+func TestInitiateCallUseCase_Execute_EmergencyRefused(t *testing.T) {
+	audit := &mockAuditRepository{}
+	mockRepo := &mockCallRepository{}
+	mockVoIP := &mockVoIPService{session: &domain.CallSession{SessionID: "test-session-id"}}
+	guards := newInitiateTestGuards()
+	guards.ShortCodes = newShortCodeTestGuard(t, audit)
+	uc := NewInitiateCallUseCase(mockRepo, nil, mockVoIP, nil, nil, guards)
+
+	_, err := uc.Execute(context.Background(), InitiateCallInput{UserID: "user-1", PhoneNumber: "112", Region: "DE"})
+
+	var refused *domain.ShortCodeError
+	if !errors.As(err, &refused) {
+		t.Fatalf("expected ShortCodeError, got %v", err)
+	}
+	if refused.Number != "112" || refused.Country != "DE" || refused.Kind != "emergency" || refused.Guidance() == "" {
+		t.Errorf("unexpected error: %+v", refused)
+	}
+	if mockRepo.createdCall != nil {
+		t.Error("expected no call to be created")
+	}
+	if len(audit.events) != 1 || audit.events[0].Type != domain.AuditShortCodeRefused || audit.events[0].UserID != "user-1" || audit.events[0].PhoneNumber != "112" {
+		t.Errorf("expected a refused short code audit event, got %+v", audit.events)
+	}
+}
+
+func TestInitiateCallUseCase_Execute_ServiceCodeRefused(t *testing.T) {
+	audit := &mockAuditRepository{}
+	guards := newInitiateTestGuards()
+	guards.ShortCodes = newShortCodeTestGuard(t, audit, "US:911")
+	uc := NewInitiateCallUseCase(&mockCallRepository{}, nil, &mockVoIPService{}, nil, nil, guards)
+
+	_, err := uc.Execute(context.Background(), InitiateCallInput{UserID: "user-1", PhoneNumber: "411", Region: "US"})
+
+	var refused *domain.ShortCodeError
+	if !errors.As(err, &refused) || refused.Kind != "service" {
+		t.Fatalf("expected a refused service code, got %v", err)
+	}
+	if len(audit.events) != 1 || audit.events[0].Detail != "service: directory" {
+		t.Errorf("unexpected audit events: %+v", audit.events)
+	}
+}
+
+func TestInitiateCallUseCase_Execute_EmergencyRouted(t *testing.T) {
+	audit := &mockAuditRepository{}
+	mockRepo := &mockCallRepository{}
+	mockVoIP := &mockVoIPService{session: &domain.CallSession{SessionID: "test-session-id", ProviderCallSID: "CA123"}}
+	guards := newInitiateTestGuards()
+	guards.ShortCodes = newShortCodeTestGuard(t, audit, "US:911")
+	uc := NewInitiateCallUseCase(mockRepo, nil, mockVoIP, nil, nil, guards)
+
+	output, err := uc.Execute(context.Background(), InitiateCallInput{UserID: "user-1", PhoneNumber: "911", Region: "US"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if output.PhoneNumber != "911" || output.ShortCode == nil || output.Number != nil {
+		t.Errorf("unexpected output: %+v", output)
+	}
+	if mockRepo.createdCall == nil || mockRepo.createdCall.PhoneNumber != "911" {
+		t.Errorf("expected the call to 911 to be created, got %+v", mockRepo.createdCall)
+	}
+	if len(audit.events) != 1 || audit.events[0].Type != domain.AuditShortCodeRouted || audit.events[0].Country != "US" {
+		t.Errorf("expected a routed short code audit event, got %+v", audit.events)
+	}
+}
+
+func TestShortCodeGuard_Check(t *testing.T) {
+	audit := &mockAuditRepository{err: errors.New("db down")}
+	guard := newShortCodeTestGuard(t, audit)
+
+	if code, err := guard.Check(context.Background(), ShortCodeAttempt{UserID: "user-1", PhoneNumber: "+491512345678"}); code != nil || err != nil {
+		t.Errorf("expected a regular number to pass, got %+v, %v", code, err)
+	}
+	if _, err := guard.Check(context.Background(), ShortCodeAttempt{UserID: "user-1", PhoneNumber: "911"}); !errors.Is(err, domain.ErrEmergencyNotSupported) {
+		t.Errorf("expected emergency to be refused despite the audit failure, got %v", err)
+	}
+
+	bare := NewShortCodeGuard(nil, nil)
+	if _, err := bare.Check(context.Background(), ShortCodeAttempt{UserID: "user-1", PhoneNumber: "112"}); !errors.Is(err, domain.ErrEmergencyNotSupported) {
+		t.Errorf("expected a guard without routes or audit log to refuse emergency, got %v", err)
+	}
+}
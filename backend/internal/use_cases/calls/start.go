@@ -21,10 +21,11 @@ type StartCallOutput struct {
 
 type StartCallUseCase struct {
 	callRepo domain.CallRepository
+	events   domain.EventPublisher
 }
 
-func NewStartCallUseCase(callRepo domain.CallRepository) *StartCallUseCase {
-	return &StartCallUseCase{callRepo: callRepo}
+func NewStartCallUseCase(callRepo domain.CallRepository, events domain.EventPublisher) *StartCallUseCase {
+	return &StartCallUseCase{callRepo: callRepo, events: events}
 }
 
 func (uc *StartCallUseCase) Execute(ctx context.Context, input StartCallInput) (*StartCallOutput, error) {
@@ -51,6 +52,8 @@ func (uc *StartCallUseCase) Execute(ctx context.Context, input StartCallInput) (
 
 	slog.Info("call created", "call_id", call.ID, "user_id", input.UserID, "phone", input.PhoneNumber)
 
+	publishCallStatus(ctx, uc.events, call)
+
 	return &StartCallOutput{
 		CallID:    call.ID,
 		StartTime: call.StartTime,
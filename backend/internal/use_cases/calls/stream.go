@@ -0,0 +1,86 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type StreamCallInput struct {
+	UserID      string
+	CallID      string
+	LastEventID string
+}
+
+type StreamCallOutput struct {
+	Call *domain.Call
+	// Subscription delivers the owner's events, which the caller filters by
+	// call ID. It is nil when the call has already finished.
+	Subscription domain.EventSubscription
+}
+
+type StreamCallUseCase struct {
+	callRepo   domain.CallRepository
+	subscriber domain.EventSubscriber
+}
+
+func NewStreamCallUseCase(callRepo domain.CallRepository, subscriber domain.EventSubscriber) *StreamCallUseCase {
+	return &StreamCallUseCase{
+		callRepo:   callRepo,
+		subscriber: subscriber,
+	}
+}
+
+func (uc *StreamCallUseCase) Execute(ctx context.Context, input StreamCallInput) (*StreamCallOutput, error) {
+	if input.CallID == "" {
+		return nil, errors.New("call_id is required")
+	}
+
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	// Subscribe before loading the call so that a change made in between is
+	// delivered rather than lost.
+	sub, err := uc.subscriber.Subscribe(input.UserID, input.LastEventID)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidEventID) {
+			return nil, err
+		}
+		slog.Error("failed to subscribe to call events", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to subscribe to call events")
+	}
+
+	call, err := uc.callRepo.GetByID(ctx, input.CallID)
+	if err != nil {
+		sub.Close()
+		slog.Error("failed to get call", "error", err, "call_id", input.CallID)
+		return nil, errors.New("failed to get call")
+	}
+
+	if call == nil {
+		sub.Close()
+		return nil, errors.New("call not found")
+	}
+
+	if call.UserID != input.UserID {
+		sub.Close()
+		slog.Warn("unauthorized call stream attempt",
+			"call_id", input.CallID,
+			"user_id", input.UserID,
+			"call_user_id", call.UserID)
+		return nil, errors.New("unauthorized")
+	}
+
+	if call.Status.IsTerminal() {
+		sub.Close()
+		return &StreamCallOutput{Call: call}, nil
+	}
+
+	return &StreamCallOutput{
+		Call:         call,
+		Subscription: sub,
+	}, nil
+}
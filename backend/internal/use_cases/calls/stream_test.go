@@ -0,0 +1,146 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockEventSubscription struct {
+	events chan *domain.CallEvent
+	closed bool
+}
+
+func (m *mockEventSubscription) Events() <-chan *domain.CallEvent {
+	return m.events
+}
+
+func (m *mockEventSubscription) Close() {
+	m.closed = true
+}
+
+type mockEventSubscriber struct {
+	subscription *mockEventSubscription
+	lastEventID  string
+	err          error
+}
+
+func (m *mockEventSubscriber) Subscribe(userID, lastEventID string) (domain.EventSubscription, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.lastEventID = lastEventID
+	m.subscription = &mockEventSubscription{events: make(chan *domain.CallEvent)}
+	return m.subscription, nil
+}
+
+func TestStreamCallUseCase_Execute_Success(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{
+		call: &domain.Call{
+			ID:     "call-1",
+			UserID: "user-1",
+			Status: domain.CallStatusConnecting,
+		},
+	}
+	subscriber := &mockEventSubscriber{}
+
+	uc := NewStreamCallUseCase(mockRepo, subscriber)
+
+	output, err := uc.Execute(context.Background(), StreamCallInput{
+		UserID:      "user-1",
+		CallID:      "call-1",
+		LastEventID: "7",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.Subscription == nil {
+		t.Fatal("expected subscription for an ongoing call")
+	}
+
+	if subscriber.lastEventID != "7" {
+		t.Errorf("expected last event id '7' to be passed on, got '%s'", subscriber.lastEventID)
+	}
+}
+
+func TestStreamCallUseCase_Execute_FinishedCall(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{
+		call: &domain.Call{
+			ID:     "call-1",
+			UserID: "user-1",
+			Status: domain.CallStatusCompleted,
+		},
+	}
+	subscriber := &mockEventSubscriber{}
+
+	uc := NewStreamCallUseCase(mockRepo, subscriber)
+
+	output, err := uc.Execute(context.Background(), StreamCallInput{
+		UserID: "user-1",
+		CallID: "call-1",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.Subscription != nil {
+		t.Error("expected no subscription for a finished call")
+	}
+
+	if !subscriber.subscription.closed {
+		t.Error("expected subscription to be closed")
+	}
+}
+
+func TestStreamCallUseCase_Execute_Unauthorized(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{
+		call: &domain.Call{
+			ID:     "call-1",
+			UserID: "other-user",
+			Status: domain.CallStatusActive,
+		},
+	}
+	subscriber := &mockEventSubscriber{}
+
+	uc := NewStreamCallUseCase(mockRepo, subscriber)
+
+	_, err := uc.Execute(context.Background(), StreamCallInput{
+		UserID: "user-1",
+		CallID: "call-1",
+	})
+	if err == nil || err.Error() != "unauthorized" {
+		t.Fatalf("expected 'unauthorized' error, got %v", err)
+	}
+
+	if !subscriber.subscription.closed {
+		t.Error("expected subscription to be closed")
+	}
+}
+
+func TestStreamCallUseCase_Execute_CallNotFound(t *testing.T) {
+	uc := NewStreamCallUseCase(&mockCallRepositoryForTerminate{}, &mockEventSubscriber{})
+
+	_, err := uc.Execute(context.Background(), StreamCallInput{
+		UserID: "user-1",
+		CallID: "call-1",
+	})
+	if err == nil || err.Error() != "call not found" {
+		t.Errorf("expected 'call not found' error, got %v", err)
+	}
+}
+
+func TestStreamCallUseCase_Execute_InvalidLastEventID(t *testing.T) {
+	uc := NewStreamCallUseCase(&mockCallRepositoryForTerminate{}, &mockEventSubscriber{err: domain.ErrInvalidEventID})
+
+	_, err := uc.Execute(context.Background(), StreamCallInput{
+		UserID:      "user-1",
+		CallID:      "call-1",
+		LastEventID: "abc",
+	})
+	if !errors.Is(err, domain.ErrInvalidEventID) {
+		t.Errorf("expected ErrInvalidEventID, got %v", err)
+	}
+}
@@ -23,12 +23,16 @@ type TerminateCallOutput struct {
 type TerminateCallUseCase struct {
 	callRepo    domain.CallRepository
 	voipService domain.VoIPService
+	events      domain.EventPublisher
+	holdPolicy  domain.HoldPolicy
 }
 
-func NewTerminateCallUseCase(callRepo domain.CallRepository, voipService domain.VoIPService) *TerminateCallUseCase {
+func NewTerminateCallUseCase(callRepo domain.CallRepository, voipService domain.VoIPService, events domain.EventPublisher, holdPolicy domain.HoldPolicy) *TerminateCallUseCase {
 	return &TerminateCallUseCase{
 		callRepo:    callRepo,
 		voipService: voipService,
+		events:      events,
+		holdPolicy:  holdPolicy,
 	}
 }
 
@@ -59,16 +63,37 @@ func (uc *TerminateCallUseCase) Execute(ctx context.Context, input TerminateCall
 		return nil, errors.New("unauthorized")
 	}
 
+	// A finished call keeps its final status and duration. A transferred
+	// call is finished for the caller only: its provider call now carries
+	// the transfer target's conversation and must not be hung up.
+	if call.Status.IsTerminal() {
+		return &TerminateCallOutput{
+			CallID:   call.ID,
+			Duration: call.Duration,
+			Status:   string(call.Status),
+		}, nil
+	}
+
 	if call.SessionID != "" {
 		if err := uc.voipService.TerminateCall(ctx, call.SessionID); err != nil {
-			slog.Warn("failed to terminate voip session", 
-				"error", err, 
-				"session_id", call.SessionID)
+			if !errors.Is(err, domain.ErrSessionNotFound) {
+				slog.Error("failed to terminate voip session",
+					"error", err,
+					"session_id", call.SessionID,
+					"provider_call_sid", call.ProviderCallSID)
+				return nil, errors.New("failed to terminate call")
+			}
+			slog.Warn("voip session not found on termination",
+				"session_id", call.SessionID,
+				"provider_call_sid", call.ProviderCallSID)
+			if err := uc.hangUp(ctx, call); err != nil {
+				return nil, errors.New("failed to terminate call")
+			}
 		}
 	}
 
-	duration := int(time.Since(call.StartTime).Seconds())
-	call.Duration = duration
+	uc.holdPolicy.Apply(call, int(time.Since(call.StartTime).Seconds()), time.Now())
+	duration := call.Duration
 	call.Status = domain.CallStatusCompleted
 
 	if err := uc.callRepo.Update(ctx, call); err != nil {
@@ -84,6 +109,8 @@ func (uc *TerminateCallUseCase) Execute(ctx context.Context, input TerminateCall
 		"session_id", call.SessionID,
 		"duration", duration)
 
+	publishCallStatus(ctx, uc.events, call)
+
 	return &TerminateCallOutput{
 		CallID:   call.ID,
 		Duration: duration,
@@ -91,3 +118,22 @@ func (uc *TerminateCallUseCase) Execute(ctx context.Context, input TerminateCall
 	}, nil
 }
 
+
+// hangUp ends the provider call of a call without a session, such as a
+// Voice SDK call, so it does not keep running and billing after the user
+// hung up.
+func (uc *TerminateCallUseCase) hangUp(ctx context.Context, call *domain.Call) error {
+	terminator, ok := uc.voipService.(domain.ProviderCallTerminator)
+	if !ok || call.ProviderCallSID == "" {
+		return nil
+	}
+
+	if err := terminator.HangUp(ctx, call.ProviderCallSID); err != nil {
+		slog.Error("failed to hang up provider call",
+			"error", err,
+			"call_id", call.ID,
+			"provider_call_sid", call.ProviderCallSID)
+		return err
+	}
+	return nil
+}
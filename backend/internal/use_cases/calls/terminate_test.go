@@ -35,26 +35,61 @@ func (m *mockCallRepositoryForTerminate) GetByID(ctx context.Context, id string)
 	return m.call, nil
 }
 
-func (m *mockCallRepositoryForTerminate) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Call, error) {
+func (m *mockCallRepositoryForTerminate) GetByProviderCallSID(ctx context.Context, providerCallSID string) (*domain.Call, error) {
+	return nil, nil
+}
+
+func (m *mockCallRepositoryForTerminate) ListByUserID(ctx context.Context, userID string) ([]*domain.Call, error) {
 	return nil, nil
 }
 
 type mockVoIPServiceForTerminate struct {
 	terminateError error
+	terminated     []string
 }
 
-func (m *mockVoIPServiceForTerminate) InitiateCall(ctx context.Context, phoneNumber string) (*domain.CallSession, error) {
+func (m *mockVoIPServiceForTerminate) InitiateCall(ctx context.Context, phoneNumber string, opts domain.CallOptions) (*domain.CallSession, error) {
 	return nil, nil
 }
 
 func (m *mockVoIPServiceForTerminate) TerminateCall(ctx context.Context, sessionID string) error {
-	return m.terminateError
+	if m.terminateError != nil {
+		return m.terminateError
+	}
+	m.terminated = append(m.terminated, sessionID)
+	return nil
 }
 
 func (m *mockVoIPServiceForTerminate) GetSessionStatus(ctx context.Context, sessionID string) (domain.SessionStatus, error) {
 	return domain.SessionStatusActive, nil
 }
 
+type mockVoIPServiceForHangUp struct {
+	mockVoIPServiceForTerminate
+	hangUpError error
+	hungUp      string
+}
+
+func (m *mockVoIPServiceForHangUp) HangUp(ctx context.Context, providerCallSID string) error {
+	if m.hangUpError != nil {
+		return m.hangUpError
+	}
+	m.hungUp = providerCallSID
+	return nil
+}
+
+func newVoiceSDKTestCall() *domain.Call {
+	return &domain.Call{
+		ID:              "test-call-id",
+		UserID:          "test-user-id",
+		PhoneNumber:     "+491512345678",
+		StartTime:       time.Now().Add(-30 * time.Second),
+		Status:          domain.CallStatusActive,
+		SessionID:       "voice_sdk",
+		ProviderCallSID: "CA123",
+	}
+}
+
 func TestTerminateCallUseCase_Execute_Success(t *testing.T) {
 	startTime := time.Now().Add(-30 * time.Second)
 	mockRepo := &mockCallRepositoryForTerminate{
@@ -69,7 +104,7 @@ func TestTerminateCallUseCase_Execute_Success(t *testing.T) {
 	}
 	mockVoIP := &mockVoIPServiceForTerminate{}
 
-	uc := NewTerminateCallUseCase(mockRepo, mockVoIP)
+	uc := NewTerminateCallUseCase(mockRepo, mockVoIP, nil, domain.HoldTimeIncluded)
 
 	input := TerminateCallInput{
 		UserID: "test-user-id",
@@ -111,7 +146,7 @@ func TestTerminateCallUseCase_Execute_MissingCallID(t *testing.T) {
 	mockRepo := &mockCallRepositoryForTerminate{}
 	mockVoIP := &mockVoIPServiceForTerminate{}
 
-	uc := NewTerminateCallUseCase(mockRepo, mockVoIP)
+	uc := NewTerminateCallUseCase(mockRepo, mockVoIP, nil, domain.HoldTimeIncluded)
 
 	input := TerminateCallInput{
 		UserID: "test-user-id",
@@ -137,7 +172,7 @@ func TestTerminateCallUseCase_Execute_MissingUserID(t *testing.T) {
 	mockRepo := &mockCallRepositoryForTerminate{}
 	mockVoIP := &mockVoIPServiceForTerminate{}
 
-	uc := NewTerminateCallUseCase(mockRepo, mockVoIP)
+	uc := NewTerminateCallUseCase(mockRepo, mockVoIP, nil, domain.HoldTimeIncluded)
 
 	input := TerminateCallInput{
 		UserID: "",
@@ -165,7 +200,7 @@ func TestTerminateCallUseCase_Execute_CallNotFound(t *testing.T) {
 	}
 	mockVoIP := &mockVoIPServiceForTerminate{}
 
-	uc := NewTerminateCallUseCase(mockRepo, mockVoIP)
+	uc := NewTerminateCallUseCase(mockRepo, mockVoIP, nil, domain.HoldTimeIncluded)
 
 	input := TerminateCallInput{
 		UserID: "test-user-id",
@@ -200,7 +235,7 @@ func TestTerminateCallUseCase_Execute_Unauthorized(t *testing.T) {
 	}
 	mockVoIP := &mockVoIPServiceForTerminate{}
 
-	uc := NewTerminateCallUseCase(mockRepo, mockVoIP)
+	uc := NewTerminateCallUseCase(mockRepo, mockVoIP, nil, domain.HoldTimeIncluded)
 
 	input := TerminateCallInput{
 		UserID: "test-user-id",
@@ -236,7 +271,7 @@ func TestTerminateCallUseCase_Execute_RepositoryFailure(t *testing.T) {
 	}
 	mockVoIP := &mockVoIPServiceForTerminate{}
 
-	uc := NewTerminateCallUseCase(mockRepo, mockVoIP)
+	uc := NewTerminateCallUseCase(mockRepo, mockVoIP, nil, domain.HoldTimeIncluded)
 
 	input := TerminateCallInput{
 		UserID: "test-user-id",
@@ -258,7 +293,7 @@ func TestTerminateCallUseCase_Execute_RepositoryFailure(t *testing.T) {
 	}
 }
 
-func TestTerminateCallUseCase_Execute_VoIPFailure_ContinuesAnyway(t *testing.T) {
+func TestTerminateCallUseCase_Execute_SessionNotFound_ContinuesAnyway(t *testing.T) {
 	startTime := time.Now().Add(-30 * time.Second)
 	mockRepo := &mockCallRepositoryForTerminate{
 		call: &domain.Call{
@@ -271,10 +306,10 @@ func TestTerminateCallUseCase_Execute_VoIPFailure_ContinuesAnyway(t *testing.T)
 		},
 	}
 	mockVoIP := &mockVoIPServiceForTerminate{
-		terminateError: errors.New("voip service unavailable"),
+		terminateError: domain.ErrSessionNotFound,
 	}
 
-	uc := NewTerminateCallUseCase(mockRepo, mockVoIP)
+	uc := NewTerminateCallUseCase(mockRepo, mockVoIP, nil, domain.HoldTimeIncluded)
 
 	input := TerminateCallInput{
 		UserID: "test-user-id",
@@ -292,7 +327,89 @@ func TestTerminateCallUseCase_Execute_VoIPFailure_ContinuesAnyway(t *testing.T)
 	}
 
 	if mockRepo.updatedCall == nil {
-		t.Fatal("expected call to be updated despite VoIP failure")
+		t.Fatal("expected call to be updated despite missing VoIP session")
 	}
 }
 
+func TestTerminateCallUseCase_Execute_VoIPFailure(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{
+		call: &domain.Call{
+			ID:              "test-call-id",
+			UserID:          "test-user-id",
+			PhoneNumber:     "+491512345678",
+			StartTime:       time.Now().Add(-30 * time.Second),
+			Status:          domain.CallStatusActive,
+			SessionID:       "test-session-id",
+			ProviderCallSID: "CA123",
+		},
+	}
+	mockVoIP := &mockVoIPServiceForTerminate{
+		terminateError: errors.New("voip service unavailable"),
+	}
+
+	uc := NewTerminateCallUseCase(mockRepo, mockVoIP, nil, domain.HoldTimeIncluded)
+
+	input := TerminateCallInput{
+		UserID: "test-user-id",
+		CallID: "test-call-id",
+	}
+
+	output, err := uc.Execute(context.Background(), input)
+
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	if output != nil {
+		t.Errorf("expected nil output, got %v", output)
+	}
+
+	if err.Error() != "failed to terminate call" {
+		t.Errorf("expected error 'failed to terminate call', got '%s'", err.Error())
+	}
+
+	if mockRepo.updatedCall != nil {
+		t.Error("expected call not to be marked completed when provider hangup fails")
+	}
+}
+
+func TestTerminateCallUseCase_Execute_VoiceSDKHangsUpProviderCall(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newVoiceSDKTestCall()}
+	mockVoIP := &mockVoIPServiceForHangUp{
+		mockVoIPServiceForTerminate: mockVoIPServiceForTerminate{terminateError: domain.ErrSessionNotFound},
+	}
+
+	uc := NewTerminateCallUseCase(mockRepo, mockVoIP, nil, domain.HoldTimeIncluded)
+
+	output, err := uc.Execute(context.Background(), TerminateCallInput{UserID: "test-user-id", CallID: "test-call-id"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if mockVoIP.hungUp != "CA123" {
+		t.Errorf("expected provider call CA123 to be hung up, got '%s'", mockVoIP.hungUp)
+	}
+
+	if output.Status != "completed" || mockRepo.updatedCall == nil {
+		t.Errorf("expected completed call, got %+v", output)
+	}
+}
+
+func TestTerminateCallUseCase_Execute_VoiceSDKHangUpFailure(t *testing.T) {
+	mockRepo := &mockCallRepositoryForTerminate{call: newVoiceSDKTestCall()}
+	mockVoIP := &mockVoIPServiceForHangUp{
+		mockVoIPServiceForTerminate: mockVoIPServiceForTerminate{terminateError: domain.ErrSessionNotFound},
+		hangUpError:                 errors.New("provider down"),
+	}
+
+	uc := NewTerminateCallUseCase(mockRepo, mockVoIP, nil, domain.HoldTimeIncluded)
+
+	_, err := uc.Execute(context.Background(), TerminateCallInput{UserID: "test-user-id", CallID: "test-call-id"})
+	if err == nil || err.Error() != "failed to terminate call" {
+		t.Fatalf("expected 'failed to terminate call', got %v", err)
+	}
+
+	if mockRepo.updatedCall != nil {
+		t.Error("expected call not to be marked completed when provider hangup fails")
+	}
+}
@@ -0,0 +1,331 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type TransferCallInput struct {
+	UserID string
+	CallID string
+	Mode   domain.TransferMode
+	// PhoneNumber or TargetEmail, the email of another platform user, is
+	// who the call is transferred to.
+	PhoneNumber string
+	TargetEmail string
+}
+
+type TransferCallOutput struct {
+	// CallID is the transfer leg. For an attended transfer it is the
+	// consultation call, answered like an initiated call.
+	CallID       string
+	ParentCallID string
+	Mode         string
+	Status       string
+	SessionID    string
+	SDPOffer     string
+}
+
+// TransferCallUseCase hands the called party of a call over to a phone number
+// or to another user's browser. Each transfer is a new call record, a leg,
+// linked to the transferred call, so both show up in history.
+//
+// A blind transfer connects the called party to the target right away and
+// ends the caller's call as transferred. An attended transfer puts the call
+// on hold and places a consultation call to the target; CompleteTransferUseCase
+// then connects the two.
+type TransferCallUseCase struct {
+	callRepo    domain.CallRepository
+	userRepo    domain.UserRepository
+	voipService domain.VoIPService
+	events      domain.EventPublisher
+	holdPolicy  domain.HoldPolicy
+	shortCodes  *ShortCodeGuard
+}
+
+func NewTransferCallUseCase(callRepo domain.CallRepository, userRepo domain.UserRepository, voipService domain.VoIPService, events domain.EventPublisher, holdPolicy domain.HoldPolicy, shortCodes *ShortCodeGuard) *TransferCallUseCase {
+	return &TransferCallUseCase{
+		callRepo:    callRepo,
+		userRepo:    userRepo,
+		voipService: voipService,
+		events:      events,
+		holdPolicy:  holdPolicy,
+		shortCodes:  shortCodes,
+	}
+}
+
+func (uc *TransferCallUseCase) Execute(ctx context.Context, input TransferCallInput) (*TransferCallOutput, error) {
+	transferrer, ok := uc.voipService.(domain.CallTransferrer)
+	if !ok {
+		return nil, errors.New("transfer is not supported")
+	}
+
+	if !input.Mode.IsValid() {
+		return nil, errors.New("invalid transfer mode")
+	}
+
+	target, err := uc.target(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	call, err := ownedWebRTCCall(ctx, uc.callRepo, input.CallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := liveCall(call); err != nil {
+		return nil, err
+	}
+
+	if err := outsideConference(call); err != nil {
+		return nil, err
+	}
+
+	if input.Mode == domain.TransferAttended {
+		return uc.consult(ctx, transferrer, call, target)
+	}
+	return uc.blind(ctx, transferrer, call, target)
+}
+
+func (uc *TransferCallUseCase) target(ctx context.Context, input TransferCallInput) (domain.TransferTarget, error) {
+	if (input.PhoneNumber == "") == (input.TargetEmail == "") {
+		return domain.TransferTarget{}, errors.New("phone_number or email is required")
+	}
+
+	if input.PhoneNumber != "" {
+		phoneNumber, err := uc.shortCodes.internationalDestination(ctx, ShortCodeAttempt{
+			UserID:      input.UserID,
+			PhoneNumber: input.PhoneNumber,
+			CallID:      input.CallID,
+		})
+		if err != nil {
+			return domain.TransferTarget{}, err
+		}
+		return domain.TransferTarget{PhoneNumber: phoneNumber}, nil
+	}
+
+	user, err := uc.userRepo.GetByEmail(ctx, input.TargetEmail)
+	if err != nil {
+		slog.Error("failed to get transfer target", "error", err)
+		return domain.TransferTarget{}, errors.New("failed to get user")
+	}
+
+	if user == nil {
+		return domain.TransferTarget{}, errors.New("transfer target not found")
+	}
+
+	if user.ID == input.UserID {
+		return domain.TransferTarget{}, errors.New("cannot transfer a call to yourself")
+	}
+
+	return domain.TransferTarget{UserID: user.ID}, nil
+}
+
+func (uc *TransferCallUseCase) blind(ctx context.Context, transferrer domain.CallTransferrer, call *domain.Call, target domain.TransferTarget) (*TransferCallOutput, error) {
+	leg := newTransferLeg(call, target, domain.TransferBlind)
+
+	if err := uc.callRepo.Create(ctx, leg); err != nil {
+		slog.Error("failed to create call record", "error", err, "user_id", call.UserID)
+		return nil, errors.New("failed to create call record")
+	}
+
+	if err := transferrer.Transfer(ctx, call.SessionID, target, leg.ID); err != nil {
+		leg.Status = domain.CallStatusFailed
+		if updateErr := uc.callRepo.Update(ctx, leg); updateErr != nil {
+			slog.Error("failed to update call", "error", updateErr, "call_id", leg.ID)
+		}
+		return nil, controlError(err, call, "failed to transfer call")
+	}
+
+	if err := markTransferred(ctx, uc.callRepo, uc.events, uc.holdPolicy, call); err != nil {
+		return nil, err
+	}
+
+	slog.Info("call transferred",
+		"call_id", call.ID,
+		"leg_id", leg.ID,
+		"mode", leg.TransferMode,
+		"transfer_user_id", leg.TransferUserID)
+
+	publishCallStatus(ctx, uc.events, leg)
+
+	return transferOutput(leg), nil
+}
+
+func (uc *TransferCallUseCase) consult(ctx context.Context, transferrer domain.CallTransferrer, call *domain.Call, target domain.TransferTarget) (*TransferCallOutput, error) {
+	holder, ok := uc.voipService.(domain.CallHolder)
+	if !ok {
+		return nil, errors.New("hold is not supported")
+	}
+
+	if call.Status == domain.CallStatusActive {
+		if err := putOnHold(ctx, uc.callRepo, holder, uc.events, call); err != nil {
+			return nil, err
+		}
+	}
+
+	session, err := transferrer.Consult(ctx, target, domain.CallOptions{Identity: call.UserID})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPhoneNumber) {
+			return nil, err
+		}
+		slog.Error("failed to place consultation call",
+			"error", err,
+			"call_id", call.ID,
+			"transfer_user_id", target.UserID)
+		return nil, errors.New("failed to transfer call")
+	}
+
+	leg := newTransferLeg(call, target, domain.TransferAttended)
+	leg.SessionID = session.SessionID
+	leg.ProviderCallSID = session.ProviderCallSID
+	leg.SDPOffer = session.SDPOffer
+
+	if err := uc.callRepo.Create(ctx, leg); err != nil {
+		slog.Error("failed to create call record",
+			"error", err,
+			"user_id", call.UserID,
+			"session_id", session.SessionID)
+		return nil, errors.New("failed to create call record")
+	}
+
+	slog.Info("consultation call placed",
+		"call_id", call.ID,
+		"leg_id", leg.ID,
+		"session_id", leg.SessionID,
+		"transfer_user_id", leg.TransferUserID)
+
+	publishCallStatus(ctx, uc.events, leg)
+
+	return transferOutput(leg), nil
+}
+
+type CompleteTransferInput struct {
+	UserID string
+	// CallID is the consultation call of an attended transfer.
+	CallID string
+}
+
+// CompleteTransferUseCase finishes an attended transfer: the held call's
+// called party and the consultation call's target are connected, and the
+// caller leaves both. To abandon the transfer instead, terminate the
+// consultation call and resume the held one.
+type CompleteTransferUseCase struct {
+	callRepo    domain.CallRepository
+	voipService domain.VoIPService
+	events      domain.EventPublisher
+	holdPolicy  domain.HoldPolicy
+}
+
+func NewCompleteTransferUseCase(callRepo domain.CallRepository, voipService domain.VoIPService, events domain.EventPublisher, holdPolicy domain.HoldPolicy) *CompleteTransferUseCase {
+	return &CompleteTransferUseCase{
+		callRepo:    callRepo,
+		voipService: voipService,
+		events:      events,
+		holdPolicy:  holdPolicy,
+	}
+}
+
+func (uc *CompleteTransferUseCase) Execute(ctx context.Context, input CompleteTransferInput) (*TransferCallOutput, error) {
+	transferrer, ok := uc.voipService.(domain.CallTransferrer)
+	if !ok {
+		return nil, errors.New("transfer is not supported")
+	}
+
+	leg, err := ownedWebRTCCall(ctx, uc.callRepo, input.CallID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if leg.TransferMode != domain.TransferAttended || leg.ParentCallID == "" {
+		return nil, errors.New("call is not a consultation call")
+	}
+
+	if err := liveCall(leg); err != nil {
+		return nil, err
+	}
+
+	call, err := uc.callRepo.GetByID(ctx, leg.ParentCallID)
+	if err != nil {
+		slog.Error("failed to get call", "error", err, "call_id", leg.ParentCallID)
+		return nil, errors.New("failed to get call")
+	}
+
+	if call == nil || call.Status.IsTerminal() {
+		return nil, errors.New("call already ended")
+	}
+
+	if err := transferrer.Bridge(ctx, call.SessionID, leg.SessionID); err != nil {
+		return nil, controlError(err, call, "failed to transfer call")
+	}
+
+	if err := markTransferred(ctx, uc.callRepo, uc.events, uc.holdPolicy, call); err != nil {
+		return nil, err
+	}
+
+	// The consultation call carries on as the transferred call; its own
+	// hold, if any, is over.
+	if leg.Status == domain.CallStatusOnHold {
+		leg.EndHold(time.Now())
+		leg.Status = domain.CallStatusActive
+		if err := uc.callRepo.Update(ctx, leg); err != nil {
+			slog.Error("failed to update call", "error", err, "call_id", leg.ID)
+			return nil, errors.New("failed to update call")
+		}
+		publishCallStatus(ctx, uc.events, leg)
+	}
+
+	slog.Info("call transferred",
+		"call_id", call.ID,
+		"leg_id", leg.ID,
+		"mode", leg.TransferMode,
+		"transfer_user_id", leg.TransferUserID)
+
+	return transferOutput(leg), nil
+}
+
+func newTransferLeg(call *domain.Call, target domain.TransferTarget, mode domain.TransferMode) *domain.Call {
+	return &domain.Call{
+		UserID:         call.UserID,
+		PhoneNumber:    target.PhoneNumber,
+		StartTime:      time.Now(),
+		Status:         domain.CallStatusConnecting,
+		Direction:      domain.CallDirectionOutbound,
+		ParentCallID:   call.ID,
+		TransferMode:   mode,
+		TransferUserID: target.UserID,
+	}
+}
+
+// markTransferred ends the caller's part in a transferred call. The call's
+// session now carries the transfer target's conversation, so the call lets
+// go of it: nothing done to the caller's call may reach the target.
+func markTransferred(ctx context.Context, callRepo domain.CallRepository, events domain.EventPublisher, holdPolicy domain.HoldPolicy, call *domain.Call) error {
+	call.Status = domain.CallStatusTransferred
+	call.SessionID = ""
+	holdPolicy.Apply(call, int(time.Since(call.StartTime).Seconds()), time.Now())
+
+	if err := callRepo.Update(ctx, call); err != nil {
+		slog.Error("failed to update call", "error", err, "call_id", call.ID)
+		return errors.New("failed to update call")
+	}
+
+	publishCallStatus(ctx, events, call)
+	return nil
+}
+
+func transferOutput(leg *domain.Call) *TransferCallOutput {
+	return &TransferCallOutput{
+		CallID:       leg.ID,
+		ParentCallID: leg.ParentCallID,
+		Mode:         string(leg.TransferMode),
+		Status:       string(leg.Status),
+		SessionID:    leg.SessionID,
+		SDPOffer:     leg.SDPOffer,
+	}
+}
@@ -0,0 +1,355 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockCallRepositoryForTransfer struct {
+	calls   map[string]*domain.Call
+	created []*domain.Call
+}
+
+func newMockCallRepositoryForTransfer(calls ...*domain.Call) *mockCallRepositoryForTransfer {
+	m := &mockCallRepositoryForTransfer{calls: make(map[string]*domain.Call)}
+	for _, call := range calls {
+		m.calls[call.ID] = call
+	}
+	return m
+}
+
+func (m *mockCallRepositoryForTransfer) Create(ctx context.Context, call *domain.Call) error {
+	call.ID = "leg-1"
+	m.calls[call.ID] = call
+	m.created = append(m.created, call)
+	return nil
+}
+
+func (m *mockCallRepositoryForTransfer) Update(ctx context.Context, call *domain.Call) error {
+	m.calls[call.ID] = call
+	return nil
+}
+
+func (m *mockCallRepositoryForTransfer) GetByID(ctx context.Context, id string) (*domain.Call, error) {
+	return m.calls[id], nil
+}
+
+func (m *mockCallRepositoryForTransfer) GetByProviderCallSID(ctx context.Context, providerCallSID string) (*domain.Call, error) {
+	return nil, nil
+}
+
+func (m *mockCallRepositoryForTransfer) ListByUserID(ctx context.Context, userID string) ([]*domain.Call, error) {
+	return nil, nil
+}
+
+type mockUserRepositoryForTransfer struct {
+	users map[string]*domain.User
+}
+
+func (m *mockUserRepositoryForTransfer) Create(ctx context.Context, user *domain.User) error {
+	return nil
+}
+
+func (m *mockUserRepositoryForTransfer) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return m.users[email], nil
+}
+
+func (m *mockUserRepositoryForTransfer) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	return nil, nil
+}
+
+func newMockUserRepositoryForTransfer() *mockUserRepositoryForTransfer {
+	return &mockUserRepositoryForTransfer{users: map[string]*domain.User{
+		"assistant@example.com": {ID: "user-1", Email: "assistant@example.com"},
+		"boss@example.com":      {ID: "user-2", Email: "boss@example.com"},
+	}}
+}
+
+type mockVoIPServiceForTransfer struct {
+	mockVoIPServiceForHold
+	transferError error
+	consultError  error
+	bridgeError   error
+	transferred   string
+	legID         string
+	target        domain.TransferTarget
+	bridged       [2]string
+}
+
+func (m *mockVoIPServiceForTransfer) Transfer(ctx context.Context, sessionID string, target domain.TransferTarget, legID string) error {
+	if m.transferError != nil {
+		return m.transferError
+	}
+	m.transferred = sessionID
+	m.target = target
+	m.legID = legID
+	return nil
+}
+
+func (m *mockVoIPServiceForTransfer) Consult(ctx context.Context, target domain.TransferTarget, opts domain.CallOptions) (*domain.CallSession, error) {
+	if m.consultError != nil {
+		return nil, m.consultError
+	}
+	m.target = target
+	m.opts = opts
+	return &domain.CallSession{SessionID: "sess_2", ProviderCallSID: "CA2", SDPOffer: "v=0"}, nil
+}
+
+func (m *mockVoIPServiceForTransfer) Bridge(ctx context.Context, sessionID, consultSessionID string) error {
+	if m.bridgeError != nil {
+		return m.bridgeError
+	}
+	m.bridged = [2]string{sessionID, consultSessionID}
+	return nil
+}
+
+func newConsultTestCall() *domain.Call {
+	return &domain.Call{
+		ID:           "leg-1",
+		UserID:       "user-1",
+		PhoneNumber:  "+14155550100",
+		SessionID:    "sess_2",
+		Status:       domain.CallStatusActive,
+		ParentCallID: "call-1",
+		TransferMode: domain.TransferAttended,
+	}
+}
+
+func TestTransferCallUseCase_Execute_Blind(t *testing.T) {
+	mockRepo := newMockCallRepositoryForTransfer(newDTMFTestCall())
+	mockVoIP := &mockVoIPServiceForTransfer{}
+	events := &mockEventPublisher{}
+
+	uc := NewTransferCallUseCase(mockRepo, newMockUserRepositoryForTransfer(), mockVoIP, events, domain.HoldTimeIncluded, NewShortCodeGuard(nil, nil))
+
+	output, err := uc.Execute(context.Background(), TransferCallInput{
+		UserID:      "user-1",
+		CallID:      "call-1",
+		Mode:        domain.TransferBlind,
+		TargetEmail: "boss@example.com",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if mockVoIP.transferred != "sess_1" || mockVoIP.target.UserID != "user-2" || mockVoIP.legID != "leg-1" {
+		t.Errorf("unexpected transfer: session=%s target=%+v leg=%s", mockVoIP.transferred, mockVoIP.target, mockVoIP.legID)
+	}
+
+	if output.CallID != "leg-1" || output.ParentCallID != "call-1" || output.Mode != "blind" || output.Status != "connecting" {
+		t.Errorf("unexpected output: %+v", output)
+	}
+
+	leg := mockRepo.calls["leg-1"]
+	if leg.UserID != "user-1" || leg.TransferUserID != "user-2" || leg.PhoneNumber != "" {
+		t.Errorf("unexpected transfer leg: %+v", leg)
+	}
+
+	if status := mockRepo.calls["call-1"].Status; status != domain.CallStatusTransferred {
+		t.Errorf("expected transferred call, got status '%s'", status)
+	}
+
+	if len(events.events) != 2 || events.events[0].Status != domain.CallStatusTransferred || events.events[1].ParentCallID != "call-1" {
+		t.Errorf("expected transferred and leg events, got %+v", events.events)
+	}
+}
+
+func TestTransferCallUseCase_Execute_BlindProviderFailure(t *testing.T) {
+	mockRepo := newMockCallRepositoryForTransfer(newDTMFTestCall())
+	mockVoIP := &mockVoIPServiceForTransfer{transferError: errors.New("provider down")}
+
+	uc := NewTransferCallUseCase(mockRepo, newMockUserRepositoryForTransfer(), mockVoIP, nil, domain.HoldTimeIncluded, NewShortCodeGuard(nil, nil))
+
+	_, err := uc.Execute(context.Background(), TransferCallInput{
+		UserID:      "user-1",
+		CallID:      "call-1",
+		Mode:        domain.TransferBlind,
+		PhoneNumber: "+14155550100",
+	})
+	if err == nil || err.Error() != "failed to transfer call" {
+		t.Fatalf("expected failed to transfer call error, got %v", err)
+	}
+
+	if status := mockRepo.calls["leg-1"].Status; status != domain.CallStatusFailed {
+		t.Errorf("expected failed leg, got status '%s'", status)
+	}
+	if status := mockRepo.calls["call-1"].Status; status != domain.CallStatusActive {
+		t.Errorf("expected call to stay active, got status '%s'", status)
+	}
+}
+
+func TestTransferCallUseCase_Execute_Attended(t *testing.T) {
+	mockRepo := newMockCallRepositoryForTransfer(newDTMFTestCall())
+	mockVoIP := &mockVoIPServiceForTransfer{}
+	events := &mockEventPublisher{}
+
+	uc := NewTransferCallUseCase(mockRepo, newMockUserRepositoryForTransfer(), mockVoIP, events, domain.HoldTimeIncluded, NewShortCodeGuard(nil, nil))
+
+	output, err := uc.Execute(context.Background(), TransferCallInput{
+		UserID:      "user-1",
+		CallID:      "call-1",
+		Mode:        domain.TransferAttended,
+		PhoneNumber: "+14155550100",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if mockVoIP.held != "sess_1" {
+		t.Errorf("expected call put on hold, got '%s'", mockVoIP.held)
+	}
+	if mockVoIP.target.PhoneNumber != "+14155550100" || mockVoIP.opts.Identity != "user-1" {
+		t.Errorf("unexpected consultation call: target=%+v opts=%+v", mockVoIP.target, mockVoIP.opts)
+	}
+
+	if output.CallID != "leg-1" || output.Mode != "attended" || output.SessionID != "sess_2" || output.SDPOffer != "v=0" {
+		t.Errorf("unexpected output: %+v", output)
+	}
+
+	if status := mockRepo.calls["call-1"].Status; status != domain.CallStatusOnHold {
+		t.Errorf("expected held call, got status '%s'", status)
+	}
+}
+
+func TestTransferCallUseCase_Execute_Errors(t *testing.T) {
+	ringing := newDTMFTestCall()
+	ringing.Status = domain.CallStatusConnecting
+
+	cases := []struct {
+		name     string
+		call     *domain.Call
+		voip     domain.VoIPService
+		input    TransferCallInput
+		expected string
+	}{
+		{"not supported", newDTMFTestCall(), &mockVoIPServiceForHold{}, TransferCallInput{Mode: domain.TransferBlind, PhoneNumber: "+14155550100"}, "transfer is not supported"},
+		{"invalid mode", newDTMFTestCall(), &mockVoIPServiceForTransfer{}, TransferCallInput{Mode: "warm", PhoneNumber: "+14155550100"}, "invalid transfer mode"},
+		{"no target", newDTMFTestCall(), &mockVoIPServiceForTransfer{}, TransferCallInput{Mode: domain.TransferBlind}, "phone_number or email is required"},
+		{"two targets", newDTMFTestCall(), &mockVoIPServiceForTransfer{}, TransferCallInput{Mode: domain.TransferBlind, PhoneNumber: "+14155550100", TargetEmail: "boss@example.com"}, "phone_number or email is required"},
+		{"invalid number", newDTMFTestCall(), &mockVoIPServiceForTransfer{}, TransferCallInput{Mode: domain.TransferBlind, PhoneNumber: "12345"}, "invalid phone number"},
+		{"emergency", newDTMFTestCall(), &mockVoIPServiceForTransfer{}, TransferCallInput{Mode: domain.TransferBlind, PhoneNumber: "112"}, domain.ErrEmergencyNotSupported.Error()},
+		{"unknown user", newDTMFTestCall(), &mockVoIPServiceForTransfer{}, TransferCallInput{Mode: domain.TransferBlind, TargetEmail: "nobody@example.com"}, "transfer target not found"},
+		{"self", newDTMFTestCall(), &mockVoIPServiceForTransfer{}, TransferCallInput{Mode: domain.TransferBlind, TargetEmail: "assistant@example.com"}, "cannot transfer a call to yourself"},
+		{"not answered", ringing, &mockVoIPServiceForTransfer{}, TransferCallInput{Mode: domain.TransferBlind, PhoneNumber: "+14155550100"}, "call is not active"},
+		{"consult failure", newDTMFTestCall(), &mockVoIPServiceForTransfer{consultError: errors.New("provider down")}, TransferCallInput{Mode: domain.TransferAttended, PhoneNumber: "+14155550100"}, "failed to transfer call"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := newMockCallRepositoryForTransfer(tc.call)
+			uc := NewTransferCallUseCase(mockRepo, newMockUserRepositoryForTransfer(), tc.voip, nil, domain.HoldTimeIncluded, NewShortCodeGuard(nil, nil))
+
+			tc.input.UserID = "user-1"
+			tc.input.CallID = "call-1"
+			_, err := uc.Execute(context.Background(), tc.input)
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("expected error '%s', got %v", tc.expected, err)
+			}
+			if len(mockRepo.created) != 0 {
+				t.Errorf("expected no transfer leg, got %+v", mockRepo.created)
+			}
+		})
+	}
+}
+
+func TestCompleteTransferUseCase_Execute_Success(t *testing.T) {
+	mockRepo := newMockCallRepositoryForTransfer(newHeldTestCall(), newConsultTestCall())
+	mockVoIP := &mockVoIPServiceForTransfer{}
+	events := &mockEventPublisher{}
+
+	uc := NewCompleteTransferUseCase(mockRepo, mockVoIP, events, domain.HoldTimeExcluded)
+
+	output, err := uc.Execute(context.Background(), CompleteTransferInput{UserID: "user-1", CallID: "leg-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if mockVoIP.bridged != [2]string{"sess_1", "sess_2"} {
+		t.Errorf("unexpected bridge: %v", mockVoIP.bridged)
+	}
+
+	if output.CallID != "leg-1" || output.ParentCallID != "call-1" || output.Status != "active" {
+		t.Errorf("unexpected output: %+v", output)
+	}
+
+	call := mockRepo.calls["call-1"]
+	if call.Status != domain.CallStatusTransferred || call.HeldAt != nil || call.SessionID != "" {
+		t.Errorf("expected transferred call with its hold ended, got %+v", call)
+	}
+
+	if len(events.events) != 1 || events.events[0].CallID != "call-1" {
+		t.Errorf("expected transferred event, got %+v", events.events)
+	}
+}
+
+func TestTerminateCallUseCase_Execute_AfterTransfer(t *testing.T) {
+	mockRepo := newMockCallRepositoryForTransfer(newDTMFTestCall())
+	mockVoIP := &mockVoIPServiceForTransfer{}
+
+	transfer := NewTransferCallUseCase(mockRepo, newMockUserRepositoryForTransfer(), mockVoIP, nil, domain.HoldTimeIncluded, NewShortCodeGuard(nil, nil))
+	if _, err := transfer.Execute(context.Background(), TransferCallInput{
+		UserID:      "user-1",
+		CallID:      "call-1",
+		Mode:        domain.TransferBlind,
+		PhoneNumber: "+14155550100",
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	call := mockRepo.calls["call-1"]
+	if call.SessionID != "" {
+		t.Errorf("expected the transferred call to let go of its session, got '%s'", call.SessionID)
+	}
+	duration := call.Duration
+
+	uc := NewTerminateCallUseCase(mockRepo, mockVoIP, nil, domain.HoldTimeIncluded)
+
+	output, err := uc.Execute(context.Background(), TerminateCallInput{UserID: "user-1", CallID: "call-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(mockVoIP.terminated) != 0 {
+		t.Errorf("expected the transfer target's call to stay up, got hangups %v", mockVoIP.terminated)
+	}
+
+	if output.Status != "transferred" || output.Duration != duration {
+		t.Errorf("expected the stored status and duration, got %+v", output)
+	}
+
+	if status := mockRepo.calls["call-1"].Status; status != domain.CallStatusTransferred {
+		t.Errorf("expected transferred call, got status '%s'", status)
+	}
+}
+
+func TestCompleteTransferUseCase_Execute_Errors(t *testing.T) {
+	blindLeg := newConsultTestCall()
+	blindLeg.TransferMode = domain.TransferBlind
+	ended := newHeldTestCall()
+	ended.Status = domain.CallStatusCompleted
+
+	cases := []struct {
+		name     string
+		calls    []*domain.Call
+		voip     domain.VoIPService
+		expected string
+	}{
+		{"not supported", []*domain.Call{newHeldTestCall(), newConsultTestCall()}, &mockVoIPServiceForHold{}, "transfer is not supported"},
+		{"not a consultation", []*domain.Call{newHeldTestCall(), blindLeg}, &mockVoIPServiceForTransfer{}, "call is not a consultation call"},
+		{"held call ended", []*domain.Call{ended, newConsultTestCall()}, &mockVoIPServiceForTransfer{}, "call already ended"},
+		{"bridge failure", []*domain.Call{newHeldTestCall(), newConsultTestCall()}, &mockVoIPServiceForTransfer{bridgeError: domain.ErrSessionEnded}, "call already ended"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := newMockCallRepositoryForTransfer(tc.calls...)
+			uc := NewCompleteTransferUseCase(mockRepo, tc.voip, nil, domain.HoldTimeIncluded)
+
+			_, err := uc.Execute(context.Background(), CompleteTransferInput{UserID: "user-1", CallID: "leg-1"})
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("expected error '%s', got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package calls
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type UpdateCallStatusInput struct {
+	// ProviderCallSID identifies the call, or CallID does for transfer legs
+	// whose provider call is not known in advance.
+	ProviderCallSID string
+	CallID          string
+	Status          domain.CallStatus
+	// Duration is the provider-reported duration in seconds. When it is zero,
+	// the duration of a finished call is measured from its start time.
+	Duration int
+}
+
+type UpdateCallStatusOutput struct {
+	CallID   string
+	Status   string
+	Duration int
+}
+
+// UpdateCallStatusUseCase applies status changes reported by the VoIP
+// provider to the call record and notifies the call owner. A conference
+// ends when its last participant's call does.
+type UpdateCallStatusUseCase struct {
+	callRepo       domain.CallRepository
+	conferenceRepo domain.ConferenceRepository
+	voipService    domain.VoIPService
+	events         domain.EventPublisher
+	holdPolicy     domain.HoldPolicy
+}
+
+func NewUpdateCallStatusUseCase(callRepo domain.CallRepository, conferenceRepo domain.ConferenceRepository, voipService domain.VoIPService, events domain.EventPublisher, holdPolicy domain.HoldPolicy) *UpdateCallStatusUseCase {
+	return &UpdateCallStatusUseCase{
+		callRepo:       callRepo,
+		conferenceRepo: conferenceRepo,
+		voipService:    voipService,
+		events:         events,
+		holdPolicy:     holdPolicy,
+	}
+}
+
+func (uc *UpdateCallStatusUseCase) Execute(ctx context.Context, input UpdateCallStatusInput) (*UpdateCallStatusOutput, error) {
+	if input.ProviderCallSID == "" && input.CallID == "" {
+		return nil, errors.New("provider_call_sid is required")
+	}
+
+	if input.Status == "" {
+		return nil, errors.New("status is required")
+	}
+
+	var call *domain.Call
+	var err error
+	if input.CallID != "" {
+		call, err = uc.callRepo.GetByID(ctx, input.CallID)
+	} else {
+		call, err = uc.callRepo.GetByProviderCallSID(ctx, input.ProviderCallSID)
+	}
+	if err != nil {
+		slog.Error("failed to get call", "error", err, "provider_call_sid", input.ProviderCallSID, "call_id", input.CallID)
+		return nil, errors.New("failed to get call")
+	}
+
+	if call == nil {
+		return nil, errors.New("call not found")
+	}
+
+	// A finished call keeps its final status: the provider reports the hangup
+	// after TerminateCallUseCase has already completed the call. A held call
+	// is still answered as far as the provider knows, and an answered call
+	// does not go back to connecting when another leg of it starts ringing.
+	answered := call.Status == domain.CallStatusActive || call.Status == domain.CallStatusOnHold
+	if call.Status.IsTerminal() || call.Status == input.Status ||
+		(call.Status == domain.CallStatusOnHold && input.Status == domain.CallStatusActive) ||
+		(answered && input.Status == domain.CallStatusConnecting) {
+		return &UpdateCallStatusOutput{
+			CallID:   call.ID,
+			Status:   string(call.Status),
+			Duration: call.Duration,
+		}, nil
+	}
+
+	call.Status = input.Status
+	if input.Status.IsTerminal() {
+		total := input.Duration
+		if total == 0 {
+			total = int(time.Since(call.StartTime).Seconds())
+		}
+		uc.holdPolicy.Apply(call, total, time.Now())
+	}
+
+	if err := uc.callRepo.Update(ctx, call); err != nil {
+		slog.Error("failed to update call", "error", err, "call_id", call.ID)
+		return nil, errors.New("failed to update call")
+	}
+
+	slog.Info("call status updated by provider",
+		"call_id", call.ID,
+		"provider_call_sid", call.ProviderCallSID,
+		"status", call.Status)
+
+	publishCallStatus(ctx, uc.events, call)
+
+	if call.Status.IsTerminal() && call.ConferenceID != "" {
+		uc.closeConference(ctx, call.ConferenceID)
+	}
+
+	return &UpdateCallStatusOutput{
+		CallID:   call.ID,
+		Status:   string(call.Status),
+		Duration: call.Duration,
+	}, nil
+}
+
+// closeConference ends the call's conference once no participant is left.
+// The call's own update has been applied either way, so failures are only
+// logged.
+func (uc *UpdateCallStatusUseCase) closeConference(ctx context.Context, conferenceID string) {
+	if uc.conferenceRepo == nil {
+		return
+	}
+
+	conference, err := uc.conferenceRepo.GetByID(ctx, conferenceID)
+	if err != nil || conference == nil {
+		slog.Error("failed to get conference", "error", err, "conference_id", conferenceID)
+		return
+	}
+
+	if err := endConferenceIfEmpty(ctx, uc.conferenceRepo, uc.voipService, conference); err != nil {
+		slog.Error("failed to close conference", "error", err, "conference_id", conferenceID)
+	}
+}
@@ -0,0 +1,220 @@
+package calls
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockCallRepositoryForUpdateStatus struct {
+	call        *domain.Call
+	updatedCall *domain.Call
+}
+
+func (m *mockCallRepositoryForUpdateStatus) Create(ctx context.Context, call *domain.Call) error {
+	return nil
+}
+
+func (m *mockCallRepositoryForUpdateStatus) Update(ctx context.Context, call *domain.Call) error {
+	m.updatedCall = call
+	return nil
+}
+
+func (m *mockCallRepositoryForUpdateStatus) GetByID(ctx context.Context, id string) (*domain.Call, error) {
+	return m.call, nil
+}
+
+func (m *mockCallRepositoryForUpdateStatus) GetByProviderCallSID(ctx context.Context, providerCallSID string) (*domain.Call, error) {
+	if m.call == nil || m.call.ProviderCallSID != providerCallSID {
+		return nil, nil
+	}
+	return m.call, nil
+}
+
+func (m *mockCallRepositoryForUpdateStatus) ListByUserID(ctx context.Context, userID string) ([]*domain.Call, error) {
+	return nil, nil
+}
+
+type mockEventPublisher struct {
+	events []*domain.CallEvent
+}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, event *domain.CallEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func TestUpdateCallStatusUseCase_Execute_Answered(t *testing.T) {
+	mockRepo := &mockCallRepositoryForUpdateStatus{
+		call: &domain.Call{
+			ID:              "call-1",
+			UserID:          "user-1",
+			ProviderCallSID: "CA123",
+			StartTime:       time.Now(),
+			Status:          domain.CallStatusConnecting,
+		},
+	}
+	events := &mockEventPublisher{}
+
+	uc := NewUpdateCallStatusUseCase(mockRepo, nil, nil, events, domain.HoldTimeIncluded)
+
+	output, err := uc.Execute(context.Background(), UpdateCallStatusInput{
+		ProviderCallSID: "CA123",
+		Status:          domain.CallStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.Status != "active" {
+		t.Errorf("expected status 'active', got '%s'", output.Status)
+	}
+
+	if mockRepo.updatedCall == nil {
+		t.Fatal("expected call to be updated")
+	}
+
+	if len(events.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events.events))
+	}
+	if events.events[0].UserID != "user-1" || events.events[0].CallID != "call-1" || events.events[0].Status != domain.CallStatusActive {
+		t.Errorf("unexpected event: %+v", events.events[0])
+	}
+}
+
+func TestUpdateCallStatusUseCase_Execute_CompletedUsesProviderDuration(t *testing.T) {
+	mockRepo := &mockCallRepositoryForUpdateStatus{
+		call: &domain.Call{
+			ID:              "call-1",
+			UserID:          "user-1",
+			ProviderCallSID: "CA123",
+			StartTime:       time.Now().Add(-time.Minute),
+			Status:          domain.CallStatusActive,
+		},
+	}
+
+	uc := NewUpdateCallStatusUseCase(mockRepo, nil, nil, nil, domain.HoldTimeIncluded)
+
+	output, err := uc.Execute(context.Background(), UpdateCallStatusInput{
+		ProviderCallSID: "CA123",
+		Status:          domain.CallStatusCompleted,
+		Duration:        42,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.Duration != 42 {
+		t.Errorf("expected duration 42, got %d", output.Duration)
+	}
+}
+
+func TestUpdateCallStatusUseCase_Execute_FinishedCallUnchanged(t *testing.T) {
+	mockRepo := &mockCallRepositoryForUpdateStatus{
+		call: &domain.Call{
+			ID:              "call-1",
+			UserID:          "user-1",
+			ProviderCallSID: "CA123",
+			Duration:        30,
+			Status:          domain.CallStatusCompleted,
+		},
+	}
+	events := &mockEventPublisher{}
+
+	uc := NewUpdateCallStatusUseCase(mockRepo, nil, nil, events, domain.HoldTimeIncluded)
+
+	output, err := uc.Execute(context.Background(), UpdateCallStatusInput{
+		ProviderCallSID: "CA123",
+		Status:          domain.CallStatusFailed,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.Status != "completed" {
+		t.Errorf("expected status to stay 'completed', got '%s'", output.Status)
+	}
+
+	if mockRepo.updatedCall != nil {
+		t.Error("expected finished call not to be updated")
+	}
+
+	if len(events.events) != 0 {
+		t.Errorf("expected no events, got %d", len(events.events))
+	}
+}
+
+func TestUpdateCallStatusUseCase_Execute_AnsweredCallStaysAnswered(t *testing.T) {
+	mockRepo := &mockCallRepositoryForUpdateStatus{
+		call: &domain.Call{
+			ID:              "call-1",
+			UserID:          "user-1",
+			ProviderCallSID: "CA123",
+			Status:          domain.CallStatusActive,
+		},
+	}
+
+	uc := NewUpdateCallStatusUseCase(mockRepo, nil, nil, &mockEventPublisher{}, domain.HoldTimeIncluded)
+
+	output, err := uc.Execute(context.Background(), UpdateCallStatusInput{
+		ProviderCallSID: "CA123",
+		Status:          domain.CallStatusConnecting,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.Status != "active" {
+		t.Errorf("expected status to stay 'active', got '%s'", output.Status)
+	}
+
+	if mockRepo.updatedCall != nil {
+		t.Error("expected answered call not to be updated")
+	}
+}
+
+func TestUpdateCallStatusUseCase_Execute_CallNotFound(t *testing.T) {
+	uc := NewUpdateCallStatusUseCase(&mockCallRepositoryForUpdateStatus{}, nil, nil, nil, domain.HoldTimeIncluded)
+
+	_, err := uc.Execute(context.Background(), UpdateCallStatusInput{
+		ProviderCallSID: "CA404",
+		Status:          domain.CallStatusActive,
+	})
+	if err == nil || err.Error() != "call not found" {
+		t.Errorf("expected 'call not found' error, got %v", err)
+	}
+}
+
+func TestUpdateCallStatusUseCase_Execute_ByCallID(t *testing.T) {
+	mockRepo := &mockCallRepositoryForUpdateStatus{
+		call: &domain.Call{
+			ID:           "leg-1",
+			UserID:       "user-1",
+			StartTime:    time.Now(),
+			Status:       domain.CallStatusConnecting,
+			ParentCallID: "call-1",
+			TransferMode: domain.TransferBlind,
+		},
+	}
+	events := &mockEventPublisher{}
+
+	uc := NewUpdateCallStatusUseCase(mockRepo, nil, nil, events, domain.HoldTimeIncluded)
+
+	output, err := uc.Execute(context.Background(), UpdateCallStatusInput{
+		CallID: "leg-1",
+		Status: domain.CallStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.CallID != "leg-1" || output.Status != "active" {
+		t.Errorf("unexpected output: %+v", output)
+	}
+
+	if len(events.events) != 1 || events.events[0].ParentCallID != "call-1" {
+		t.Errorf("expected event of the transfer leg, got %+v", events.events)
+	}
+}
@@ -0,0 +1,177 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+// ConferenceHistoryItem is a conference with its participants' calls. Cost
+// sums the participants that have a price.
+type ConferenceHistoryItem struct {
+	ConferenceID string             `json:"conferenceId"`
+	Status       string             `json:"status"`
+	StartTime    time.Time          `json:"startTime"`
+	EndTime      *time.Time         `json:"endTime,omitempty"`
+	Duration     int                `json:"duration"`
+	Cost         string             `json:"cost,omitempty"`
+	Currency     string             `json:"currency,omitempty"`
+	Participants []*CallHistoryItem `json:"participants"`
+}
+
+type ListConferencesInput struct {
+	UserID string
+	Page   int
+	Limit  int
+}
+
+type ListConferencesOutput struct {
+	Conferences []*ConferenceHistoryItem `json:"conferences"`
+	Total       int                      `json:"total"`
+	Page        int                      `json:"page"`
+	Limit       int                      `json:"limit"`
+}
+
+type ListConferencesUseCase struct {
+	conferenceRepo domain.ConferenceRepository
+	rates          *domain.CallRates
+}
+
+func NewListConferencesUseCase(conferenceRepo domain.ConferenceRepository, rates *domain.CallRates) *ListConferencesUseCase {
+	return &ListConferencesUseCase{
+		conferenceRepo: conferenceRepo,
+		rates:          rates,
+	}
+}
+
+func (uc *ListConferencesUseCase) Execute(ctx context.Context, input ListConferencesInput) (*ListConferencesOutput, error) {
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	conferences, err := uc.conferenceRepo.ListByUserID(ctx, input.UserID)
+	if err != nil {
+		slog.Error("failed to get conference history", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to get conference history")
+	}
+
+	total := len(conferences)
+
+	page := input.Page
+	if page < 1 {
+		page = 1
+	}
+
+	limit := input.Limit
+	if limit < 1 {
+		limit = 20
+	}
+
+	start := (page - 1) * limit
+	end := start + limit
+
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	items := make([]*ConferenceHistoryItem, 0, end-start)
+	for _, conference := range conferences[start:end] {
+		item, err := conferenceItem(ctx, uc.conferenceRepo, uc.rates, conference)
+		if err != nil {
+			return nil, errors.New("failed to get conference history")
+		}
+		items = append(items, item)
+	}
+
+	return &ListConferencesOutput{
+		Conferences: items,
+		Total:       total,
+		Page:        page,
+		Limit:       limit,
+	}, nil
+}
+
+type GetConferenceInput struct {
+	UserID       string
+	ConferenceID string
+}
+
+type GetConferenceUseCase struct {
+	conferenceRepo domain.ConferenceRepository
+	rates          *domain.CallRates
+}
+
+func NewGetConferenceUseCase(conferenceRepo domain.ConferenceRepository, rates *domain.CallRates) *GetConferenceUseCase {
+	return &GetConferenceUseCase{
+		conferenceRepo: conferenceRepo,
+		rates:          rates,
+	}
+}
+
+func (uc *GetConferenceUseCase) Execute(ctx context.Context, input GetConferenceInput) (*ConferenceHistoryItem, error) {
+	if input.ConferenceID == "" {
+		return nil, errors.New("conference_id is required")
+	}
+
+	conference, err := uc.conferenceRepo.GetByID(ctx, input.ConferenceID)
+	if err != nil {
+		slog.Error("failed to get conference", "error", err, "conference_id", input.ConferenceID)
+		return nil, errors.New("failed to get conference")
+	}
+	if conference == nil {
+		return nil, errors.New("conference not found")
+	}
+
+	if conference.UserID != input.UserID {
+		slog.Warn("unauthorized conference access attempt",
+			"conference_id", input.ConferenceID,
+			"owner_id", conference.UserID,
+			"requester_id", input.UserID)
+		return nil, errors.New("unauthorized")
+	}
+
+	item, err := conferenceItem(ctx, uc.conferenceRepo, uc.rates, conference)
+	if err != nil {
+		return nil, errors.New("failed to get conference")
+	}
+	return item, nil
+}
+
+func conferenceItem(ctx context.Context, conferenceRepo domain.ConferenceRepository, rates *domain.CallRates, conference *domain.Conference) (*ConferenceHistoryItem, error) {
+	participants, err := conferenceRepo.ListParticipants(ctx, conference.ID)
+	if err != nil {
+		slog.Error("failed to list conference participants", "error", err, "conference_id", conference.ID)
+		return nil, err
+	}
+
+	item := &ConferenceHistoryItem{
+		ConferenceID: conference.ID,
+		Status:       string(conference.Status),
+		StartTime:    conference.StartTime,
+		EndTime:      conference.EndTime,
+		Duration:     conference.Elapsed(time.Now()),
+		Participants: make([]*CallHistoryItem, 0, len(participants)),
+	}
+
+	var total domain.Amount
+	priced := false
+	for _, call := range participants {
+		item.Participants = append(item.Participants, historyItem(call, rates))
+		if cost, ok := callCost(call, rates); ok {
+			total += cost
+			priced = true
+		}
+	}
+	if priced {
+		item.Cost = total.String()
+		item.Currency = rates.Currency()
+	}
+
+	return item, nil
+}
@@ -0,0 +1,110 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type RecordingItem struct {
+	RecordingID string    `json:"recordingId"`
+	Duration    int       `json:"duration"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"contentType"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// DTMFItem is a sequence of digits sent into the call, masked.
+type DTMFItem struct {
+	Digits string    `json:"digits"`
+	SentAt time.Time `json:"sentAt"`
+}
+
+type CallDetail struct {
+	CallHistoryItem
+	Recordings []*RecordingItem `json:"recordings"`
+	DTMF       []*DTMFItem      `json:"dtmf"`
+}
+
+type GetCallInput struct {
+	UserID string
+	CallID string
+}
+
+type GetCallUseCase struct {
+	callRepo   domain.CallRepository
+	recordings domain.RecordingRepository
+	audit      domain.AuditRepository
+	rates      *domain.CallRates
+}
+
+func NewGetCallUseCase(callRepo domain.CallRepository, recordings domain.RecordingRepository, audit domain.AuditRepository, rates *domain.CallRates) *GetCallUseCase {
+	return &GetCallUseCase{
+		callRepo:   callRepo,
+		recordings: recordings,
+		audit:      audit,
+		rates:      rates,
+	}
+}
+
+func (uc *GetCallUseCase) Execute(ctx context.Context, input GetCallInput) (*CallDetail, error) {
+	if input.CallID == "" {
+		return nil, errors.New("call_id is required")
+	}
+
+	call, err := uc.callRepo.GetByID(ctx, input.CallID)
+	if err != nil {
+		slog.Error("failed to get call", "error", err, "call_id", input.CallID)
+		return nil, errors.New("failed to get call")
+	}
+	if call == nil {
+		return nil, errors.New("call not found")
+	}
+
+	if call.UserID != input.UserID {
+		slog.Warn("unauthorized call access attempt",
+			"call_id", input.CallID,
+			"owner_id", call.UserID,
+			"requester_id", input.UserID)
+		return nil, errors.New("unauthorized")
+	}
+
+	recordings, err := uc.recordings.ListByCallID(ctx, call.ID)
+	if err != nil {
+		slog.Error("failed to get call recordings", "error", err, "call_id", call.ID)
+		return nil, errors.New("failed to get call")
+	}
+
+	dtmf, err := uc.audit.ListByCallID(ctx, call.ID, domain.AuditCallDTMF)
+	if err != nil {
+		slog.Error("failed to get call dtmf", "error", err, "call_id", call.ID)
+		return nil, errors.New("failed to get call")
+	}
+
+	detail := &CallDetail{
+		CallHistoryItem: *historyItem(call, uc.rates),
+		Recordings:      make([]*RecordingItem, 0, len(recordings)),
+		DTMF:            make([]*DTMFItem, 0, len(dtmf)),
+	}
+	for _, recording := range recordings {
+		detail.Recordings = append(detail.Recordings, &RecordingItem{
+			RecordingID: recording.ID,
+			Duration:    recording.Duration,
+			Size:        recording.Size,
+			ContentType: recording.ContentType,
+			CreatedAt:   recording.CreatedAt,
+		})
+	}
+
+	for _, event := range dtmf {
+		detail.DTMF = append(detail.DTMF, &DTMFItem{
+			Digits: event.Detail,
+			SentAt: event.CreatedAt,
+		})
+	}
+
+	return detail, nil
+}
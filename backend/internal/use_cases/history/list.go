@@ -10,11 +10,55 @@ import (
 )
 
 type CallHistoryItem struct {
-	CallID      string    `json:"callId"`
-	PhoneNumber string    `json:"phoneNumber"`
-	StartTime   time.Time `json:"startTime"`
-	Duration    int       `json:"duration"`
-	Status      string    `json:"status"`
+	CallID         string    `json:"callId"`
+	PhoneNumber    string    `json:"phoneNumber"`
+	StartTime      time.Time `json:"startTime"`
+	Duration       int       `json:"duration"`
+	HoldDuration   int       `json:"holdDuration"`
+	Status         string    `json:"status"`
+	Direction      string    `json:"direction"`
+	ParentCallID   string    `json:"parentCallId,omitempty"`
+	TransferMode   string    `json:"transferMode,omitempty"`
+	TransferUserID string    `json:"transferUserId,omitempty"`
+	ConferenceID   string    `json:"conferenceId,omitempty"`
+	// CallbackDestination is set on the first leg of a callback call, the
+	// one to the user's own phone.
+	CallbackDestination string `json:"callbackDestination,omitempty"`
+	CallerID            string `json:"callerId,omitempty"`
+	Cost                string `json:"cost,omitempty"`
+	Currency            string `json:"currency,omitempty"`
+}
+
+// historyItem describes a call; Cost is set when a rate applies to it.
+func historyItem(call *domain.Call, rates *domain.CallRates) *CallHistoryItem {
+	item := &CallHistoryItem{
+		CallID:              call.ID,
+		PhoneNumber:         call.PhoneNumber,
+		StartTime:           call.StartTime,
+		Duration:            call.Duration,
+		HoldDuration:        call.HoldDuration,
+		Status:              string(call.Status),
+		Direction:           string(call.Direction),
+		ParentCallID:        call.ParentCallID,
+		TransferMode:        string(call.TransferMode),
+		TransferUserID:      call.TransferUserID,
+		ConferenceID:        call.ConferenceID,
+		CallbackDestination: call.CallbackDestination,
+		CallerID:            call.CallerID,
+	}
+	if cost, ok := callCost(call, rates); ok {
+		item.Cost = cost.String()
+		item.Currency = rates.Currency()
+	}
+	return item
+}
+
+// callCost prices the finished outbound calls the user pays for.
+func callCost(call *domain.Call, rates *domain.CallRates) (domain.Amount, bool) {
+	if call.Direction == domain.CallDirectionInbound || !call.Status.IsTerminal() {
+		return 0, false
+	}
+	return rates.Cost(call.PhoneNumber, call.Duration)
 }
 
 type ListHistoryInput struct {
@@ -34,10 +78,14 @@ type ListHistoryOutput struct {
 
 type ListHistoryUseCase struct {
 	callRepo domain.CallRepository
+	rates    *domain.CallRates
 }
 
-func NewListHistoryUseCase(callRepo domain.CallRepository) *ListHistoryUseCase {
-	return &ListHistoryUseCase{callRepo: callRepo}
+func NewListHistoryUseCase(callRepo domain.CallRepository, rates *domain.CallRates) *ListHistoryUseCase {
+	return &ListHistoryUseCase{
+		callRepo: callRepo,
+		rates:    rates,
+	}
 }
 
 func (uc *ListHistoryUseCase) Execute(ctx context.Context, input ListHistoryInput) (*ListHistoryOutput, error) {
@@ -91,13 +139,7 @@ func (uc *ListHistoryUseCase) Execute(ctx context.Context, input ListHistoryInpu
 
 	items := make([]*CallHistoryItem, 0, len(paginatedCalls))
 	for _, call := range paginatedCalls {
-		items = append(items, &CallHistoryItem{
-			CallID:      call.ID,
-			PhoneNumber: call.PhoneNumber,
-			StartTime:   call.StartTime,
-			Duration:    call.Duration,
-			Status:      string(call.Status),
-		})
+		items = append(items, historyItem(call, uc.rates))
 	}
 
 	return &ListHistoryOutput{
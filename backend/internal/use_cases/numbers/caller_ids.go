@@ -0,0 +1,390 @@
+package numbers
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/phone"
+)
+
+const (
+	verificationCodeDigits = 6
+	verificationCodeTTL    = 10 * time.Minute
+	// maxVerifyAttempts wrong codes void the pending code; a new
+	// verification call has to be requested then.
+	maxVerifyAttempts = 5
+
+	// Verification calls ring numbers the user has not proven to own, so
+	// they are capped per user and per number, whoever asks for them.
+	verificationCallWindow        = 24 * time.Hour
+	maxVerificationCallsPerUser   = 10
+	maxVerificationCallsPerNumber = 3
+)
+
+type CallerIDItem struct {
+	ID         string     `json:"id"`
+	Number     string     `json:"number"`
+	Verified   bool       `json:"verified"`
+	Default    bool       `json:"default"`
+	VerifiedAt *time.Time `json:"verifiedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+func callerIDItem(callerID *domain.CallerID) *CallerIDItem {
+	return &CallerIDItem{
+		ID:         callerID.ID,
+		Number:     callerID.Number,
+		Verified:   callerID.Verified(),
+		Default:    callerID.IsDefault,
+		VerifiedAt: callerID.VerifiedAt,
+		CreatedAt:  callerID.CreatedAt,
+	}
+}
+
+type AddCallerIDInput struct {
+	UserID string
+	Number string
+}
+
+// AddCallerIDUseCase registers one of the user's own numbers as a caller ID
+// and calls it to read out a verification code. Adding a number that is
+// still unverified calls it again with a new code once the previous one has
+// expired. Every verification call is recorded in the audit log, which the
+// per-user and per-number caps are counted from.
+type AddCallerIDUseCase struct {
+	callerIDs   domain.CallerIDRepository
+	audit       domain.AuditRepository
+	voipService domain.VoIPService
+}
+
+func NewAddCallerIDUseCase(callerIDs domain.CallerIDRepository, audit domain.AuditRepository, voipService domain.VoIPService) *AddCallerIDUseCase {
+	return &AddCallerIDUseCase{
+		callerIDs:   callerIDs,
+		audit:       audit,
+		voipService: voipService,
+	}
+}
+
+func (uc *AddCallerIDUseCase) Execute(ctx context.Context, input AddCallerIDInput) (*CallerIDItem, error) {
+	verifier, ok := uc.voipService.(domain.CallerIDVerifier)
+	if !ok {
+		return nil, errors.New("caller id verification is not supported")
+	}
+
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	number, err := phone.Parse(input.Number, "")
+	if err != nil || number.E164 != input.Number {
+		return nil, domain.ErrInvalidPhoneNumber
+	}
+
+	// Premium rate numbers earn whoever owns them for every call, so
+	// verifying one would pay them for our calls.
+	if number.Type == phone.TypePremium {
+		return nil, errors.New("premium rate numbers cannot be caller ids")
+	}
+
+	callerID, err := uc.callerIDs.GetByUserIDAndNumber(ctx, input.UserID, input.Number)
+	if err != nil {
+		slog.Error("failed to get caller id", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to get caller id")
+	}
+
+	if callerID != nil && callerID.Verified() {
+		return nil, errors.New("caller id already verified")
+	}
+
+	now := time.Now()
+	if callerID != nil && callerID.CodeExpiresAt != nil && now.Before(*callerID.CodeExpiresAt) {
+		return nil, errors.New("verification call already placed")
+	}
+
+	if err := uc.checkVerificationCalls(ctx, input.UserID, input.Number, now); err != nil {
+		return nil, err
+	}
+
+	code, err := generateVerificationCode()
+	if err != nil {
+		slog.Error("failed to generate verification code", "error", err)
+		return nil, errors.New("failed to generate verification code")
+	}
+
+	expiresAt := now.Add(verificationCodeTTL)
+	if callerID == nil {
+		callerID = &domain.CallerID{
+			UserID:           input.UserID,
+			Number:           input.Number,
+			VerificationCode: code,
+			CodeExpiresAt:    &expiresAt,
+		}
+		err = uc.callerIDs.Create(ctx, callerID)
+	} else {
+		callerID.VerificationCode = code
+		callerID.CodeExpiresAt = &expiresAt
+		callerID.VerifyAttempts = 0
+		err = uc.callerIDs.Update(ctx, callerID)
+	}
+	if err != nil {
+		slog.Error("failed to save caller id", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to save caller id")
+	}
+
+	// The call is recorded before it is placed: a call that is not counted
+	// must not be placed.
+	event := &domain.AuditEvent{
+		UserID:      input.UserID,
+		Type:        domain.AuditCallerIDVerificationCall,
+		PhoneNumber: callerID.Number,
+		Country:     number.Country,
+	}
+	if err := uc.audit.Create(ctx, event); err != nil {
+		slog.Error("failed to record verification call", "error", err, "caller_id", callerID.ID)
+		return nil, errors.New("failed to place verification call")
+	}
+
+	if err := verifier.ReadOutCode(ctx, callerID.Number, code); err != nil {
+		if errors.Is(err, domain.ErrInvalidPhoneNumber) {
+			return nil, err
+		}
+		slog.Error("failed to place verification call", "error", err, "caller_id", callerID.ID)
+		return nil, errors.New("failed to place verification call")
+	}
+
+	slog.Info("caller id verification call placed", "caller_id", callerID.ID, "user_id", input.UserID)
+
+	return callerIDItem(callerID), nil
+}
+
+// checkVerificationCalls refuses another verification call once the user,
+// or anyone, has rung the number too often within the window.
+func (uc *AddCallerIDUseCase) checkVerificationCalls(ctx context.Context, userID, number string, now time.Time) error {
+	since := now.Add(-verificationCallWindow)
+
+	byUser, err := uc.audit.Count(ctx, domain.AuditFilter{Type: domain.AuditCallerIDVerificationCall, UserID: userID, Since: since})
+	if err != nil {
+		slog.Error("failed to count verification calls", "error", err, "user_id", userID)
+		return errors.New("failed to place verification call")
+	}
+	if byUser >= maxVerificationCallsPerUser {
+		slog.Warn("verification call limit reached", "user_id", userID, "calls", byUser)
+		return errors.New("too many verification calls")
+	}
+
+	toNumber, err := uc.audit.Count(ctx, domain.AuditFilter{Type: domain.AuditCallerIDVerificationCall, PhoneNumber: number, Since: since})
+	if err != nil {
+		slog.Error("failed to count verification calls", "error", err, "user_id", userID)
+		return errors.New("failed to place verification call")
+	}
+	if toNumber >= maxVerificationCallsPerNumber {
+		slog.Warn("verification call limit reached for number", "user_id", userID, "calls", toNumber)
+		return errors.New("too many verification calls")
+	}
+
+	return nil
+}
+
+type VerifyCallerIDInput struct {
+	UserID     string
+	CallerIDID string
+	Code       string
+}
+
+// VerifyCallerIDUseCase checks the code the user heard on the verification
+// call.
+type VerifyCallerIDUseCase struct {
+	callerIDs domain.CallerIDRepository
+}
+
+func NewVerifyCallerIDUseCase(callerIDs domain.CallerIDRepository) *VerifyCallerIDUseCase {
+	return &VerifyCallerIDUseCase{callerIDs: callerIDs}
+}
+
+func (uc *VerifyCallerIDUseCase) Execute(ctx context.Context, input VerifyCallerIDInput) (*CallerIDItem, error) {
+	if input.Code == "" {
+		return nil, errors.New("code is required")
+	}
+
+	callerID, err := ownedCallerID(ctx, uc.callerIDs, input.CallerIDID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if callerID.Verified() {
+		return nil, errors.New("caller id already verified")
+	}
+
+	now := time.Now()
+	if callerID.VerificationCode == "" || callerID.CodeExpiresAt == nil || now.After(*callerID.CodeExpiresAt) {
+		return nil, errors.New("verification code expired")
+	}
+
+	if input.Code != callerID.VerificationCode {
+		callerID.VerifyAttempts++
+		if callerID.VerifyAttempts >= maxVerifyAttempts {
+			callerID.VerificationCode = ""
+			callerID.CodeExpiresAt = nil
+		}
+		if err := uc.callerIDs.Update(ctx, callerID); err != nil {
+			slog.Error("failed to update caller id", "error", err, "caller_id", callerID.ID)
+			return nil, errors.New("failed to update caller id")
+		}
+		slog.Warn("wrong caller id verification code", "caller_id", callerID.ID, "attempts", callerID.VerifyAttempts)
+		return nil, errors.New("invalid verification code")
+	}
+
+	callerID.VerifiedAt = &now
+	callerID.VerificationCode = ""
+	callerID.CodeExpiresAt = nil
+	callerID.VerifyAttempts = 0
+	if err := uc.callerIDs.Update(ctx, callerID); err != nil {
+		slog.Error("failed to update caller id", "error", err, "caller_id", callerID.ID)
+		return nil, errors.New("failed to update caller id")
+	}
+
+	slog.Info("caller id verified", "caller_id", callerID.ID, "user_id", input.UserID)
+
+	return callerIDItem(callerID), nil
+}
+
+type ListCallerIDsInput struct {
+	UserID string
+}
+
+type ListCallerIDsOutput struct {
+	CallerIDs []*CallerIDItem `json:"callerIds"`
+}
+
+type ListCallerIDsUseCase struct {
+	callerIDs domain.CallerIDRepository
+}
+
+func NewListCallerIDsUseCase(callerIDs domain.CallerIDRepository) *ListCallerIDsUseCase {
+	return &ListCallerIDsUseCase{callerIDs: callerIDs}
+}
+
+func (uc *ListCallerIDsUseCase) Execute(ctx context.Context, input ListCallerIDsInput) (*ListCallerIDsOutput, error) {
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	callerIDs, err := uc.callerIDs.ListByUserID(ctx, input.UserID)
+	if err != nil {
+		slog.Error("failed to get caller ids", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to get caller ids")
+	}
+
+	items := make([]*CallerIDItem, 0, len(callerIDs))
+	for _, callerID := range callerIDs {
+		items = append(items, callerIDItem(callerID))
+	}
+
+	return &ListCallerIDsOutput{CallerIDs: items}, nil
+}
+
+type CallerIDInput struct {
+	UserID     string
+	CallerIDID string
+}
+
+// SetDefaultCallerIDUseCase picks the verified number shown on the user's
+// calls when none is chosen for the call.
+type SetDefaultCallerIDUseCase struct {
+	callerIDs domain.CallerIDRepository
+}
+
+func NewSetDefaultCallerIDUseCase(callerIDs domain.CallerIDRepository) *SetDefaultCallerIDUseCase {
+	return &SetDefaultCallerIDUseCase{callerIDs: callerIDs}
+}
+
+func (uc *SetDefaultCallerIDUseCase) Execute(ctx context.Context, input CallerIDInput) (*CallerIDItem, error) {
+	callerID, err := ownedCallerID(ctx, uc.callerIDs, input.CallerIDID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !callerID.Verified() {
+		return nil, domain.ErrCallerIDNotVerified
+	}
+
+	if err := uc.callerIDs.SetDefault(ctx, input.UserID, callerID.ID); err != nil {
+		slog.Error("failed to set default caller id", "error", err, "caller_id", callerID.ID)
+		return nil, errors.New("failed to update caller id")
+	}
+	callerID.IsDefault = true
+
+	slog.Info("default caller id set", "caller_id", callerID.ID, "user_id", input.UserID)
+
+	return callerIDItem(callerID), nil
+}
+
+// DeleteCallerIDUseCase removes a caller ID; calls that used it keep the
+// number in their history.
+type DeleteCallerIDUseCase struct {
+	callerIDs domain.CallerIDRepository
+}
+
+func NewDeleteCallerIDUseCase(callerIDs domain.CallerIDRepository) *DeleteCallerIDUseCase {
+	return &DeleteCallerIDUseCase{callerIDs: callerIDs}
+}
+
+func (uc *DeleteCallerIDUseCase) Execute(ctx context.Context, input CallerIDInput) error {
+	callerID, err := ownedCallerID(ctx, uc.callerIDs, input.CallerIDID, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.callerIDs.Delete(ctx, callerID.ID); err != nil {
+		slog.Error("failed to delete caller id", "error", err, "caller_id", callerID.ID)
+		return errors.New("failed to delete caller id")
+	}
+
+	slog.Info("caller id deleted", "caller_id", callerID.ID, "user_id", input.UserID)
+	return nil
+}
+
+func ownedCallerID(ctx context.Context, callerIDs domain.CallerIDRepository, id, userID string) (*domain.CallerID, error) {
+	if id == "" {
+		return nil, errors.New("caller_id is required")
+	}
+
+	if userID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	callerID, err := callerIDs.GetByID(ctx, id)
+	if err != nil {
+		slog.Error("failed to get caller id", "error", err, "caller_id", id)
+		return nil, errors.New("failed to get caller id")
+	}
+
+	if callerID == nil {
+		return nil, errors.New("caller id not found")
+	}
+
+	if callerID.UserID != userID {
+		slog.Warn("unauthorized caller id access", "caller_id", id, "user_id", userID)
+		return nil, errors.New("unauthorized")
+	}
+
+	return callerID, nil
+}
+
+func generateVerificationCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < verificationCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", verificationCodeDigits, n), nil
+}
@@ -0,0 +1,207 @@
+package numbers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockCallerIDRepository struct {
+	callerIDs map[string]*domain.CallerID
+}
+
+func (m *mockCallerIDRepository) Create(ctx context.Context, callerID *domain.CallerID) error {
+	callerID.ID = "cid-new"
+	m.callerIDs[callerID.ID] = callerID
+	return nil
+}
+
+func (m *mockCallerIDRepository) Update(ctx context.Context, callerID *domain.CallerID) error {
+	return nil
+}
+
+func (m *mockCallerIDRepository) GetByID(ctx context.Context, id string) (*domain.CallerID, error) {
+	return m.callerIDs[id], nil
+}
+
+func (m *mockCallerIDRepository) GetByUserIDAndNumber(ctx context.Context, userID, number string) (*domain.CallerID, error) {
+	for _, callerID := range m.callerIDs {
+		if callerID.UserID == userID && callerID.Number == number {
+			return callerID, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockCallerIDRepository) GetDefault(ctx context.Context, userID string) (*domain.CallerID, error) {
+	return nil, nil
+}
+
+func (m *mockCallerIDRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.CallerID, error) {
+	return nil, nil
+}
+
+func (m *mockCallerIDRepository) SetDefault(ctx context.Context, userID, id string) error {
+	return nil
+}
+
+func (m *mockCallerIDRepository) Delete(ctx context.Context, id string) error {
+	delete(m.callerIDs, id)
+	return nil
+}
+
+type mockAuditRepository struct {
+	events []*domain.AuditEvent
+}
+
+func (m *mockAuditRepository) Create(ctx context.Context, event *domain.AuditEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockAuditRepository) Count(ctx context.Context, filter domain.AuditFilter) (int, error) {
+	count := 0
+	for _, event := range m.events {
+		if event.Type == filter.Type &&
+			(filter.UserID == "" || event.UserID == filter.UserID) &&
+			(filter.PhoneNumber == "" || event.PhoneNumber == filter.PhoneNumber) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockAuditRepository) ListByCallID(ctx context.Context, callID string, eventType domain.AuditEventType) ([]*domain.AuditEvent, error) {
+	return nil, nil
+}
+
+type mockVerifier struct {
+	domain.VoIPService
+	phoneNumber string
+	code        string
+	calls       int
+}
+
+func (m *mockVerifier) ReadOutCode(ctx context.Context, phoneNumber, code string) error {
+	m.phoneNumber = phoneNumber
+	m.code = code
+	m.calls++
+	return nil
+}
+
+func TestAddAndVerifyCallerID(t *testing.T) {
+	repo := &mockCallerIDRepository{callerIDs: map[string]*domain.CallerID{}}
+	verifier := &mockVerifier{}
+	add := NewAddCallerIDUseCase(repo, &mockAuditRepository{}, verifier)
+	verify := NewVerifyCallerIDUseCase(repo)
+
+	item, err := add.Execute(context.Background(), AddCallerIDInput{UserID: "user-1", Number: "+14155550100"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if item.Verified || verifier.phoneNumber != "+14155550100" || len(verifier.code) != verificationCodeDigits {
+		t.Fatalf("expected a code to be read out to the number, got %+v %+v", item, verifier)
+	}
+
+	if _, err := verify.Execute(context.Background(), VerifyCallerIDInput{UserID: "user-2", CallerIDID: item.ID, Code: verifier.code}); err == nil || err.Error() != "unauthorized" {
+		t.Errorf("expected 'unauthorized', got %v", err)
+	}
+
+	item, err = verify.Execute(context.Background(), VerifyCallerIDInput{UserID: "user-1", CallerIDID: item.ID, Code: verifier.code})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !item.Verified || repo.callerIDs[item.ID].VerificationCode != "" {
+		t.Errorf("expected the caller id to be verified and the code cleared, got %+v", repo.callerIDs[item.ID])
+	}
+
+	if _, err := add.Execute(context.Background(), AddCallerIDInput{UserID: "user-1", Number: "+14155550100"}); err == nil || err.Error() != "caller id already verified" {
+		t.Errorf("expected 'caller id already verified', got %v", err)
+	}
+}
+
+func TestVerifyCallerID_Rejected(t *testing.T) {
+	expiresAt := time.Now().Add(time.Minute)
+	expired := time.Now().Add(-time.Minute)
+	repo := &mockCallerIDRepository{callerIDs: map[string]*domain.CallerID{
+		"cid-1": {ID: "cid-1", UserID: "user-1", Number: "+14155550100", VerificationCode: "123456", CodeExpiresAt: &expiresAt},
+		"cid-2": {ID: "cid-2", UserID: "user-1", Number: "+14155550101", VerificationCode: "123456", CodeExpiresAt: &expired},
+	}}
+	verify := NewVerifyCallerIDUseCase(repo)
+
+	if _, err := verify.Execute(context.Background(), VerifyCallerIDInput{UserID: "user-1", CallerIDID: "cid-2", Code: "123456"}); err == nil || err.Error() != "verification code expired" {
+		t.Errorf("expected 'verification code expired', got %v", err)
+	}
+
+	for i := 0; i < maxVerifyAttempts; i++ {
+		if _, err := verify.Execute(context.Background(), VerifyCallerIDInput{UserID: "user-1", CallerIDID: "cid-1", Code: "000000"}); err == nil || err.Error() != "invalid verification code" {
+			t.Fatalf("attempt %d: expected 'invalid verification code', got %v", i+1, err)
+		}
+	}
+
+	// Too many wrong codes void the pending one, even if it is right.
+	if _, err := verify.Execute(context.Background(), VerifyCallerIDInput{UserID: "user-1", CallerIDID: "cid-1", Code: "123456"}); err == nil || err.Error() != "verification code expired" {
+		t.Errorf("expected 'verification code expired', got %v", err)
+	}
+	if repo.callerIDs["cid-1"].Verified() {
+		t.Error("expected the caller id to stay unverified")
+	}
+}
+
+func TestAddCallerID_LimitsVerificationCalls(t *testing.T) {
+	repo := &mockCallerIDRepository{callerIDs: map[string]*domain.CallerID{}}
+	audit := &mockAuditRepository{}
+	verifier := &mockVerifier{}
+	add := NewAddCallerIDUseCase(repo, audit, verifier)
+	ctx := context.Background()
+
+	if _, err := add.Execute(ctx, AddCallerIDInput{UserID: "user-1", Number: "+19005550100"}); err == nil || err.Error() != "premium rate numbers cannot be caller ids" {
+		t.Errorf("expected 'premium rate numbers cannot be caller ids', got %v", err)
+	}
+
+	item, err := add.Execute(ctx, AddCallerIDInput{UserID: "user-1", Number: "+14155550100"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(audit.events) != 1 || audit.events[0].Type != domain.AuditCallerIDVerificationCall || audit.events[0].PhoneNumber != "+14155550100" {
+		t.Fatalf("expected the verification call to be audited, got %+v", audit.events)
+	}
+
+	// The code read out a moment ago is still valid.
+	if _, err := add.Execute(ctx, AddCallerIDInput{UserID: "user-1", Number: "+14155550100"}); err == nil || err.Error() != "verification call already placed" {
+		t.Errorf("expected 'verification call already placed', got %v", err)
+	}
+
+	// Once it expires the number may be rung again, up to the cap.
+	for i := 1; i < maxVerificationCallsPerNumber; i++ {
+		repo.callerIDs[item.ID].CodeExpiresAt = nil
+		if _, err := add.Execute(ctx, AddCallerIDInput{UserID: "user-1", Number: "+14155550100"}); err != nil {
+			t.Fatalf("call %d: expected no error, got %v", i+1, err)
+		}
+	}
+	repo.callerIDs[item.ID].CodeExpiresAt = nil
+	if _, err := add.Execute(ctx, AddCallerIDInput{UserID: "user-1", Number: "+14155550100"}); err == nil || err.Error() != "too many verification calls" {
+		t.Errorf("expected 'too many verification calls', got %v", err)
+	}
+
+	// The number cap holds across users.
+	if _, err := add.Execute(ctx, AddCallerIDInput{UserID: "user-2", Number: "+14155550100"}); err == nil || err.Error() != "too many verification calls" {
+		t.Errorf("expected 'too many verification calls' for another user, got %v", err)
+	}
+
+	// So does the user cap across numbers.
+	audit.events = nil
+	for i := 0; i < maxVerificationCallsPerUser; i++ {
+		audit.events = append(audit.events, &domain.AuditEvent{Type: domain.AuditCallerIDVerificationCall, UserID: "user-3", PhoneNumber: fmt.Sprintf("+1415555%04d", i)})
+	}
+	if _, err := add.Execute(ctx, AddCallerIDInput{UserID: "user-3", Number: "+14155559999"}); err == nil || err.Error() != "too many verification calls" {
+		t.Errorf("expected 'too many verification calls' for the user, got %v", err)
+	}
+
+	if verifier.calls != maxVerificationCallsPerNumber {
+		t.Errorf("expected %d verification calls, got %d", maxVerificationCallsPerNumber, verifier.calls)
+	}
+}
@@ -0,0 +1,55 @@
+package numbers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type NumberItem struct {
+	ID        string    `json:"id"`
+	Number    string    `json:"number"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ListNumbersInput struct {
+	UserID string
+}
+
+type ListNumbersOutput struct {
+	Numbers []*NumberItem `json:"numbers"`
+}
+
+type ListNumbersUseCase struct {
+	phoneNumbers domain.PhoneNumberRepository
+}
+
+func NewListNumbersUseCase(phoneNumbers domain.PhoneNumberRepository) *ListNumbersUseCase {
+	return &ListNumbersUseCase{phoneNumbers: phoneNumbers}
+}
+
+func (uc *ListNumbersUseCase) Execute(ctx context.Context, input ListNumbersInput) (*ListNumbersOutput, error) {
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	numbers, err := uc.phoneNumbers.ListByUserID(ctx, input.UserID)
+	if err != nil {
+		slog.Error("failed to get phone numbers", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to get phone numbers")
+	}
+
+	items := make([]*NumberItem, 0, len(numbers))
+	for _, number := range numbers {
+		items = append(items, &NumberItem{
+			ID:        number.ID,
+			Number:    number.Number,
+			CreatedAt: number.CreatedAt,
+		})
+	}
+
+	return &ListNumbersOutput{Numbers: items}, nil
+}
@@ -0,0 +1,52 @@
+package numbers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/phone"
+)
+
+type ParseNumberInput struct {
+	Number string
+	// Region is the ISO 3166-1 country code national numbers are read in.
+	Region string
+}
+
+type ParseNumberOutput struct {
+	E164           string `json:"e164"`
+	Country        string `json:"country,omitempty"`
+	CallingCode    string `json:"callingCode,omitempty"`
+	NationalNumber string `json:"nationalNumber,omitempty"`
+	Type           string `json:"type"`
+	// Carrier is the operator the range was allocated to; the number may
+	// have been ported since.
+	Carrier string `json:"carrier,omitempty"`
+}
+
+// ParseNumberUseCase shows how a number will be dialled before calling it.
+type ParseNumberUseCase struct{}
+
+func NewParseNumberUseCase() *ParseNumberUseCase {
+	return &ParseNumberUseCase{}
+}
+
+func (uc *ParseNumberUseCase) Execute(ctx context.Context, input ParseNumberInput) (*ParseNumberOutput, error) {
+	if input.Number == "" {
+		return nil, errors.New("number is required")
+	}
+
+	number, err := phone.Parse(input.Number, input.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ParseNumberOutput{
+		E164:           number.E164,
+		Country:        number.Country,
+		CallingCode:    number.CallingCode,
+		NationalNumber: number.NationalNumber,
+		Type:           string(number.Type),
+		Carrier:        number.Carrier,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package recordings
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type GetAudioInput struct {
+	UserID      string
+	CallID      string
+	RecordingID string
+}
+
+type GetAudioOutput struct {
+	Recording *domain.Recording
+	// Audio must be closed by the caller.
+	Audio io.ReadSeekCloser
+}
+
+type GetAudioUseCase struct {
+	recordings domain.RecordingRepository
+	blobs      domain.BlobStore
+}
+
+func NewGetAudioUseCase(recordings domain.RecordingRepository, blobs domain.BlobStore) *GetAudioUseCase {
+	return &GetAudioUseCase{
+		recordings: recordings,
+		blobs:      blobs,
+	}
+}
+
+func (uc *GetAudioUseCase) Execute(ctx context.Context, input GetAudioInput) (*GetAudioOutput, error) {
+	if input.RecordingID == "" {
+		return nil, errors.New("recording_id is required")
+	}
+
+	recording, err := uc.recordings.GetByID(ctx, input.RecordingID)
+	if err != nil {
+		slog.Error("failed to get recording", "error", err, "recording_id", input.RecordingID)
+		return nil, errors.New("failed to get recording")
+	}
+	if recording == nil || recording.CallID != input.CallID {
+		return nil, errors.New("recording not found")
+	}
+
+	if recording.UserID != input.UserID {
+		slog.Warn("unauthorized recording access attempt",
+			"recording_id", recording.ID,
+			"owner_id", recording.UserID,
+			"requester_id", input.UserID)
+		return nil, errors.New("unauthorized")
+	}
+
+	audio, err := uc.blobs.Open(ctx, recording.BlobKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrBlobNotFound) {
+			slog.Warn("recording audio is missing", "recording_id", recording.ID, "blob_key", recording.BlobKey)
+			return nil, errors.New("recording not found")
+		}
+		slog.Error("failed to open recording audio", "error", err, "recording_id", recording.ID)
+		return nil, errors.New("failed to open recording audio")
+	}
+
+	return &GetAudioOutput{
+		Recording: recording,
+		Audio:     audio,
+	}, nil
+}
@@ -0,0 +1,178 @@
+package recordings
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+var recordingSIDRe = regexp.MustCompile(`^[A-Za-z0-9]{1,64}$`)
+
+// RecordingFetcher downloads a finished recording from the VoIP provider.
+type RecordingFetcher interface {
+	Fetch(ctx context.Context, recordingURL string) (io.ReadCloser, string, error)
+}
+
+type SaveRecordingInput struct {
+	ProviderCallSID string
+	// CallID and Identity link a Voice SDK call, whose provider call is not
+	// known to us, through the callback URL; the call must belong to the
+	// client identity that placed it.
+	CallID       string
+	Identity     string
+	RecordingSID string
+	RecordingURL string
+	Duration     int
+}
+
+type SaveRecordingOutput struct {
+	RecordingID string
+}
+
+// SaveRecordingUseCase copies a finished call recording from the provider
+// into our blob store.
+type SaveRecordingUseCase struct {
+	callRepo   domain.CallRepository
+	recordings domain.RecordingRepository
+	blobs      domain.BlobStore
+	fetcher    RecordingFetcher
+	maxSize    int64
+}
+
+func NewSaveRecordingUseCase(callRepo domain.CallRepository, recordings domain.RecordingRepository, blobs domain.BlobStore, fetcher RecordingFetcher, maxSize int64) *SaveRecordingUseCase {
+	return &SaveRecordingUseCase{
+		callRepo:   callRepo,
+		recordings: recordings,
+		blobs:      blobs,
+		fetcher:    fetcher,
+		maxSize:    maxSize,
+	}
+}
+
+func (uc *SaveRecordingUseCase) Execute(ctx context.Context, input SaveRecordingInput) (*SaveRecordingOutput, error) {
+	if input.ProviderCallSID == "" && input.CallID == "" {
+		return nil, errors.New("provider_call_sid is required")
+	}
+	if !recordingSIDRe.MatchString(input.RecordingSID) {
+		return nil, errors.New("invalid recording_sid")
+	}
+	if input.RecordingURL == "" {
+		return nil, errors.New("recording_url is required")
+	}
+	if uc.fetcher == nil {
+		return nil, errors.New("recording is not configured")
+	}
+
+	// The provider retries the callback until it gets a 2xx.
+	existing, err := uc.recordings.GetByRecordingSID(ctx, input.RecordingSID)
+	if err != nil {
+		slog.Error("failed to get recording", "error", err, "recording_sid", input.RecordingSID)
+		return nil, errors.New("failed to get recording")
+	}
+	if existing != nil {
+		return &SaveRecordingOutput{RecordingID: existing.ID}, nil
+	}
+
+	call, err := uc.findCall(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	body, contentType, err := uc.fetcher.Fetch(ctx, input.RecordingURL)
+	if err != nil {
+		slog.Error("failed to download recording", "error", err, "recording_sid", input.RecordingSID)
+		return nil, errors.New("failed to download recording")
+	}
+	defer body.Close()
+
+	key := "recordings/" + call.UserID + "/" + input.RecordingSID + extension(contentType)
+
+	var src io.Reader = body
+	if uc.maxSize > 0 {
+		src = io.LimitReader(body, uc.maxSize+1)
+	}
+	size, err := uc.blobs.Put(ctx, key, src)
+	if err != nil {
+		slog.Error("failed to store recording", "error", err, "recording_sid", input.RecordingSID)
+		return nil, errors.New("failed to store recording")
+	}
+	if uc.maxSize > 0 && size > uc.maxSize {
+		uc.deleteBlob(ctx, key)
+		slog.Warn("recording exceeds size limit", "recording_sid", input.RecordingSID, "max_size", uc.maxSize)
+		return nil, errors.New("recording too large")
+	}
+
+	recording := &domain.Recording{
+		CallID:       call.ID,
+		UserID:       call.UserID,
+		RecordingSID: input.RecordingSID,
+		BlobKey:      key,
+		ContentType:  contentType,
+		Size:         size,
+		Duration:     input.Duration,
+	}
+	if err := uc.recordings.Create(ctx, recording); err != nil {
+		uc.deleteBlob(ctx, key)
+		slog.Error("failed to create recording", "error", err, "recording_sid", input.RecordingSID)
+		return nil, errors.New("failed to create recording")
+	}
+
+	slog.Info("call recording saved",
+		"recording_id", recording.ID,
+		"call_id", call.ID,
+		"user_id", call.UserID,
+		"size", size)
+
+	return &SaveRecordingOutput{RecordingID: recording.ID}, nil
+}
+
+func (uc *SaveRecordingUseCase) findCall(ctx context.Context, input SaveRecordingInput) (*domain.Call, error) {
+	var (
+		call *domain.Call
+		err  error
+	)
+	if input.CallID != "" {
+		call, err = uc.callRepo.GetByID(ctx, input.CallID)
+	} else {
+		call, err = uc.callRepo.GetByProviderCallSID(ctx, input.ProviderCallSID)
+	}
+	if err != nil {
+		slog.Error("failed to get call", "error", err, "call_id", input.CallID, "provider_call_sid", input.ProviderCallSID)
+		return nil, errors.New("failed to get call")
+	}
+	if call == nil {
+		return nil, errors.New("call not found")
+	}
+
+	if input.CallID != "" && call.UserID != input.Identity {
+		slog.Warn("recording callback for another user's call",
+			"call_id", call.ID,
+			"owner_id", call.UserID,
+			"identity", input.Identity)
+		return nil, errors.New("unauthorized")
+	}
+
+	return call, nil
+}
+
+func (uc *SaveRecordingUseCase) deleteBlob(ctx context.Context, key string) {
+	if err := uc.blobs.Delete(ctx, key); err != nil {
+		slog.Warn("failed to delete recording", "error", err, "blob_key", key)
+	}
+}
+
+func extension(contentType string) string {
+	switch {
+	case strings.Contains(contentType, "mpeg"), strings.Contains(contentType, "mp3"):
+		return ".mp3"
+	case strings.Contains(contentType, "wav"):
+		return ".wav"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,155 @@
+package recordings
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockCallRepository struct {
+	call *domain.Call
+}
+
+func (m *mockCallRepository) Create(ctx context.Context, call *domain.Call) error { return nil }
+
+func (m *mockCallRepository) Update(ctx context.Context, call *domain.Call) error { return nil }
+
+func (m *mockCallRepository) GetByID(ctx context.Context, id string) (*domain.Call, error) {
+	if m.call != nil && m.call.ID == id {
+		return m.call, nil
+	}
+	return nil, nil
+}
+
+func (m *mockCallRepository) GetByProviderCallSID(ctx context.Context, sid string) (*domain.Call, error) {
+	if m.call != nil && m.call.ProviderCallSID == sid {
+		return m.call, nil
+	}
+	return nil, nil
+}
+
+func (m *mockCallRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Call, error) {
+	return nil, nil
+}
+
+type mockRecordingRepository struct {
+	recordings []*domain.Recording
+}
+
+func (m *mockRecordingRepository) Create(ctx context.Context, recording *domain.Recording) error {
+	recording.ID = "rec-1"
+	m.recordings = append(m.recordings, recording)
+	return nil
+}
+
+func (m *mockRecordingRepository) GetByID(ctx context.Context, id string) (*domain.Recording, error) {
+	for _, r := range m.recordings {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRecordingRepository) GetByRecordingSID(ctx context.Context, sid string) (*domain.Recording, error) {
+	for _, r := range m.recordings {
+		if r.RecordingSID == sid {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRecordingRepository) ListByCallID(ctx context.Context, callID string) ([]*domain.Recording, error) {
+	return m.recordings, nil
+}
+
+type mockBlobStore struct {
+	blobs map[string]string
+}
+
+func (m *mockBlobStore) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	m.blobs[key] = string(data)
+	return int64(len(data)), nil
+}
+
+func (m *mockBlobStore) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	return nil, domain.ErrBlobNotFound
+}
+
+func (m *mockBlobStore) Delete(ctx context.Context, key string) error {
+	delete(m.blobs, key)
+	return nil
+}
+
+type mockFetcher struct{}
+
+func (mockFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, string, error) {
+	return io.NopCloser(strings.NewReader("RIFF")), "audio/x-wav", nil
+}
+
+func TestSaveRecordingUseCase_Execute_ByProviderCallSID(t *testing.T) {
+	callRepo := &mockCallRepository{call: &domain.Call{ID: "call-1", UserID: "user-1", ProviderCallSID: "CA123"}}
+	recordingRepo := &mockRecordingRepository{}
+	blobs := &mockBlobStore{blobs: map[string]string{}}
+	uc := NewSaveRecordingUseCase(callRepo, recordingRepo, blobs, mockFetcher{}, 0)
+
+	input := SaveRecordingInput{ProviderCallSID: "CA123", RecordingSID: "RE1", RecordingURL: "https://api.twilio.com/r/RE1", Duration: 42}
+	output, err := uc.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if output.RecordingID != "rec-1" || len(recordingRepo.recordings) != 1 {
+		t.Fatalf("expected one recording, got %+v", recordingRepo.recordings)
+	}
+	saved := recordingRepo.recordings[0]
+	if saved.CallID != "call-1" || saved.UserID != "user-1" || saved.Duration != 42 || saved.BlobKey != "recordings/user-1/RE1.wav" {
+		t.Errorf("unexpected recording: %+v", saved)
+	}
+	if blobs.blobs[saved.BlobKey] != "RIFF" {
+		t.Errorf("expected audio to be stored, got %v", blobs.blobs)
+	}
+
+	if _, err := uc.Execute(context.Background(), input); err != nil || len(recordingRepo.recordings) != 1 {
+		t.Errorf("expected retried callback to be idempotent, got %v and %d recordings", err, len(recordingRepo.recordings))
+	}
+}
+
+func TestSaveRecordingUseCase_Execute_VoiceSDKCall(t *testing.T) {
+	callRepo := &mockCallRepository{call: &domain.Call{ID: "call-1", UserID: "user-1", SessionID: "voice_sdk"}}
+	recordingRepo := &mockRecordingRepository{}
+	uc := NewSaveRecordingUseCase(callRepo, recordingRepo, &mockBlobStore{blobs: map[string]string{}}, mockFetcher{}, 0)
+
+	_, err := uc.Execute(context.Background(), SaveRecordingInput{
+		ProviderCallSID: "CAbrowser",
+		CallID:          "call-1",
+		Identity:        "user-2",
+		RecordingSID:    "RE1",
+		RecordingURL:    "https://api.twilio.com/r/RE1",
+	})
+	if err == nil || err.Error() != "unauthorized" {
+		t.Errorf("expected 'unauthorized' error for another user's call, got %v", err)
+	}
+
+	_, err = uc.Execute(context.Background(), SaveRecordingInput{
+		ProviderCallSID: "CAbrowser",
+		CallID:          "call-1",
+		Identity:        "user-1",
+		RecordingSID:    "RE1",
+		RecordingURL:    "https://api.twilio.com/r/RE1",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(recordingRepo.recordings) != 1 || recordingRepo.recordings[0].CallID != "call-1" {
+		t.Errorf("expected recording linked to call-1, got %+v", recordingRepo.recordings)
+	}
+}
@@ -0,0 +1,123 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type QuietHoursOutput struct {
+	Mode  string `json:"mode"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Default is true while the user follows the platform defaults.
+	Default bool `json:"default"`
+}
+
+func quietHoursOutput(quiet domain.QuietHours, isDefault bool) *QuietHoursOutput {
+	start, end, _ := strings.Cut(quiet.Window(), "-")
+	return &QuietHoursOutput{
+		Mode:    string(quiet.Mode),
+		Start:   start,
+		End:     end,
+		Default: isDefault,
+	}
+}
+
+type GetQuietHoursInput struct {
+	UserID string
+}
+
+type GetQuietHoursUseCase struct {
+	settings domain.UserSettingsRepository
+	defaults domain.QuietHours
+}
+
+func NewGetQuietHoursUseCase(settings domain.UserSettingsRepository, defaults domain.QuietHours) *GetQuietHoursUseCase {
+	return &GetQuietHoursUseCase{settings: settings, defaults: defaults}
+}
+
+func (uc *GetQuietHoursUseCase) Execute(ctx context.Context, input GetQuietHoursInput) (*QuietHoursOutput, error) {
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	settings, err := uc.settings.Get(ctx, input.UserID)
+	if err != nil {
+		slog.Error("failed to get user settings", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to get settings")
+	}
+
+	if settings == nil || settings.QuietHours == nil {
+		return quietHoursOutput(uc.defaults, true), nil
+	}
+	return quietHoursOutput(*settings.QuietHours, false), nil
+}
+
+type UpdateQuietHoursInput struct {
+	UserID string
+	Mode   string
+	// Start and End are local times of day at the destination, "22:00".
+	Start string
+	End   string
+}
+
+type UpdateQuietHoursUseCase struct {
+	settings domain.UserSettingsRepository
+}
+
+func NewUpdateQuietHoursUseCase(settings domain.UserSettingsRepository) *UpdateQuietHoursUseCase {
+	return &UpdateQuietHoursUseCase{settings: settings}
+}
+
+func (uc *UpdateQuietHoursUseCase) Execute(ctx context.Context, input UpdateQuietHoursInput) (*QuietHoursOutput, error) {
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	quiet, err := domain.ParseQuietHours(input.Mode, input.Start+"-"+input.End)
+	if err != nil {
+		return nil, errors.New("invalid quiet hours")
+	}
+
+	if err := uc.settings.Save(ctx, &domain.UserSettings{UserID: input.UserID, QuietHours: &quiet}); err != nil {
+		slog.Error("failed to save user settings", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to save settings")
+	}
+
+	slog.Info("quiet hours updated", "user_id", input.UserID, "mode", quiet.Mode, "window", quiet.Window())
+
+	return quietHoursOutput(quiet, false), nil
+}
+
+type ResetQuietHoursInput struct {
+	UserID string
+}
+
+// ResetQuietHoursUseCase returns the user to the platform defaults.
+type ResetQuietHoursUseCase struct {
+	settings domain.UserSettingsRepository
+	defaults domain.QuietHours
+}
+
+func NewResetQuietHoursUseCase(settings domain.UserSettingsRepository, defaults domain.QuietHours) *ResetQuietHoursUseCase {
+	return &ResetQuietHoursUseCase{settings: settings, defaults: defaults}
+}
+
+func (uc *ResetQuietHoursUseCase) Execute(ctx context.Context, input ResetQuietHoursInput) (*QuietHoursOutput, error) {
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	if err := uc.settings.Save(ctx, &domain.UserSettings{UserID: input.UserID}); err != nil {
+		slog.Error("failed to save user settings", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to save settings")
+	}
+
+	slog.Info("quiet hours reset", "user_id", input.UserID)
+
+	return quietHoursOutput(uc.defaults, true), nil
+}
@@ -0,0 +1,43 @@
+package voicemail
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type DeleteVoicemailInput struct {
+	UserID      string
+	VoicemailID string
+}
+
+type DeleteVoicemailUseCase struct {
+	voicemails domain.VoicemailRepository
+	blobs      domain.BlobStore
+	policy     RetentionPolicy
+}
+
+func NewDeleteVoicemailUseCase(voicemails domain.VoicemailRepository, blobs domain.BlobStore, policy RetentionPolicy) *DeleteVoicemailUseCase {
+	return &DeleteVoicemailUseCase{
+		voicemails: voicemails,
+		blobs:      blobs,
+		policy:     policy,
+	}
+}
+
+func (uc *DeleteVoicemailUseCase) Execute(ctx context.Context, input DeleteVoicemailInput) error {
+	voicemail, err := ownedVoicemail(ctx, uc.voicemails, uc.policy, input.VoicemailID, input.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := remove(ctx, uc.voicemails, uc.blobs, voicemail); err != nil {
+		slog.Error("failed to delete voicemail", "error", err, "voicemail_id", voicemail.ID)
+		return errors.New("failed to delete voicemail")
+	}
+
+	slog.Info("voicemail deleted", "voicemail_id", voicemail.ID, "user_id", input.UserID)
+	return nil
+}
@@ -0,0 +1,70 @@
+package voicemail
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+func newStoredVoicemail(blobs *mockBlobStore) *domain.Voicemail {
+	v := &domain.Voicemail{ID: "vm-1", UserID: "user-1", BlobKey: "voicemail/user-1/RE1.wav", CreatedAt: time.Now()}
+	blobs.blobs[v.BlobKey] = []byte("RIFF")
+	return v
+}
+
+func TestDeleteVoicemailUseCase_Execute_Success(t *testing.T) {
+	blobs := newMockBlobStore()
+	voicemails := newMockVoicemailRepository(newStoredVoicemail(blobs))
+	uc := NewDeleteVoicemailUseCase(voicemails, blobs, RetentionPolicy{})
+
+	if err := uc.Execute(context.Background(), DeleteVoicemailInput{UserID: "user-1", VoicemailID: "vm-1"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(voicemails.voicemails) != 0 || len(blobs.blobs) != 0 {
+		t.Errorf("expected voicemail and audio to be deleted, got %v %v", voicemails.voicemails, blobs.blobs)
+	}
+}
+
+func TestDeleteVoicemailUseCase_Execute_Unauthorized(t *testing.T) {
+	blobs := newMockBlobStore()
+	voicemails := newMockVoicemailRepository(newStoredVoicemail(blobs))
+	uc := NewDeleteVoicemailUseCase(voicemails, blobs, RetentionPolicy{})
+
+	err := uc.Execute(context.Background(), DeleteVoicemailInput{UserID: "user-2", VoicemailID: "vm-1"})
+	if err == nil || err.Error() != "unauthorized" {
+		t.Errorf("expected 'unauthorized' error, got %v", err)
+	}
+	if len(voicemails.voicemails) != 1 {
+		t.Error("expected voicemail to be kept")
+	}
+}
+
+func TestGetAudioUseCase_Execute_Expired(t *testing.T) {
+	blobs := newMockBlobStore()
+	stored := newStoredVoicemail(blobs)
+	stored.CreatedAt = time.Now().Add(-48 * time.Hour)
+	uc := NewGetAudioUseCase(newMockVoicemailRepository(stored), blobs, RetentionPolicy{MaxAge: 24 * time.Hour})
+
+	_, err := uc.Execute(context.Background(), GetAudioInput{UserID: "user-1", VoicemailID: "vm-1"})
+	if err == nil || err.Error() != "voicemail not found" {
+		t.Errorf("expected 'voicemail not found' error, got %v", err)
+	}
+}
+
+func TestMarkReadUseCase_Execute_KeepsFirstReadTime(t *testing.T) {
+	blobs := newMockBlobStore()
+	stored := newStoredVoicemail(blobs)
+	firstRead := time.Now().Add(-time.Hour)
+	stored.ReadAt = &firstRead
+	uc := NewMarkReadUseCase(newMockVoicemailRepository(stored), RetentionPolicy{})
+
+	item, err := uc.Execute(context.Background(), MarkReadInput{UserID: "user-1", VoicemailID: "vm-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if item.ReadAt == nil || !item.ReadAt.Equal(firstRead) {
+		t.Errorf("expected read time to stay %v, got %v", firstRead, item.ReadAt)
+	}
+}
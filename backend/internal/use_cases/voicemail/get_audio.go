@@ -0,0 +1,57 @@
+package voicemail
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type GetAudioInput struct {
+	UserID      string
+	VoicemailID string
+}
+
+type GetAudioOutput struct {
+	Voicemail *VoicemailItem
+	// Audio must be closed by the caller.
+	Audio io.ReadSeekCloser
+}
+
+type GetAudioUseCase struct {
+	voicemails domain.VoicemailRepository
+	blobs      domain.BlobStore
+	policy     RetentionPolicy
+}
+
+func NewGetAudioUseCase(voicemails domain.VoicemailRepository, blobs domain.BlobStore, policy RetentionPolicy) *GetAudioUseCase {
+	return &GetAudioUseCase{
+		voicemails: voicemails,
+		blobs:      blobs,
+		policy:     policy,
+	}
+}
+
+func (uc *GetAudioUseCase) Execute(ctx context.Context, input GetAudioInput) (*GetAudioOutput, error) {
+	voicemail, err := ownedVoicemail(ctx, uc.voicemails, uc.policy, input.VoicemailID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	audio, err := uc.blobs.Open(ctx, voicemail.BlobKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrBlobNotFound) {
+			slog.Warn("voicemail audio is missing", "voicemail_id", voicemail.ID, "blob_key", voicemail.BlobKey)
+			return nil, errors.New("voicemail not found")
+		}
+		slog.Error("failed to open voicemail audio", "error", err, "voicemail_id", voicemail.ID)
+		return nil, errors.New("failed to open voicemail audio")
+	}
+
+	return &GetAudioOutput{
+		Voicemail: toItem(voicemail),
+		Audio:     audio,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package voicemail
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type ListVoicemailInput struct {
+	UserID string
+}
+
+type ListVoicemailOutput struct {
+	Voicemails []*VoicemailItem `json:"voicemails"`
+	Unread     int              `json:"unread"`
+}
+
+type ListVoicemailUseCase struct {
+	voicemails domain.VoicemailRepository
+	policy     RetentionPolicy
+}
+
+func NewListVoicemailUseCase(voicemails domain.VoicemailRepository, policy RetentionPolicy) *ListVoicemailUseCase {
+	return &ListVoicemailUseCase{
+		voicemails: voicemails,
+		policy:     policy,
+	}
+}
+
+func (uc *ListVoicemailUseCase) Execute(ctx context.Context, input ListVoicemailInput) (*ListVoicemailOutput, error) {
+	if input.UserID == "" {
+		return nil, errors.New("user_id is required")
+	}
+
+	voicemails, err := uc.voicemails.ListByUserID(ctx, input.UserID)
+	if err != nil {
+		slog.Error("failed to get voicemails", "error", err, "user_id", input.UserID)
+		return nil, errors.New("failed to get voicemails")
+	}
+
+	now := time.Now()
+	output := &ListVoicemailOutput{Voicemails: make([]*VoicemailItem, 0, len(voicemails))}
+	for _, voicemail := range voicemails {
+		if uc.policy.expired(voicemail, now) {
+			continue
+		}
+		if voicemail.ReadAt == nil {
+			output.Unread++
+		}
+		output.Voicemails = append(output.Voicemails, toItem(voicemail))
+	}
+
+	return output, nil
+}
@@ -0,0 +1,47 @@
+package voicemail
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type MarkReadInput struct {
+	UserID      string
+	VoicemailID string
+}
+
+type MarkReadUseCase struct {
+	voicemails domain.VoicemailRepository
+	policy     RetentionPolicy
+}
+
+func NewMarkReadUseCase(voicemails domain.VoicemailRepository, policy RetentionPolicy) *MarkReadUseCase {
+	return &MarkReadUseCase{
+		voicemails: voicemails,
+		policy:     policy,
+	}
+}
+
+// Execute is idempotent: a voicemail that is already read keeps its
+// original read time.
+func (uc *MarkReadUseCase) Execute(ctx context.Context, input MarkReadInput) (*VoicemailItem, error) {
+	voicemail, err := ownedVoicemail(ctx, uc.voicemails, uc.policy, input.VoicemailID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if voicemail.ReadAt == nil {
+		now := time.Now()
+		if err := uc.voicemails.MarkRead(ctx, voicemail.ID, now); err != nil {
+			slog.Error("failed to mark voicemail read", "error", err, "voicemail_id", voicemail.ID)
+			return nil, errors.New("failed to update voicemail")
+		}
+		voicemail.ReadAt = &now
+	}
+
+	return toItem(voicemail), nil
+}
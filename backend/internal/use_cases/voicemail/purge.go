@@ -0,0 +1,52 @@
+package voicemail
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+// PurgeExpiredUseCase deletes voicemails older than the retention age. It is
+// run periodically; expired voicemails are hidden from users in between.
+type PurgeExpiredUseCase struct {
+	voicemails domain.VoicemailRepository
+	blobs      domain.BlobStore
+	policy     RetentionPolicy
+}
+
+func NewPurgeExpiredUseCase(voicemails domain.VoicemailRepository, blobs domain.BlobStore, policy RetentionPolicy) *PurgeExpiredUseCase {
+	return &PurgeExpiredUseCase{
+		voicemails: voicemails,
+		blobs:      blobs,
+		policy:     policy,
+	}
+}
+
+// Execute returns how many voicemails were deleted.
+func (uc *PurgeExpiredUseCase) Execute(ctx context.Context) (int, error) {
+	if uc.policy.MaxAge <= 0 {
+		return 0, nil
+	}
+
+	expired, err := uc.voicemails.ListOlderThan(ctx, time.Now().Add(-uc.policy.MaxAge))
+	if err != nil {
+		slog.Error("failed to list expired voicemails", "error", err)
+		return 0, err
+	}
+
+	deleted := 0
+	for _, voicemail := range expired {
+		if err := remove(ctx, uc.voicemails, uc.blobs, voicemail); err != nil {
+			slog.Warn("failed to purge voicemail", "error", err, "voicemail_id", voicemail.ID)
+			continue
+		}
+		deleted++
+	}
+
+	if deleted > 0 {
+		slog.Info("expired voicemails purged", "count", deleted)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,45 @@
+package voicemail
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+func TestPurgeExpiredUseCase_Execute(t *testing.T) {
+	blobs := newMockBlobStore()
+	old := &domain.Voicemail{ID: "vm-old", UserID: "user-1", BlobKey: "voicemail/user-1/REold.wav", CreatedAt: time.Now().Add(-31 * 24 * time.Hour)}
+	fresh := &domain.Voicemail{ID: "vm-new", UserID: "user-1", BlobKey: "voicemail/user-1/REnew.wav", CreatedAt: time.Now()}
+	blobs.blobs[old.BlobKey] = []byte("old")
+	blobs.blobs[fresh.BlobKey] = []byte("new")
+	voicemails := newMockVoicemailRepository(old, fresh)
+
+	uc := NewPurgeExpiredUseCase(voicemails, blobs, RetentionPolicy{MaxAge: 30 * 24 * time.Hour})
+
+	deleted, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 voicemail purged, got %d", deleted)
+	}
+	if _, ok := voicemails.voicemails["vm-new"]; !ok || len(voicemails.voicemails) != 1 {
+		t.Errorf("expected only the fresh voicemail to remain, got %v", voicemails.voicemails)
+	}
+	if _, ok := blobs.blobs[old.BlobKey]; ok {
+		t.Error("expected purged audio to be deleted")
+	}
+}
+
+func TestPurgeExpiredUseCase_Execute_NoMaxAge(t *testing.T) {
+	blobs := newMockBlobStore()
+	old := &domain.Voicemail{ID: "vm-old", UserID: "user-1", CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+	voicemails := newMockVoicemailRepository(old)
+
+	deleted, err := NewPurgeExpiredUseCase(voicemails, blobs, RetentionPolicy{}).Execute(context.Background())
+	if err != nil || deleted != 0 || len(voicemails.voicemails) != 1 {
+		t.Errorf("expected nothing purged without a max age, got %d, %v", deleted, err)
+	}
+}
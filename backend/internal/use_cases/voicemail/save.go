@@ -0,0 +1,165 @@
+package voicemail
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+var recordingSIDRe = regexp.MustCompile(`^[A-Za-z0-9]{1,64}$`)
+
+type SaveVoicemailInput struct {
+	ProviderCallSID string
+	RecordingSID    string
+	RecordingURL    string
+	Duration        int
+}
+
+type SaveVoicemailOutput struct {
+	VoicemailID string
+}
+
+// SaveVoicemailUseCase copies a finished recording from the provider into
+// our blob store and attaches it to the call it was left on.
+type SaveVoicemailUseCase struct {
+	callRepo   domain.CallRepository
+	voicemails domain.VoicemailRepository
+	blobs      domain.BlobStore
+	fetcher    RecordingFetcher
+	policy     RetentionPolicy
+}
+
+func NewSaveVoicemailUseCase(callRepo domain.CallRepository, voicemails domain.VoicemailRepository, blobs domain.BlobStore, fetcher RecordingFetcher, policy RetentionPolicy) *SaveVoicemailUseCase {
+	return &SaveVoicemailUseCase{
+		callRepo:   callRepo,
+		voicemails: voicemails,
+		blobs:      blobs,
+		fetcher:    fetcher,
+		policy:     policy,
+	}
+}
+
+func (uc *SaveVoicemailUseCase) Execute(ctx context.Context, input SaveVoicemailInput) (*SaveVoicemailOutput, error) {
+	if input.ProviderCallSID == "" {
+		return nil, errors.New("provider_call_sid is required")
+	}
+	if !recordingSIDRe.MatchString(input.RecordingSID) {
+		return nil, errors.New("invalid recording_sid")
+	}
+	if input.RecordingURL == "" {
+		return nil, errors.New("recording_url is required")
+	}
+	if uc.fetcher == nil {
+		return nil, errors.New("voicemail is not configured")
+	}
+
+	// The provider retries the callback until it gets a 2xx.
+	existing, err := uc.voicemails.GetByRecordingSID(ctx, input.RecordingSID)
+	if err != nil {
+		slog.Error("failed to get voicemail", "error", err, "recording_sid", input.RecordingSID)
+		return nil, errors.New("failed to get voicemail")
+	}
+	if existing != nil {
+		return &SaveVoicemailOutput{VoicemailID: existing.ID}, nil
+	}
+
+	call, err := uc.callRepo.GetByProviderCallSID(ctx, input.ProviderCallSID)
+	if err != nil {
+		slog.Error("failed to get call", "error", err, "provider_call_sid", input.ProviderCallSID)
+		return nil, errors.New("failed to get call")
+	}
+	if call == nil {
+		return nil, errors.New("call not found")
+	}
+
+	body, contentType, err := uc.fetcher.Fetch(ctx, input.RecordingURL)
+	if err != nil {
+		slog.Error("failed to download recording", "error", err, "recording_sid", input.RecordingSID)
+		return nil, errors.New("failed to download recording")
+	}
+	defer body.Close()
+
+	key := "voicemail/" + call.UserID + "/" + input.RecordingSID + extension(contentType)
+
+	var src io.Reader = body
+	if uc.policy.MaxSize > 0 {
+		src = io.LimitReader(body, uc.policy.MaxSize+1)
+	}
+	size, err := uc.blobs.Put(ctx, key, src)
+	if err != nil {
+		slog.Error("failed to store recording", "error", err, "recording_sid", input.RecordingSID)
+		return nil, errors.New("failed to store recording")
+	}
+	if uc.policy.MaxSize > 0 && size > uc.policy.MaxSize {
+		uc.deleteBlob(ctx, key)
+		slog.Warn("recording exceeds size limit", "recording_sid", input.RecordingSID, "max_size", uc.policy.MaxSize)
+		return nil, errors.New("recording too large")
+	}
+
+	voicemail := &domain.Voicemail{
+		UserID:       call.UserID,
+		CallID:       call.ID,
+		From:         call.PhoneNumber,
+		RecordingSID: input.RecordingSID,
+		BlobKey:      key,
+		ContentType:  contentType,
+		Size:         size,
+		Duration:     input.Duration,
+	}
+	if err := uc.voicemails.Create(ctx, voicemail); err != nil {
+		uc.deleteBlob(ctx, key)
+		slog.Error("failed to create voicemail", "error", err, "recording_sid", input.RecordingSID)
+		return nil, errors.New("failed to create voicemail")
+	}
+
+	slog.Info("voicemail saved",
+		"voicemail_id", voicemail.ID,
+		"call_id", call.ID,
+		"user_id", call.UserID,
+		"size", size)
+
+	uc.trim(ctx, call.UserID)
+
+	return &SaveVoicemailOutput{VoicemailID: voicemail.ID}, nil
+}
+
+// trim drops the oldest voicemails once the user is over MaxPerUser.
+func (uc *SaveVoicemailUseCase) trim(ctx context.Context, userID string) {
+	if uc.policy.MaxPerUser <= 0 {
+		return
+	}
+
+	voicemails, err := uc.voicemails.ListByUserID(ctx, userID)
+	if err != nil {
+		slog.Warn("failed to list voicemails for retention", "error", err, "user_id", userID)
+		return
+	}
+
+	for i := uc.policy.MaxPerUser; i < len(voicemails); i++ {
+		if err := remove(ctx, uc.voicemails, uc.blobs, voicemails[i]); err != nil {
+			slog.Warn("failed to delete old voicemail", "error", err, "voicemail_id", voicemails[i].ID)
+		}
+	}
+}
+
+func (uc *SaveVoicemailUseCase) deleteBlob(ctx context.Context, key string) {
+	if err := uc.blobs.Delete(ctx, key); err != nil {
+		slog.Warn("failed to delete recording", "error", err, "blob_key", key)
+	}
+}
+
+func extension(contentType string) string {
+	switch {
+	case strings.Contains(contentType, "mpeg"), strings.Contains(contentType, "mp3"):
+		return ".mp3"
+	case strings.Contains(contentType, "wav"):
+		return ".wav"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,268 @@
+package voicemail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+type mockCallRepository struct {
+	call *domain.Call
+}
+
+func (m *mockCallRepository) Create(ctx context.Context, call *domain.Call) error { return nil }
+
+func (m *mockCallRepository) Update(ctx context.Context, call *domain.Call) error { return nil }
+
+func (m *mockCallRepository) GetByID(ctx context.Context, id string) (*domain.Call, error) {
+	return nil, nil
+}
+
+func (m *mockCallRepository) GetByProviderCallSID(ctx context.Context, sid string) (*domain.Call, error) {
+	if m.call != nil && m.call.ProviderCallSID == sid {
+		return m.call, nil
+	}
+	return nil, nil
+}
+
+func (m *mockCallRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Call, error) {
+	return nil, nil
+}
+
+type mockVoicemailRepository struct {
+	voicemails map[string]*domain.Voicemail
+	createErr  error
+	nextID     int
+}
+
+func newMockVoicemailRepository(voicemails ...*domain.Voicemail) *mockVoicemailRepository {
+	m := &mockVoicemailRepository{voicemails: map[string]*domain.Voicemail{}}
+	for _, v := range voicemails {
+		m.voicemails[v.ID] = v
+	}
+	return m
+}
+
+func (m *mockVoicemailRepository) Create(ctx context.Context, voicemail *domain.Voicemail) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	m.nextID++
+	voicemail.ID = fmt.Sprintf("vm-new-%d", m.nextID)
+	voicemail.CreatedAt = time.Now()
+	m.voicemails[voicemail.ID] = voicemail
+	return nil
+}
+
+func (m *mockVoicemailRepository) GetByID(ctx context.Context, id string) (*domain.Voicemail, error) {
+	return m.voicemails[id], nil
+}
+
+func (m *mockVoicemailRepository) GetByRecordingSID(ctx context.Context, sid string) (*domain.Voicemail, error) {
+	for _, v := range m.voicemails {
+		if v.RecordingSID == sid {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockVoicemailRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Voicemail, error) {
+	var list []*domain.Voicemail
+	for _, v := range m.voicemails {
+		if v.UserID == userID {
+			list = append(list, v)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+	return list, nil
+}
+
+func (m *mockVoicemailRepository) ListOlderThan(ctx context.Context, cutoff time.Time) ([]*domain.Voicemail, error) {
+	var list []*domain.Voicemail
+	for _, v := range m.voicemails {
+		if v.CreatedAt.Before(cutoff) {
+			list = append(list, v)
+		}
+	}
+	return list, nil
+}
+
+func (m *mockVoicemailRepository) MarkRead(ctx context.Context, id string, readAt time.Time) error {
+	m.voicemails[id].ReadAt = &readAt
+	return nil
+}
+
+func (m *mockVoicemailRepository) Delete(ctx context.Context, id string) error {
+	delete(m.voicemails, id)
+	return nil
+}
+
+type mockBlobStore struct {
+	blobs map[string][]byte
+}
+
+func newMockBlobStore() *mockBlobStore {
+	return &mockBlobStore{blobs: map[string][]byte{}}
+}
+
+func (m *mockBlobStore) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	m.blobs[key] = data
+	return int64(len(data)), nil
+}
+
+func (m *mockBlobStore) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	data, ok := m.blobs[key]
+	if !ok {
+		return nil, domain.ErrBlobNotFound
+	}
+	return nopCloser{bytes.NewReader(data)}, nil
+}
+
+func (m *mockBlobStore) Delete(ctx context.Context, key string) error {
+	if _, ok := m.blobs[key]; !ok {
+		return domain.ErrBlobNotFound
+	}
+	delete(m.blobs, key)
+	return nil
+}
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
+
+type mockFetcher struct {
+	body string
+	err  error
+}
+
+func (m *mockFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, string, error) {
+	if m.err != nil {
+		return nil, "", m.err
+	}
+	return io.NopCloser(strings.NewReader(m.body)), "audio/x-wav", nil
+}
+
+func newSaveInput() SaveVoicemailInput {
+	return SaveVoicemailInput{
+		ProviderCallSID: "CA123",
+		RecordingSID:    "RE123",
+		RecordingURL:    "https://api.twilio.com/2010-04-01/Accounts/AC1/Recordings/RE123",
+		Duration:        7,
+	}
+}
+
+func newInboundCall() *domain.Call {
+	return &domain.Call{ID: "call-1", UserID: "user-1", PhoneNumber: "+491512345678", ProviderCallSID: "CA123"}
+}
+
+func TestSaveVoicemailUseCase_Execute_Success(t *testing.T) {
+	voicemails := newMockVoicemailRepository()
+	blobs := newMockBlobStore()
+	uc := NewSaveVoicemailUseCase(&mockCallRepository{call: newInboundCall()}, voicemails, blobs, &mockFetcher{body: "RIFF"}, RetentionPolicy{})
+
+	output, err := uc.Execute(context.Background(), newSaveInput())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	saved := voicemails.voicemails[output.VoicemailID]
+	if saved == nil {
+		t.Fatal("expected voicemail to be created")
+	}
+	if saved.UserID != "user-1" || saved.CallID != "call-1" || saved.From != "+491512345678" || saved.Duration != 7 || saved.Size != 4 {
+		t.Errorf("unexpected voicemail: %+v", saved)
+	}
+	if saved.BlobKey != "voicemail/user-1/RE123.wav" || string(blobs.blobs[saved.BlobKey]) != "RIFF" {
+		t.Errorf("expected audio under voicemail/user-1/RE123.wav, got %v", blobs.blobs)
+	}
+
+	again, err := uc.Execute(context.Background(), newSaveInput())
+	if err != nil || again.VoicemailID != output.VoicemailID {
+		t.Errorf("expected retried callback to return the same voicemail, got %+v, %v", again, err)
+	}
+	if len(voicemails.voicemails) != 1 {
+		t.Errorf("expected 1 voicemail after retry, got %d", len(voicemails.voicemails))
+	}
+}
+
+func TestSaveVoicemailUseCase_Execute_Validation(t *testing.T) {
+	uc := NewSaveVoicemailUseCase(&mockCallRepository{call: newInboundCall()}, newMockVoicemailRepository(), newMockBlobStore(), &mockFetcher{body: "RIFF"}, RetentionPolicy{})
+
+	for name, mutate := range map[string]func(*SaveVoicemailInput){
+		"provider_call_sid is required": func(in *SaveVoicemailInput) { in.ProviderCallSID = "" },
+		"invalid recording_sid":         func(in *SaveVoicemailInput) { in.RecordingSID = "../RE1" },
+		"recording_url is required":     func(in *SaveVoicemailInput) { in.RecordingURL = "" },
+		"call not found":                func(in *SaveVoicemailInput) { in.ProviderCallSID = "CA999" },
+	} {
+		input := newSaveInput()
+		mutate(&input)
+		if _, err := uc.Execute(context.Background(), input); err == nil || err.Error() != name {
+			t.Errorf("expected '%s' error, got %v", name, err)
+		}
+	}
+}
+
+func TestSaveVoicemailUseCase_Execute_TooLarge(t *testing.T) {
+	voicemails := newMockVoicemailRepository()
+	blobs := newMockBlobStore()
+	uc := NewSaveVoicemailUseCase(&mockCallRepository{call: newInboundCall()}, voicemails, blobs, &mockFetcher{body: "RIFF-audio"}, RetentionPolicy{MaxSize: 4})
+
+	_, err := uc.Execute(context.Background(), newSaveInput())
+	if err == nil || err.Error() != "recording too large" {
+		t.Errorf("expected 'recording too large' error, got %v", err)
+	}
+	if len(blobs.blobs) != 0 || len(voicemails.voicemails) != 0 {
+		t.Errorf("expected nothing stored, got blobs=%v voicemails=%v", blobs.blobs, voicemails.voicemails)
+	}
+}
+
+func TestSaveVoicemailUseCase_Execute_CreateFailsRemovesAudio(t *testing.T) {
+	voicemails := newMockVoicemailRepository()
+	voicemails.createErr = errors.New("db down")
+	blobs := newMockBlobStore()
+	uc := NewSaveVoicemailUseCase(&mockCallRepository{call: newInboundCall()}, voicemails, blobs, &mockFetcher{body: "RIFF"}, RetentionPolicy{})
+
+	if _, err := uc.Execute(context.Background(), newSaveInput()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if len(blobs.blobs) != 0 {
+		t.Errorf("expected orphaned audio to be removed, got %v", blobs.blobs)
+	}
+}
+
+func TestSaveVoicemailUseCase_Execute_TrimsOldest(t *testing.T) {
+	old := &domain.Voicemail{ID: "vm-old", UserID: "user-1", BlobKey: "voicemail/user-1/REold.wav", CreatedAt: time.Now().Add(-time.Hour)}
+	voicemails := newMockVoicemailRepository(old)
+	blobs := newMockBlobStore()
+	blobs.blobs[old.BlobKey] = []byte("old")
+	uc := NewSaveVoicemailUseCase(&mockCallRepository{call: newInboundCall()}, voicemails, blobs, &mockFetcher{body: "RIFF"}, RetentionPolicy{MaxPerUser: 1})
+
+	if _, err := uc.Execute(context.Background(), newSaveInput()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, ok := voicemails.voicemails["vm-old"]; ok {
+		t.Error("expected oldest voicemail to be trimmed")
+	}
+	if _, ok := blobs.blobs[old.BlobKey]; ok {
+		t.Error("expected trimmed voicemail audio to be deleted")
+	}
+	if len(voicemails.voicemails) != 1 {
+		t.Errorf("expected 1 voicemail to remain, got %d", len(voicemails.voicemails))
+	}
+}
@@ -0,0 +1,93 @@
+package voicemail
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/Nikita-Smirnov-idk/Browser-International-Calls-Platform/backend/internal/domain"
+)
+
+// RetentionPolicy bounds how much voicemail a user keeps. A zero MaxAge or
+// MaxPerUser disables that limit.
+type RetentionPolicy struct {
+	MaxAge     time.Duration
+	MaxPerUser int
+	// MaxSize caps a single recording in bytes.
+	MaxSize int64
+}
+
+// RecordingFetcher downloads a finished recording from the VoIP provider.
+type RecordingFetcher interface {
+	Fetch(ctx context.Context, recordingURL string) (io.ReadCloser, string, error)
+}
+
+type VoicemailItem struct {
+	ID          string     `json:"id"`
+	CallID      string     `json:"callId,omitempty"`
+	From        string     `json:"from"`
+	Duration    int        `json:"duration"`
+	Size        int64      `json:"size"`
+	ContentType string     `json:"contentType"`
+	ReadAt      *time.Time `json:"readAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+func toItem(voicemail *domain.Voicemail) *VoicemailItem {
+	return &VoicemailItem{
+		ID:          voicemail.ID,
+		CallID:      voicemail.CallID,
+		From:        voicemail.From,
+		Duration:    voicemail.Duration,
+		Size:        voicemail.Size,
+		ContentType: voicemail.ContentType,
+		ReadAt:      voicemail.ReadAt,
+		CreatedAt:   voicemail.CreatedAt,
+	}
+}
+
+// expired reports whether the voicemail is past retention but not purged yet.
+func (p RetentionPolicy) expired(voicemail *domain.Voicemail, now time.Time) bool {
+	return p.MaxAge > 0 && voicemail.CreatedAt.Before(now.Add(-p.MaxAge))
+}
+
+// ownedVoicemail loads a voicemail and checks that it belongs to the user.
+// Expired voicemails are reported as missing even before the purge runs.
+func ownedVoicemail(ctx context.Context, voicemails domain.VoicemailRepository, policy RetentionPolicy, id, userID string) (*domain.Voicemail, error) {
+	if id == "" {
+		return nil, errors.New("voicemail_id is required")
+	}
+
+	voicemail, err := voicemails.GetByID(ctx, id)
+	if err != nil {
+		slog.Error("failed to get voicemail", "error", err, "voicemail_id", id)
+		return nil, errors.New("failed to get voicemail")
+	}
+	if voicemail == nil || policy.expired(voicemail, time.Now()) {
+		return nil, errors.New("voicemail not found")
+	}
+
+	if voicemail.UserID != userID {
+		slog.Warn("unauthorized voicemail access attempt",
+			"voicemail_id", id,
+			"owner_id", voicemail.UserID,
+			"requester_id", userID)
+		return nil, errors.New("unauthorized")
+	}
+
+	return voicemail, nil
+}
+
+// remove deletes the row first, so that a failed blob delete leaves an
+// orphaned file rather than a voicemail that cannot be played.
+func remove(ctx context.Context, voicemails domain.VoicemailRepository, blobs domain.BlobStore, voicemail *domain.Voicemail) error {
+	if err := voicemails.Delete(ctx, voicemail.ID); err != nil {
+		return err
+	}
+	if err := blobs.Delete(ctx, voicemail.BlobKey); err != nil && !errors.Is(err, domain.ErrBlobNotFound) {
+		slog.Warn("failed to delete voicemail audio", "error", err, "voicemail_id", voicemail.ID, "blob_key", voicemail.BlobKey)
+	}
+	return nil
+}
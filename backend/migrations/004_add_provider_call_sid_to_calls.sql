@@ -0,0 +1,3 @@
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS provider_call_sid VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_calls_provider_call_sid ON calls(provider_call_sid);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS voip_sessions (
+    session_id VARCHAR(255) PRIMARY KEY,
+    provider_call_sid VARCHAR(64),
+    phone_number VARCHAR(20) NOT NULL,
+    sdp_offer TEXT,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_voip_sessions_expires_at ON voip_sessions(expires_at);
@@ -0,0 +1 @@
+ALTER TABLE voip_sessions ADD COLUMN IF NOT EXISTS sdp_answer TEXT;
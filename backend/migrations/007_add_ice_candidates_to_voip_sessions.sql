@@ -0,0 +1,2 @@
+ALTER TABLE voip_sessions ADD COLUMN IF NOT EXISTS local_candidates JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE voip_sessions ADD COLUMN IF NOT EXISTS remote_candidates JSONB NOT NULL DEFAULT '[]';
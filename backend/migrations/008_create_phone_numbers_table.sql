@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS phone_numbers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    number VARCHAR(20) NOT NULL UNIQUE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider_sid VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_phone_numbers_user_id ON phone_numbers(user_id);
+
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS direction VARCHAR(10) NOT NULL DEFAULT 'outbound';
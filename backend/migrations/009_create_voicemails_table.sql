@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS voicemails (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    call_id UUID REFERENCES calls(id) ON DELETE SET NULL,
+    from_number VARCHAR(50) NOT NULL,
+    recording_sid VARCHAR(64) NOT NULL UNIQUE,
+    blob_key VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    duration INTEGER NOT NULL DEFAULT 0,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_voicemails_user_created ON voicemails(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_voicemails_created_at ON voicemails(created_at);
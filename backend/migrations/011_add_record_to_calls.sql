@@ -0,0 +1 @@
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS record BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,3 @@
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS muted BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS held_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS hold_duration INTEGER NOT NULL DEFAULT 0;
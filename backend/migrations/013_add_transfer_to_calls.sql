@@ -0,0 +1,5 @@
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS parent_call_id UUID REFERENCES calls(id) ON DELETE SET NULL;
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS transfer_mode VARCHAR(10);
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS transfer_user_id UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_calls_parent_call_id ON calls(parent_call_id);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS conferences (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    host_session_id VARCHAR(255),
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE,
+    duration INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_conferences_user_id ON conferences(user_id);
+
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS conference_id UUID REFERENCES conferences(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_calls_conference_id ON calls(conference_id);
@@ -0,0 +1 @@
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS callback_destination VARCHAR(20);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS caller_ids (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    number VARCHAR(20) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT false,
+    verification_code VARCHAR(10),
+    code_expires_at TIMESTAMP WITH TIME ZONE,
+    verify_attempts INTEGER NOT NULL DEFAULT 0,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_caller_ids_user_id ON caller_ids(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_caller_ids_user_default ON caller_ids(user_id) WHERE is_default;
+
+ALTER TABLE calls ADD COLUMN IF NOT EXISTS caller_id VARCHAR(20);
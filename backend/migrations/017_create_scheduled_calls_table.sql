@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS scheduled_calls (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    phone_number VARCHAR(20) NOT NULL,
+    caller_number VARCHAR(20),
+    mode VARCHAR(10) NOT NULL,
+    scheduled_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    time_zone VARCHAR(64) NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',
+    claimed_at TIMESTAMP WITH TIME ZONE,
+    fired_at TIMESTAMP WITH TIME ZONE,
+    call_id UUID REFERENCES calls(id) ON DELETE SET NULL,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_calls_user_id ON scheduled_calls(user_id, scheduled_at);
+CREATE INDEX IF NOT EXISTS idx_scheduled_calls_due ON scheduled_calls(scheduled_at) WHERE status = 'pending';
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS user_settings (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    quiet_hours_mode VARCHAR(10),
+    quiet_hours_start SMALLINT,
+    quiet_hours_end SMALLINT,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
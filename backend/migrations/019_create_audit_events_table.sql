@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    type VARCHAR(40) NOT NULL,
+    phone_number VARCHAR(20),
+    country VARCHAR(2),
+    detail TEXT,
+    call_id UUID REFERENCES calls(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_type ON audit_events(type, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_user_id ON audit_events(user_id);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_audit_events_call_id ON audit_events(call_id);
@@ -0,0 +1 @@
+ALTER TABLE voip_sessions ALTER COLUMN phone_number TYPE VARCHAR(255);